@@ -0,0 +1,13 @@
+data "mrl_databricks_cluster" "shared" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  cluster_name  = "shared-autoscaling"
+}
+
+resource "mrl_databricks_library" "pandas" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  cluster_id    = data.mrl_databricks_cluster.shared.cluster_id
+
+  pypi = {
+    package = "pandas==2.2.2"
+  }
+}
@@ -0,0 +1,10 @@
+data "mrl_databricks_cluster_events" "etl" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  cluster_id    = "0312-104522-abcd1234"
+  event_types   = ["TERMINATING", "DRIVER_NOT_RESPONDING"]
+  limit         = 10
+}
+
+output "last_termination" {
+  value = try(jsondecode(data.mrl_databricks_cluster_events.etl.events[0].details).reason, null)
+}
@@ -0,0 +1,8 @@
+data "mrl_databricks_clusters" "etl" {
+  workspace_url         = mrl_databricks_workspace.this.workspace_url
+  cluster_name_contains = "etl"
+}
+
+output "etl_cluster_ids" {
+  value = data.mrl_databricks_clusters.etl.ids
+}
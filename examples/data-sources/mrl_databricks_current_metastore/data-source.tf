@@ -0,0 +1,10 @@
+data "mrl_databricks_current_metastore" "this" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+}
+
+resource "mrl_databricks_metastore_data_access" "root" {
+  workspace_url       = "https://adb-12358685563655.17.azuredatabricks.net"
+  metastore_id        = data.mrl_databricks_current_metastore.this.id
+  name                = "mrl-metastore-root"
+  access_connector_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-platform/providers/Microsoft.Databricks/accessConnectors/mrl-unity-connector"
+}
@@ -0,0 +1,9 @@
+data "mrl_databricks_current_user" "me" {}
+
+# A development cluster per deploying principal.
+resource "mrl_databricks_cluster" "dev" {
+  cluster_name  = "dev-${data.mrl_databricks_current_user.me.alphanumeric}"
+  spark_version = "15.4.x-scala2.12"
+  node_type_id  = "Standard_DS3_v2"
+  num_workers   = 1
+}
@@ -1,5 +1,10 @@
 data "mrl_databricks_dbfs" "example" {
-  adb_id    = "https://adb-12358685563655.17.azuredatabricks.net"
-  token     = "dapif6546496494e8464658496f9c4219"
-  root_path = "/FileStore/jars/init-libs"
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  root_path     = "/FileStore/jars/init-libs"
+}
+
+data "mrl_databricks_dbfs" "jars" {
+  root_path = "/FileStore/jars"
+  recursive = true
+  pattern   = "*.jar"
 }
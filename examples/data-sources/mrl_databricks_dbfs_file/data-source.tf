@@ -0,0 +1,8 @@
+data "mrl_databricks_dbfs_file" "environment" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  path          = "/FileStore/conf/environment.json"
+}
+
+locals {
+  environment = jsondecode(data.mrl_databricks_dbfs_file.environment.content)
+}
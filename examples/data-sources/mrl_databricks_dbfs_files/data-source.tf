@@ -0,0 +1,18 @@
+data "mrl_databricks_dbfs_files" "example" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  root_path     = "/FileStore/jars/init-libs"
+}
+
+data "mrl_databricks_dbfs_files" "jars" {
+  root_path = "/FileStore/jars"
+  recursive = true
+  pattern   = "*.jar"
+}
+
+output "jar_count" {
+  value = data.mrl_databricks_dbfs_files.jars.file_count
+}
+
+output "jar_bytes" {
+  value = data.mrl_databricks_dbfs_files.jars.total_size_bytes
+}
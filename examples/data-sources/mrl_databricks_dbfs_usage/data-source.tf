@@ -0,0 +1,8 @@
+data "mrl_databricks_dbfs_usage" "filestore" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  path          = "/FileStore"
+}
+
+output "filestore_directories_over_10gb" {
+  value = [for d in data.mrl_databricks_dbfs_usage.filestore.directories : d.path if d.total_bytes > 10 * 1024 * 1024 * 1024]
+}
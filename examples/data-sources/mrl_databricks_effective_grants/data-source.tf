@@ -0,0 +1,13 @@
+data "mrl_databricks_effective_grants" "orders" {
+  workspace_url  = "https://adb-12358685563655.17.azuredatabricks.net"
+  securable_type = "table"
+  full_name      = "main.sales.orders"
+  principal      = "data-analysts"
+
+  lifecycle {
+    postcondition {
+      condition     = contains([for g in self.grants : g.privilege], "SELECT")
+      error_message = "data-analysts must be able to read main.sales.orders."
+    }
+  }
+}
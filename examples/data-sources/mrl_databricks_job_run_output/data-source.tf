@@ -0,0 +1,15 @@
+data "mrl_databricks_job_run_output" "bootstrap" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  run_id        = 418273645109283
+
+  lifecycle {
+    postcondition {
+      condition     = self.result_state == "SUCCESS"
+      error_message = "The bootstrap run did not succeed: ${self.error}"
+    }
+  }
+}
+
+output "bootstrap_result" {
+  value = jsondecode(data.mrl_databricks_job_run_output.bootstrap.notebook_result)
+}
@@ -0,0 +1,11 @@
+data "mrl_databricks_node_types" "photon" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  min_cores     = 8
+  min_memory_gb = 32
+  category      = "Memory Optimized"
+  photon        = true
+}
+
+output "photon_node_type" {
+  value = data.mrl_databricks_node_types.photon.smallest
+}
@@ -0,0 +1,18 @@
+data "mrl_databricks_spark_versions" "lts" {
+  workspace_url     = mrl_databricks_workspace.this.workspace_url
+  long_term_support = true
+}
+
+data "mrl_databricks_node_types" "small" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  min_cores     = 4
+  min_memory_gb = 14
+}
+
+resource "mrl_databricks_cluster" "etl" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  cluster_name  = "etl"
+  spark_version = data.mrl_databricks_spark_versions.lts.latest
+  node_type_id  = data.mrl_databricks_node_types.small.smallest
+  num_workers   = 2
+}
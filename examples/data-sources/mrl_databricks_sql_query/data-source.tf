@@ -0,0 +1,13 @@
+data "mrl_databricks_sql_query" "storage_accounts" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  warehouse_id  = "5f9a0c2e4b7d1e38"
+  statement     = "SELECT name, account_id FROM platform.config.storage_accounts WHERE env = :env"
+
+  parameters = {
+    env = "prod"
+  }
+}
+
+output "storage_account_ids" {
+  value = { for row in data.mrl_databricks_sql_query.storage_accounts.rows : row.name => row.account_id }
+}
@@ -0,0 +1,10 @@
+data "mrl_databricks_workspace" "this" {
+  name                = "mrl-dev-adb"
+  resource_group_name = "mrl-dev-rg"
+}
+
+resource "mrl_databricks_dbfs_file" "example" {
+  workspace_url = data.mrl_databricks_workspace.this.workspace_url
+  local_path    = "../tools/main.go"
+  content_md5   = filemd5("../tools/main.go")
+}
@@ -0,0 +1,7 @@
+data "mrl_databricks_workspace_bundle" "dr" {
+  workspace_url  = "https://adb-12358685563655.17.azuredatabricks.net"
+  output_path    = "${path.module}/bundles/dr.tar.gz"
+  notebook_paths = ["/Shared/etl", "/Shared/reports/daily"]
+  job_ids        = [1024, 2048]
+  cluster_ids    = ["0412-093512-abcd123"]
+}
@@ -0,0 +1,8 @@
+data "mrl_databricks_workspace_conf" "this" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  keys          = ["enableIpAccessLists", "enableTokensConfig"]
+}
+
+output "ip_access_lists_enabled" {
+  value = data.mrl_databricks_workspace_conf.this.values["enableIpAccessLists"] == "true"
+}
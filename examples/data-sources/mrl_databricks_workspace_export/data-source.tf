@@ -0,0 +1,10 @@
+data "mrl_databricks_workspace_export" "etl" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  path          = "/Shared/etl"
+  format        = "DBC"
+}
+
+resource "local_file" "etl_backup" {
+  filename       = "${path.module}/backups/etl-${data.mrl_databricks_workspace_export.etl.content_md5}.dbc"
+  content_base64 = data.mrl_databricks_workspace_export.etl.content_base64
+}
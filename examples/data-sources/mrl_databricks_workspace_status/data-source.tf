@@ -0,0 +1,18 @@
+data "mrl_databricks_workspace_status" "this" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+}
+
+resource "mrl_databricks_dbfs_files" "init_libs" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+
+  files = {
+    "/FileStore/jars/init-libs/app.jar" = { local_path = "${path.module}/build/app.jar" }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = data.mrl_databricks_workspace_status.this.authenticated
+      error_message = "The workspace cannot be used: ${coalesce(data.mrl_databricks_workspace_status.this.error, "unknown error")}"
+    }
+  }
+}
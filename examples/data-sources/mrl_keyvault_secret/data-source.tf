@@ -0,0 +1,11 @@
+data "mrl_keyvault_secret" "databricks_pat" {
+  vault_url = "https://mrl-platform.vault.azure.net"
+  name      = "databricks-pat"
+}
+
+resource "mrl_databricks_secret" "databricks_pat" {
+  workspace_url   = "https://adb-12358685563655.17.azuredatabricks.net"
+  scope           = "platform"
+  key             = "databricks-pat"
+  string_value_wo = data.mrl_keyvault_secret.databricks_pat.value
+}
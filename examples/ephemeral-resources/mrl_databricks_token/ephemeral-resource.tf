@@ -0,0 +1,7 @@
+# A token for the run only, revoked when it ends and never stored in the plan
+# or state. Reference it from ephemeral contexts such as provider
+# configuration or write-only arguments.
+ephemeral "mrl_databricks_token" "deploy" {
+  comment          = "terraform deploy"
+  lifetime_seconds = 2 * 3600
+}
@@ -0,0 +1,9 @@
+# The token is only available during the run and never stored in the plan or
+# state. Reference it from ephemeral contexts such as provider configuration
+# or write-only arguments.
+ephemeral "mrl_storage_sas" "landing" {
+  storage_account_name = "mrldevlake"
+  container_name       = "landing"
+  permissions          = "rl"
+  validity             = "2h"
+}
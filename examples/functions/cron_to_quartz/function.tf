@@ -0,0 +1,4 @@
+output "weekday_schedule" {
+  # "0 */15 9-17 ? * MON-FRI"
+  value = provider::mrl::cron_to_quartz("*/15 9-17 * * 1-5")
+}
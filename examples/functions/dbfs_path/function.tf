@@ -0,0 +1,9 @@
+output "default_path" {
+  # "/FileStore/jars/init-libs/main.jar"
+  value = provider::mrl::dbfs_path("${path.module}/build/main.jar", null)
+}
+
+output "release_path" {
+  # "/releases/v1/main.jar"
+  value = provider::mrl::dbfs_path("${path.module}/build/main.jar", "dbfs:/releases//v1/")
+}
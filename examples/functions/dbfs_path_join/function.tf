@@ -0,0 +1,4 @@
+output "init_libs_path" {
+  # "/FileStore/jars/init-libs/main.jar"
+  value = provider::mrl::dbfs_path_join("/FileStore/", "jars//init-libs/", "main.jar")
+}
@@ -0,0 +1,15 @@
+resource "local_file" "grants" {
+  filename = "${path.module}/build/grants.sql"
+  content  = templatefile("${path.module}/grants.sql.tftpl", { readers = var.readers })
+}
+
+resource "mrl_databricks_sql_statement" "grants" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  warehouse_id  = mrl_databricks_sql_warehouse.analytics.id
+  statement     = local_file.grants.content
+
+  # Run the statement again whenever the generated file changes.
+  triggers = {
+    grants = provider::mrl::file_base64(local_file.grants.filename)
+  }
+}
@@ -0,0 +1,5 @@
+resource "mrl_databricks_dbfs_file" "example" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  local_path    = "../tools/main.go"
+  content_md5   = provider::mrl::file_md5("../tools/main.go")
+}
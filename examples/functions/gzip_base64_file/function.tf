@@ -0,0 +1,4 @@
+locals {
+  # Fail the plan if the init script grows beyond 48 KiB.
+  init_script_gzip = provider::mrl::gzip_base64_file("${path.module}/scripts/init.sh", 49152)
+}
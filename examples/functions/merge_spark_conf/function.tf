@@ -0,0 +1,11 @@
+locals {
+  base_spark_conf = {
+    "spark.databricks.delta.preview.enabled" = true
+    "spark.sql.shuffle.partitions"           = 200
+  }
+
+  # { "spark.databricks.delta.preview.enabled" = "true", "spark.sql.shuffle.partitions" = "64" }
+  spark_conf = provider::mrl::merge_spark_conf(local.base_spark_conf, {
+    "spark.sql.shuffle.partitions" = "64.0"
+  })
+}
@@ -0,0 +1,4 @@
+locals {
+  # { language = "PYTHON", format = "JUPYTER" }
+  etl_notebook = provider::mrl::notebook_language("${path.module}/notebooks/etl.ipynb")
+}
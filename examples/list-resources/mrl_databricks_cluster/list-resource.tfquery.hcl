@@ -0,0 +1,7 @@
+list "mrl_databricks_cluster" "all" {
+  provider = mrl
+
+  config {
+    workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  }
+}
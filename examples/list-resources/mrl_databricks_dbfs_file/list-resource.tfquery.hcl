@@ -0,0 +1,9 @@
+list "mrl_databricks_dbfs_file" "init_libs" {
+  provider = mrl
+
+  config {
+    workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+    path          = "/FileStore/jars/init-libs"
+    recursive     = true
+  }
+}
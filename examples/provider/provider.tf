@@ -1,6 +1,22 @@
 provider "mrl" {
-  clientid       = "abc"
-  clientsecret   = "def"
-  tenantid       = "ghi"
-  subscriptionid = "jkl"
+  clientid       = "11111111-1111-1111-1111-111111111111"
+  clientsecret   = var.client_secret
+  tenantid       = "22222222-2222-2222-2222-222222222222"
+  subscriptionid = "33333333-3333-3333-3333-333333333333"
+
+  # Used by Databricks resources and data sources that do not set workspace_url.
+  databricks {
+    host = "adb-12358685563655.17.azuredatabricks.net"
+
+    # Credentials of the other workspaces, for the resources that name them
+    # in workspace_url or a workspace block.
+    dynamic "workspace" {
+      for_each = var.workspaces
+      content {
+        host          = workspace.value.host
+        client_id     = workspace.value.client_id
+        client_secret = workspace.value.client_secret
+      }
+    }
+  }
 }
\ No newline at end of file
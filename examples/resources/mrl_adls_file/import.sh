@@ -0,0 +1,2 @@
+# Files are imported by storage_account_name|filesystem|path.
+terraform import mrl_adls_file.example "mrldatalake|raw|config/pipeline.json"
@@ -0,0 +1,7 @@
+resource "mrl_adls_file" "example" {
+  storage_account_name = "mrllandingzone"
+  filesystem           = "raw"
+  path                 = "/artifacts/init-libs/main.jar"
+  local_path           = "../build/main.jar"
+  content_md5          = provider::mrl::file_md5("../build/main.jar")
+}
@@ -0,0 +1,2 @@
+# Filesystems are imported by storage_account_name|name.
+terraform import mrl_adls_filesystem.example "mrldatalake|raw"
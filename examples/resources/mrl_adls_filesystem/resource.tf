@@ -0,0 +1,17 @@
+resource "mrl_adls_filesystem" "landing" {
+  storage_account_name = "mrldevlake"
+  name                 = "landing"
+
+  ace = [
+    { scope = "access", type = "user", permissions = "rwx" },
+    { scope = "access", type = "group", permissions = "r-x" },
+    { scope = "access", type = "other", permissions = "---" },
+    { scope = "access", type = "user", id = "11111111-1111-1111-1111-111111111111", permissions = "rwx" },
+    { scope = "access", type = "mask", permissions = "rwx" },
+    { scope = "default", type = "user", permissions = "rwx" },
+    { scope = "default", type = "group", permissions = "r-x" },
+    { scope = "default", type = "other", permissions = "---" },
+    { scope = "default", type = "user", id = "11111111-1111-1111-1111-111111111111", permissions = "rwx" },
+    { scope = "default", type = "mask", permissions = "rwx" },
+  ]
+}
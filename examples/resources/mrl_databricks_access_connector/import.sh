@@ -0,0 +1,2 @@
+# Imported by ARM resource ID.
+terraform import mrl_databricks_access_connector.example "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-platform/providers/Microsoft.Databricks/accessConnectors/mrl-unity-connector"
@@ -0,0 +1,14 @@
+resource "mrl_databricks_access_connector" "unity" {
+  name                = "mrl-dev-unity-connector"
+  resource_group_name = "mrl-dev-rg"
+  location            = "westeurope"
+}
+
+# Let the connector identity read and write the lake behind the external
+# locations.
+resource "mrl_role_assignment" "unity_lake" {
+  scope                = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Storage/storageAccounts/mrldevlake"
+  role_definition_name = "Storage Blob Data Contributor"
+  principal_id         = mrl_databricks_access_connector.unity.principal_id
+  principal_type       = "ServicePrincipal"
+}
@@ -0,0 +1,2 @@
+# Catalogs are imported by workspace_url|name.
+terraform import mrl_databricks_catalog.analytics "https://adb-12358685563655.17.azuredatabricks.net|analytics"
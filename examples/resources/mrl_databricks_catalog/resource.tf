@@ -0,0 +1,8 @@
+resource "mrl_databricks_catalog" "analytics" {
+  workspace_url  = "https://adb-12358685563655.17.azuredatabricks.net"
+  name           = "analytics"
+  comment        = "Curated analytics data"
+  owner          = "data-engineers"
+  storage_root   = "abfss://analytics@mrldatalake.dfs.core.windows.net/catalog"
+  isolation_mode = "ISOLATED"
+}
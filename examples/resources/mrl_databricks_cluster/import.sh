@@ -0,0 +1,3 @@
+# Clusters are imported by workspace_url|cluster_id. Import blocks can also use
+# the identity attributes workspace_url and cluster_id.
+terraform import mrl_databricks_cluster.etl "https://adb-12358685563655.17.azuredatabricks.net|0923-164208-meows279"
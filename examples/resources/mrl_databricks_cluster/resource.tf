@@ -0,0 +1,27 @@
+resource "mrl_databricks_dbfs_file" "init" {
+  local_path  = "scripts/install-drivers.sh"
+  dbfs_path   = "/init-scripts/install-drivers.sh"
+  content_md5 = filemd5("scripts/install-drivers.sh")
+}
+
+resource "mrl_databricks_cluster" "etl" {
+  cluster_name            = "etl"
+  spark_version           = "15.4.x-scala2.12"
+  node_type_id            = "Standard_DS3_v2"
+  autotermination_minutes = 30
+
+  autoscale = {
+    min_workers = 1
+    max_workers = 4
+  }
+
+  spark_conf = {
+    "spark.sql.shuffle.partitions" = "64"
+  }
+
+  custom_tags = {
+    team = "data-platform"
+  }
+
+  init_scripts = [mrl_databricks_dbfs_file.init.dbfs_path]
+}
@@ -0,0 +1,3 @@
+# Cluster policies are imported by workspace_url|policy_id. Import blocks can
+# also use the identity attributes workspace_url and policy_id.
+terraform import mrl_databricks_cluster_policy.etl "https://adb-12358685563655.17.azuredatabricks.net|ABCD1234EF567890"
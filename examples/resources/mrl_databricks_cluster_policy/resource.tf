@@ -0,0 +1,45 @@
+resource "mrl_databricks_cluster_policy" "etl" {
+  name                  = "etl"
+  description           = "Small autoscaling clusters on the LTS runtime"
+  max_clusters_per_user = 2
+
+  definition = jsonencode({
+    "spark_version" = {
+      type  = "fixed"
+      value = "15.4.x-scala2.12"
+    }
+    "autotermination_minutes" = {
+      type     = "range"
+      maxValue = 60
+    }
+    "autoscale.max_workers" = {
+      type     = "range"
+      maxValue = 8
+    }
+  })
+}
+
+resource "mrl_databricks_cluster_policy" "personal" {
+  name             = "personal-compute"
+  policy_family_id = "personal-vm"
+
+  policy_family_definition_overrides = jsonencode({
+    "node_type_id" = {
+      type   = "allowlist"
+      values = ["Standard_DS3_v2", "Standard_DS4_v2"]
+    }
+  })
+}
+
+resource "mrl_databricks_cluster" "etl" {
+  cluster_name            = "etl"
+  policy_id               = mrl_databricks_cluster_policy.etl.policy_id
+  spark_version           = "15.4.x-scala2.12"
+  node_type_id            = "Standard_DS3_v2"
+  autotermination_minutes = 30
+
+  autoscale = {
+    min_workers = 1
+    max_workers = 4
+  }
+}
@@ -0,0 +1,6 @@
+resource "mrl_databricks_dbfs_directory" "init_libs" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  local_dir     = "${path.module}/libs"
+  dbfs_prefix   = "/FileStore/jars/init-libs"
+  parallelism   = 16
+}
@@ -0,0 +1,6 @@
+# Files are imported by workspace_url|dbfs_path, authenticating with the
+# provider databricks_client_id and databricks_client_secret. Import blocks can
+# also use the identity attributes workspace_url and dbfs_path, and
+# terraform plan -generate-config-out writes the configuration; set local_path
+# in it before applying.
+terraform import mrl_databricks_dbfs_file.example "https://adb-12358685563655.17.azuredatabricks.net|/FileStore/jars/init-libs/spark-utils.jar"
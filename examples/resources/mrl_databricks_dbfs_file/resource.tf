@@ -0,0 +1,34 @@
+resource "mrl_databricks_dbfs_file" "example" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  local_path    = "../tools/main.go"
+  content_md5   = filemd5("../tools/main.go")
+}
+
+# Uploaded to /FileStore/jars/<env>/<version>/main.go, with env and version
+# set in dbfs_path_variables of the provider databricks block.
+resource "mrl_databricks_dbfs_file" "versioned" {
+  workspace_url             = "https://adb-12358685563655.17.azuredatabricks.net"
+  local_path                = "../tools/main.go"
+  dbfs_path_prefix_template = "/FileStore/jars/{env}/{version}"
+}
+
+# The same file in several workspaces from one provider instance. Each
+# authenticates with the credentials of its workspace block in the provider
+# databricks block, which are never stored in the state.
+resource "mrl_databricks_dbfs_file" "per_workspace" {
+  for_each = var.workspaces
+
+  local_path = "../tools/main.go"
+  dbfs_path  = "/FileStore/jars/main.go"
+
+  workspace {
+    host = each.value.host
+  }
+}
+
+# Move an instance of the deprecated mrl_databricks_dbfs type without
+# uploading the file again. Requires Terraform 1.8 or later.
+moved {
+  from = mrl_databricks_dbfs.example
+  to   = mrl_databricks_dbfs_file.example
+}
@@ -0,0 +1,17 @@
+resource "mrl_databricks_dbfs_files" "init_libs" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  parallelism   = 16
+
+  # Delete jars uploaded to init-libs by hand.
+  authoritative_prefix = "/FileStore/jars/init-libs"
+
+  files = merge(
+    {
+      for f in fileset("${path.module}/libs", "*.jar") :
+      "/FileStore/jars/init-libs/${f}" => { local_path = "${path.module}/libs/${f}" }
+    },
+    {
+      "/FileStore/conf/environment.txt" = { content = "dev" }
+    },
+  )
+}
@@ -0,0 +1,7 @@
+resource "mrl_databricks_diagnostic_setting" "audit" {
+  name                       = "audit-to-log-analytics"
+  workspace_id               = mrl_databricks_workspace.this.id
+  log_analytics_workspace_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.OperationalInsights/workspaces/mrl-dev-logs"
+  log_category_groups        = ["audit"]
+  log_categories             = ["clusters", "jobs", "unityCatalog"]
+}
@@ -0,0 +1,7 @@
+# Installs the libraries uploaded to DBFS on every cluster of the workspace.
+resource "mrl_databricks_global_init_script" "install_libs" {
+  name       = "install-init-libs"
+  local_path = "scripts/install-init-libs.sh"
+  enabled    = true
+  position   = 0
+}
@@ -0,0 +1,5 @@
+resource "mrl_databricks_group" "data_engineers" {
+  display_name = "data-engineers"
+  entitlements = ["allow-cluster-create", "databricks-sql-access"]
+  members      = [mrl_databricks_service_principal.pipeline.id]
+}
@@ -0,0 +1,24 @@
+resource "mrl_databricks_instance_pool" "etl" {
+  instance_pool_name                    = "etl"
+  node_type_id                          = "Standard_DS3_v2"
+  min_idle_instances                    = 1
+  max_capacity                          = 10
+  idle_instance_autotermination_minutes = 15
+  preloaded_spark_versions              = ["15.4.x-scala2.12"]
+
+  azure_attributes = {
+    availability       = "SPOT_AZURE"
+    spot_bid_max_price = -1
+  }
+
+  custom_tags = {
+    team = "data-platform"
+  }
+}
+
+resource "mrl_databricks_cluster" "etl" {
+  cluster_name     = "etl"
+  spark_version    = "15.4.x-scala2.12"
+  instance_pool_id = mrl_databricks_instance_pool.etl.id
+  num_workers      = 2
+}
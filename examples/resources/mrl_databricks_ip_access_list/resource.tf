@@ -0,0 +1,15 @@
+resource "mrl_databricks_workspace_conf" "ip_access_lists" {
+  custom_config = {
+    enableIpAccessLists = "true"
+  }
+}
+
+# Only the office and the CI runners may reach the workspace.
+resource "mrl_databricks_ip_access_list" "office" {
+  label             = "office"
+  list_type         = "ALLOW"
+  ip_addresses      = ["203.0.113.0/24", "198.51.100.7"]
+  caller_ip_address = "198.51.100.7"
+
+  depends_on = [mrl_databricks_workspace_conf.ip_access_lists]
+}
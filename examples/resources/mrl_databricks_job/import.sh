@@ -0,0 +1,3 @@
+# Jobs are imported by workspace_url|job_id. Import blocks can also use the
+# identity attributes workspace_url and job_id.
+terraform import mrl_databricks_job.nightly "https://adb-12358685563655.17.azuredatabricks.net|123456789"
@@ -0,0 +1,39 @@
+resource "mrl_databricks_dbfs_file" "etl_jar" {
+  local_path  = "build/etl.jar"
+  dbfs_path   = "/FileStore/jars/etl.jar"
+  content_md5 = filemd5("build/etl.jar")
+}
+
+resource "mrl_databricks_job" "nightly_etl" {
+  name = "nightly-etl"
+
+  new_cluster = {
+    spark_version = "15.4.x-scala2.12"
+    node_type_id  = "Standard_DS3_v2"
+    num_workers   = 2
+  }
+
+  spark_jar_task = {
+    main_class_name = "com.example.etl.Main"
+    parameters      = ["--date", "yesterday"]
+    jar_paths       = [mrl_databricks_dbfs_file.etl_jar.dbfs_path]
+  }
+
+  schedule = {
+    quartz_cron_expression = provider::mrl::cron_to_quartz("0 2 * * *")
+    timezone_id            = "Europe/Amsterdam"
+  }
+}
+
+# Run a notebook on an existing cluster.
+resource "mrl_databricks_job" "report" {
+  name                = "weekly-report"
+  existing_cluster_id = "0923-164208-meows279"
+
+  notebook_task = {
+    notebook_path = "/Shared/reports/weekly"
+    base_parameters = {
+      region = "emea"
+    }
+  }
+}
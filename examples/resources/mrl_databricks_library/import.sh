@@ -0,0 +1,4 @@
+# Libraries are imported by workspace_url|cluster_id|library, the library being
+# its kind and location as in its id. Import blocks can also use the identity
+# attributes workspace_url, cluster_id and library.
+terraform import mrl_databricks_library.requests "https://adb-12358685563655.17.azuredatabricks.net|0923-164208-meows279|pypi:requests==2.32.3"
@@ -0,0 +1,29 @@
+resource "mrl_databricks_dbfs_file" "app" {
+  local_path = "build/libs/app.jar"
+  dbfs_path  = "/FileStore/jars/app.jar"
+}
+
+resource "mrl_databricks_library" "app" {
+  cluster_id = mrl_databricks_cluster.etl.id
+  jar        = "dbfs:${mrl_databricks_dbfs_file.app.dbfs_path}"
+}
+
+resource "mrl_databricks_library" "requests" {
+  cluster_id = mrl_databricks_cluster.etl.id
+
+  pypi = {
+    package = "requests==2.32.3"
+  }
+}
+
+resource "mrl_databricks_library" "jsoup" {
+  cluster_id = mrl_databricks_cluster.etl.id
+
+  maven = {
+    coordinates = "org.jsoup:jsoup:1.17.2"
+  }
+
+  timeouts = {
+    create = "30m"
+  }
+}
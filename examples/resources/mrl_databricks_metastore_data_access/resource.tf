@@ -0,0 +1,12 @@
+resource "mrl_databricks_access_connector" "unity" {
+  name                = "mrl-unity-connector"
+  resource_group_name = "mrl-platform"
+  location            = "westeurope"
+}
+
+resource "mrl_databricks_metastore_data_access" "root" {
+  workspace_url       = "https://adb-12358685563655.17.azuredatabricks.net"
+  metastore_id        = "3a5c1e2f-8d4b-4f6a-9c7e-1b2d3e4f5a6b"
+  name                = "mrl-metastore-root"
+  access_connector_id = mrl_databricks_access_connector.unity.id
+}
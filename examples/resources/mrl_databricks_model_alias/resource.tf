@@ -0,0 +1,6 @@
+resource "mrl_databricks_model_alias" "champion" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  model_name    = "ml.forecasting.demand"
+  alias         = "champion"
+  version       = 7
+}
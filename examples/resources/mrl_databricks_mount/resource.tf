@@ -0,0 +1,10 @@
+# Mounts the landing directory of the raw filesystem at /mnt/raw, reading the
+# client secret of the provider service principal from a secret scope.
+resource "mrl_databricks_mount" "raw" {
+  name                 = "raw"
+  storage_account_name = "mrlplatformdata"
+  container_name       = "raw"
+  directory            = "/landing"
+  client_secret_scope  = "platform"
+  client_secret_key    = "spn-secret"
+}
@@ -0,0 +1,19 @@
+# Language and format are detected from the file.
+resource "mrl_databricks_notebook" "ingest" {
+  path       = "/Shared/etl/ingest"
+  local_path = "notebooks/ingest.py"
+}
+
+resource "mrl_databricks_notebook" "explore" {
+  path       = "/Shared/etl/explore"
+  local_path = "notebooks/explore.ipynb"
+}
+
+resource "mrl_databricks_job" "ingest" {
+  name                = "ingest"
+  existing_cluster_id = "0923-164208-meows279"
+
+  notebook_task = {
+    notebook_path = mrl_databricks_notebook.ingest.path
+  }
+}
@@ -0,0 +1,5 @@
+resource "mrl_databricks_permission_assignment" "data_engineers" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  principal_id  = 1045897263519870
+  permission    = "USER"
+}
@@ -0,0 +1,20 @@
+resource "mrl_databricks_permissions" "etl_cluster" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  object_type   = "clusters"
+  object_id     = mrl_databricks_cluster.etl.id
+
+  access_control = [
+    {
+      group_name       = "data-engineers"
+      permission_level = "CAN_RESTART"
+    },
+    {
+      service_principal_name = var.etl_application_id
+      permission_level       = "CAN_MANAGE"
+    },
+    {
+      user_name        = "analyst@example.com"
+      permission_level = "CAN_ATTACH_TO"
+    },
+  ]
+}
@@ -0,0 +1,2 @@
+# Imported by ARM resource ID.
+terraform import mrl_databricks_private_endpoint.example "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-network/providers/Microsoft.Network/privateEndpoints/mrl-dev-ui-api"
@@ -0,0 +1,19 @@
+resource "mrl_databricks_private_endpoint" "ui_api" {
+  name                 = "mrl-dev-adb-ui-api"
+  resource_group_name  = "mrl-dev-rg"
+  location             = "westeurope"
+  subnet_id            = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Network/virtualNetworks/mrl-dev-vnet/subnets/private-endpoints"
+  workspace_id         = mrl_databricks_workspace.this.id
+  subresource          = "databricks_ui_api"
+  private_dns_zone_ids = ["/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Network/privateDnsZones/privatelink.azuredatabricks.net"]
+}
+
+resource "mrl_databricks_private_endpoint" "browser_auth" {
+  name                 = "mrl-dev-adb-auth"
+  resource_group_name  = "mrl-dev-rg"
+  location             = "westeurope"
+  subnet_id            = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Network/virtualNetworks/mrl-dev-vnet/subnets/private-endpoints"
+  workspace_id         = mrl_databricks_workspace.this.id
+  subresource          = "browser_authentication"
+  private_dns_zone_ids = ["/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Network/privateDnsZones/privatelink.azuredatabricks.net"]
+}
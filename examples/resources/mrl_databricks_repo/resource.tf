@@ -0,0 +1,11 @@
+resource "mrl_databricks_repo" "pipelines" {
+  url          = "https://github.com/example/pipelines.git"
+  git_provider = "gitHub"
+  path         = "/Repos/deploy@example.com/pipelines"
+  branch       = "release/2024.10"
+}
+
+resource "mrl_databricks_repo" "pinned" {
+  url = "https://dev.azure.com/example/data/_git/pipelines"
+  tag = "v1.4.0"
+}
@@ -0,0 +1,2 @@
+# Schemas are imported by workspace_url|catalog_name|name.
+terraform import mrl_databricks_schema.raw "https://adb-12358685563655.17.azuredatabricks.net|analytics|raw"
@@ -0,0 +1,6 @@
+resource "mrl_databricks_schema" "raw" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  catalog_name  = mrl_databricks_catalog.analytics.name
+  name          = "raw"
+  comment       = "Landing zone of the ingestion jobs"
+}
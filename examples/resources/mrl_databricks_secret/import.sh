@@ -0,0 +1,4 @@
+# Secrets are imported by workspace_url|scope|key. Import blocks can also use
+# the identity attributes workspace_url, scope and key. The value is never read
+# back; set string_value_wo_version to write the configured value.
+terraform import mrl_databricks_secret.storage_key "https://adb-12358685563655.17.azuredatabricks.net|app|storage-key"
@@ -0,0 +1,12 @@
+variable "storage_key" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "mrl_databricks_secret" "storage_key" {
+  scope                   = mrl_databricks_secret_scope.app.name
+  key                     = "storage-key"
+  string_value_wo         = var.storage_key
+  string_value_wo_version = 1
+}
@@ -0,0 +1,5 @@
+# Secret scopes are imported by workspace_url|name. Import blocks can also use
+# the identity attributes workspace_url and name. initial_manage_principal
+# cannot be read back, so leave it unset on imported scopes to avoid replacing
+# them.
+terraform import mrl_databricks_secret_scope.app "https://adb-12358685563655.17.azuredatabricks.net|app"
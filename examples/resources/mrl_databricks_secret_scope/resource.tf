@@ -0,0 +1,14 @@
+resource "mrl_databricks_secret_scope" "app" {
+  name                     = "app"
+  initial_manage_principal = "users"
+}
+
+# Scope reading its secrets from an Azure Key Vault.
+resource "mrl_databricks_secret_scope" "vault" {
+  name = "vault"
+
+  keyvault_metadata = {
+    resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-data/providers/Microsoft.KeyVault/vaults/kv-data"
+    dns_name    = "https://kv-data.vault.azure.net/"
+  }
+}
@@ -0,0 +1,7 @@
+# Registers the Microsoft Entra ID application of the deployment pipeline in
+# the workspace.
+resource "mrl_databricks_service_principal" "pipeline" {
+  application_id = "00000000-0000-0000-0000-000000000000"
+  display_name   = "deployment-pipeline"
+  entitlements   = ["allow-cluster-create"]
+}
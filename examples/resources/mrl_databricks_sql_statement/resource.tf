@@ -0,0 +1,21 @@
+resource "mrl_databricks_sql_statement" "grant_landing" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  warehouse_id  = var.warehouse_id
+  statement     = "GRANT USE CATALOG, USE SCHEMA, SELECT ON CATALOG landing TO `data-readers`"
+  on_destroy    = "REVOKE USE CATALOG, USE SCHEMA, SELECT ON CATALOG landing FROM `data-readers`"
+
+  triggers = {
+    group_version = var.readers_group_version
+  }
+}
+
+resource "mrl_databricks_sql_statement" "bootstrap" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  warehouse_id  = var.warehouse_id
+  catalog       = "landing"
+  statement     = "CREATE SCHEMA IF NOT EXISTS IDENTIFIER(:schema_name)"
+
+  parameters = {
+    schema_name = "raw"
+  }
+}
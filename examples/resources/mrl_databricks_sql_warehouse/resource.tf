@@ -0,0 +1,18 @@
+resource "mrl_databricks_sql_warehouse" "analytics" {
+  workspace_url             = mrl_databricks_workspace.this.workspace_url
+  name                      = "analytics"
+  cluster_size              = "Small"
+  auto_stop_mins            = 30
+  max_num_clusters          = 4
+  enable_serverless_compute = true
+
+  tags = {
+    team = "data-platform"
+  }
+}
+
+resource "mrl_databricks_sql_statement" "landing" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  warehouse_id  = mrl_databricks_sql_warehouse.analytics.id
+  statement     = "CREATE CATALOG IF NOT EXISTS landing"
+}
@@ -0,0 +1,18 @@
+# A token for an external scheduler, rotated monthly. The new token is stored
+# before the old one is revoked.
+resource "mrl_databricks_token" "scheduler" {
+  comment          = "airflow"
+  lifetime_seconds = 90 * 24 * 3600
+  rotate_after     = "720h"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "mrl_keyvault_secret" "scheduler_token" {
+  vault_url       = "https://mrl-platform.vault.azure.net"
+  name            = "databricks-scheduler-token"
+  value           = mrl_databricks_token.scheduler.token_value
+  expiration_date = mrl_databricks_token.scheduler.expiry_time
+}
@@ -0,0 +1,6 @@
+resource "mrl_databricks_unity_volume_file" "example" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  local_path    = "../tools/main.go"
+  volume_path   = "/Volumes/main/default/libs/main.go"
+  content_md5   = filemd5("../tools/main.go")
+}
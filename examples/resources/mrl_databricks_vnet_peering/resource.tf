@@ -0,0 +1,9 @@
+resource "mrl_databricks_vnet_peering" "hub" {
+  name                      = "adb-to-hub"
+  workspace_id              = mrl_databricks_workspace.this.id
+  remote_virtual_network_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-hub-rg/providers/Microsoft.Network/virtualNetworks/mrl-hub-vnet"
+  allow_forwarded_traffic   = true
+  use_remote_gateways       = true
+}
+
+# The hub side peers back to mrl_databricks_vnet_peering.hub.databricks_virtual_network_id.
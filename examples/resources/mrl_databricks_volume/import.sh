@@ -0,0 +1,2 @@
+# Volumes are imported by workspace_url|catalog_name|schema_name|name.
+terraform import mrl_databricks_volume.libs "https://adb-12358685563655.17.azuredatabricks.net|analytics|raw|libs"
@@ -0,0 +1,23 @@
+resource "mrl_databricks_volume" "libs" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  catalog_name  = mrl_databricks_catalog.analytics.name
+  schema_name   = mrl_databricks_schema.raw.name
+  name          = "libs"
+  comment       = "Job libraries"
+}
+
+resource "mrl_databricks_volume" "landing" {
+  workspace_url    = "https://adb-12358685563655.17.azuredatabricks.net"
+  catalog_name     = mrl_databricks_catalog.analytics.name
+  schema_name      = mrl_databricks_schema.raw.name
+  name             = "landing"
+  volume_type      = "EXTERNAL"
+  storage_location = "abfss://landing@mrldatalake.dfs.core.windows.net/"
+}
+
+resource "mrl_databricks_unity_volume_file" "tool" {
+  workspace_url = "https://adb-12358685563655.17.azuredatabricks.net"
+  local_path    = "../tools/main.go"
+  volume_path   = "${mrl_databricks_volume.libs.volume_path}/main.go"
+  content_md5   = filemd5("../tools/main.go")
+}
@@ -0,0 +1,2 @@
+# Imported by ARM resource ID.
+terraform import mrl_databricks_workspace.example "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-platform/providers/Microsoft.Databricks/workspaces/mrl-dev"
@@ -0,0 +1,24 @@
+resource "mrl_databricks_workspace" "this" {
+  name                          = "mrl-dev-adb"
+  resource_group_name           = "mrl-dev-rg"
+  location                      = "westeurope"
+  sku                           = "premium"
+  public_network_access_enabled = true
+
+  custom_parameters = {
+    virtual_network_id  = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Network/virtualNetworks/mrl-dev-vnet"
+    public_subnet_name  = "databricks-host"
+    private_subnet_name = "databricks-container"
+    no_public_ip        = true
+  }
+
+  tags = {
+    environment = "dev"
+  }
+}
+
+resource "mrl_databricks_dbfs_file" "example" {
+  workspace_url = mrl_databricks_workspace.this.workspace_url
+  local_path    = "../tools/main.go"
+  content_md5   = filemd5("../tools/main.go")
+}
@@ -0,0 +1,7 @@
+resource "mrl_databricks_workspace_archive" "etl" {
+  workspace_url = "https://adb-98765432109876.5.azuredatabricks.net"
+  path          = "/Shared/etl"
+  local_path    = "${path.module}/bundles/etl.dbc"
+  format        = "DBC"
+  overwrite     = true
+}
@@ -0,0 +1,5 @@
+resource "mrl_databricks_workspace_bundle_restore" "dr" {
+  workspace_url = "https://adb-98765432109876.5.azuredatabricks.net"
+  bundle_path   = data.mrl_databricks_workspace_bundle.dr.output_path
+  bundle_md5    = data.mrl_databricks_workspace_bundle.dr.content_md5
+}
@@ -0,0 +1,9 @@
+# Hardens the workspace: IP access lists, and personal access tokens that
+# expire within 90 days.
+resource "mrl_databricks_workspace_conf" "hardening" {
+  custom_config = {
+    enableIpAccessLists  = "true"
+    enableTokensConfig   = "true"
+    maxTokenLifetimeDays = "90"
+  }
+}
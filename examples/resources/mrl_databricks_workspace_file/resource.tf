@@ -0,0 +1,12 @@
+resource "mrl_databricks_workspace_file" "ingest" {
+  path       = "/Shared/etl/ingest"
+  local_path = "notebooks/ingest.py"
+  format     = "SOURCE"
+  language   = "PYTHON"
+}
+
+# A plain file, such as a requirements file read by a notebook.
+resource "mrl_databricks_workspace_file" "requirements" {
+  path       = "/Shared/etl/requirements.txt"
+  local_path = "requirements.txt"
+}
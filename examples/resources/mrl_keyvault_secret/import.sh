@@ -0,0 +1,2 @@
+# Secrets are imported by their versionless ID.
+terraform import mrl_keyvault_secret.example "https://mrl-platform.vault.azure.net/secrets/databricks-pat"
@@ -0,0 +1,11 @@
+resource "mrl_keyvault_secret" "databricks_pat" {
+  vault_url       = "https://mrl-platform.vault.azure.net"
+  name            = "databricks-pat"
+  value           = var.databricks_pat
+  content_type    = "databricks-pat"
+  expiration_date = "2027-01-01T00:00:00Z"
+
+  tags = {
+    owner = "platform"
+  }
+}
@@ -0,0 +1,2 @@
+# Imported by ARM resource ID.
+terraform import mrl_role_assignment.example "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-data/providers/Microsoft.Storage/storageAccounts/mrldatalake/providers/Microsoft.Authorization/roleAssignments/9f2c1a4e-5b6d-4c7e-8f90-1a2b3c4d5e6f"
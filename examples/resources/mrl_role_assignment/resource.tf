@@ -0,0 +1,6 @@
+resource "mrl_role_assignment" "connector_blob_contributor" {
+  scope                = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/mrl-dev-rg/providers/Microsoft.Storage/storageAccounts/mrldevlake"
+  role_definition_name = "Storage Blob Data Contributor"
+  principal_id         = "11111111-1111-1111-1111-111111111111"
+  principal_type       = "ServicePrincipal"
+}
@@ -0,0 +1,2 @@
+# Blobs are imported by storage_account_name|container_name|name.
+terraform import mrl_storage_blob.example "mrlartifacts|releases|tools/main.go"
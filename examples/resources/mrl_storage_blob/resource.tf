@@ -0,0 +1,12 @@
+resource "mrl_storage_blob" "example" {
+  storage_account_name = "mrlartifacts"
+  container_name       = "init-libs"
+  name                 = "v1/main.jar"
+  local_path           = "../build/main.jar"
+  content_type         = "application/java-archive"
+  content_md5          = provider::mrl::file_md5("../build/main.jar")
+
+  metadata = {
+    build = "1234"
+  }
+}
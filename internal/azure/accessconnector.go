@@ -0,0 +1,66 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+)
+
+// accessConnectorAPIVersion is the Microsoft.Databricks API version serving
+// access connectors.
+const accessConnectorAPIVersion = "2023-05-01"
+
+// ManagedIdentity is the identity block of an ARM resource.
+type ManagedIdentity struct {
+	Type                   string                           `json:"type"`
+	PrincipalID            string                           `json:"principalId,omitempty"`
+	TenantID               string                           `json:"tenantId,omitempty"`
+	UserAssignedIdentities map[string]*UserAssignedIdentity `json:"userAssignedIdentities,omitempty"`
+}
+
+// UserAssignedIdentity is a user-assigned identity attached to a resource.
+type UserAssignedIdentity struct {
+	PrincipalID string `json:"principalId,omitempty"`
+	ClientID    string `json:"clientId,omitempty"`
+}
+
+// AccessConnector is a Microsoft.Databricks/accessConnectors resource.
+type AccessConnector struct {
+	ID         string            `json:"id,omitempty"`
+	Name       string            `json:"name,omitempty"`
+	Location   string            `json:"location"`
+	Tags       map[string]string `json:"tags,omitempty"`
+	Identity   *ManagedIdentity  `json:"identity,omitempty"`
+	Properties struct {
+		ProvisioningState string `json:"provisioningState,omitempty"`
+	} `json:"properties"`
+}
+
+// AccessConnectorID returns the ARM ID of a Databricks access connector.
+func AccessConnectorID(subscriptionID, resourceGroup, name string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.Databricks/accessConnectors/%s", ResourceGroupID(subscriptionID, resourceGroup), name)
+}
+
+// GetAccessConnector reads an access connector by ARM ID.
+func (c *Client) GetAccessConnector(ctx context.Context, id string) (*AccessConnector, error) {
+	var connector AccessConnector
+	if err := c.ARMGet(ctx, id, accessConnectorAPIVersion, &connector); err != nil {
+		return nil, err
+	}
+	return &connector, nil
+}
+
+// PutAccessConnector creates or updates an access connector and waits until
+// it is provisioned.
+func (c *Client) PutAccessConnector(ctx context.Context, id string, connector AccessConnector) (*AccessConnector, error) {
+	var result AccessConnector
+	if err := c.ARMPut(ctx, id, accessConnectorAPIVersion, connector, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteAccessConnector deletes an access connector and waits until it is
+// gone.
+func (c *Client) DeleteAccessConnector(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, accessConnectorAPIVersion)
+}
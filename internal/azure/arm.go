@@ -0,0 +1,174 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// ManagementScope is the token scope of Azure Resource Manager in the public
+// cloud.
+const ManagementScope = "https://management.azure.com/.default"
+
+// defaultManagementEndpoint is the Azure Resource Manager endpoint of the
+// public cloud.
+const defaultManagementEndpoint = "https://management.azure.com"
+
+// ARMURL returns the URL of an ARM resource for the given API version.
+func (c *Client) ARMURL(id, apiVersion string) string {
+	return c.env.ManagementEndpoint + "/" + strings.TrimPrefix(id, "/") + "?api-version=" + apiVersion
+}
+
+// ARMGet reads an ARM resource into out.
+func (c *Client) ARMGet(ctx context.Context, id, apiVersion string, out interface{}) error {
+	_, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodGet, c.ARMURL(id, apiVersion), nil, out)
+	return err
+}
+
+// ResourceID is a parsed ARM resource ID.
+type ResourceID struct {
+	SubscriptionID string
+	ResourceGroup  string
+	Provider       string
+	// Types and Names hold the resource type and name segments, from the
+	// top-level resource down to the child resource.
+	Types []string
+	Names []string
+}
+
+// Name returns the name of the innermost resource.
+func (id ResourceID) Name() string {
+	if len(id.Names) == 0 {
+		return ""
+	}
+	return id.Names[len(id.Names)-1]
+}
+
+// ParseResourceID parses an ID of the form
+// /subscriptions/{sub}/resourceGroups/{rg}/providers/{ns}/{type}/{name}[/{type}/{name}...].
+func ParseResourceID(id string) (ResourceID, error) {
+	segments := strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 6 || !strings.EqualFold(segments[0], "subscriptions") ||
+		!strings.EqualFold(segments[2], "resourceGroups") || !strings.EqualFold(segments[4], "providers") {
+		return ResourceID{}, fmt.Errorf("%q is not a resource ID of the form /subscriptions/{id}/resourceGroups/{name}/providers/{namespace}/{type}/{name}", id)
+	}
+
+	parsed := ResourceID{
+		SubscriptionID: segments[1],
+		ResourceGroup:  segments[3],
+		Provider:       segments[5],
+	}
+	rest := segments[6:]
+	if len(rest) == 0 || len(rest)%2 != 0 {
+		return ResourceID{}, fmt.Errorf("%q does not end with a resource type and name", id)
+	}
+	for i := 0; i < len(rest); i += 2 {
+		parsed.Types = append(parsed.Types, rest[i])
+		parsed.Names = append(parsed.Names, rest[i+1])
+	}
+
+	return parsed, nil
+}
+
+// ResourceGroupID returns the ID of a resource group.
+func ResourceGroupID(subscriptionID, resourceGroup string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s", subscriptionID, resourceGroup)
+}
+
+// defaultPollInterval is used when a long-running operation response carries
+// no Retry-After header.
+const defaultPollInterval = 10 * time.Second
+
+// ARMPut creates or updates an ARM resource and waits for the operation to
+// finish, decoding the final resource into out.
+func (c *Client) ARMPut(ctx context.Context, id, apiVersion string, in, out interface{}) error {
+	resp, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodPut, c.ARMURL(id, apiVersion), in, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := c.waitForOperation(ctx, resp); err != nil {
+		return err
+	}
+
+	return c.ARMGet(ctx, id, apiVersion, out)
+}
+
+// ARMDelete deletes an ARM resource and waits for the operation to finish.
+// Deleting a resource that does not exist is not an error.
+func (c *Client) ARMDelete(ctx context.Context, id, apiVersion string) error {
+	resp, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodDelete, c.ARMURL(id, apiVersion), nil, nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.waitForOperation(ctx, resp)
+}
+
+// waitForOperation follows the Azure-AsyncOperation or Location header of a
+// long-running operation until it reaches a terminal state.
+func (c *Client) waitForOperation(ctx context.Context, resp *http.Response) error {
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted {
+		return nil
+	}
+
+	if asyncURL := resp.Header.Get("Azure-AsyncOperation"); asyncURL != "" {
+		return c.pollAsyncOperation(ctx, asyncURL, retryAfter(resp))
+	}
+	if location := resp.Header.Get("Location"); location != "" {
+		return c.pollLocation(ctx, location, retryAfter(resp))
+	}
+
+	return nil
+}
+
+func (c *Client) pollAsyncOperation(ctx context.Context, u string, interval time.Duration) error {
+	terminal := poll.StateIn("succeeded", "failed", "canceled", "cancelled")
+
+	return poll.Poller{Interval: interval}.Wait(ctx, func(ctx context.Context) (bool, time.Duration, error) {
+		status := struct {
+			Status string `json:"status"`
+			Error  struct {
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"error"`
+		}{}
+		resp, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodGet, u, nil, &status)
+		if err != nil {
+			return false, 0, err
+		}
+
+		if !terminal(status.Status) {
+			return false, retryAfter(resp), nil
+		}
+		if !strings.EqualFold(status.Status, "succeeded") {
+			return true, 0, fmt.Errorf("operation %s: %s: %s", strings.ToLower(status.Status), status.Error.Code, status.Error.Message)
+		}
+		return true, 0, nil
+	})
+}
+
+func (c *Client) pollLocation(ctx context.Context, u string, interval time.Duration) error {
+	return poll.Poller{Interval: interval}.Wait(ctx, func(ctx context.Context) (bool, time.Duration, error) {
+		resp, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodGet, u, nil, nil)
+		if err != nil {
+			return false, 0, err
+		}
+		return resp.StatusCode != http.StatusAccepted, retryAfter(resp), nil
+	})
+}
+
+// retryAfter returns the polling interval requested by the server.
+func retryAfter(resp *http.Response) time.Duration {
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	return defaultPollInterval
+}
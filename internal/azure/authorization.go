@@ -0,0 +1,105 @@
+package azure
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const authorizationAPIVersion = "2022-04-01"
+
+// RoleAssignment is an Azure RBAC role assignment.
+type RoleAssignment struct {
+	ID         string                   `json:"id,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+	Properties RoleAssignmentProperties `json:"properties"`
+}
+
+// RoleAssignmentProperties holds the properties of a role assignment.
+type RoleAssignmentProperties struct {
+	RoleDefinitionID string `json:"roleDefinitionId"`
+	PrincipalID      string `json:"principalId"`
+	PrincipalType    string `json:"principalType,omitempty"`
+	Scope            string `json:"scope,omitempty"`
+	Description      string `json:"description,omitempty"`
+}
+
+// RoleDefinition is an Azure RBAC role definition.
+type RoleDefinition struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	Properties struct {
+		RoleName string `json:"roleName"`
+	} `json:"properties"`
+}
+
+// NewAssignmentName returns a random GUID usable as role assignment name.
+func NewAssignmentName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// RoleAssignmentID returns the ID of a role assignment below scope.
+func RoleAssignmentID(scope, name string) string {
+	return strings.TrimRight(scope, "/") + "/providers/Microsoft.Authorization/roleAssignments/" + name
+}
+
+// GetRoleAssignment reads a role assignment by ID.
+func (c *Client) GetRoleAssignment(ctx context.Context, id string) (*RoleAssignment, error) {
+	var assignment RoleAssignment
+	if err := c.ARMGet(ctx, id, authorizationAPIVersion, &assignment); err != nil {
+		return nil, err
+	}
+	return &assignment, nil
+}
+
+// CreateRoleAssignment creates a role assignment. Role assignments cannot be
+// updated in place.
+func (c *Client) CreateRoleAssignment(ctx context.Context, id string, assignment RoleAssignment) (*RoleAssignment, error) {
+	var result RoleAssignment
+	if err := c.ARMPut(ctx, id, authorizationAPIVersion, assignment, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteRoleAssignment deletes a role assignment.
+func (c *Client) DeleteRoleAssignment(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, authorizationAPIVersion)
+}
+
+// GetRoleDefinition reads a role definition by ARM ID.
+func (c *Client) GetRoleDefinition(ctx context.Context, id string) (*RoleDefinition, error) {
+	var definition RoleDefinition
+	if err := c.ARMGet(ctx, id, authorizationAPIVersion, &definition); err != nil {
+		return nil, err
+	}
+	return &definition, nil
+}
+
+// FindRoleDefinition looks up a role definition by its display name, such as
+// "Storage Blob Data Contributor", at the given scope.
+func (c *Client) FindRoleDefinition(ctx context.Context, scope, roleName string) (*RoleDefinition, error) {
+	u := c.env.ManagementEndpoint + "/" + strings.Trim(scope, "/") +
+		"/providers/Microsoft.Authorization/roleDefinitions?api-version=" + authorizationAPIVersion +
+		"&$filter=" + url.QueryEscape(fmt.Sprintf("roleName eq '%s'", roleName))
+
+	var list struct {
+		Value []RoleDefinition `json:"value"`
+	}
+	if _, err := c.DoJSON(ctx, c.env.ManagementScope, http.MethodGet, u, nil, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Value) == 0 {
+		return nil, fmt.Errorf("no role definition named %q at scope %s", roleName, scope)
+	}
+	return &list.Value[0], nil
+}
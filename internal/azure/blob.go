@@ -0,0 +1,116 @@
+package azure
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// metadataHeaderPrefix is the canonical prefix of blob metadata headers.
+const metadataHeaderPrefix = "X-Ms-Meta-"
+
+// BlobProperties describes a blob.
+type BlobProperties struct {
+	PathProperties
+	// Metadata keys are lower case, Azure treats them case insensitively.
+	Metadata map[string]string
+}
+
+// BlobURL returns the URL of a blob.
+func (c *Client) BlobURL(account, container, blob string) string {
+	return fmt.Sprintf("https://%s.blob.%s/%s/%s", account, c.env.StorageSuffix, url.PathEscape(container), escapePath(blob))
+}
+
+// UploadBlockBlob creates or overwrites a block blob with the content of r,
+// staging it in blocks and committing the block list with the content type,
+// MD5 and metadata. It returns the hex MD5 of the uploaded content.
+func (c *Client) UploadBlockBlob(ctx context.Context, account, container, blob string, r io.Reader, contentType string, metadata map[string]string) (string, error) {
+	u := c.BlobURL(account, container, blob)
+
+	hash := md5.New()
+	buf := make([]byte, uploadChunkSize)
+	var blockIDs []string
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+			// Block IDs must all have the same length within a blob.
+			blockID := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("block-%08d", len(blockIDs))))
+			blockURL := u + "?comp=block&blockid=" + url.QueryEscape(blockID)
+			if _, err := c.doStorage(ctx, http.MethodPut, blockURL, buf[:n], nil); err != nil {
+				return "", fmt.Errorf("put block %d: %w", len(blockIDs), err)
+			}
+			blockIDs = append(blockIDs, blockID)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return "", readErr
+		}
+	}
+
+	blockList := struct {
+		XMLName xml.Name `xml:"BlockList"`
+		Latest  []string `xml:"Latest"`
+	}{Latest: blockIDs}
+	body, err := xml.Marshal(blockList)
+	if err != nil {
+		return "", err
+	}
+	body = append([]byte(xml.Header), body...)
+
+	sum := hash.Sum(nil)
+	headers := map[string]string{
+		"Content-Type":           "application/xml",
+		"X-Ms-Blob-Content-Md5":  base64.StdEncoding.EncodeToString(sum),
+		"X-Ms-Blob-Content-Type": contentType,
+	}
+	if contentType == "" {
+		headers["X-Ms-Blob-Content-Type"] = "application/octet-stream"
+	}
+	for k, v := range metadata {
+		headers[metadataHeaderPrefix+k] = v
+	}
+
+	if _, err := c.doStorage(ctx, http.MethodPut, u+"?comp=blocklist", body, headers); err != nil {
+		return "", fmt.Errorf("put block list: %w", err)
+	}
+
+	return hex.EncodeToString(sum), nil
+}
+
+// GetBlobProperties returns the properties and metadata of a blob.
+func (c *Client) GetBlobProperties(ctx context.Context, account, container, blob string) (*BlobProperties, error) {
+	resp, err := c.doStorage(ctx, http.MethodHead, c.BlobURL(account, container, blob), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	props := &BlobProperties{
+		PathProperties: *pathPropertiesFromResponse(resp),
+		Metadata:       map[string]string{},
+	}
+	for k, v := range resp.Header {
+		if strings.HasPrefix(k, metadataHeaderPrefix) && len(v) > 0 {
+			props.Metadata[strings.ToLower(strings.TrimPrefix(k, metadataHeaderPrefix))] = v[0]
+		}
+	}
+
+	return props, nil
+}
+
+// DeleteBlob deletes a blob together with its snapshots.
+func (c *Client) DeleteBlob(ctx context.Context, account, container, blob string) error {
+	_, err := c.doStorage(ctx, http.MethodDelete, c.BlobURL(account, container, blob), nil, map[string]string{
+		"X-Ms-Delete-Snapshots": "include",
+	})
+	return err
+}
@@ -0,0 +1,148 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// Token scopes used to authenticate against Azure services. StorageScope is
+// the same in every cloud; KeyVaultScope is that of the public cloud.
+const (
+	StorageScope  = "https://storage.azure.com/.default"
+	KeyVaultScope = "https://vault.azure.net/.default"
+)
+
+// TokenFunc returns an OAuth access token for the given scope.
+type TokenFunc func(ctx context.Context, scope string) (string, error)
+
+// Client issues authenticated requests against Azure REST APIs on behalf of
+// the provider credential.
+type Client struct {
+	httpClient *http.Client
+	token      TokenFunc
+	env        Environment
+}
+
+// NewClient returns a Client sending requests to the public cloud through
+// httpClient and authenticating them with tokens obtained from token.
+func NewClient(httpClient *http.Client, token TokenFunc) *Client {
+	return &Client{
+		httpClient: httpClient,
+		token:      token,
+		env:        PublicCloud,
+	}
+}
+
+// ResponseError is returned for any response with a status code of 400 or
+// above.
+type ResponseError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	RequestID  string
+}
+
+// Error implements error.
+func (e *ResponseError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("azure request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("azure request failed with status %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// IsNotFound reports whether err is a ResponseError for a 404 response.
+func IsNotFound(err error) bool {
+	respErr, ok := err.(*ResponseError)
+	return ok && respErr.StatusCode == http.StatusNotFound
+}
+
+// Do sends the request with a bearer token for scope. Responses with a status
+// code of 400 or above are consumed and returned as a *ResponseError; otherwise
+// the caller must close the response body.
+func (c *Client) Do(ctx context.Context, scope string, req *http.Request) (*http.Response, error) {
+	token, err := c.token(ctx, scope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to obtain a token for %v: %w", scope, err)
+	}
+
+	req = req.WithContext(ctx)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+
+	defer resp.Body.Close()
+	return nil, parseResponseError(resp)
+}
+
+// DoJSON sends a request with an optional JSON body and decodes the JSON
+// response into out when out is not nil.
+func (c *Client) DoJSON(ctx context.Context, scope, method, u string, in, out interface{}) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.Do(ctx, scope, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil && err != io.EOF {
+			return resp, fmt.Errorf("unable to decode response from %v: %w", u, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// parseResponseError decodes the error payloads of ARM, Key Vault and the
+// storage data plane, which all use slightly different shapes.
+func parseResponseError(resp *http.Response) error {
+	respErr := &ResponseError{
+		StatusCode: resp.StatusCode,
+		Code:       resp.Header.Get("X-Ms-Error-Code"),
+		RequestID:  resp.Header.Get("X-Ms-Request-Id"),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	payload := struct {
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}{}
+	if json.Unmarshal(body, &payload) == nil && payload.Error.Code != "" {
+		respErr.Code = payload.Error.Code
+		respErr.Message = payload.Error.Message
+	} else if len(body) > 0 {
+		respErr.Message = string(body)
+	}
+
+	return respErr
+}
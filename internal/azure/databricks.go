@@ -0,0 +1,85 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+)
+
+// databricksAPIVersion is the Microsoft.Databricks resource provider API
+// version.
+const databricksAPIVersion = "2023-02-01"
+
+// Sku is the SKU of an ARM resource.
+type Sku struct {
+	Name string `json:"name"`
+}
+
+// DatabricksWorkspace is a Microsoft.Databricks/workspaces resource.
+type DatabricksWorkspace struct {
+	ID         string                        `json:"id,omitempty"`
+	Name       string                        `json:"name,omitempty"`
+	Location   string                        `json:"location"`
+	Sku        *Sku                          `json:"sku,omitempty"`
+	Tags       map[string]string             `json:"tags,omitempty"`
+	Properties DatabricksWorkspaceProperties `json:"properties"`
+}
+
+// DatabricksWorkspaceProperties are the properties of a Databricks workspace.
+type DatabricksWorkspaceProperties struct {
+	ManagedResourceGroupID string `json:"managedResourceGroupId"`
+	WorkspaceURL           string `json:"workspaceUrl,omitempty"`
+	WorkspaceID            string `json:"workspaceId,omitempty"`
+	ProvisioningState      string `json:"provisioningState,omitempty"`
+	PublicNetworkAccess    string `json:"publicNetworkAccess,omitempty"`
+	RequiredNsgRules       string `json:"requiredNsgRules,omitempty"`
+
+	Parameters *DatabricksWorkspaceParameters `json:"parameters,omitempty"`
+}
+
+// DatabricksWorkspaceParameters are the custom parameters of a workspace, used
+// for VNet injection.
+type DatabricksWorkspaceParameters struct {
+	CustomVirtualNetworkID  *StringParameter `json:"customVirtualNetworkId,omitempty"`
+	CustomPublicSubnetName  *StringParameter `json:"customPublicSubnetName,omitempty"`
+	CustomPrivateSubnetName *StringParameter `json:"customPrivateSubnetName,omitempty"`
+	EnableNoPublicIP        *BoolParameter   `json:"enableNoPublicIp,omitempty"`
+}
+
+// StringParameter is a string valued workspace custom parameter.
+type StringParameter struct {
+	Value string `json:"value"`
+}
+
+// BoolParameter is a boolean workspace custom parameter.
+type BoolParameter struct {
+	Value bool `json:"value"`
+}
+
+// DatabricksWorkspaceID returns the ARM ID of a Databricks workspace.
+func DatabricksWorkspaceID(subscriptionID, resourceGroup, name string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.Databricks/workspaces/%s", ResourceGroupID(subscriptionID, resourceGroup), name)
+}
+
+// GetDatabricksWorkspace reads a Databricks workspace by ARM ID.
+func (c *Client) GetDatabricksWorkspace(ctx context.Context, id string) (*DatabricksWorkspace, error) {
+	var workspace DatabricksWorkspace
+	if err := c.ARMGet(ctx, id, databricksAPIVersion, &workspace); err != nil {
+		return nil, err
+	}
+	return &workspace, nil
+}
+
+// PutDatabricksWorkspace creates or updates a workspace and waits until it is
+// provisioned.
+func (c *Client) PutDatabricksWorkspace(ctx context.Context, id string, workspace DatabricksWorkspace) (*DatabricksWorkspace, error) {
+	var result DatabricksWorkspace
+	if err := c.ARMPut(ctx, id, databricksAPIVersion, workspace, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteDatabricksWorkspace deletes a workspace and waits until it is gone.
+func (c *Client) DeleteDatabricksWorkspace(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, databricksAPIVersion)
+}
@@ -0,0 +1,162 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// storageAPIVersion is the x-ms-version sent to the storage data plane.
+const storageAPIVersion = "2021-08-06"
+
+// uploadChunkSize is the size of each append or block request when uploading.
+const uploadChunkSize = 4 << 20
+
+// PathProperties describes a file in a storage account.
+type PathProperties struct {
+	ContentLength int64
+	// ContentMD5 is the lowercase hex MD5 recorded for the content, empty when
+	// the service has none.
+	ContentMD5   string
+	ContentType  string
+	ETag         string
+	LastModified time.Time
+}
+
+// escapePath escapes every segment of a slash separated path.
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// DataLakeURL returns the DFS endpoint URL of a path in an ADLS Gen2 filesystem.
+func (c *Client) DataLakeURL(account, filesystem, p string) string {
+	u := fmt.Sprintf("https://%s.dfs.%s/%s", account, c.env.StorageSuffix, url.PathEscape(filesystem))
+	if p = escapePath(p); p != "" {
+		u += "/" + p
+	}
+	return u
+}
+
+func (c *Client) storageRequest(ctx context.Context, method, u string, body io.Reader, contentLength int64) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = contentLength
+	req.Header.Set("X-Ms-Version", storageAPIVersion)
+	req.Header.Set("X-Ms-Date", time.Now().UTC().Format(http.TimeFormat))
+	return req, nil
+}
+
+func (c *Client) doStorage(ctx context.Context, method, u string, body []byte, headers map[string]string) (*http.Response, error) {
+	req, err := c.storageRequest(ctx, method, u, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := c.Do(ctx, StorageScope, req)
+	if err != nil {
+		return nil, err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return resp, nil
+}
+
+// UploadDataLakeFile creates or overwrites a file in an ADLS Gen2 filesystem
+// with the content of r, appending it in chunks and recording its MD5 on
+// flush. It returns the hex MD5 of the uploaded content.
+func (c *Client) UploadDataLakeFile(ctx context.Context, account, filesystem, p string, r io.Reader, contentType string) (string, error) {
+	u := c.DataLakeURL(account, filesystem, p)
+
+	if _, err := c.doStorage(ctx, http.MethodPut, u+"?resource=file", nil, nil); err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+
+	hash := md5.New()
+	buf := make([]byte, uploadChunkSize)
+	var position int64
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			hash.Write(buf[:n])
+			appendURL := fmt.Sprintf("%s?action=append&position=%d", u, position)
+			if _, err := c.doStorage(ctx, http.MethodPatch, appendURL, buf[:n], nil); err != nil {
+				return "", fmt.Errorf("append at offset %d: %w", position, err)
+			}
+			position += int64(n)
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			return "", readErr
+		}
+	}
+
+	sum := hash.Sum(nil)
+	headers := map[string]string{
+		"X-Ms-Content-Md5": base64.StdEncoding.EncodeToString(sum),
+	}
+	if contentType != "" {
+		headers["X-Ms-Content-Type"] = contentType
+	}
+
+	flushURL := fmt.Sprintf("%s?action=flush&position=%d", u, position)
+	if _, err := c.doStorage(ctx, http.MethodPatch, flushURL, nil, headers); err != nil {
+		return "", fmt.Errorf("flush: %w", err)
+	}
+
+	return hex.EncodeToString(sum), nil
+}
+
+// GetDataLakeFileProperties returns the properties of a file in an ADLS Gen2
+// filesystem.
+func (c *Client) GetDataLakeFileProperties(ctx context.Context, account, filesystem, p string) (*PathProperties, error) {
+	resp, err := c.doStorage(ctx, http.MethodHead, c.DataLakeURL(account, filesystem, p), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return pathPropertiesFromResponse(resp), nil
+}
+
+// DeleteDataLakePath deletes a file or, with recursive set, a directory.
+func (c *Client) DeleteDataLakePath(ctx context.Context, account, filesystem, p string, recursive bool) error {
+	u := c.DataLakeURL(account, filesystem, p) + "?recursive=" + strconv.FormatBool(recursive)
+	_, err := c.doStorage(ctx, http.MethodDelete, u, nil, nil)
+	return err
+}
+
+func pathPropertiesFromResponse(resp *http.Response) *PathProperties {
+	h := resp.Header
+	props := &PathProperties{
+		ContentLength: resp.ContentLength,
+		ContentType:   h.Get("Content-Type"),
+		ETag:          h.Get("ETag"),
+	}
+	if n, err := strconv.ParseInt(h.Get("Content-Length"), 10, 64); err == nil {
+		props.ContentLength = n
+	}
+	if sum, err := base64.StdEncoding.DecodeString(h.Get("Content-Md5")); err == nil && len(sum) > 0 {
+		props.ContentMD5 = hex.EncodeToString(sum)
+	}
+	if t, err := http.ParseTime(h.Get("Last-Modified")); err == nil {
+		props.LastModified = t.UTC()
+	}
+	return props
+}
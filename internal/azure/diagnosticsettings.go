@@ -0,0 +1,63 @@
+package azure
+
+import (
+	"context"
+	"strings"
+)
+
+// diagnosticSettingsAPIVersion is the Microsoft.Insights diagnostic settings
+// API version.
+const diagnosticSettingsAPIVersion = "2021-05-01-preview"
+
+// DiagnosticSetting is a Microsoft.Insights/diagnosticSettings extension
+// resource.
+type DiagnosticSetting struct {
+	ID         string                      `json:"id,omitempty"`
+	Name       string                      `json:"name,omitempty"`
+	Properties DiagnosticSettingProperties `json:"properties"`
+}
+
+// DiagnosticSettingProperties are the destinations and log categories of a
+// diagnostic setting.
+type DiagnosticSettingProperties struct {
+	WorkspaceID                 string          `json:"workspaceId,omitempty"`
+	EventHubAuthorizationRuleID string          `json:"eventHubAuthorizationRuleId,omitempty"`
+	EventHubName                string          `json:"eventHubName,omitempty"`
+	StorageAccountID            string          `json:"storageAccountId,omitempty"`
+	Logs                        []DiagnosticLog `json:"logs"`
+}
+
+// DiagnosticLog enables a log category or category group.
+type DiagnosticLog struct {
+	Category      string `json:"category,omitempty"`
+	CategoryGroup string `json:"categoryGroup,omitempty"`
+	Enabled       bool   `json:"enabled"`
+}
+
+// DiagnosticSettingID returns the ID of a diagnostic setting on a resource.
+func DiagnosticSettingID(resourceID, name string) string {
+	return strings.TrimRight(resourceID, "/") + "/providers/Microsoft.Insights/diagnosticSettings/" + name
+}
+
+// GetDiagnosticSetting reads a diagnostic setting by ID.
+func (c *Client) GetDiagnosticSetting(ctx context.Context, id string) (*DiagnosticSetting, error) {
+	var setting DiagnosticSetting
+	if err := c.ARMGet(ctx, id, diagnosticSettingsAPIVersion, &setting); err != nil {
+		return nil, err
+	}
+	return &setting, nil
+}
+
+// PutDiagnosticSetting creates or replaces a diagnostic setting.
+func (c *Client) PutDiagnosticSetting(ctx context.Context, id string, setting DiagnosticSetting) (*DiagnosticSetting, error) {
+	var result DiagnosticSetting
+	if err := c.ARMPut(ctx, id, diagnosticSettingsAPIVersion, setting, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteDiagnosticSetting deletes a diagnostic setting.
+func (c *Client) DeleteDiagnosticSetting(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, diagnosticSettingsAPIVersion)
+}
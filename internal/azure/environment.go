@@ -0,0 +1,72 @@
+package azure
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+)
+
+// Environment holds the endpoints of an Azure cloud.
+type Environment struct {
+	// Name is the value of the provider environment attribute selecting the
+	// cloud.
+	Name string
+	// Cloud configures the Microsoft Entra ID authority of azidentity
+	// credentials.
+	Cloud cloud.Configuration
+	// ManagementEndpoint is the Azure Resource Manager endpoint.
+	ManagementEndpoint string
+	// ManagementScope is the token scope of Azure Resource Manager.
+	ManagementScope string
+	// KeyVaultScope is the token scope of Key Vault.
+	KeyVaultScope string
+	// StorageSuffix is the DNS suffix of storage account endpoints.
+	StorageSuffix string
+}
+
+// The Azure clouds the provider supports.
+var (
+	PublicCloud = Environment{
+		Name:               "public",
+		Cloud:              cloud.AzurePublic,
+		ManagementEndpoint: defaultManagementEndpoint,
+		ManagementScope:    ManagementScope,
+		KeyVaultScope:      KeyVaultScope,
+		StorageSuffix:      "core.windows.net",
+	}
+	USGovernmentCloud = Environment{
+		Name:               "usgovernment",
+		Cloud:              cloud.AzureGovernment,
+		ManagementEndpoint: "https://management.usgovcloudapi.net",
+		ManagementScope:    "https://management.usgovcloudapi.net/.default",
+		KeyVaultScope:      "https://vault.usgovcloudapi.net/.default",
+		StorageSuffix:      "core.usgovcloudapi.net",
+	}
+	ChinaCloud = Environment{
+		Name:               "china",
+		Cloud:              cloud.AzureChina,
+		ManagementEndpoint: "https://management.chinacloudapi.cn",
+		ManagementScope:    "https://management.chinacloudapi.cn/.default",
+		KeyVaultScope:      "https://vault.azure.cn/.default",
+		StorageSuffix:      "core.chinacloudapi.cn",
+	}
+)
+
+// Environments lists the supported clouds, public first.
+var Environments = []Environment{PublicCloud, USGovernmentCloud, ChinaCloud}
+
+// EnvironmentByName returns the cloud called name, and false when there is
+// none.
+func EnvironmentByName(name string) (Environment, bool) {
+	for _, env := range Environments {
+		if env.Name == name {
+			return env, true
+		}
+	}
+	return Environment{}, false
+}
+
+// WithEnvironment makes c send its requests to the endpoints of env. It
+// returns c.
+func (c *Client) WithEnvironment(env Environment) *Client {
+	c.env = env
+	return c
+}
@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+)
+
+// AccessControl is the owner, owning group and POSIX ACL of an ADLS Gen2 path.
+type AccessControl struct {
+	Owner string
+	Group string
+	// ACL is the comma separated list of access control entries, including
+	// default entries prefixed with "default:".
+	ACL string
+}
+
+// CreateFilesystem creates an ADLS Gen2 filesystem.
+func (c *Client) CreateFilesystem(ctx context.Context, account, filesystem string) error {
+	_, err := c.doStorage(ctx, http.MethodPut, c.DataLakeURL(account, filesystem, "")+"?resource=filesystem", nil, nil)
+	return err
+}
+
+// GetFilesystemProperties returns the properties of an ADLS Gen2 filesystem.
+func (c *Client) GetFilesystemProperties(ctx context.Context, account, filesystem string) (*PathProperties, error) {
+	resp, err := c.doStorage(ctx, http.MethodHead, c.DataLakeURL(account, filesystem, "")+"?resource=filesystem", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return pathPropertiesFromResponse(resp), nil
+}
+
+// DeleteFilesystem deletes an ADLS Gen2 filesystem with all of its content.
+func (c *Client) DeleteFilesystem(ctx context.Context, account, filesystem string) error {
+	_, err := c.doStorage(ctx, http.MethodDelete, c.DataLakeURL(account, filesystem, "")+"?resource=filesystem", nil, nil)
+	return err
+}
+
+// GetAccessControl returns the access control of a path, the root directory
+// of the filesystem when p is empty.
+func (c *Client) GetAccessControl(ctx context.Context, account, filesystem, p string) (*AccessControl, error) {
+	resp, err := c.doStorage(ctx, http.MethodHead, c.DataLakeURL(account, filesystem, p)+"/?action=getAccessControl", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AccessControl{
+		Owner: resp.Header.Get("X-Ms-Owner"),
+		Group: resp.Header.Get("X-Ms-Group"),
+		ACL:   resp.Header.Get("X-Ms-Acl"),
+	}, nil
+}
+
+// SetAccessControl sets the owner, owning group and ACL of a path, the root
+// directory of the filesystem when p is empty. Empty fields are left as is.
+func (c *Client) SetAccessControl(ctx context.Context, account, filesystem, p string, ac AccessControl) error {
+	headers := map[string]string{}
+	if ac.Owner != "" {
+		headers["X-Ms-Owner"] = ac.Owner
+	}
+	if ac.Group != "" {
+		headers["X-Ms-Group"] = ac.Group
+	}
+	if ac.ACL != "" {
+		headers["X-Ms-Acl"] = ac.ACL
+	}
+	_, err := c.doStorage(ctx, http.MethodPatch, c.DataLakeURL(account, filesystem, p)+"/?action=setAccessControl", nil, headers)
+	return err
+}
@@ -0,0 +1,68 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// keyVaultAPIVersion is the Key Vault data plane API version.
+const keyVaultAPIVersion = "7.4"
+
+// SecretAttributes are the management attributes of a Key Vault secret. Times
+// are Unix seconds.
+type SecretAttributes struct {
+	Enabled   *bool  `json:"enabled,omitempty"`
+	Expires   *int64 `json:"exp,omitempty"`
+	NotBefore *int64 `json:"nbf,omitempty"`
+	Created   int64  `json:"created,omitempty"`
+	Updated   int64  `json:"updated,omitempty"`
+}
+
+// Secret is a Key Vault secret bundle.
+type Secret struct {
+	ID          string            `json:"id,omitempty"`
+	Value       string            `json:"value"`
+	ContentType string            `json:"contentType,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Attributes  SecretAttributes  `json:"attributes"`
+}
+
+// Version returns the version segment of the secret ID.
+func (s *Secret) Version() string {
+	return s.ID[strings.LastIndex(s.ID, "/")+1:]
+}
+
+func secretURL(vaultURL, name, version string) string {
+	u := strings.TrimRight(vaultURL, "/") + "/secrets/" + url.PathEscape(name)
+	if version != "" {
+		u += "/" + url.PathEscape(version)
+	}
+	return u + "?api-version=" + keyVaultAPIVersion
+}
+
+// GetSecret reads a secret. An empty version reads the current version.
+func (c *Client) GetSecret(ctx context.Context, vaultURL, name, version string) (*Secret, error) {
+	var secret Secret
+	if _, err := c.DoJSON(ctx, c.env.KeyVaultScope, http.MethodGet, secretURL(vaultURL, name, version), nil, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// SetSecret writes a new version of a secret.
+func (c *Client) SetSecret(ctx context.Context, vaultURL, name string, secret Secret) (*Secret, error) {
+	var result Secret
+	if _, err := c.DoJSON(ctx, c.env.KeyVaultScope, http.MethodPut, secretURL(vaultURL, name, ""), secret, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteSecret deletes every version of a secret. Vaults with soft delete
+// enabled keep it recoverable until it is purged.
+func (c *Client) DeleteSecret(ctx context.Context, vaultURL, name string) error {
+	_, err := c.DoJSON(ctx, c.env.KeyVaultScope, http.MethodDelete, secretURL(vaultURL, name, ""), nil, nil)
+	return err
+}
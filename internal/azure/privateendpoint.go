@@ -0,0 +1,129 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+)
+
+// networkAPIVersion is the Microsoft.Network resource provider API version.
+const networkAPIVersion = "2023-05-01"
+
+// SubResource references another ARM resource by ID.
+type SubResource struct {
+	ID string `json:"id"`
+}
+
+// PrivateEndpoint is a Microsoft.Network/privateEndpoints resource.
+type PrivateEndpoint struct {
+	ID         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location"`
+	Tags       map[string]string         `json:"tags,omitempty"`
+	Properties PrivateEndpointProperties `json:"properties"`
+}
+
+// PrivateEndpointProperties are the properties of a private endpoint.
+type PrivateEndpointProperties struct {
+	Subnet                        SubResource                    `json:"subnet"`
+	PrivateLinkServiceConnections []PrivateLinkServiceConnection `json:"privateLinkServiceConnections"`
+	CustomDnsConfigs              []CustomDnsConfig              `json:"customDnsConfigs,omitempty"`
+	ProvisioningState             string                         `json:"provisioningState,omitempty"`
+}
+
+// PrivateLinkServiceConnection connects a private endpoint to a sub-resource
+// of a private link enabled service.
+type PrivateLinkServiceConnection struct {
+	Name       string `json:"name"`
+	Properties struct {
+		PrivateLinkServiceID              string   `json:"privateLinkServiceId"`
+		GroupIDs                          []string `json:"groupIds"`
+		PrivateLinkServiceConnectionState *struct {
+			Status      string `json:"status"`
+			Description string `json:"description"`
+		} `json:"privateLinkServiceConnectionState,omitempty"`
+	} `json:"properties"`
+}
+
+// CustomDnsConfig is a DNS name and the private IP addresses it resolves to.
+type CustomDnsConfig struct {
+	Fqdn        string   `json:"fqdn"`
+	IPAddresses []string `json:"ipAddresses"`
+}
+
+// PrivateDnsZoneGroup registers the private endpoint in private DNS zones.
+type PrivateDnsZoneGroup struct {
+	Properties struct {
+		PrivateDnsZoneConfigs []PrivateDnsZoneConfig `json:"privateDnsZoneConfigs"`
+	} `json:"properties"`
+}
+
+// PrivateDnsZoneConfig references a private DNS zone of a zone group.
+type PrivateDnsZoneConfig struct {
+	Name       string `json:"name"`
+	Properties struct {
+		PrivateDnsZoneID string `json:"privateDnsZoneId"`
+	} `json:"properties"`
+}
+
+// PrivateEndpointID returns the ARM ID of a private endpoint.
+func PrivateEndpointID(subscriptionID, resourceGroup, name string) string {
+	return fmt.Sprintf("%s/providers/Microsoft.Network/privateEndpoints/%s", ResourceGroupID(subscriptionID, resourceGroup), name)
+}
+
+// GetPrivateEndpoint reads a private endpoint by ARM ID.
+func (c *Client) GetPrivateEndpoint(ctx context.Context, id string) (*PrivateEndpoint, error) {
+	var endpoint PrivateEndpoint
+	if err := c.ARMGet(ctx, id, networkAPIVersion, &endpoint); err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// PutPrivateEndpoint creates or updates a private endpoint and waits until it
+// is provisioned.
+func (c *Client) PutPrivateEndpoint(ctx context.Context, id string, endpoint PrivateEndpoint) (*PrivateEndpoint, error) {
+	var result PrivateEndpoint
+	if err := c.ARMPut(ctx, id, networkAPIVersion, endpoint, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeletePrivateEndpoint deletes a private endpoint and waits until it is gone.
+func (c *Client) DeletePrivateEndpoint(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, networkAPIVersion)
+}
+
+// PutPrivateDnsZoneGroup registers the private endpoint id in the given
+// private DNS zones, replacing the "default" zone group.
+func (c *Client) PutPrivateDnsZoneGroup(ctx context.Context, id string, zoneIDs []string) error {
+	var group PrivateDnsZoneGroup
+	for _, zoneID := range zoneIDs {
+		var config PrivateDnsZoneConfig
+		parsed, err := ParseResourceID(zoneID)
+		if err != nil {
+			return err
+		}
+		config.Name = parsed.Name()
+		config.Properties.PrivateDnsZoneID = zoneID
+		group.Properties.PrivateDnsZoneConfigs = append(group.Properties.PrivateDnsZoneConfigs, config)
+	}
+
+	var result PrivateDnsZoneGroup
+	return c.ARMPut(ctx, id+"/privateDnsZoneGroups/default", networkAPIVersion, group, &result)
+}
+
+// GetPrivateDnsZoneIDs returns the private DNS zones the endpoint is
+// registered in through its "default" zone group.
+func (c *Client) GetPrivateDnsZoneIDs(ctx context.Context, id string) ([]string, error) {
+	var group PrivateDnsZoneGroup
+	if err := c.ARMGet(ctx, id+"/privateDnsZoneGroups/default", networkAPIVersion, &group); err != nil {
+		return nil, err
+	}
+
+	var zoneIDs []string
+	for _, config := range group.Properties.PrivateDnsZoneConfigs {
+		zoneIDs = append(zoneIDs, config.Properties.PrivateDnsZoneID)
+	}
+	return zoneIDs, nil
+}
@@ -0,0 +1,56 @@
+package azure
+
+import (
+	"context"
+	"strings"
+)
+
+// WorkspaceVNetPeering is a Microsoft.Databricks/workspaces/virtualNetworkPeerings
+// resource, peering the workspace managed virtual network with another one.
+type WorkspaceVNetPeering struct {
+	ID         string                         `json:"id,omitempty"`
+	Name       string                         `json:"name,omitempty"`
+	Properties WorkspaceVNetPeeringProperties `json:"properties"`
+}
+
+// WorkspaceVNetPeeringProperties are the properties of a workspace peering.
+type WorkspaceVNetPeeringProperties struct {
+	RemoteVirtualNetwork      SubResource  `json:"remoteVirtualNetwork"`
+	DatabricksVirtualNetwork  *SubResource `json:"databricksVirtualNetwork,omitempty"`
+	AllowVirtualNetworkAccess bool         `json:"allowVirtualNetworkAccess"`
+	AllowForwardedTraffic     bool         `json:"allowForwardedTraffic"`
+	AllowGatewayTransit       bool         `json:"allowGatewayTransit"`
+	UseRemoteGateways         bool         `json:"useRemoteGateways"`
+	PeeringState              string       `json:"peeringState,omitempty"`
+	ProvisioningState         string       `json:"provisioningState,omitempty"`
+}
+
+// WorkspaceVNetPeeringID returns the ARM ID of a peering of the workspace.
+func WorkspaceVNetPeeringID(workspaceID, name string) string {
+	return strings.TrimRight(workspaceID, "/") + "/virtualNetworkPeerings/" + name
+}
+
+// GetWorkspaceVNetPeering reads a workspace peering by ARM ID.
+func (c *Client) GetWorkspaceVNetPeering(ctx context.Context, id string) (*WorkspaceVNetPeering, error) {
+	var peering WorkspaceVNetPeering
+	if err := c.ARMGet(ctx, id, databricksAPIVersion, &peering); err != nil {
+		return nil, err
+	}
+	return &peering, nil
+}
+
+// PutWorkspaceVNetPeering creates or updates a workspace peering and waits
+// until it is provisioned.
+func (c *Client) PutWorkspaceVNetPeering(ctx context.Context, id string, peering WorkspaceVNetPeering) (*WorkspaceVNetPeering, error) {
+	var result WorkspaceVNetPeering
+	if err := c.ARMPut(ctx, id, databricksAPIVersion, peering, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteWorkspaceVNetPeering deletes a workspace peering and waits until it
+// is gone.
+func (c *Client) DeleteWorkspaceVNetPeering(ctx context.Context, id string) error {
+	return c.ARMDelete(ctx, id, databricksAPIVersion)
+}
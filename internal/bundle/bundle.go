@@ -0,0 +1,153 @@
+// Package bundle reads and writes workspace disaster recovery bundles:
+// gzipped tar archives holding a JSON manifest of jobs and clusters and the
+// DBC exports of notebooks and directories.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"terraform-provider-mrl/internal/databricks"
+)
+
+// Version is the manifest format written by this package.
+const Version = 1
+
+// manifestName is the archive member holding the manifest.
+const manifestName = "manifest.json"
+
+// Manifest lists the assets of a bundle.
+type Manifest struct {
+	Version   int        `json:"version"`
+	Notebooks []Notebook `json:"notebooks,omitempty"`
+	Jobs      []Job      `json:"jobs,omitempty"`
+	Clusters  []Cluster  `json:"clusters,omitempty"`
+}
+
+// Notebook is a notebook or directory exported as a DBC archive.
+type Notebook struct {
+	Path        string                     `json:"path"`
+	ObjectType  string                     `json:"object_type"`
+	File        string                     `json:"file"`
+	Permissions []databricks.AccessControl `json:"permissions,omitempty"`
+}
+
+// Job is the snapshot of a job.
+type Job struct {
+	JobID       int64                      `json:"job_id"`
+	Settings    json.RawMessage            `json:"settings"`
+	Permissions []databricks.AccessControl `json:"permissions,omitempty"`
+}
+
+// Cluster is the snapshot of a cluster configuration.
+type Cluster struct {
+	ClusterID   string                     `json:"cluster_id"`
+	Spec        json.RawMessage            `json:"spec"`
+	Permissions []databricks.AccessControl `json:"permissions,omitempty"`
+}
+
+// Bundle is a manifest and the content of the files it refers to.
+type Bundle struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+// Write writes b as a gzipped tar archive. The output only depends on the
+// content of b, so unchanged assets give an identical archive.
+func Write(w io.Writer, b *Bundle) error {
+	manifest := b.Manifest
+	manifest.Version = Version
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeMember(tw, manifestName, data); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(b.Files))
+	for name := range b.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeMember(tw, name, b.Files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// writeMember adds a regular file without timestamps to the archive.
+func writeMember(tw *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		Format:   tar.FormatPAX,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
+
+// Read reads a bundle written by Write.
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	b := &Bundle{Files: map[string][]byte{}}
+	var manifest []byte
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, err
+		}
+		if header.Name == manifestName {
+			manifest = data
+			continue
+		}
+		b.Files[header.Name] = data
+	}
+
+	if manifest == nil {
+		return nil, errors.New("bundle has no " + manifestName)
+	}
+	if err := json.Unmarshal(manifest, &b.Manifest); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", manifestName, err)
+	}
+	if b.Manifest.Version != Version {
+		return nil, fmt.Errorf("unsupported bundle version %d", b.Manifest.Version)
+	}
+	for _, notebook := range b.Manifest.Notebooks {
+		if _, ok := b.Files[notebook.File]; !ok {
+			return nil, fmt.Errorf("bundle misses %s, the export of %s", notebook.File, notebook.Path)
+		}
+	}
+	return b, nil
+}
@@ -0,0 +1,90 @@
+package bundle
+
+import (
+	"encoding/json"
+)
+
+// clusterSpecFields are the fields of a cluster description that the create
+// API accepts. Everything else, such as the state or the driver node, only
+// describes the running cluster.
+var clusterSpecFields = []string{
+	"apply_policy_default_values",
+	"autoscale",
+	"autotermination_minutes",
+	"aws_attributes",
+	"azure_attributes",
+	"cluster_log_conf",
+	"cluster_name",
+	"custom_tags",
+	"data_security_mode",
+	"docker_image",
+	"driver_instance_pool_id",
+	"driver_node_type_id",
+	"enable_elastic_disk",
+	"enable_local_disk_encryption",
+	"gcp_attributes",
+	"init_scripts",
+	"instance_pool_id",
+	"is_single_node",
+	"kind",
+	"node_type_id",
+	"num_workers",
+	"policy_id",
+	"runtime_engine",
+	"single_user_name",
+	"spark_conf",
+	"spark_env_vars",
+	"spark_version",
+	"ssh_public_keys",
+	"use_ml_runtime",
+	"workload_type",
+}
+
+// ClusterSpec reduces a cluster description returned by the get API to a
+// create request.
+func ClusterSpec(info json.RawMessage) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(info, &fields); err != nil {
+		return nil, err
+	}
+
+	spec := make(map[string]json.RawMessage, len(clusterSpecFields))
+	for _, name := range clusterSpecFields {
+		if value, ok := fields[name]; ok {
+			spec[name] = value
+		}
+	}
+	return json.Marshal(spec)
+}
+
+// RemapClusters rewrites the existing_cluster_id of the tasks of job settings
+// found in clusterIDs, so restored jobs run on restored clusters.
+func RemapClusters(settings json.RawMessage, clusterIDs map[string]string) (json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(settings, &fields); err != nil {
+		return nil, err
+	}
+	if _, ok := fields["tasks"]; !ok {
+		return settings, nil
+	}
+
+	var tasks []map[string]json.RawMessage
+	if err := json.Unmarshal(fields["tasks"], &tasks); err != nil {
+		return nil, err
+	}
+	for _, task := range tasks {
+		var clusterID string
+		if raw, ok := task["existing_cluster_id"]; !ok || json.Unmarshal(raw, &clusterID) != nil {
+			continue
+		}
+		if restored, ok := clusterIDs[clusterID]; ok {
+			task["existing_cluster_id"], _ = json.Marshal(restored)
+		}
+	}
+
+	var err error
+	if fields["tasks"], err = json.Marshal(tasks); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
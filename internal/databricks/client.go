@@ -0,0 +1,119 @@
+package databricks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Client issues REST API calls against a single Databricks workspace.
+type Client struct {
+	httpClient *http.Client
+	host       string
+	token      string
+	uploads    Limiter
+	// consistencyTimeout bounds the wait for DBFS to report an uploaded
+	// file. Zero does not wait.
+	consistencyTimeout time.Duration
+}
+
+// NewClient returns a Client for the workspace at host, authenticating with
+// token and sending requests through httpClient.
+func NewClient(httpClient *http.Client, host, token string) *Client {
+	return &Client{
+		httpClient: httpClient,
+		host:       WorkspaceURL(host),
+		token:      token,
+	}
+}
+
+// WorkspaceURL returns the base URL of the workspace at host, which may be
+// given with or without the https:// scheme. Workspaces on any cloud are
+// accepted, such as adb-1234.5.azuredatabricks.net or
+// dbc-a1b2c3d4-e5f6.cloud.databricks.com.
+func WorkspaceURL(host string) string {
+	host = strings.TrimRight(strings.TrimSpace(host), "/")
+	if !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+	return host
+}
+
+// APIError is the error payload returned by the Databricks REST API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	ErrorCode  string `json:"error_code"`
+	Message    string `json:"message"`
+}
+
+// Error implements error.
+func (e *APIError) Error() string {
+	if e.ErrorCode == "" {
+		return fmt.Sprintf("databricks request failed with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("databricks request failed with status %d: %s: %s", e.StatusCode, e.ErrorCode, e.Message)
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusNotFound || apiErr.ErrorCode == "RESOURCE_DOES_NOT_EXIST"
+}
+
+// notFound turns the INVALID_PARAMETER_VALUE error that the clusters and
+// jobs APIs return for a deleted object into one IsNotFound reports true for.
+func notFound(err error) error {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode == "INVALID_PARAMETER_VALUE" && strings.Contains(apiErr.Message, "does not exist") {
+		return &APIError{StatusCode: http.StatusNotFound, ErrorCode: "RESOURCE_DOES_NOT_EXIST", Message: apiErr.Message}
+	}
+	return err
+}
+
+// Do sends a request to the API path p, encoding in as the JSON body when it
+// is not nil and decoding the JSON response into out when it is not nil.
+func (c *Client) Do(ctx context.Context, method, p string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.host+p, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return apiErr
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,55 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ClusterPolicy is a cluster policy. Definition is the JSON document of the
+// policy rules. A policy created from a policy family has no definition of
+// its own: PolicyFamilyDefinitionOverrides, a JSON document too, is merged
+// into the family definition and Definition is the result when read.
+type ClusterPolicy struct {
+	PolicyID                        string `json:"policy_id,omitempty"`
+	Name                            string `json:"name"`
+	Definition                      string `json:"definition,omitempty"`
+	Description                     string `json:"description,omitempty"`
+	MaxClustersPerUser              int64  `json:"max_clusters_per_user,omitempty"`
+	PolicyFamilyID                  string `json:"policy_family_id,omitempty"`
+	PolicyFamilyDefinitionOverrides string `json:"policy_family_definition_overrides,omitempty"`
+}
+
+// CreateClusterPolicy creates a cluster policy and returns its ID.
+func (c *Client) CreateClusterPolicy(ctx context.Context, policy ClusterPolicy) (string, error) {
+	var result struct {
+		PolicyID string `json:"policy_id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/policies/clusters/create", policy, &result); err != nil {
+		return "", err
+	}
+	return result.PolicyID, nil
+}
+
+// GetClusterPolicy returns a cluster policy. A policy that does not exist
+// gives an error for which IsNotFound reports true.
+func (c *Client) GetClusterPolicy(ctx context.Context, policyID string) (*ClusterPolicy, error) {
+	var policy ClusterPolicy
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/policies/clusters/get?policy_id="+url.QueryEscape(policyID), nil, &policy); err != nil {
+		return nil, notFound(err)
+	}
+	return &policy, nil
+}
+
+// EditClusterPolicy replaces the policy policy.PolicyID. Fields left empty
+// are cleared, so the whole policy must be sent. Clusters governed by the
+// policy are not changed until they are edited.
+func (c *Client) EditClusterPolicy(ctx context.Context, policy ClusterPolicy) error {
+	return notFound(c.Do(ctx, http.MethodPost, "/api/2.0/policies/clusters/edit", policy, nil))
+}
+
+// DeleteClusterPolicy deletes a cluster policy. Clusters governed by it keep
+// running but can no longer be edited until given another policy.
+func (c *Client) DeleteClusterPolicy(ctx context.Context, policyID string) error {
+	return notFound(c.Do(ctx, http.MethodPost, "/api/2.0/policies/clusters/delete", map[string]interface{}{"policy_id": policyID}, nil))
+}
@@ -0,0 +1,251 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// Cluster states.
+const (
+	ClusterPending     = "PENDING"
+	ClusterRunning     = "RUNNING"
+	ClusterRestarting  = "RESTARTING"
+	ClusterResizing    = "RESIZING"
+	ClusterTerminating = "TERMINATING"
+	ClusterTerminated  = "TERMINATED"
+	ClusterError       = "ERROR"
+)
+
+// ClusterSourceJob is the cluster source of the clusters created by job runs.
+const ClusterSourceJob = "JOB"
+
+// clusterPollInterval is how often a starting cluster is polled.
+const clusterPollInterval = 10 * time.Second
+
+// ClusterSpec is the configuration of a cluster accepted by the create and
+// edit APIs.
+type ClusterSpec struct {
+	ClusterID              string            `json:"cluster_id,omitempty"`
+	ClusterName            string            `json:"cluster_name,omitempty"`
+	SparkVersion           string            `json:"spark_version"`
+	NodeTypeID             string            `json:"node_type_id,omitempty"`
+	DriverNodeTypeID       string            `json:"driver_node_type_id,omitempty"`
+	InstancePoolID         string            `json:"instance_pool_id,omitempty"`
+	PolicyID               string            `json:"policy_id,omitempty"`
+	NumWorkers             int64             `json:"num_workers"`
+	Autoscale              *Autoscale        `json:"autoscale,omitempty"`
+	AutoterminationMinutes int64             `json:"autotermination_minutes,omitempty"`
+	SparkConf              map[string]string `json:"spark_conf,omitempty"`
+	CustomTags             map[string]string `json:"custom_tags,omitempty"`
+	InitScripts            []InitScript      `json:"init_scripts,omitempty"`
+}
+
+// Autoscale bounds the number of workers of an autoscaling cluster.
+type Autoscale struct {
+	MinWorkers int64 `json:"min_workers"`
+	MaxWorkers int64 `json:"max_workers"`
+}
+
+// InitScript is a script run on every node of a cluster when it starts.
+type InitScript struct {
+	Dbfs *InitScriptDestination `json:"dbfs,omitempty"`
+}
+
+// InitScriptDestination is the location of an init script.
+type InitScriptDestination struct {
+	Destination string `json:"destination"`
+}
+
+// ClusterInfo describes a cluster.
+type ClusterInfo struct {
+	ClusterSpec
+	State         string `json:"state"`
+	StateMessage  string `json:"state_message"`
+	ClusterSource string `json:"cluster_source"`
+}
+
+// ClusterEventsRequest filters the events of a cluster. Times are in epoch
+// milliseconds; zero values are not sent.
+type ClusterEventsRequest struct {
+	ClusterID  string   `json:"cluster_id"`
+	StartTime  int64    `json:"start_time,omitempty"`
+	EndTime    int64    `json:"end_time,omitempty"`
+	Order      string   `json:"order,omitempty"`
+	EventTypes []string `json:"event_types,omitempty"`
+	Limit      int64    `json:"limit,omitempty"`
+}
+
+// ClusterEvent is an entry of the event log of a cluster.
+type ClusterEvent struct {
+	ClusterID string          `json:"cluster_id"`
+	Timestamp int64           `json:"timestamp"`
+	Type      string          `json:"type"`
+	Details   json.RawMessage `json:"details,omitempty"`
+}
+
+// ClusterEvents returns the first page of cluster events matching req. The
+// API returns at most 500 events per page.
+func (c *Client) ClusterEvents(ctx context.Context, req ClusterEventsRequest) ([]ClusterEvent, error) {
+	var result struct {
+		Events []ClusterEvent `json:"events"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/clusters/events", req, &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// GetCluster returns the description of a cluster as returned by the API,
+// including runtime fields the create API does not accept.
+func (c *Client) GetCluster(ctx context.Context, clusterID string) (json.RawMessage, error) {
+	var info json.RawMessage
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/clusters/get?cluster_id="+url.QueryEscape(clusterID), nil, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// GetClusterInfo returns the decoded description of a cluster. A cluster
+// that does not exist gives an error for which IsNotFound reports true.
+func (c *Client) GetClusterInfo(ctx context.Context, clusterID string) (*ClusterInfo, error) {
+	var info ClusterInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/clusters/get?cluster_id="+url.QueryEscape(clusterID), nil, &info); err != nil {
+		return nil, notFound(err)
+	}
+	return &info, nil
+}
+
+// ListClusters returns every cluster of the workspace, following the pages
+// of the list API.
+func (c *Client) ListClusters(ctx context.Context) ([]ClusterInfo, error) {
+	var clusters []ClusterInfo
+	pageToken := ""
+	for {
+		var result struct {
+			Clusters      []ClusterInfo `json:"clusters"`
+			NextPageToken string        `json:"next_page_token"`
+		}
+		p := "/api/2.1/clusters/list?page_size=100"
+		if pageToken != "" {
+			p += "&page_token=" + url.QueryEscape(pageToken)
+		}
+		if err := c.Do(ctx, http.MethodGet, p, nil, &result); err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, result.Clusters...)
+		if result.NextPageToken == "" {
+			return clusters, nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+// CreateCluster creates and starts a cluster from spec, a ClusterSpec or its
+// JSON encoding, and returns its ID.
+func (c *Client) CreateCluster(ctx context.Context, spec interface{}) (string, error) {
+	var result struct {
+		ClusterID string `json:"cluster_id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/clusters/create", spec, &result); err != nil {
+		return "", err
+	}
+	return result.ClusterID, nil
+}
+
+// StartCluster starts a terminated cluster.
+func (c *Client) StartCluster(ctx context.Context, clusterID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.1/clusters/start", map[string]interface{}{"cluster_id": clusterID}, nil)
+}
+
+// TerminateCluster stops a cluster. Its configuration is kept.
+func (c *Client) TerminateCluster(ctx context.Context, clusterID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.1/clusters/delete", map[string]interface{}{"cluster_id": clusterID}, nil)
+}
+
+// PermanentDeleteCluster terminates a cluster and removes it.
+func (c *Client) PermanentDeleteCluster(ctx context.Context, clusterID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.1/clusters/permanent-delete", map[string]interface{}{"cluster_id": clusterID}, nil)
+}
+
+// EditCluster replaces the configuration of the cluster spec.ClusterID. A
+// running cluster is restarted to apply it.
+func (c *Client) EditCluster(ctx context.Context, spec ClusterSpec) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.1/clusters/edit", spec, nil)
+}
+
+// WaitCluster waits until the cluster is running, for at most timeout, or
+// until ctx is done when timeout is zero, and returns its description. A
+// cluster that ends in the ERROR state, or terminates while starting, gives
+// an error with its state message.
+func (c *Client) WaitCluster(ctx context.Context, clusterID string, timeout time.Duration) (*ClusterInfo, error) {
+	var info *ClusterInfo
+	refresh := func(ctx context.Context) (string, error) {
+		var err error
+		info, err = c.GetClusterInfo(ctx, clusterID)
+		if err != nil {
+			return "", err
+		}
+		return info.State, nil
+	}
+
+	_, err := poll.WaitForState(ctx, refresh,
+		[]string{ClusterRunning},
+		[]string{ClusterPending, ClusterRestarting, ClusterResizing, ClusterTerminating},
+		timeout, clusterPollInterval)
+	var unexpected *poll.UnexpectedStateError
+	if errors.As(err, &unexpected) {
+		return info, fmt.Errorf("cluster %s is %s: %s", clusterID, info.State, info.StateMessage)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// SparkVersion is a Databricks runtime version a cluster can run.
+type SparkVersion struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// SparkVersions returns the Databricks runtime versions available in the
+// workspace.
+func (c *Client) SparkVersions(ctx context.Context) ([]SparkVersion, error) {
+	var result struct {
+		Versions []SparkVersion `json:"versions"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/clusters/spark-versions", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Versions, nil
+}
+
+// NodeType is a node type clusters of the workspace can use.
+type NodeType struct {
+	NodeTypeID          string  `json:"node_type_id"`
+	MemoryMB            int64   `json:"memory_mb"`
+	NumCores            float64 `json:"num_cores"`
+	NumGPUs             int64   `json:"num_gpus"`
+	Category            string  `json:"category"`
+	IsDeprecated        bool    `json:"is_deprecated"`
+	IsHidden            bool    `json:"is_hidden"`
+	PhotonWorkerCapable bool    `json:"photon_worker_capable"`
+	PhotonDriverCapable bool    `json:"photon_driver_capable"`
+}
+
+// NodeTypes returns the node types available in the workspace.
+func (c *Client) NodeTypes(ctx context.Context) ([]NodeType, error) {
+	var result struct {
+		NodeTypes []NodeType `json:"node_types"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/clusters/list-node-types", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.NodeTypes, nil
+}
@@ -0,0 +1,132 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// Command states of the command execution API.
+const (
+	CommandFinished  = "Finished"
+	CommandCancelled = "Cancelled"
+	CommandError     = "Error"
+)
+
+// commandPollInterval is how often a running command is polled.
+const commandPollInterval = 2 * time.Second
+
+// CommandResults is the output of a finished command.
+type CommandResults struct {
+	// ResultType is text for a command that succeeded and error for one
+	// that raised.
+	ResultType string      `json:"resultType"`
+	Data       interface{} `json:"data"`
+	Summary    string      `json:"summary"`
+	Cause      string      `json:"cause"`
+}
+
+// CommandStatus is the state of a command.
+type CommandStatus struct {
+	ID      string          `json:"id"`
+	Status  string          `json:"status"`
+	Results *CommandResults `json:"results"`
+}
+
+// CreateContext creates an execution context for language on a running
+// cluster and returns its ID.
+func (c *Client) CreateContext(ctx context.Context, clusterID, language string) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	body := map[string]interface{}{"clusterId": clusterID, "language": language}
+	if err := c.Do(ctx, http.MethodPost, "/api/1.2/contexts/create", body, &result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// DestroyContext removes an execution context and the state it holds.
+func (c *Client) DestroyContext(ctx context.Context, clusterID, contextID string) error {
+	body := map[string]interface{}{"clusterId": clusterID, "contextId": contextID}
+	return c.Do(ctx, http.MethodPost, "/api/1.2/contexts/destroy", body, nil)
+}
+
+// ExecuteCommand starts command in an execution context and returns the
+// command ID.
+func (c *Client) ExecuteCommand(ctx context.Context, clusterID, contextID, language, command string) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	body := map[string]interface{}{
+		"clusterId": clusterID,
+		"contextId": contextID,
+		"language":  language,
+		"command":   command,
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/1.2/commands/execute", body, &result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// GetCommandStatus returns the state of a command, with its results once it
+// finished.
+func (c *Client) GetCommandStatus(ctx context.Context, clusterID, contextID, commandID string) (*CommandStatus, error) {
+	query := url.Values{
+		"clusterId": {clusterID},
+		"contextId": {contextID},
+		"commandId": {commandID},
+	}
+	var status CommandStatus
+	if err := c.Do(ctx, http.MethodGet, "/api/1.2/commands/status?"+query.Encode(), nil, &status); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// RunCommand runs command on a running cluster in a new execution context,
+// waits until it finishes and returns its text output. A command that
+// raises gives an error with its summary and cause. The context is
+// destroyed afterwards.
+func (c *Client) RunCommand(ctx context.Context, clusterID, language, command string) (string, error) {
+	contextID, err := c.CreateContext(ctx, clusterID, language)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = c.DestroyContext(context.WithoutCancel(ctx), clusterID, contextID)
+	}()
+
+	commandID, err := c.ExecuteCommand(ctx, clusterID, contextID, language, command)
+	if err != nil {
+		return "", err
+	}
+
+	var status *CommandStatus
+	check := func(ctx context.Context) (bool, time.Duration, error) {
+		var err error
+		status, err = c.GetCommandStatus(ctx, clusterID, contextID, commandID)
+		if err != nil {
+			return false, 0, err
+		}
+		return poll.StateIn(CommandFinished, CommandCancelled, CommandError)(status.Status), 0, nil
+	}
+	if err := (poll.Poller{Interval: commandPollInterval}).Wait(ctx, check); err != nil {
+		return "", err
+	}
+
+	if status.Results == nil || status.Status != CommandFinished {
+		return "", fmt.Errorf("command %s is %s", commandID, status.Status)
+	}
+	if status.Results.ResultType == "error" {
+		return "", fmt.Errorf("command %s failed: %s: %s", commandID, status.Results.Summary, status.Results.Cause)
+	}
+	if text, ok := status.Results.Data.(string); ok {
+		return text, nil
+	}
+	return "", nil
+}
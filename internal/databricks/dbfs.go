@@ -0,0 +1,267 @@
+package databricks
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// DbfsBlockSize is the largest block the DBFS add-block API accepts.
+const DbfsBlockSize = 1 << 20
+
+// DefaultConsistencyTimeout is how long an upload waits by default for DBFS to
+// report the uploaded file.
+const DefaultConsistencyTimeout = 30 * time.Second
+
+// dbfsStatusPollInterval is the wait between status checks of an uploaded
+// file. DBFS usually catches up within a second or two.
+const dbfsStatusPollInterval = 500 * time.Millisecond
+
+// States of an uploaded file while DBFS catches up with the upload.
+const (
+	dbfsStatusMissing  = "missing"
+	dbfsStatusPartial  = "partial"
+	dbfsStatusComplete = "complete"
+)
+
+// FileInfo describes a DBFS file or directory.
+type FileInfo struct {
+	Path             string `json:"path"`
+	IsDir            bool   `json:"is_dir"`
+	FileSize         int64  `json:"file_size"`
+	ModificationTime int64  `json:"modification_time"`
+}
+
+// DbfsPut uploads the content of r to path through the streaming
+// create/add-block/close API, overwriting an existing file. It returns the hex
+// MD5 of the uploaded content.
+func (c *Client) DbfsPut(ctx context.Context, path string, r io.Reader) (string, error) {
+	return c.DbfsPutBlocks(ctx, path, r, DbfsBlockSize)
+}
+
+// DbfsPutBlocks is DbfsPut with blocks of blockSize bytes, at most
+// DbfsBlockSize. Only one block is held in memory at a time. The upload waits
+// for a slot of the upload Limiter of c, if any, and afterwards for DBFS to
+// report the file, as set by WithConsistencyTimeout.
+func (c *Client) DbfsPutBlocks(ctx context.Context, path string, r io.Reader, blockSize int) (string, error) {
+	if blockSize <= 0 || blockSize > DbfsBlockSize {
+		return "", fmt.Errorf("block size %d is not between 1 and %d bytes", blockSize, DbfsBlockSize)
+	}
+	if err := c.uploads.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer c.uploads.release()
+
+	var handle struct {
+		Handle int64 `json:"handle"`
+	}
+	create := map[string]interface{}{"path": path, "overwrite": true}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/dbfs/create", create, &handle); err != nil {
+		return "", err
+	}
+
+	// A failed upload closes its handle rather than leaking it. The close
+	// error is ignored in favour of that of the upload, and the close is
+	// sent even when ctx is cancelled.
+	abort := func() {
+		_ = c.Do(context.WithoutCancel(ctx), http.MethodPost, "/api/2.0/dbfs/close", map[string]interface{}{"handle": handle.Handle}, nil)
+	}
+
+	hash := md5.New()
+	buf := make([]byte, blockSize)
+	var size int64
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if n > 0 {
+			size += int64(n)
+			hash.Write(buf[:n])
+			block := map[string]interface{}{
+				"handle": handle.Handle,
+				"data":   base64.StdEncoding.EncodeToString(buf[:n]),
+			}
+			if err := c.Do(ctx, http.MethodPost, "/api/2.0/dbfs/add-block", block, nil); err != nil {
+				abort()
+				return "", err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			break
+		}
+		if readErr != nil {
+			abort()
+			return "", readErr
+		}
+	}
+
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/dbfs/close", map[string]interface{}{"handle": handle.Handle}, nil); err != nil {
+		return "", err
+	}
+	if c.consistencyTimeout > 0 {
+		if _, err := c.DbfsWaitStatus(ctx, path, size, c.consistencyTimeout); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// WithConsistencyTimeout makes uploads of c wait up to timeout for DBFS to
+// report the uploaded file with its full size, so that a status read right
+// after the upload does not see a missing or stale file. Zero, the default of
+// NewClient, does not wait. It returns c.
+func (c *Client) WithConsistencyTimeout(timeout time.Duration) *Client {
+	c.consistencyTimeout = timeout
+	return c
+}
+
+// DbfsWaitStatus polls the status of the DBFS file at path until it exists
+// with size bytes, for at most timeout, and returns it. Not found errors are
+// retried; other errors are returned at once.
+func (c *Client) DbfsWaitStatus(ctx context.Context, path string, size int64, timeout time.Duration) (*FileInfo, error) {
+	var info *FileInfo
+	refresh := func(ctx context.Context) (string, error) {
+		var err error
+		info, err = c.DbfsGetStatus(ctx, path)
+		if IsNotFound(err) {
+			info = nil
+			return dbfsStatusMissing, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		if info.IsDir || info.FileSize != size {
+			return dbfsStatusPartial, nil
+		}
+		return dbfsStatusComplete, nil
+	}
+
+	_, err := poll.WaitForState(ctx, refresh,
+		[]string{dbfsStatusComplete},
+		[]string{dbfsStatusMissing, dbfsStatusPartial},
+		timeout, dbfsStatusPollInterval)
+	var timeoutErr *poll.TimeoutError
+	if errors.As(err, &timeoutErr) {
+		if info == nil {
+			return nil, fmt.Errorf("%s was uploaded but DBFS did not report it within %s", path, timeout)
+		}
+		return nil, fmt.Errorf("%s was uploaded with %d bytes but DBFS still reports %d bytes after %s", path, size, info.FileSize, timeout)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// DbfsGetStatus returns the status of a DBFS file or directory.
+func (c *Client) DbfsGetStatus(ctx context.Context, path string) (*FileInfo, error) {
+	var info FileInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/dbfs/get-status?path="+url.QueryEscape(path), nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// DbfsList lists the content of a DBFS directory, or the file itself when
+// path is a file.
+func (c *Client) DbfsList(ctx context.Context, path string) ([]FileInfo, error) {
+	var list struct {
+		Files []FileInfo `json:"files"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/dbfs/list?path="+url.QueryEscape(path), nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Files, nil
+}
+
+// dbfsWalkParallelism bounds the directories DbfsWalk lists at once.
+const dbfsWalkParallelism = 8
+
+// DbfsWalk lists the directory root and, with recursive set, the directories
+// under it. The DBFS list API returns a directory in a single response, so
+// each directory is a page: fn is called with the entries of one directory at
+// a time, as its listing arrives, and only the paths of the directories left
+// to list are held meanwhile. Up to dbfsWalkParallelism directories are
+// listed concurrently, so pages arrive in no particular order; fn is never
+// called concurrently. The walk stops at the first error of a listing or of
+// fn.
+func (c *Client) DbfsWalk(ctx context.Context, root string, recursive bool, fn func([]FileInfo) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type page struct {
+		dir   string
+		files []FileInfo
+		err   error
+	}
+	pages := make(chan page)
+	pending := []string{root}
+	running := 0
+	var walkErr error
+	for {
+		for walkErr == nil && running < dbfsWalkParallelism && len(pending) > 0 {
+			dir := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			running++
+			go func() {
+				files, err := c.DbfsList(ctx, dir)
+				pages <- page{dir: dir, files: files, err: err}
+			}()
+		}
+		if running == 0 {
+			return walkErr
+		}
+
+		p := <-pages
+		running--
+		if walkErr != nil {
+			// Drain the listings still running.
+			continue
+		}
+		if p.err != nil {
+			walkErr = p.err
+			if p.dir != root {
+				walkErr = fmt.Errorf("%s: %w", p.dir, p.err)
+			}
+			cancel()
+			continue
+		}
+		if err := fn(p.files); err != nil {
+			walkErr = err
+			cancel()
+			continue
+		}
+		if recursive {
+			for _, file := range p.files {
+				if file.IsDir {
+					pending = append(pending, file.Path)
+				}
+			}
+		}
+	}
+}
+
+// DbfsDelete deletes a DBFS file or, with recursive set, a directory.
+func (c *Client) DbfsDelete(ctx context.Context, path string, recursive bool) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/dbfs/delete", map[string]interface{}{"path": path, "recursive": recursive}, nil)
+}
+
+// DbfsRead reads up to length bytes of a DBFS file from offset. Fewer bytes
+// are returned at the end of the file.
+func (c *Client) DbfsRead(ctx context.Context, path string, offset, length int64) ([]byte, error) {
+	var chunk struct {
+		BytesRead int64  `json:"bytes_read"`
+		Data      string `json:"data"`
+	}
+	p := fmt.Sprintf("/api/2.0/dbfs/read?path=%s&offset=%d&length=%d", url.QueryEscape(path), offset, length)
+	if err := c.Do(ctx, http.MethodGet, p, nil, &chunk); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(chunk.Data)
+}
@@ -0,0 +1,113 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// VolumeFileInfo is the metadata of a file in a Unity Catalog volume.
+type VolumeFileInfo struct {
+	Size         int64
+	LastModified time.Time
+}
+
+// filesPath returns the Files API path of the volume file at p, such as
+// /Volumes/main/default/libs/app.jar.
+func filesPath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/api/2.0/fs/files/" + strings.Join(segments, "/")
+}
+
+// doRaw sends a request with a raw body of size bytes and returns the
+// response of a successful call. Failed calls give an APIError; HEAD
+// responses carry no error payload, so only the status is set then.
+func (c *Client) doRaw(ctx context.Context, method, p string, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.host+p, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/octet-stream")
+		req.ContentLength = size
+		if size == 0 {
+			req.Body = http.NoBody
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		_ = json.NewDecoder(resp.Body).Decode(apiErr)
+		return nil, apiErr
+	}
+	return resp, nil
+}
+
+// FilesUpload uploads size bytes read from r to the volume file at path,
+// overwriting an existing file. Missing parent directories are created. The
+// upload waits for a slot of the upload Limiter of c, if any.
+func (c *Client) FilesUpload(ctx context.Context, path string, r io.Reader, size int64) error {
+	if err := c.uploads.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.uploads.release()
+	resp, err := c.doRaw(ctx, http.MethodPut, filesPath(path)+"?overwrite=true", r, size)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
+
+// FilesGetMetadata returns the size and modification time of the volume file
+// at path. A missing file gives an error for which IsNotFound reports true.
+func (c *Client) FilesGetMetadata(ctx context.Context, path string) (*VolumeFileInfo, error) {
+	resp, err := c.doRaw(ctx, http.MethodHead, filesPath(path), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	info := &VolumeFileInfo{}
+	if info.Size, err = strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err != nil {
+		return nil, err
+	}
+	if info.LastModified, err = http.ParseTime(resp.Header.Get("Last-Modified")); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// FilesDownload returns the content of the volume file at path. The caller
+// closes it.
+func (c *Client) FilesDownload(ctx context.Context, path string) (io.ReadCloser, error) {
+	resp, err := c.doRaw(ctx, http.MethodGet, filesPath(path), nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// FilesDelete deletes the volume file at path.
+func (c *Client) FilesDelete(ctx context.Context, path string) error {
+	resp, err := c.doRaw(ctx, http.MethodDelete, filesPath(path), nil, 0)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return resp.Body.Close()
+}
@@ -0,0 +1,85 @@
+package databricks
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+)
+
+// GlobalInitScriptMaxBytes is the largest script the global init scripts API
+// accepts.
+const GlobalInitScriptMaxBytes = 64 << 10
+
+// GlobalInitScript is a script run on every cluster of the workspace when it
+// starts.
+type GlobalInitScript struct {
+	ScriptID  string `json:"script_id"`
+	Name      string `json:"name"`
+	Enabled   bool   `json:"enabled"`
+	Position  int64  `json:"position"`
+	CreatedAt int64  `json:"created_at"`
+	UpdatedAt int64  `json:"updated_at"`
+	// Content is the decoded body of the script. It is only set by
+	// GetGlobalInitScript.
+	Content []byte `json:"-"`
+}
+
+func globalInitScriptPath(id string) string {
+	return "/api/2.0/global-init-scripts/" + id
+}
+
+// globalInitScriptBody returns the request body of a create or update.
+// A negative position appends the script after the existing ones.
+func globalInitScriptBody(name string, content []byte, enabled bool, position int64) map[string]interface{} {
+	body := map[string]interface{}{
+		"name":    name,
+		"script":  base64.StdEncoding.EncodeToString(content),
+		"enabled": enabled,
+	}
+	if position >= 0 {
+		body["position"] = position
+	}
+	return body
+}
+
+// CreateGlobalInitScript creates a global init script at position, or after
+// the existing scripts when position is negative, and returns its ID.
+func (c *Client) CreateGlobalInitScript(ctx context.Context, name string, content []byte, enabled bool, position int64) (string, error) {
+	var out struct {
+		ScriptID string `json:"script_id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/global-init-scripts", globalInitScriptBody(name, content, enabled, position), &out); err != nil {
+		return "", err
+	}
+	return out.ScriptID, nil
+}
+
+// GetGlobalInitScript returns a global init script with its content. A script
+// that does not exist gives an error for which IsNotFound reports true.
+func (c *Client) GetGlobalInitScript(ctx context.Context, id string) (*GlobalInitScript, error) {
+	var out struct {
+		GlobalInitScript
+		Script string `json:"script"`
+	}
+	if err := c.Do(ctx, http.MethodGet, globalInitScriptPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	content, err := base64.StdEncoding.DecodeString(out.Script)
+	if err != nil {
+		return nil, err
+	}
+	script := out.GlobalInitScript
+	script.Content = content
+	return &script, nil
+}
+
+// UpdateGlobalInitScript replaces the name, content, state and position of a
+// global init script. A negative position keeps the current one.
+func (c *Client) UpdateGlobalInitScript(ctx context.Context, id, name string, content []byte, enabled bool, position int64) error {
+	return c.Do(ctx, http.MethodPatch, globalInitScriptPath(id), globalInitScriptBody(name, content, enabled, position), nil)
+}
+
+// DeleteGlobalInitScript deletes a global init script.
+func (c *Client) DeleteGlobalInitScript(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, globalInitScriptPath(id), nil, nil)
+}
@@ -0,0 +1,90 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Instance pool states.
+const (
+	InstancePoolActive  = "ACTIVE"
+	InstancePoolDeleted = "DELETED"
+)
+
+// Azure availability types of pool instances.
+const (
+	AvailabilitySpotAzure     = "SPOT_AZURE"
+	AvailabilityOnDemandAzure = "ON_DEMAND_AZURE"
+)
+
+// InstancePoolSpec is the configuration of an instance pool accepted by the
+// create and edit APIs.
+type InstancePoolSpec struct {
+	InstancePoolID                     string                  `json:"instance_pool_id,omitempty"`
+	InstancePoolName                   string                  `json:"instance_pool_name"`
+	NodeTypeID                         string                  `json:"node_type_id"`
+	MinIdleInstances                   int64                   `json:"min_idle_instances"`
+	MaxCapacity                        int64                   `json:"max_capacity,omitempty"`
+	IdleInstanceAutoterminationMinutes int64                   `json:"idle_instance_autotermination_minutes"`
+	PreloadedSparkVersions             []string                `json:"preloaded_spark_versions,omitempty"`
+	AzureAttributes                    *InstancePoolAzureAttrs `json:"azure_attributes,omitempty"`
+	CustomTags                         map[string]string       `json:"custom_tags,omitempty"`
+}
+
+// InstancePoolAzureAttrs sets the availability of the instances of a pool on
+// Azure. SpotBidMaxPrice is the highest price paid for spot instances, in US
+// dollars, or -1 to pay up to the on-demand price.
+type InstancePoolAzureAttrs struct {
+	Availability    string  `json:"availability,omitempty"`
+	SpotBidMaxPrice float64 `json:"spot_bid_max_price,omitempty"`
+}
+
+// InstancePoolInfo describes an instance pool.
+type InstancePoolInfo struct {
+	InstancePoolSpec
+	State string `json:"state"`
+}
+
+// CreateInstancePool creates an instance pool and returns its ID.
+func (c *Client) CreateInstancePool(ctx context.Context, spec InstancePoolSpec) (string, error) {
+	var result struct {
+		InstancePoolID string `json:"instance_pool_id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/instance-pools/create", spec, &result); err != nil {
+		return "", err
+	}
+	return result.InstancePoolID, nil
+}
+
+// GetInstancePool returns the description of an instance pool. A pool that
+// does not exist gives an error for which IsNotFound reports true.
+func (c *Client) GetInstancePool(ctx context.Context, instancePoolID string) (*InstancePoolInfo, error) {
+	var info InstancePoolInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/instance-pools/get?instance_pool_id="+url.QueryEscape(instancePoolID), nil, &info); err != nil {
+		return nil, notFound(err)
+	}
+	return &info, nil
+}
+
+// EditInstancePool changes the name, sizes and idle timeout of the pool
+// spec.InstancePoolID. The node type cannot change but must be sent.
+func (c *Client) EditInstancePool(ctx context.Context, spec InstancePoolSpec) error {
+	body := map[string]interface{}{
+		"instance_pool_id":                      spec.InstancePoolID,
+		"instance_pool_name":                    spec.InstancePoolName,
+		"node_type_id":                          spec.NodeTypeID,
+		"min_idle_instances":                    spec.MinIdleInstances,
+		"idle_instance_autotermination_minutes": spec.IdleInstanceAutoterminationMinutes,
+	}
+	if spec.MaxCapacity > 0 {
+		body["max_capacity"] = spec.MaxCapacity
+	}
+	return c.Do(ctx, http.MethodPost, "/api/2.0/instance-pools/edit", body, nil)
+}
+
+// DeleteInstancePool deletes an instance pool. Its idle instances are
+// terminated; clusters using it keep running.
+func (c *Client) DeleteInstancePool(ctx context.Context, instancePoolID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/instance-pools/delete", map[string]interface{}{"instance_pool_id": instancePoolID}, nil)
+}
@@ -0,0 +1,73 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+)
+
+// IP access list types.
+const (
+	IPAccessListAllow = "ALLOW"
+	IPAccessListBlock = "BLOCK"
+)
+
+// IPAccessList is a list of IP addresses and CIDR ranges allowed or blocked
+// from the workspace.
+type IPAccessList struct {
+	ListID       string   `json:"list_id,omitempty"`
+	Label        string   `json:"label"`
+	ListType     string   `json:"list_type"`
+	IPAddresses  []string `json:"ip_addresses"`
+	Enabled      bool     `json:"enabled"`
+	AddressCount int64    `json:"address_count,omitempty"`
+}
+
+func ipAccessListPath(id string) string {
+	return "/api/2.0/ip-access-lists/" + id
+}
+
+// CreateIPAccessList creates an enabled IP access list and returns it. The
+// workspace rejects lists that block the IP address of the caller.
+func (c *Client) CreateIPAccessList(ctx context.Context, list IPAccessList) (*IPAccessList, error) {
+	body := map[string]interface{}{
+		"label":        list.Label,
+		"list_type":    list.ListType,
+		"ip_addresses": list.IPAddresses,
+	}
+	var out struct {
+		IPAccessList IPAccessList `json:"ip_access_list"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/ip-access-lists", body, &out); err != nil {
+		return nil, err
+	}
+	return &out.IPAccessList, nil
+}
+
+// GetIPAccessList returns an IP access list. A list that does not exist
+// gives an error for which IsNotFound reports true.
+func (c *Client) GetIPAccessList(ctx context.Context, id string) (*IPAccessList, error) {
+	var out struct {
+		IPAccessList IPAccessList `json:"ip_access_list"`
+	}
+	if err := c.Do(ctx, http.MethodGet, ipAccessListPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.IPAccessList, nil
+}
+
+// ReplaceIPAccessList replaces the label, type, addresses and state of the
+// IP access list list.ListID.
+func (c *Client) ReplaceIPAccessList(ctx context.Context, list IPAccessList) error {
+	body := map[string]interface{}{
+		"label":        list.Label,
+		"list_type":    list.ListType,
+		"ip_addresses": list.IPAddresses,
+		"enabled":      list.Enabled,
+	}
+	return c.Do(ctx, http.MethodPut, ipAccessListPath(list.ListID), body, nil)
+}
+
+// DeleteIPAccessList deletes an IP access list.
+func (c *Client) DeleteIPAccessList(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, ipAccessListPath(id), nil, nil)
+}
@@ -0,0 +1,166 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Job is a job and its settings, in the shape the create API accepts.
+type Job struct {
+	JobID    int64           `json:"job_id"`
+	Settings json.RawMessage `json:"settings"`
+}
+
+// JobSettings are the settings of a job, as far as the mrl_databricks_job
+// resource manages them.
+type JobSettings struct {
+	Name              string        `json:"name"`
+	Tasks             []JobTask     `json:"tasks"`
+	Schedule          *CronSchedule `json:"schedule,omitempty"`
+	MaxConcurrentRuns int64         `json:"max_concurrent_runs,omitempty"`
+}
+
+// JobTask is a task of a job. It runs either on an existing cluster or on a
+// new cluster created for each run.
+type JobTask struct {
+	TaskKey           string        `json:"task_key"`
+	ExistingClusterID string        `json:"existing_cluster_id,omitempty"`
+	NewCluster        *ClusterSpec  `json:"new_cluster,omitempty"`
+	NotebookTask      *NotebookTask `json:"notebook_task,omitempty"`
+	SparkJarTask      *SparkJarTask `json:"spark_jar_task,omitempty"`
+	Libraries         []Library     `json:"libraries,omitempty"`
+}
+
+// NotebookTask runs a workspace notebook.
+type NotebookTask struct {
+	NotebookPath   string            `json:"notebook_path"`
+	BaseParameters map[string]string `json:"base_parameters,omitempty"`
+}
+
+// SparkJarTask runs the main class of a JAR installed as a library of the
+// task.
+type SparkJarTask struct {
+	MainClassName string   `json:"main_class_name"`
+	Parameters    []string `json:"parameters,omitempty"`
+}
+
+// CronSchedule triggers job runs on a Quartz cron schedule.
+type CronSchedule struct {
+	QuartzCronExpression string `json:"quartz_cron_expression"`
+	TimezoneID           string `json:"timezone_id"`
+	PauseStatus          string `json:"pause_status,omitempty"`
+}
+
+// RunState is the state of a job run.
+type RunState struct {
+	LifeCycleState string `json:"life_cycle_state"`
+	ResultState    string `json:"result_state,omitempty"`
+	StateMessage   string `json:"state_message,omitempty"`
+}
+
+// RunOutput is the output of a single task run.
+type RunOutput struct {
+	Metadata struct {
+		RunID      int64    `json:"run_id"`
+		JobID      int64    `json:"job_id"`
+		State      RunState `json:"state"`
+		RunPageURL string   `json:"run_page_url"`
+		StartTime  int64    `json:"start_time"`
+		EndTime    int64    `json:"end_time"`
+	} `json:"metadata"`
+	NotebookOutput *struct {
+		Result    string `json:"result"`
+		Truncated bool   `json:"truncated"`
+	} `json:"notebook_output,omitempty"`
+	Logs          string `json:"logs,omitempty"`
+	LogsTruncated bool   `json:"logs_truncated,omitempty"`
+	Error         string `json:"error,omitempty"`
+	ErrorTrace    string `json:"error_trace,omitempty"`
+}
+
+// GetRunOutput returns the output of a task run. Runs of multi-task jobs have
+// no output of their own; the run ID of one of their tasks is needed.
+func (c *Client) GetRunOutput(ctx context.Context, runID int64) (*RunOutput, error) {
+	var output RunOutput
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/jobs/runs/get-output?run_id="+strconv.FormatInt(runID, 10), nil, &output); err != nil {
+		return nil, err
+	}
+	return &output, nil
+}
+
+// GetJob returns a job with its settings.
+func (c *Client) GetJob(ctx context.Context, jobID int64) (*Job, error) {
+	var job Job
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/jobs/get?job_id="+strconv.FormatInt(jobID, 10), nil, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetJobSettings returns the decoded settings of a job. A job that does not
+// exist gives an error for which IsNotFound reports true.
+func (c *Client) GetJobSettings(ctx context.Context, jobID int64) (*JobSettings, error) {
+	var job struct {
+		Settings JobSettings `json:"settings"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/jobs/get?job_id="+strconv.FormatInt(jobID, 10), nil, &job); err != nil {
+		return nil, notFound(err)
+	}
+	return &job.Settings, nil
+}
+
+// JobInfo is a job with its decoded settings.
+type JobInfo struct {
+	JobID    int64       `json:"job_id"`
+	Settings JobSettings `json:"settings"`
+}
+
+// ListJobs returns every job of the workspace with its tasks, following the
+// pages of the list API.
+func (c *Client) ListJobs(ctx context.Context) ([]JobInfo, error) {
+	var jobs []JobInfo
+	pageToken := ""
+	for {
+		var result struct {
+			Jobs          []JobInfo `json:"jobs"`
+			NextPageToken string    `json:"next_page_token"`
+		}
+		p := "/api/2.1/jobs/list?limit=100&expand_tasks=true"
+		if pageToken != "" {
+			p += "&page_token=" + url.QueryEscape(pageToken)
+		}
+		if err := c.Do(ctx, http.MethodGet, p, nil, &result); err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, result.Jobs...)
+		if result.NextPageToken == "" {
+			return jobs, nil
+		}
+		pageToken = result.NextPageToken
+	}
+}
+
+// CreateJob creates a job from its settings, a JobSettings or their JSON
+// encoding, and returns its ID.
+func (c *Client) CreateJob(ctx context.Context, settings interface{}) (int64, error) {
+	var result struct {
+		JobID int64 `json:"job_id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/jobs/create", settings, &result); err != nil {
+		return 0, err
+	}
+	return result.JobID, nil
+}
+
+// DeleteJob deletes a job.
+func (c *Client) DeleteJob(ctx context.Context, jobID int64) error {
+	return notFound(c.Do(ctx, http.MethodPost, "/api/2.1/jobs/delete", map[string]interface{}{"job_id": jobID}, nil))
+}
+
+// ResetJob replaces all settings of a job.
+func (c *Client) ResetJob(ctx context.Context, jobID int64, settings JobSettings) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.1/jobs/reset", map[string]interface{}{"job_id": jobID, "new_settings": settings}, nil)
+}
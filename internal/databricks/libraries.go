@@ -0,0 +1,153 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// Library install states reported by the cluster-status API.
+const (
+	LibraryPending            = "PENDING"
+	LibraryResolving          = "RESOLVING"
+	LibraryInstalling         = "INSTALLING"
+	LibraryInstalled          = "INSTALLED"
+	LibraryFailed             = "FAILED"
+	LibrarySkipped            = "SKIPPED"
+	LibraryUninstallOnRestart = "UNINSTALL_ON_RESTART"
+)
+
+// libraryPollInterval is how often a library being installed is polled.
+const libraryPollInterval = 5 * time.Second
+
+// Library is a library installed on a cluster, or on the cluster of a job
+// task. Exactly one field is set.
+type Library struct {
+	Jar   string        `json:"jar,omitempty"`
+	Whl   string        `json:"whl,omitempty"`
+	Pypi  *PypiLibrary  `json:"pypi,omitempty"`
+	Maven *MavenLibrary `json:"maven,omitempty"`
+	Cran  *CranLibrary  `json:"cran,omitempty"`
+}
+
+// PypiLibrary is a Python package installed from PyPI or another index.
+type PypiLibrary struct {
+	Package string `json:"package"`
+	Repo    string `json:"repo,omitempty"`
+}
+
+// MavenLibrary is a JVM library resolved from Maven Central or another
+// repository.
+type MavenLibrary struct {
+	Coordinates string   `json:"coordinates"`
+	Repo        string   `json:"repo,omitempty"`
+	Exclusions  []string `json:"exclusions,omitempty"`
+}
+
+// CranLibrary is an R package installed from CRAN or another mirror.
+type CranLibrary struct {
+	Package string `json:"package"`
+	Repo    string `json:"repo,omitempty"`
+}
+
+// String identifies the library by its kind and location, such as
+// jar:dbfs:/FileStore/jars/app.jar or pypi:requests==2.32.3. The
+// cluster-status API echoes libraries back in this form.
+func (l Library) String() string {
+	switch {
+	case l.Jar != "":
+		return "jar:" + l.Jar
+	case l.Whl != "":
+		return "whl:" + l.Whl
+	case l.Pypi != nil:
+		return "pypi:" + l.Pypi.Package
+	case l.Maven != nil:
+		return "maven:" + l.Maven.Coordinates
+	case l.Cran != nil:
+		return "cran:" + l.Cran.Package
+	}
+	return ""
+}
+
+// LibraryStatus is the install state of a library on a cluster.
+type LibraryStatus struct {
+	Library  Library  `json:"library"`
+	Status   string   `json:"status"`
+	Messages []string `json:"messages,omitempty"`
+}
+
+// InstallLibraries installs libraries on a cluster. Libraries of a
+// terminated cluster are installed when it starts.
+func (c *Client) InstallLibraries(ctx context.Context, clusterID string, libraries []Library) error {
+	body := map[string]interface{}{"cluster_id": clusterID, "libraries": libraries}
+	return c.Do(ctx, http.MethodPost, "/api/2.0/libraries/install", body, nil)
+}
+
+// UninstallLibraries marks libraries of a cluster for removal. They are
+// removed when the cluster restarts.
+func (c *Client) UninstallLibraries(ctx context.Context, clusterID string, libraries []Library) error {
+	body := map[string]interface{}{"cluster_id": clusterID, "libraries": libraries}
+	return c.Do(ctx, http.MethodPost, "/api/2.0/libraries/uninstall", body, nil)
+}
+
+// ClusterLibraryStatuses returns the install state of the libraries of a
+// cluster. A cluster that does not exist gives an error for which IsNotFound
+// reports true.
+func (c *Client) ClusterLibraryStatuses(ctx context.Context, clusterID string) ([]LibraryStatus, error) {
+	var result struct {
+		LibraryStatuses []LibraryStatus `json:"library_statuses"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/libraries/cluster-status?cluster_id="+url.QueryEscape(clusterID), nil, &result); err != nil {
+		return nil, notFound(err)
+	}
+	return result.LibraryStatuses, nil
+}
+
+// ClusterLibraryStatus returns the install state of library on a cluster, or
+// nil when the library is not installed.
+func (c *Client) ClusterLibraryStatus(ctx context.Context, clusterID string, library Library) (*LibraryStatus, error) {
+	statuses, err := c.ClusterLibraryStatuses(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range statuses {
+		if statuses[i].Library.String() == library.String() {
+			return &statuses[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// WaitLibrary waits until library is installed on a running cluster, until
+// ctx is done, and returns its status. A library that fails to install gives
+// an error with the messages of the cluster.
+func (c *Client) WaitLibrary(ctx context.Context, clusterID string, library Library) (*LibraryStatus, error) {
+	var status *LibraryStatus
+	check := func(ctx context.Context) (bool, time.Duration, error) {
+		var err error
+		status, err = c.ClusterLibraryStatus(ctx, clusterID, library)
+		if err != nil {
+			return false, 0, err
+		}
+		if status == nil {
+			return false, 0, fmt.Errorf("library %s is not installed on cluster %s", library, clusterID)
+		}
+		return poll.StateIn(LibraryInstalled, LibraryFailed, LibrarySkipped, LibraryUninstallOnRestart)(status.Status), 0, nil
+	}
+
+	done, _, err := check(ctx)
+	if err == nil && !done {
+		err = poll.Poller{Interval: libraryPollInterval}.Wait(ctx, check)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if status.Status != LibraryInstalled {
+		return status, fmt.Errorf("library %s is %s on cluster %s: %s", library, status.Status, clusterID, strings.Join(status.Messages, "; "))
+	}
+	return status, nil
+}
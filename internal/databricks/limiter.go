@@ -0,0 +1,82 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultMaxParallelUploads is the number of uploads a Limiter from
+// NewLimiter lets run at once when given no positive limit.
+const DefaultMaxParallelUploads = 8
+
+// Limiter bounds the number of operations in flight across every Client it is
+// attached to. A nil Limiter does not limit anything.
+type Limiter chan struct{}
+
+// NewLimiter returns a Limiter allowing n operations at once, or
+// DefaultMaxParallelUploads when n is not positive.
+func NewLimiter(n int) Limiter {
+	if n <= 0 {
+		n = DefaultMaxParallelUploads
+	}
+	return make(Limiter, n)
+}
+
+// acquire waits for a free slot, or until ctx is done.
+func (l Limiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees the slot taken by acquire.
+func (l Limiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// WithUploadLimiter makes the uploads of c share the slots of l, so that the
+// uploads of all resources of a provider together stay within one limit. It
+// returns c.
+func (c *Client) WithUploadLimiter(l Limiter) *Client {
+	c.uploads = l
+	return c
+}
+
+// ForEach runs fn for every item with at most parallelism calls in flight
+// and returns the errors of the failed calls, each prefixed with its item.
+func ForEach(items []string, parallelism int64, fn func(string) error) error {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs []error
+	)
+	sem := make(chan struct{}, parallelism)
+	for _, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fn(item); err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", item, err))
+				mu.Unlock()
+			}
+		}(item)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
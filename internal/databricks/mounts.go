@@ -0,0 +1,80 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MountPrefix is the DBFS directory mount points are created in.
+const MountPrefix = "/mnt/"
+
+// SecretRef names a secret of a Databricks secret scope.
+type SecretRef struct {
+	Scope string
+	Key   string
+}
+
+// Mount describes a DBFS mount of cloud storage.
+type Mount struct {
+	// MountPoint is the DBFS path of the mount, such as /mnt/raw.
+	MountPoint string
+	// Source is the storage URI, such as
+	// abfss://raw@account.dfs.core.windows.net/landing.
+	Source string
+	// ExtraConfigs are the Hadoop configurations the storage is accessed
+	// with.
+	ExtraConfigs map[string]string
+	// SecretConfigs are Hadoop configurations whose value is read from a
+	// secret on the cluster, so that it is not sent with the command.
+	SecretConfigs map[string]SecretRef
+}
+
+// pythonString quotes s as a Python string literal. JSON string escapes are
+// valid in Python.
+func pythonString(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// command returns the Python command mounting m with dbutils.
+func (m Mount) command() string {
+	var keys []string
+	for k := range m.ExtraConfigs {
+		keys = append(keys, k)
+	}
+	for k := range m.SecretConfigs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var configs []string
+	for _, k := range keys {
+		value := pythonString(m.ExtraConfigs[k])
+		if ref, ok := m.SecretConfigs[k]; ok {
+			value = fmt.Sprintf("dbutils.secrets.get(scope=%s, key=%s)", pythonString(ref.Scope), pythonString(ref.Key))
+		}
+		configs = append(configs, fmt.Sprintf("  %s: %s,", pythonString(k), value))
+	}
+
+	return fmt.Sprintf("dbutils.fs.mount(source=%s, mount_point=%s, extra_configs={\n%s\n})\ndbutils.fs.refreshMounts()\n",
+		pythonString(m.Source), pythonString(m.MountPoint), strings.Join(configs, "\n"))
+}
+
+// CreateMount mounts storage to DBFS by running dbutils on a running
+// cluster. It fails when the mount point is already mounted.
+func (c *Client) CreateMount(ctx context.Context, clusterID string, m Mount) error {
+	_, err := c.RunCommand(ctx, clusterID, "python", m.command())
+	return err
+}
+
+// DeleteMount unmounts mountPoint by running dbutils on a running cluster.
+// A mount point that is not mounted is ignored.
+func (c *Client) DeleteMount(ctx context.Context, clusterID, mountPoint string) error {
+	command := fmt.Sprintf("mount_point = %s\nif any(m.mountPoint == mount_point for m in dbutils.fs.mounts()):\n  dbutils.fs.unmount(mount_point)\ndbutils.fs.refreshMounts()\n",
+		pythonString(mountPoint))
+	_, err := c.RunCommand(ctx, clusterID, "python", command)
+	return err
+}
@@ -0,0 +1,137 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// oauthScope is the scope requested for workspace OAuth tokens.
+const oauthScope = "all-apis"
+
+// oauthExpiryDelta is how long before its expiry a cached token is renewed.
+const oauthExpiryDelta = time.Minute
+
+// AzureDatabricksScope is the Microsoft Entra ID scope of tokens accepted by
+// Azure Databricks workspaces. 2ff814a6-3304-4ab8-85cb-cd0e6f879c1d is the
+// application ID of the AzureDatabricks first-party application, which is the
+// same in the public, US Government and China clouds.
+const AzureDatabricksScope = "2ff814a6-3304-4ab8-85cb-cd0e6f879c1d/.default"
+
+// ErrNoCredentials is returned for requests sent without a token when neither
+// an OAuth client nor Azure credentials are configured.
+var ErrNoCredentials = errors.New("no Databricks token is set and neither an OAuth client ID and secret nor Azure credentials are configured")
+
+// OAuthTransport authenticates Databricks requests sent without a bearer token
+// as a service principal, using the OAuth client credentials flow against the
+// token endpoint of the workspace, or else a Microsoft Entra ID token for
+// Azure Databricks. Requests that carry a token pass through unchanged. OAuth
+// tokens are cached per workspace until shortly before they expire.
+type OAuthTransport struct {
+	// Base sends the requests. It also fetches the tokens.
+	Base http.RoundTripper
+	// ClientID and ClientSecret identify the service principal. When either is
+	// empty, AzureToken is used instead.
+	ClientID     string
+	ClientSecret string
+	// AzureToken returns a Microsoft Entra ID token for AzureDatabricksScope.
+	// When it is nil too, requests without a token fail with
+	// ErrNoCredentials.
+	AzureToken func(ctx context.Context) (string, error)
+
+	mu     sync.Mutex
+	tokens map[string]oauthToken
+}
+
+// oauthToken is a cached access token.
+type oauthToken struct {
+	accessToken string
+	expiry      time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *OAuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if auth := req.Header.Get("Authorization"); strings.TrimSpace(strings.TrimPrefix(auth, "Bearer")) != "" {
+		return t.Base.RoundTrip(req)
+	}
+
+	host := req.URL.Scheme + "://" + req.URL.Host
+	var token string
+	switch {
+	case t.ClientID != "" && t.ClientSecret != "":
+		var err error
+		if token, err = t.token(req.Context(), host); err != nil {
+			return nil, fmt.Errorf("getting OAuth token for %s: %w", host, err)
+		}
+	case t.AzureToken != nil:
+		var err error
+		if token, err = t.AzureToken(req.Context()); err != nil {
+			return nil, fmt.Errorf("getting Microsoft Entra ID token for %s: %w", host, err)
+		}
+	default:
+		return nil, ErrNoCredentials
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return t.Base.RoundTrip(req)
+}
+
+// token returns a valid access token for the workspace at host, fetching a
+// new one when none is cached or the cached one is about to expire.
+func (t *OAuthTransport) token(ctx context.Context, host string) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if cached, ok := t.tokens[host]; ok && time.Now().Add(oauthExpiryDelta).Before(cached.expiry) {
+		return cached.accessToken, nil
+	}
+
+	form := url.Values{
+		"grant_type": {"client_credentials"},
+		"scope":      {oauthScope},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, host+"/oidc/v1/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(t.ClientID, t.ClientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode < 300 {
+		return "", err
+	}
+	if resp.StatusCode >= 300 || body.AccessToken == "" {
+		if body.Error != "" {
+			return "", fmt.Errorf("token request failed with status %d: %s: %s", resp.StatusCode, body.Error, body.ErrorDescription)
+		}
+		return "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	if t.tokens == nil {
+		t.tokens = map[string]oauthToken{}
+	}
+	t.tokens[host] = oauthToken{
+		accessToken: body.AccessToken,
+		expiry:      time.Now().Add(time.Duration(body.ExpiresIn) * time.Second),
+	}
+	return body.AccessToken, nil
+}
@@ -0,0 +1,64 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Workspace permission levels of an account principal.
+const (
+	WorkspacePermissionUser  = "USER"
+	WorkspacePermissionAdmin = "ADMIN"
+)
+
+// PermissionAssignment is the workspace permission of an account principal.
+type PermissionAssignment struct {
+	Principal struct {
+		PrincipalID          int64  `json:"principal_id"`
+		DisplayName          string `json:"display_name"`
+		UserName             string `json:"user_name,omitempty"`
+		GroupName            string `json:"group_name,omitempty"`
+		ServicePrincipalName string `json:"service_principal_name,omitempty"`
+	} `json:"principal"`
+	Permissions []string `json:"permissions"`
+}
+
+// permissionAssignmentPath is the API path of the assignment of principalID.
+func permissionAssignmentPath(principalID int64) string {
+	return "/api/2.0/preview/permissionassignments/principals/" + strconv.FormatInt(principalID, 10)
+}
+
+// GetPermissionAssignment returns the workspace permission assignment of an
+// account principal. There is no API to read a single assignment, so the
+// full list is searched.
+func (c *Client) GetPermissionAssignment(ctx context.Context, principalID int64) (*PermissionAssignment, error) {
+	var list struct {
+		PermissionAssignments []PermissionAssignment `json:"permission_assignments"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/preview/permissionassignments", nil, &list); err != nil {
+		return nil, err
+	}
+	for _, assignment := range list.PermissionAssignments {
+		if assignment.Principal.PrincipalID == principalID {
+			return &assignment, nil
+		}
+	}
+	return nil, &APIError{
+		StatusCode: http.StatusNotFound,
+		ErrorCode:  "RESOURCE_DOES_NOT_EXIST",
+		Message:    fmt.Sprintf("principal %d has no permission assignment", principalID),
+	}
+}
+
+// PutPermissionAssignment assigns permissions on the workspace to an account
+// principal, replacing its previous assignment.
+func (c *Client) PutPermissionAssignment(ctx context.Context, principalID int64, permissions []string) error {
+	return c.Do(ctx, http.MethodPut, permissionAssignmentPath(principalID), map[string]interface{}{"permissions": permissions}, nil)
+}
+
+// DeletePermissionAssignment removes an account principal from the workspace.
+func (c *Client) DeletePermissionAssignment(ctx context.Context, principalID int64) error {
+	return c.Do(ctx, http.MethodDelete, permissionAssignmentPath(principalID), nil, nil)
+}
@@ -0,0 +1,83 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Object types of the permissions API.
+const (
+	PermissionObjectJobs        = "jobs"
+	PermissionObjectClusters    = "clusters"
+	PermissionObjectNotebooks   = "notebooks"
+	PermissionObjectDirectories = "directories"
+	PermissionObjectPools       = "instance-pools"
+	PermissionObjectRepos       = "repos"
+	PermissionObjectWarehouses  = "warehouses"
+)
+
+// PermissionOwner is the permission level of the owner of an object.
+const PermissionOwner = "IS_OWNER"
+
+// AccessControl grants a permission level on an object to a user, group or
+// service principal.
+type AccessControl struct {
+	UserName             string `json:"user_name,omitempty"`
+	GroupName            string `json:"group_name,omitempty"`
+	ServicePrincipalName string `json:"service_principal_name,omitempty"`
+	PermissionLevel      string `json:"permission_level"`
+}
+
+// GetPermissions returns the permissions set directly on an object. Inherited
+// permissions are left out. An object that does not exist gives an error for
+// which IsNotFound reports true.
+func (c *Client) GetPermissions(ctx context.Context, objectType, objectID string) ([]AccessControl, error) {
+	var result struct {
+		AccessControlList []struct {
+			UserName             string `json:"user_name"`
+			GroupName            string `json:"group_name"`
+			ServicePrincipalName string `json:"service_principal_name"`
+			AllPermissions       []struct {
+				PermissionLevel string `json:"permission_level"`
+				Inherited       bool   `json:"inherited"`
+			} `json:"all_permissions"`
+		} `json:"access_control_list"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/permissions/"+objectType+"/"+url.PathEscape(objectID), nil, &result); err != nil {
+		return nil, notFound(err)
+	}
+
+	var acl []AccessControl
+	for _, entry := range result.AccessControlList {
+		for _, permission := range entry.AllPermissions {
+			if permission.Inherited {
+				continue
+			}
+			acl = append(acl, AccessControl{
+				UserName:             entry.UserName,
+				GroupName:            entry.GroupName,
+				ServicePrincipalName: entry.ServicePrincipalName,
+				PermissionLevel:      permission.PermissionLevel,
+			})
+		}
+	}
+	return acl, nil
+}
+
+// UpdatePermissions adds permissions to an object, keeping the ones it
+// already has.
+func (c *Client) UpdatePermissions(ctx context.Context, objectType, objectID string, acl []AccessControl) error {
+	in := map[string]interface{}{"access_control_list": acl}
+	return c.Do(ctx, http.MethodPatch, "/api/2.0/permissions/"+objectType+"/"+url.PathEscape(objectID), in, nil)
+}
+
+// SetPermissions replaces the permissions set directly on an object with acl.
+// Objects with an owner keep it only when acl includes it.
+func (c *Client) SetPermissions(ctx context.Context, objectType, objectID string, acl []AccessControl) error {
+	if acl == nil {
+		acl = []AccessControl{}
+	}
+	in := map[string]interface{}{"access_control_list": acl}
+	return c.Do(ctx, http.MethodPut, "/api/2.0/permissions/"+objectType+"/"+url.PathEscape(objectID), in, nil)
+}
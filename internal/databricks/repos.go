@@ -0,0 +1,76 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// Git providers accepted by the repos API.
+const (
+	GitProviderGitHub                  = "gitHub"
+	GitProviderGitHubEnterprise        = "gitHubEnterprise"
+	GitProviderBitbucketCloud          = "bitbucketCloud"
+	GitProviderBitbucketServer         = "bitbucketServer"
+	GitProviderGitLab                  = "gitLab"
+	GitProviderGitLabEnterpriseEdition = "gitLabEnterpriseEdition"
+	GitProviderAzureDevOpsServices     = "azureDevOpsServices"
+	GitProviderAWSCodeCommit           = "awsCodeCommit"
+)
+
+// Repo is a Git folder of the workspace.
+type Repo struct {
+	ID           int64  `json:"id"`
+	URL          string `json:"url"`
+	Provider     string `json:"provider"`
+	Path         string `json:"path"`
+	Branch       string `json:"branch,omitempty"`
+	HeadCommitID string `json:"head_commit_id,omitempty"`
+}
+
+func repoPath(id int64) string {
+	return "/api/2.0/repos/" + strconv.FormatInt(id, 10)
+}
+
+// CreateRepo clones the Git repository url into the workspace at path and
+// returns the new repo. An empty provider is inferred from url and an empty
+// path defaults to a folder of the calling user.
+func (c *Client) CreateRepo(ctx context.Context, url, provider, path string) (*Repo, error) {
+	body := map[string]interface{}{"url": url}
+	if provider != "" {
+		body["provider"] = provider
+	}
+	if path != "" {
+		body["path"] = path
+	}
+	var repo Repo
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/repos", body, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// GetRepo returns a repo. A repo that does not exist gives an error for which
+// IsNotFound reports true.
+func (c *Client) GetRepo(ctx context.Context, id int64) (*Repo, error) {
+	var repo Repo
+	if err := c.Do(ctx, http.MethodGet, repoPath(id), nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// CheckoutRepo pulls the repo and checks out the latest commit of branch, or
+// the commit of tag when tag is not empty, which leaves it detached.
+func (c *Client) CheckoutRepo(ctx context.Context, id int64, branch, tag string) error {
+	body := map[string]interface{}{"branch": branch}
+	if tag != "" {
+		body = map[string]interface{}{"tag": tag}
+	}
+	return c.Do(ctx, http.MethodPatch, repoPath(id), body, nil)
+}
+
+// DeleteRepo deletes a repo and its working tree.
+func (c *Client) DeleteRepo(ctx context.Context, id int64) error {
+	return c.Do(ctx, http.MethodDelete, repoPath(id), nil, nil)
+}
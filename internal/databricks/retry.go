@@ -0,0 +1,187 @@
+package databricks
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Default retry settings, used for any RetryTransport field left at its zero
+// value.
+const (
+	DefaultMaxRetries     = 5
+	DefaultMinBackoff     = 1 * time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+	DefaultRequestTimeout = 5 * time.Minute
+)
+
+// RetryTransport retries requests that the Databricks API rate limited (429)
+// or failed with a server error (5xx), waiting with exponential backoff and
+// full jitter between attempts. A Retry-After header, when present, sets the
+// minimum wait. 429 and 503 responses mean the request was not processed and
+// are retried for every method; other server errors only for idempotent
+// methods, so that a failed POST is never applied twice. Waiting stops as soon
+// as the request context is done.
+//
+// Every attempt is logged with tflog, visible with TF_LOG=DEBUG. Only the
+// method, the URL without its query, the status, the attempt number and the
+// duration are logged; headers, such as Authorization, and bodies never are.
+//
+// When Metrics is set, every call is recorded by Endpoint with its retries
+// and its duration, waits included.
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	Metrics    *tracing.Metrics
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, retries, err := t.roundTrip(req)
+	t.Metrics.Record(req.Context(), Endpoint(req), retries, time.Since(start), err != nil || resp.StatusCode >= http.StatusBadRequest)
+	return resp, err
+}
+
+// roundTrip is RoundTrip, also returning how often the request was retried.
+func (t *RetryTransport) roundTrip(req *http.Request) (*http.Response, int, error) {
+	maxRetries := t.MaxRetries
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		// The body cannot be replayed.
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, attempt, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		start := time.Now()
+		resp, err := t.base().RoundTrip(req)
+		fields := logFields(req, attempt, time.Since(start))
+		if err != nil {
+			fields["error"] = err.Error()
+			tflog.Error(req.Context(), "HTTP request failed", fields)
+			return resp, attempt, err
+		}
+		fields["http_status"] = resp.StatusCode
+		tflog.Debug(req.Context(), "HTTP request completed", fields)
+		if attempt >= maxRetries || !retryable(req.Method, resp.StatusCode) {
+			return resp, attempt, err
+		}
+
+		wait := t.backoff(attempt, resp.Header.Get("Retry-After"))
+		fields["retry_wait_ms"] = wait.Milliseconds()
+		tflog.Info(req.Context(), "Retrying HTTP request", fields)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, attempt + 1, err
+		}
+	}
+}
+
+func (t *RetryTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return http.DefaultTransport
+}
+
+// logFields returns the log fields of an attempt at req. The query is left
+// out of the URL as it may carry credentials, such as SAS signatures.
+func logFields(req *http.Request, attempt int, duration time.Duration) map[string]interface{} {
+	u := *req.URL
+	u.RawQuery = ""
+	u.User = nil
+	return map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    u.String(),
+		"attempt":     attempt + 1,
+		"duration_ms": duration.Milliseconds(),
+	}
+}
+
+// Endpoint returns the endpoint req calls, as recorded by Metrics: the method
+// and the first four segments of a Databricks API path, with any further
+// segment, such as an object name or ID, replaced by {id}, e.g.
+// "GET /api/2.1/unity-catalog/catalogs/{id}". Requests to other APIs, such as
+// Azure Resource Manager, are recorded by method and host.
+func Endpoint(req *http.Request) string {
+	p := req.URL.Path
+	if !strings.HasPrefix(p, "/api/") {
+		return req.Method + " " + req.URL.Host
+	}
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i := 4; i < len(segments); i++ {
+		segments[i] = "{id}"
+	}
+	return req.Method + " /" + strings.Join(segments, "/")
+}
+
+// backoff returns how long to wait before retry number attempt+1.
+func (t *RetryTransport) backoff(attempt int, retryAfter string) time.Duration {
+	minBackoff, maxBackoff := t.MinBackoff, t.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultMaxBackoff
+	}
+
+	ceiling := minBackoff << uint(attempt)
+	if ceiling <= 0 || ceiling > maxBackoff {
+		ceiling = maxBackoff
+	}
+	wait := time.Duration(rand.Int63n(int64(ceiling)) + 1)
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds > 0 {
+		if after := time.Duration(seconds) * time.Second; after > wait {
+			wait = after
+		}
+	}
+	return wait
+}
+
+// retryable reports whether a response with the given status may be retried.
+func retryable(method string, status int) bool {
+	switch {
+	case status == http.StatusTooManyRequests, status == http.StatusServiceUnavailable:
+		return true
+	case status >= 500:
+		switch method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// User is a SCIM user or service principal of a workspace.
+type User struct {
+	ID          string      `json:"id"`
+	UserName    string      `json:"userName"`
+	DisplayName string      `json:"displayName"`
+	Active      bool        `json:"active"`
+	Groups      []ScimValue `json:"groups"`
+	// WorkspaceID is the numeric ID of the workspace, reported in the
+	// X-Databricks-Org-Id response header. CurrentUser sets it when the
+	// header is present.
+	WorkspaceID string `json:"-"`
+}
+
+// CurrentUser returns the principal the client authenticates as. For a
+// service principal, UserName is its application ID.
+func (c *Client) CurrentUser(ctx context.Context) (*User, error) {
+	resp, err := c.doRaw(ctx, http.MethodGet, "/api/2.0/preview/scim/v2/Me", nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var user User
+	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
+		return nil, err
+	}
+	user.WorkspaceID = resp.Header.Get("X-Databricks-Org-Id")
+	return &user, nil
+}
+
+// Entitlements of workspace users, groups and service principals.
+const (
+	EntitlementAllowClusterCreate      = "allow-cluster-create"
+	EntitlementAllowInstancePoolCreate = "allow-instance-pool-create"
+	EntitlementDatabricksSQLAccess     = "databricks-sql-access"
+	EntitlementWorkspaceAccess         = "workspace-access"
+)
+
+// SCIM schemas of the resources the scim API creates and of patch requests.
+const (
+	scimGroupSchema            = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimServicePrincipalSchema = "urn:ietf:params:scim:schemas:core:2.0:ServicePrincipal"
+	scimPatchOpSchema          = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+)
+
+// ScimValue is an element of a multi-valued SCIM attribute, such as a member
+// or an entitlement.
+type ScimValue struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+// Group is a SCIM group of a workspace.
+type Group struct {
+	ID           string      `json:"id,omitempty"`
+	DisplayName  string      `json:"displayName"`
+	ExternalID   string      `json:"externalId,omitempty"`
+	Members      []ScimValue `json:"members,omitempty"`
+	Entitlements []ScimValue `json:"entitlements,omitempty"`
+}
+
+// ServicePrincipal is a SCIM service principal of a workspace.
+type ServicePrincipal struct {
+	ID            string      `json:"id,omitempty"`
+	ApplicationID string      `json:"applicationId,omitempty"`
+	DisplayName   string      `json:"displayName"`
+	Active        bool        `json:"active"`
+	Entitlements  []ScimValue `json:"entitlements,omitempty"`
+}
+
+// ScimValues returns values as elements of a multi-valued SCIM attribute.
+func ScimValues(values []string) []ScimValue {
+	out := make([]ScimValue, 0, len(values))
+	for _, value := range values {
+		out = append(out, ScimValue{Value: value})
+	}
+	return out
+}
+
+// ScimPatchOp is an operation of a SCIM patch request.
+type ScimPatchOp struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path,omitempty"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// ScimAddValues returns the operation adding values to the multi-valued
+// attribute at path, such as members or entitlements.
+func ScimAddValues(path string, values []string) ScimPatchOp {
+	return ScimPatchOp{Op: "add", Path: path, Value: ScimValues(values)}
+}
+
+// ScimRemoveValue returns the operation removing value from the multi-valued
+// attribute at path.
+func ScimRemoveValue(path, value string) ScimPatchOp {
+	return ScimPatchOp{Op: "remove", Path: fmt.Sprintf("%s[value eq %q]", path, value)}
+}
+
+// ScimReplace returns the operation replacing the single-valued attribute at
+// path.
+func ScimReplace(path string, value interface{}) ScimPatchOp {
+	return ScimPatchOp{Op: "replace", Path: path, Value: value}
+}
+
+func groupPath(id string) string {
+	return "/api/2.0/preview/scim/v2/Groups/" + id
+}
+
+func servicePrincipalPath(id string) string {
+	return "/api/2.0/preview/scim/v2/ServicePrincipals/" + id
+}
+
+// patchScim applies ops to the SCIM resource at p. No ops sends no request.
+func (c *Client) patchScim(ctx context.Context, p string, ops []ScimPatchOp) error {
+	if len(ops) == 0 {
+		return nil
+	}
+	body := map[string]interface{}{
+		"schemas":    []string{scimPatchOpSchema},
+		"Operations": ops,
+	}
+	return c.Do(ctx, http.MethodPatch, p, body, nil)
+}
+
+// CreateGroup creates a group and returns it with its ID.
+func (c *Client) CreateGroup(ctx context.Context, group Group) (*Group, error) {
+	body := struct {
+		Schemas []string `json:"schemas"`
+		Group
+	}{[]string{scimGroupSchema}, group}
+	var out Group
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/preview/scim/v2/Groups", body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetGroup returns a group. A group that does not exist gives an error for
+// which IsNotFound reports true.
+func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
+	var out Group
+	if err := c.Do(ctx, http.MethodGet, groupPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchGroup applies ops to a group.
+func (c *Client) PatchGroup(ctx context.Context, id string, ops []ScimPatchOp) error {
+	return c.patchScim(ctx, groupPath(id), ops)
+}
+
+// DeleteGroup deletes a group. Its members are kept.
+func (c *Client) DeleteGroup(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, groupPath(id), nil, nil)
+}
+
+// CreateServicePrincipal adds a service principal to the workspace and
+// returns it with its ID. On Azure, ApplicationID must be the application ID
+// of a Microsoft Entra ID service principal; elsewhere it is generated.
+func (c *Client) CreateServicePrincipal(ctx context.Context, sp ServicePrincipal) (*ServicePrincipal, error) {
+	body := struct {
+		Schemas []string `json:"schemas"`
+		ServicePrincipal
+	}{[]string{scimServicePrincipalSchema}, sp}
+	var out ServicePrincipal
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/preview/scim/v2/ServicePrincipals", body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetServicePrincipal returns a service principal. A service principal that
+// does not exist gives an error for which IsNotFound reports true.
+func (c *Client) GetServicePrincipal(ctx context.Context, id string) (*ServicePrincipal, error) {
+	var out ServicePrincipal
+	if err := c.Do(ctx, http.MethodGet, servicePrincipalPath(id), nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PatchServicePrincipal applies ops to a service principal.
+func (c *Client) PatchServicePrincipal(ctx context.Context, id string, ops []ScimPatchOp) error {
+	return c.patchScim(ctx, servicePrincipalPath(id), ops)
+}
+
+// DeleteServicePrincipal removes a service principal from the workspace.
+func (c *Client) DeleteServicePrincipal(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, servicePrincipalPath(id), nil, nil)
+}
@@ -0,0 +1,89 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Secret scope backends.
+const (
+	ScopeBackendDatabricks    = "DATABRICKS"
+	ScopeBackendAzureKeyVault = "AZURE_KEYVAULT"
+)
+
+// SecretScope is a secret scope.
+type SecretScope struct {
+	Name             string            `json:"name"`
+	BackendType      string            `json:"backend_type"`
+	KeyvaultMetadata *KeyvaultMetadata `json:"keyvault_metadata,omitempty"`
+}
+
+// KeyvaultMetadata identifies the Azure Key Vault behind a secret scope.
+type KeyvaultMetadata struct {
+	ResourceID string `json:"resource_id"`
+	DNSName    string `json:"dns_name"`
+}
+
+// SecretMetadata describes a secret. The API never returns secret values.
+type SecretMetadata struct {
+	Key                  string `json:"key"`
+	LastUpdatedTimestamp int64  `json:"last_updated_timestamp"`
+}
+
+// CreateSecretScope creates a secret scope, backed by the Azure Key Vault kv
+// when it is not nil. Creating a Key Vault-backed scope requires a Microsoft
+// Entra ID token; personal access tokens are refused. An empty
+// initialManagePrincipal gives all workspace users MANAGE permission.
+func (c *Client) CreateSecretScope(ctx context.Context, scope, initialManagePrincipal string, kv *KeyvaultMetadata) error {
+	body := map[string]interface{}{"scope": scope}
+	if initialManagePrincipal != "" {
+		body["initial_manage_principal"] = initialManagePrincipal
+	}
+	if kv != nil {
+		body["scope_backend_type"] = ScopeBackendAzureKeyVault
+		body["backend_azure_keyvault"] = kv
+	}
+	return c.Do(ctx, http.MethodPost, "/api/2.0/secrets/scopes/create", body, nil)
+}
+
+// ListSecretScopes returns all secret scopes of the workspace.
+func (c *Client) ListSecretScopes(ctx context.Context) ([]SecretScope, error) {
+	var result struct {
+		Scopes []SecretScope `json:"scopes"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/secrets/scopes/list", nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Scopes, nil
+}
+
+// DeleteSecretScope deletes a secret scope and all its secrets.
+func (c *Client) DeleteSecretScope(ctx context.Context, scope string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/secrets/scopes/delete", map[string]interface{}{"scope": scope}, nil)
+}
+
+// PutSecret creates or overwrites a secret in a Databricks-backed scope.
+func (c *Client) PutSecret(ctx context.Context, scope, key, value string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/secrets/put", map[string]interface{}{
+		"scope":        scope,
+		"key":          key,
+		"string_value": value,
+	}, nil)
+}
+
+// ListSecrets returns the metadata of the secrets in a scope.
+func (c *Client) ListSecrets(ctx context.Context, scope string) ([]SecretMetadata, error) {
+	var result struct {
+		Secrets []SecretMetadata `json:"secrets"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/secrets/list?scope="+url.QueryEscape(scope), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Secrets, nil
+}
+
+// DeleteSecret deletes a secret from a Databricks-backed scope.
+func (c *Client) DeleteSecret(ctx context.Context, scope, key string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/secrets/delete", map[string]interface{}{"scope": scope, "key": key}, nil)
+}
@@ -0,0 +1,161 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// statementPollInterval is how often a running statement is polled.
+const statementPollInterval = 2 * time.Second
+
+// Statement states reported by the statement execution API.
+const (
+	StatementPending   = "PENDING"
+	StatementRunning   = "RUNNING"
+	StatementSucceeded = "SUCCEEDED"
+	StatementFailed    = "FAILED"
+	StatementCanceled  = "CANCELED"
+	StatementClosed    = "CLOSED"
+)
+
+// StatementRequest is a SQL statement to run on a SQL warehouse.
+type StatementRequest struct {
+	WarehouseID   string               `json:"warehouse_id"`
+	Statement     string               `json:"statement"`
+	Catalog       string               `json:"catalog,omitempty"`
+	Schema        string               `json:"schema,omitempty"`
+	Parameters    []StatementParameter `json:"parameters,omitempty"`
+	WaitTimeout   string               `json:"wait_timeout,omitempty"`
+	OnWaitTimeout string               `json:"on_wait_timeout,omitempty"`
+	Disposition   string               `json:"disposition,omitempty"`
+	Format        string               `json:"format,omitempty"`
+	RowLimit      int64                `json:"row_limit,omitempty"`
+}
+
+// StatementParameter is a named parameter marker value, referenced as :name.
+type StatementParameter struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Type  string `json:"type,omitempty"`
+}
+
+// StatementResponse is the status, manifest and first result chunk of a
+// statement.
+type StatementResponse struct {
+	StatementID string `json:"statement_id"`
+	Status      struct {
+		State string `json:"state"`
+		Error *struct {
+			ErrorCode string `json:"error_code"`
+			Message   string `json:"message"`
+		} `json:"error"`
+	} `json:"status"`
+	Manifest *struct {
+		Schema struct {
+			Columns []StatementColumn `json:"columns"`
+		} `json:"schema"`
+		TotalRowCount int64 `json:"total_row_count"`
+		Truncated     bool  `json:"truncated"`
+	} `json:"manifest"`
+	Result *StatementResult `json:"result"`
+}
+
+// StatementColumn describes a column of a statement result.
+type StatementColumn struct {
+	Name     string `json:"name"`
+	TypeName string `json:"type_name"`
+	Position int    `json:"position"`
+}
+
+// StatementResult is a chunk of rows of a statement result. Values are
+// rendered as strings, NULL as nil.
+type StatementResult struct {
+	DataArray         [][]*string `json:"data_array"`
+	NextChunkIndex    *int        `json:"next_chunk_index"`
+	NextChunkInternal string      `json:"next_chunk_internal_link"`
+}
+
+// StatementError reports a statement that did not succeed.
+type StatementError struct {
+	StatementID string
+	State       string
+	ErrorCode   string
+	Message     string
+}
+
+// Error implements error.
+func (e *StatementError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("statement %s ended in state %s", e.StatementID, e.State)
+	}
+	return fmt.Sprintf("statement %s ended in state %s: %s: %s", e.StatementID, e.State, e.ErrorCode, e.Message)
+}
+
+// ExecuteStatement runs a statement with inline JSON results and waits until
+// it reaches a terminal state. Statements that fail or are canceled return a
+// *StatementError. Cancelling ctx cancels the statement.
+func (c *Client) ExecuteStatement(ctx context.Context, req StatementRequest) (*StatementResponse, error) {
+	req.WaitTimeout = "30s"
+	req.OnWaitTimeout = "CONTINUE"
+	req.Disposition = "INLINE"
+	req.Format = "JSON_ARRAY"
+
+	var resp StatementResponse
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/sql/statements", req, &resp); err != nil {
+		return nil, err
+	}
+
+	id := resp.StatementID
+	terminal := poll.StateIn(StatementSucceeded, StatementFailed, StatementCanceled, StatementClosed)
+	if !terminal(resp.Status.State) {
+		err := poll.Poller{Interval: statementPollInterval}.Wait(ctx, func(ctx context.Context) (bool, time.Duration, error) {
+			resp = StatementResponse{}
+			if err := c.Do(ctx, http.MethodGet, "/api/2.0/sql/statements/"+id, nil, &resp); err != nil {
+				return false, 0, err
+			}
+			return terminal(resp.Status.State), 0, nil
+		})
+		if ctx.Err() != nil {
+			cancelCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			_ = c.Do(cancelCtx, http.MethodPost, "/api/2.0/sql/statements/"+id+"/cancel", nil, nil)
+			cancel()
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if resp.Status.State != StatementSucceeded {
+		stmtErr := &StatementError{StatementID: resp.StatementID, State: resp.Status.State}
+		if resp.Status.Error != nil {
+			stmtErr.ErrorCode = resp.Status.Error.ErrorCode
+			stmtErr.Message = resp.Status.Error.Message
+		}
+		return nil, stmtErr
+	}
+	return &resp, nil
+}
+
+// StatementRows returns every row of a succeeded statement, fetching the
+// chunks that follow the first one.
+func (c *Client) StatementRows(ctx context.Context, resp *StatementResponse) ([][]*string, error) {
+	if resp.Result == nil {
+		return nil, nil
+	}
+
+	rows := resp.Result.DataArray
+	next := resp.Result.NextChunkInternal
+	for next != "" {
+		var chunk StatementResult
+		if err := c.Do(ctx, http.MethodGet, next, nil, &chunk); err != nil {
+			return nil, err
+		}
+		rows = append(rows, chunk.DataArray...)
+		next = chunk.NextChunkInternal
+	}
+	return rows, nil
+}
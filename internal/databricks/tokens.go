@@ -0,0 +1,53 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+)
+
+// TokenInfo describes a personal access token of the calling user, without
+// its value.
+type TokenInfo struct {
+	TokenID      string `json:"token_id"`
+	Comment      string `json:"comment"`
+	CreationTime int64  `json:"creation_time"`
+	// ExpiryTime is -1 for a token that does not expire.
+	ExpiryTime int64 `json:"expiry_time"`
+}
+
+// CreateToken creates a personal access token of the calling user, valid for
+// lifetimeSeconds, or without expiry when it is not positive. It returns the
+// token value, which cannot be read again, and its description.
+func (c *Client) CreateToken(ctx context.Context, lifetimeSeconds int64, comment string) (string, *TokenInfo, error) {
+	body := map[string]interface{}{}
+	if lifetimeSeconds > 0 {
+		body["lifetime_seconds"] = lifetimeSeconds
+	}
+	if comment != "" {
+		body["comment"] = comment
+	}
+	var out struct {
+		TokenValue string    `json:"token_value"`
+		TokenInfo  TokenInfo `json:"token_info"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/token/create", body, &out); err != nil {
+		return "", nil, err
+	}
+	return out.TokenValue, &out.TokenInfo, nil
+}
+
+// ListTokens lists the personal access tokens of the calling user.
+func (c *Client) ListTokens(ctx context.Context) ([]TokenInfo, error) {
+	var out struct {
+		TokenInfos []TokenInfo `json:"token_infos"`
+	}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/token/list", nil, &out); err != nil {
+		return nil, err
+	}
+	return out.TokenInfos, nil
+}
+
+// RevokeToken revokes a personal access token of the calling user.
+func (c *Client) RevokeToken(ctx context.Context, tokenID string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/token/delete", map[string]interface{}{"token_id": tokenID}, nil)
+}
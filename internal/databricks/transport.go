@@ -0,0 +1,143 @@
+package databricks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+)
+
+// Default transport settings, used for any TransportConfig field left at its
+// zero value.
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+// TransportConfig tunes the HTTP transport shared by every Databricks API call
+// made by the provider.
+type TransportConfig struct {
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	TLSHandshakeTimeout time.Duration
+	EnableHTTP2         bool
+
+	// HTTPProxy and HTTPSProxy, when set, are the proxies of http and https
+	// requests, instead of those of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY
+	// environment variables.
+	HTTPProxy  *url.URL
+	HTTPSProxy *url.URL
+	// RootCAs are the certificate authorities servers are verified against.
+	// Defaults to those of the system.
+	RootCAs *x509.CertPool
+	// InsecureSkipVerify disables the verification of server certificates.
+	InsecureSkipVerify bool
+
+	// RequestTimeout bounds each API call, retries included. Defaults to
+	// DefaultRequestTimeout.
+	RequestTimeout time.Duration
+	// MaxRetries is how often a rate limited or failed call is retried. Zero
+	// disables retries.
+	MaxRetries int
+	// Metrics, when set, records the calls by endpoint.
+	Metrics *tracing.Metrics
+}
+
+// NewTransport builds an *http.Transport from the configuration.
+func NewTransport(cfg TransportConfig) *http.Transport {
+	if cfg.MaxIdleConns <= 0 {
+		cfg.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if cfg.MaxIdleConnsPerHost <= 0 {
+		cfg.MaxIdleConnsPerHost = DefaultMaxIdleConnsPerHost
+	}
+	if cfg.IdleConnTimeout <= 0 {
+		cfg.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if cfg.TLSHandshakeTimeout <= 0 {
+		cfg.TLSHandshakeTimeout = DefaultTLSHandshakeTimeout
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ExpectContinueTimeout: 1 * time.Second,
+		ForceAttemptHTTP2:     cfg.EnableHTTP2,
+	}
+
+	if cfg.HTTPProxy != nil || cfg.HTTPSProxy != nil {
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			switch {
+			case req.URL.Scheme == "https" && cfg.HTTPSProxy != nil:
+				return cfg.HTTPSProxy, nil
+			case req.URL.Scheme == "http" && cfg.HTTPProxy != nil:
+				return cfg.HTTPProxy, nil
+			}
+			return http.ProxyFromEnvironment(req)
+		}
+	}
+
+	if cfg.RootCAs != nil || cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			RootCAs:            cfg.RootCAs,
+			InsecureSkipVerify: cfg.InsecureSkipVerify,
+		}
+	}
+
+	if !cfg.EnableHTTP2 {
+		// A non-nil, empty TLSNextProto map disables HTTP/2 negotiation.
+		transport.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+	}
+
+	return transport
+}
+
+// CertPool returns the certificate authorities of the system, or an empty pool
+// when they cannot be loaded, with the PEM encoded certificates of every pem
+// added. It fails when a pem holds no certificate.
+func CertPool(pems ...[]byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	for _, pem := range pems {
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no PEM encoded certificate found")
+		}
+	}
+	return pool, nil
+}
+
+// NewHTTPClient returns an *http.Client using a transport built from cfg,
+// retrying calls as described by RetryTransport. The client is meant to be
+// created once per provider instance and shared so that connections are pooled
+// across resources.
+func NewHTTPClient(cfg TransportConfig) *http.Client {
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = DefaultRequestTimeout
+	}
+
+	return &http.Client{
+		Transport: &RetryTransport{
+			Base:       NewTransport(cfg),
+			MaxRetries: cfg.MaxRetries,
+			Metrics:    cfg.Metrics,
+		},
+		Timeout: cfg.RequestTimeout,
+	}
+}
@@ -0,0 +1,349 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// MetastoreInfo describes a Unity Catalog metastore.
+type MetastoreInfo struct {
+	MetastoreID               string `json:"metastore_id"`
+	Name                      string `json:"name"`
+	Region                    string `json:"region"`
+	Owner                     string `json:"owner"`
+	StorageRoot               string `json:"storage_root,omitempty"`
+	StorageRootCredentialID   string `json:"storage_root_credential_id,omitempty"`
+	StorageRootCredentialName string `json:"storage_root_credential_name,omitempty"`
+}
+
+// AzureManagedIdentity is the identity a storage credential authenticates
+// with: an access connector and, for user assigned identities, the identity
+// itself.
+type AzureManagedIdentity struct {
+	AccessConnectorID string `json:"access_connector_id"`
+	ManagedIdentityID string `json:"managed_identity_id,omitempty"`
+	CredentialID      string `json:"credential_id,omitempty"`
+}
+
+// StorageCredential is a Unity Catalog storage credential.
+type StorageCredential struct {
+	ID                   string                `json:"id,omitempty"`
+	Name                 string                `json:"name"`
+	Comment              string                `json:"comment,omitempty"`
+	Owner                string                `json:"owner,omitempty"`
+	AzureManagedIdentity *AzureManagedIdentity `json:"azure_managed_identity,omitempty"`
+}
+
+// GetMetastore returns a metastore by ID.
+func (c *Client) GetMetastore(ctx context.Context, id string) (*MetastoreInfo, error) {
+	var metastore MetastoreInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/unity-catalog/metastores/"+url.PathEscape(id), nil, &metastore); err != nil {
+		return nil, err
+	}
+	return &metastore, nil
+}
+
+// CurrentMetastore returns the metastore assigned to the workspace.
+func (c *Client) CurrentMetastore(ctx context.Context) (*MetastoreInfo, error) {
+	var metastore MetastoreInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/unity-catalog/metastore_summary", nil, &metastore); err != nil {
+		return nil, err
+	}
+	return &metastore, nil
+}
+
+// SetMetastoreRootCredential makes a storage credential the default data
+// access configuration of a metastore.
+func (c *Client) SetMetastoreRootCredential(ctx context.Context, metastoreID, credentialID string) error {
+	in := map[string]interface{}{"storage_root_credential_id": credentialID}
+	return c.Do(ctx, http.MethodPatch, "/api/2.1/unity-catalog/metastores/"+url.PathEscape(metastoreID), in, nil)
+}
+
+// CreateStorageCredential creates a storage credential.
+func (c *Client) CreateStorageCredential(ctx context.Context, credential StorageCredential) (*StorageCredential, error) {
+	var result StorageCredential
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/unity-catalog/storage-credentials", credential, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetStorageCredential returns a storage credential by name.
+func (c *Client) GetStorageCredential(ctx context.Context, name string) (*StorageCredential, error) {
+	var credential StorageCredential
+	if err := c.Do(ctx, http.MethodGet, "/api/2.1/unity-catalog/storage-credentials/"+url.PathEscape(name), nil, &credential); err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// UpdateStorageCredentialComment updates the comment of a storage credential.
+func (c *Client) UpdateStorageCredentialComment(ctx context.Context, name, comment string) error {
+	in := map[string]interface{}{"comment": comment}
+	return c.Do(ctx, http.MethodPatch, "/api/2.1/unity-catalog/storage-credentials/"+url.PathEscape(name), in, nil)
+}
+
+// DeleteStorageCredential deletes a storage credential, with force set even
+// when external locations or the metastore still depend on it.
+func (c *Client) DeleteStorageCredential(ctx context.Context, name string, force bool) error {
+	p := "/api/2.1/unity-catalog/storage-credentials/" + url.PathEscape(name)
+	if force {
+		p += "?force=true"
+	}
+	return c.Do(ctx, http.MethodDelete, p, nil, nil)
+}
+
+// Catalog isolation modes. An isolated catalog is only reachable from the
+// workspaces bound to it.
+const (
+	IsolationModeOpen     = "OPEN"
+	IsolationModeIsolated = "ISOLATED"
+)
+
+// Volume types.
+const (
+	VolumeTypeManaged  = "MANAGED"
+	VolumeTypeExternal = "EXTERNAL"
+)
+
+// CatalogInfo is a Unity Catalog catalog.
+type CatalogInfo struct {
+	Name            string `json:"name"`
+	Comment         string `json:"comment,omitempty"`
+	Owner           string `json:"owner,omitempty"`
+	StorageRoot     string `json:"storage_root,omitempty"`
+	StorageLocation string `json:"storage_location,omitempty"`
+	IsolationMode   string `json:"isolation_mode,omitempty"`
+	MetastoreID     string `json:"metastore_id,omitempty"`
+}
+
+// SchemaInfo is a Unity Catalog schema.
+type SchemaInfo struct {
+	Name            string `json:"name"`
+	CatalogName     string `json:"catalog_name"`
+	FullName        string `json:"full_name,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	Owner           string `json:"owner,omitempty"`
+	StorageRoot     string `json:"storage_root,omitempty"`
+	StorageLocation string `json:"storage_location,omitempty"`
+}
+
+// VolumeInfo is a Unity Catalog volume.
+type VolumeInfo struct {
+	Name            string `json:"name"`
+	CatalogName     string `json:"catalog_name"`
+	SchemaName      string `json:"schema_name"`
+	FullName        string `json:"full_name,omitempty"`
+	VolumeID        string `json:"volume_id,omitempty"`
+	VolumeType      string `json:"volume_type"`
+	StorageLocation string `json:"storage_location,omitempty"`
+	Comment         string `json:"comment,omitempty"`
+	Owner           string `json:"owner,omitempty"`
+}
+
+// SecurableUpdate is the update of the comment and owner of a catalog,
+// schema or volume. An empty Owner or IsolationMode is left unchanged;
+// IsolationMode only applies to catalogs.
+type SecurableUpdate struct {
+	Comment       string `json:"comment"`
+	Owner         string `json:"owner,omitempty"`
+	IsolationMode string `json:"isolation_mode,omitempty"`
+}
+
+func catalogPath(name string) string {
+	return "/api/2.1/unity-catalog/catalogs/" + url.PathEscape(name)
+}
+
+func schemaPath(fullName string) string {
+	return "/api/2.1/unity-catalog/schemas/" + url.PathEscape(fullName)
+}
+
+func volumePath(fullName string) string {
+	return "/api/2.1/unity-catalog/volumes/" + url.PathEscape(fullName)
+}
+
+// forceQuery returns the query deleting a securable with its content when
+// force is set.
+func forceQuery(force bool) string {
+	if force {
+		return "?force=true"
+	}
+	return ""
+}
+
+// CreateCatalog creates a catalog with the name, comment and storage root of
+// catalog. The owner and isolation mode are set through UpdateCatalog.
+func (c *Client) CreateCatalog(ctx context.Context, catalog CatalogInfo) (*CatalogInfo, error) {
+	in := map[string]interface{}{"name": catalog.Name, "comment": catalog.Comment}
+	if catalog.StorageRoot != "" {
+		in["storage_root"] = catalog.StorageRoot
+	}
+	var result CatalogInfo
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/unity-catalog/catalogs", in, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCatalog returns a catalog by name.
+func (c *Client) GetCatalog(ctx context.Context, name string) (*CatalogInfo, error) {
+	var catalog CatalogInfo
+	if err := c.Do(ctx, http.MethodGet, catalogPath(name), nil, &catalog); err != nil {
+		return nil, err
+	}
+	return &catalog, nil
+}
+
+// UpdateCatalog updates the comment, owner and isolation mode of a catalog.
+func (c *Client) UpdateCatalog(ctx context.Context, name string, update SecurableUpdate) error {
+	return c.Do(ctx, http.MethodPatch, catalogPath(name), update, nil)
+}
+
+// DeleteCatalog deletes an empty catalog or, with force set, a catalog and
+// everything in it.
+func (c *Client) DeleteCatalog(ctx context.Context, name string, force bool) error {
+	return c.Do(ctx, http.MethodDelete, catalogPath(name)+forceQuery(force), nil, nil)
+}
+
+// CreateSchema creates a schema with the name, catalog, comment and storage
+// root of schema. The owner is set through UpdateSchema.
+func (c *Client) CreateSchema(ctx context.Context, schema SchemaInfo) (*SchemaInfo, error) {
+	in := map[string]interface{}{
+		"name":         schema.Name,
+		"catalog_name": schema.CatalogName,
+		"comment":      schema.Comment,
+	}
+	if schema.StorageRoot != "" {
+		in["storage_root"] = schema.StorageRoot
+	}
+	var result SchemaInfo
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/unity-catalog/schemas", in, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetSchema returns a schema by its full name, catalog.schema.
+func (c *Client) GetSchema(ctx context.Context, fullName string) (*SchemaInfo, error) {
+	var schema SchemaInfo
+	if err := c.Do(ctx, http.MethodGet, schemaPath(fullName), nil, &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// UpdateSchema updates the comment and owner of a schema.
+func (c *Client) UpdateSchema(ctx context.Context, fullName string, update SecurableUpdate) error {
+	return c.Do(ctx, http.MethodPatch, schemaPath(fullName), update, nil)
+}
+
+// DeleteSchema deletes an empty schema or, with force set, a schema and
+// everything in it.
+func (c *Client) DeleteSchema(ctx context.Context, fullName string, force bool) error {
+	return c.Do(ctx, http.MethodDelete, schemaPath(fullName)+forceQuery(force), nil, nil)
+}
+
+// CreateVolume creates a volume. External volumes need a storage location.
+// The owner is set through UpdateVolume.
+func (c *Client) CreateVolume(ctx context.Context, volume VolumeInfo) (*VolumeInfo, error) {
+	in := map[string]interface{}{
+		"name":         volume.Name,
+		"catalog_name": volume.CatalogName,
+		"schema_name":  volume.SchemaName,
+		"volume_type":  volume.VolumeType,
+		"comment":      volume.Comment,
+	}
+	if volume.StorageLocation != "" {
+		in["storage_location"] = volume.StorageLocation
+	}
+	var result VolumeInfo
+	if err := c.Do(ctx, http.MethodPost, "/api/2.1/unity-catalog/volumes", in, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetVolume returns a volume by its full name, catalog.schema.volume.
+func (c *Client) GetVolume(ctx context.Context, fullName string) (*VolumeInfo, error) {
+	var volume VolumeInfo
+	if err := c.Do(ctx, http.MethodGet, volumePath(fullName), nil, &volume); err != nil {
+		return nil, err
+	}
+	return &volume, nil
+}
+
+// UpdateVolume updates the comment and owner of a volume.
+func (c *Client) UpdateVolume(ctx context.Context, fullName string, update SecurableUpdate) error {
+	return c.Do(ctx, http.MethodPatch, volumePath(fullName), update, nil)
+}
+
+// DeleteVolume deletes a volume. The files of a managed volume are deleted
+// with it; those of an external volume are left in place.
+func (c *Client) DeleteVolume(ctx context.Context, fullName string) error {
+	return c.Do(ctx, http.MethodDelete, volumePath(fullName), nil, nil)
+}
+
+// ModelVersion is a version of a registered model, as returned for an alias.
+type ModelVersion struct {
+	ModelName   string `json:"model_name"`
+	CatalogName string `json:"catalog_name"`
+	SchemaName  string `json:"schema_name"`
+	Version     int64  `json:"version"`
+}
+
+// registeredModelAliasPath is the API path of an alias of a registered model.
+func registeredModelAliasPath(fullName, alias string) string {
+	return "/api/2.1/unity-catalog/models/" + url.PathEscape(fullName) + "/aliases/" + url.PathEscape(alias)
+}
+
+// GetRegisteredModelAlias returns the model version an alias points to.
+func (c *Client) GetRegisteredModelAlias(ctx context.Context, fullName, alias string) (*ModelVersion, error) {
+	var version ModelVersion
+	if err := c.Do(ctx, http.MethodGet, registeredModelAliasPath(fullName, alias), nil, &version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// SetRegisteredModelAlias points an alias of a registered model at version,
+// creating the alias if needed.
+func (c *Client) SetRegisteredModelAlias(ctx context.Context, fullName, alias string, version int64) error {
+	in := map[string]interface{}{"full_name": fullName, "alias": alias, "version_num": version}
+	return c.Do(ctx, http.MethodPut, registeredModelAliasPath(fullName, alias), in, nil)
+}
+
+// DeleteRegisteredModelAlias deletes an alias of a registered model.
+func (c *Client) DeleteRegisteredModelAlias(ctx context.Context, fullName, alias string) error {
+	return c.Do(ctx, http.MethodDelete, registeredModelAliasPath(fullName, alias), nil, nil)
+}
+
+// EffectivePrivilege is a privilege a principal holds on a securable, either
+// granted directly or inherited from a parent securable.
+type EffectivePrivilege struct {
+	Privilege         string `json:"privilege"`
+	InheritedFromType string `json:"inherited_from_type,omitempty"`
+	InheritedFromName string `json:"inherited_from_name,omitempty"`
+}
+
+// EffectivePrivilegeAssignment lists the effective privileges of a principal.
+type EffectivePrivilegeAssignment struct {
+	Principal  string               `json:"principal"`
+	Privileges []EffectivePrivilege `json:"privileges"`
+}
+
+// EffectivePermissions returns the effective privileges on a securable of
+// securableType, limited to principal when it is not empty.
+func (c *Client) EffectivePermissions(ctx context.Context, securableType, fullName, principal string) ([]EffectivePrivilegeAssignment, error) {
+	p := "/api/2.1/unity-catalog/effective-permissions/" + url.PathEscape(securableType) + "/" + url.PathEscape(fullName)
+	if principal != "" {
+		p += "?principal=" + url.QueryEscape(principal)
+	}
+	var result struct {
+		PrivilegeAssignments []EffectivePrivilegeAssignment `json:"privilege_assignments"`
+	}
+	if err := c.Do(ctx, http.MethodGet, p, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.PrivilegeAssignments, nil
+}
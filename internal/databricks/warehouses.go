@@ -0,0 +1,134 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"terraform-provider-mrl/internal/poll"
+	"time"
+)
+
+// SQL warehouse states.
+const (
+	WarehouseStarting = "STARTING"
+	WarehouseRunning  = "RUNNING"
+	WarehouseStopping = "STOPPING"
+	WarehouseStopped  = "STOPPED"
+	WarehouseDeleting = "DELETING"
+	WarehouseDeleted  = "DELETED"
+)
+
+// SQL warehouse types. Serverless warehouses are PRO warehouses.
+const (
+	WarehouseTypePro     = "PRO"
+	WarehouseTypeClassic = "CLASSIC"
+)
+
+// warehousePollInterval is how often a starting warehouse is polled.
+const warehousePollInterval = 10 * time.Second
+
+// WarehouseSpec is the configuration of a SQL warehouse accepted by the
+// create and edit APIs.
+type WarehouseSpec struct {
+	Name                    string         `json:"name"`
+	ClusterSize             string         `json:"cluster_size"`
+	AutoStopMins            int64          `json:"auto_stop_mins"`
+	MinNumClusters          int64          `json:"min_num_clusters"`
+	MaxNumClusters          int64          `json:"max_num_clusters"`
+	EnableServerlessCompute bool           `json:"enable_serverless_compute"`
+	WarehouseType           string         `json:"warehouse_type,omitempty"`
+	Tags                    *WarehouseTags `json:"tags,omitempty"`
+}
+
+// WarehouseTags are the tags of a SQL warehouse, added to its cloud
+// resources.
+type WarehouseTags struct {
+	CustomTags []WarehouseTag `json:"custom_tags"`
+}
+
+// WarehouseTag is a single tag of a SQL warehouse.
+type WarehouseTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// WarehouseInfo describes a SQL warehouse.
+type WarehouseInfo struct {
+	WarehouseSpec
+	ID         string `json:"id"`
+	State      string `json:"state"`
+	JdbcURL    string `json:"jdbc_url"`
+	OdbcParams struct {
+		Hostname string `json:"hostname"`
+		Path     string `json:"path"`
+	} `json:"odbc_params"`
+	Health struct {
+		Summary string `json:"summary"`
+	} `json:"health"`
+}
+
+func warehousePath(id string) string {
+	return "/api/2.0/sql/warehouses/" + url.PathEscape(id)
+}
+
+// CreateWarehouse creates and starts a SQL warehouse and returns its ID.
+func (c *Client) CreateWarehouse(ctx context.Context, spec WarehouseSpec) (string, error) {
+	var result struct {
+		ID string `json:"id"`
+	}
+	if err := c.Do(ctx, http.MethodPost, "/api/2.0/sql/warehouses", spec, &result); err != nil {
+		return "", err
+	}
+	return result.ID, nil
+}
+
+// GetWarehouse returns the description of a SQL warehouse. A warehouse that
+// does not exist gives an error for which IsNotFound reports true.
+func (c *Client) GetWarehouse(ctx context.Context, id string) (*WarehouseInfo, error) {
+	var info WarehouseInfo
+	if err := c.Do(ctx, http.MethodGet, warehousePath(id), nil, &info); err != nil {
+		return nil, notFound(err)
+	}
+	return &info, nil
+}
+
+// EditWarehouse replaces the configuration of a SQL warehouse. A running
+// warehouse may restart to apply it.
+func (c *Client) EditWarehouse(ctx context.Context, id string, spec WarehouseSpec) error {
+	return c.Do(ctx, http.MethodPost, warehousePath(id)+"/edit", spec, nil)
+}
+
+// DeleteWarehouse deletes a SQL warehouse.
+func (c *Client) DeleteWarehouse(ctx context.Context, id string) error {
+	return c.Do(ctx, http.MethodDelete, warehousePath(id), nil, nil)
+}
+
+// WaitWarehouse waits until the SQL warehouse is running, until ctx is done,
+// and returns its description. A warehouse that stops or is deleted while
+// starting gives an error with its health summary.
+func (c *Client) WaitWarehouse(ctx context.Context, id string) (*WarehouseInfo, error) {
+	var info *WarehouseInfo
+	refresh := func(ctx context.Context) (string, error) {
+		var err error
+		info, err = c.GetWarehouse(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		return info.State, nil
+	}
+
+	_, err := poll.WaitForState(ctx, refresh,
+		[]string{WarehouseRunning},
+		[]string{WarehouseStarting, WarehouseStopping},
+		0, warehousePollInterval)
+	var unexpected *poll.UnexpectedStateError
+	if errors.As(err, &unexpected) {
+		return info, fmt.Errorf("warehouse %s is %s: %s", id, info.State, info.Health.Summary)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
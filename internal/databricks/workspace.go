@@ -0,0 +1,83 @@
+package databricks
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+)
+
+// Workspace export formats.
+const (
+	ExportFormatSource  = "SOURCE"
+	ExportFormatDBC     = "DBC"
+	ExportFormatJupyter = "JUPYTER"
+	// ExportFormatAuto imports a notebook or a plain file depending on the
+	// content and extension. It is only accepted by the import API.
+	ExportFormatAuto = "AUTO"
+)
+
+// ObjectInfo describes a workspace object.
+type ObjectInfo struct {
+	ObjectType string `json:"object_type"`
+	Path       string `json:"path"`
+	Language   string `json:"language,omitempty"`
+	ObjectID   int64  `json:"object_id"`
+	ModifiedAt int64  `json:"modified_at,omitempty"`
+}
+
+// WorkspaceGetStatus returns the status of a workspace object.
+func (c *Client) WorkspaceGetStatus(ctx context.Context, path string) (*ObjectInfo, error) {
+	var info ObjectInfo
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/workspace/get-status?path="+url.QueryEscape(path), nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// WorkspaceExport exports a workspace object or directory in format and
+// returns the base64 encoded archive.
+func (c *Client) WorkspaceExport(ctx context.Context, path, format string) (string, error) {
+	var export struct {
+		Content string `json:"content"`
+	}
+	q := url.Values{"path": {path}, "format": {format}}
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/workspace/export?"+q.Encode(), nil, &export); err != nil {
+		return "", err
+	}
+	return export.Content, nil
+}
+
+// WorkspaceMkdirs creates a workspace directory and its missing parents.
+func (c *Client) WorkspaceMkdirs(ctx context.Context, path string) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/workspace/mkdirs", map[string]interface{}{"path": path}, nil)
+}
+
+// WorkspaceImport imports an archive in format to path. language, when not
+// empty, is the language of a notebook imported in SOURCE format. The API
+// rejects overwrite for DBC archives, so callers delete the target first
+// instead. The import waits for a slot of the upload Limiter of c, if any.
+func (c *Client) WorkspaceImport(ctx context.Context, path, format, language string, content []byte, overwrite bool) error {
+	if err := c.uploads.acquire(ctx); err != nil {
+		return err
+	}
+	defer c.uploads.release()
+	in := map[string]interface{}{
+		"path":    path,
+		"format":  format,
+		"content": base64.StdEncoding.EncodeToString(content),
+	}
+	if language != "" {
+		in["language"] = language
+	}
+	if overwrite {
+		in["overwrite"] = true
+	}
+	return c.Do(ctx, http.MethodPost, "/api/2.0/workspace/import", in, nil)
+}
+
+// WorkspaceDelete deletes a workspace object or, with recursive set, a
+// directory.
+func (c *Client) WorkspaceDelete(ctx context.Context, path string, recursive bool) error {
+	return c.Do(ctx, http.MethodPost, "/api/2.0/workspace/delete", map[string]interface{}{"path": path, "recursive": recursive}, nil)
+}
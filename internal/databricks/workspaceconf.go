@@ -0,0 +1,30 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// GetWorkspaceConf returns the values of workspace configuration keys such as
+// enableIpAccessLists. Keys that are not set are missing from the result.
+func (c *Client) GetWorkspaceConf(ctx context.Context, keys []string) (map[string]string, error) {
+	var conf map[string]*string
+	if err := c.Do(ctx, http.MethodGet, "/api/2.0/workspace-conf?keys="+url.QueryEscape(strings.Join(keys, ",")), nil, &conf); err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(conf))
+	for key, value := range conf {
+		if value != nil {
+			values[key] = *value
+		}
+	}
+	return values, nil
+}
+
+// SetWorkspaceConf sets the workspace configuration keys of values. Other
+// keys keep their value.
+func (c *Client) SetWorkspaceConf(ctx context.Context, values map[string]string) error {
+	return c.Do(ctx, http.MethodPatch, "/api/2.0/workspace-conf", values, nil)
+}
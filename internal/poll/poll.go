@@ -0,0 +1,146 @@
+// Package poll waits for long-running operations to reach a terminal state.
+package poll
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Check polls an operation once. It reports done when the operation reached
+// a terminal state. A positive retryAfter replaces the poller interval before
+// the next check, for APIs that tell how long to wait.
+type Check func(ctx context.Context) (done bool, retryAfter time.Duration, err error)
+
+// Poller calls a Check until it is done.
+type Poller struct {
+	// Interval is the wait before each check.
+	Interval time.Duration
+	// Timeout bounds the whole wait. Zero waits until ctx is done.
+	Timeout time.Duration
+}
+
+// TimeoutError is returned when an operation does not finish within the
+// poller timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+// Error implements error.
+func (e *TimeoutError) Error() string {
+	return fmt.Sprintf("operation did not finish within %s", e.Timeout)
+}
+
+// Wait sleeps for the interval and calls check until it reports done or
+// fails, ctx is done, or the timeout elapses.
+func (p Poller) Wait(ctx context.Context, check Check) error {
+	waitCtx := ctx
+	if p.Timeout > 0 {
+		var cancel context.CancelFunc
+		waitCtx, cancel = context.WithTimeout(ctx, p.Timeout)
+		defer cancel()
+	}
+
+	// timedOut turns errors caused by the poller timeout, rather than by ctx,
+	// into a TimeoutError.
+	timedOut := func(err error) error {
+		if ctx.Err() == nil && errors.Is(waitCtx.Err(), context.DeadlineExceeded) {
+			return &TimeoutError{Timeout: p.Timeout}
+		}
+		return err
+	}
+
+	interval := p.Interval
+	for {
+		if err := sleep(waitCtx, interval); err != nil {
+			return timedOut(err)
+		}
+
+		done, retryAfter, err := check(waitCtx)
+		if err != nil {
+			return timedOut(err)
+		}
+		if done {
+			return nil
+		}
+
+		interval = p.Interval
+		if retryAfter > 0 {
+			interval = retryAfter
+		}
+	}
+}
+
+// StateIn returns a terminal-state predicate matching any of states, ignoring
+// case.
+func StateIn(states ...string) func(state string) bool {
+	return func(state string) bool {
+		for _, s := range states {
+			if strings.EqualFold(s, state) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// StateFunc reads the current state of an operation or resource.
+type StateFunc func(ctx context.Context) (state string, err error)
+
+// UnexpectedStateError is returned by WaitForState when the state is neither
+// a target nor a pending state.
+type UnexpectedStateError struct {
+	State  string
+	Target []string
+}
+
+// Error implements error.
+func (e *UnexpectedStateError) Error() string {
+	return fmt.Sprintf("unexpected state %q, wanted %s", e.State, strings.Join(e.Target, " or "))
+}
+
+// WaitForState calls refresh at once and then every interval until it
+// returns one of target, for at most timeout, or until ctx is done when
+// timeout is zero. States are compared ignoring case. It returns the last
+// state read, with an UnexpectedStateError when that state is neither in
+// target nor in pending, and a TimeoutError when the timeout elapses.
+func WaitForState(ctx context.Context, refresh StateFunc, target, pending []string, timeout, interval time.Duration) (string, error) {
+	isTarget, isPending := StateIn(target...), StateIn(pending...)
+
+	var state string
+	check := func(ctx context.Context) (bool, time.Duration, error) {
+		var err error
+		state, err = refresh(ctx)
+		if err != nil {
+			return false, 0, err
+		}
+		if isTarget(state) {
+			return true, 0, nil
+		}
+		if !isPending(state) {
+			return false, 0, &UnexpectedStateError{State: state, Target: target}
+		}
+		return false, 0, nil
+	}
+
+	done, _, err := check(ctx)
+	if err == nil && !done {
+		err = Poller{Interval: interval, Timeout: timeout}.Wait(ctx, check)
+	}
+	return state, err
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
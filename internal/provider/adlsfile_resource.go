@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &AdlsFileResource{}
+	_ resource.ResourceWithConfigure   = &AdlsFileResource{}
+	_ resource.ResourceWithModifyPlan  = &AdlsFileResource{}
+	_ resource.ResourceWithImportState = &AdlsFileResource{}
+)
+
+// NewAdlsFileResource is a helper function to simplify the provider implementation.
+func NewAdlsFileResource() resource.Resource {
+	return &AdlsFileResource{}
+}
+
+// AdlsFileResource is the resource implementation.
+type AdlsFileResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type adlsFileResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	StorageAccountName types.String `tfsdk:"storage_account_name"`
+	Filesystem         types.String `tfsdk:"filesystem"`
+	Path               PathValue    `tfsdk:"path"`
+	LocalPath          types.String `tfsdk:"local_path"`
+	ContentType        types.String `tfsdk:"content_type"`
+	Md5Hash            types.String `tfsdk:"content_md5"`
+	FileSize           types.Int64  `tfsdk:"file_size"`
+	LastModified       RFC3339Value `tfsdk:"modification_time"`
+	ContentChanged     types.Bool   `tfsdk:"content_changed"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AdlsFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *AdlsFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_adls_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *AdlsFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a local file to an ADLS Gen2 filesystem using the provider credential.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the file on the DFS endpoint",
+			},
+			"storage_account_name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the storage account, which must have the hierarchical namespace enabled",
+			},
+			"filesystem": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the ADLS Gen2 filesystem (container)",
+			},
+			"path": schema.StringAttribute{
+				CustomType: PathType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(PathType{}),
+				},
+				Description: "Path of the file inside the filesystem",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local path from where the file needs to be read",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content type stored with the file",
+			},
+			"content_md5": schema.StringAttribute{
+				Required:    true,
+				Description: "md5 hash of the file. A different hash on the remote file is reported as drift",
+			},
+			"content_changed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the planned change uploads new content. The plan also shows a warning with the local and remote hashes and the size delta",
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the file being managed",
+			},
+			"modification_time": schema.StringAttribute{
+				CustomType:  RFC3339Type{},
+				Computed:    true,
+				Description: "Last modified time of the file being managed, in RFC3339 format",
+			},
+		},
+	}
+}
+
+// upload sends the local file and refreshes the computed attributes.
+func (r *AdlsFileResource) upload(ctx context.Context, model *adlsFileResourceModel, action string) error {
+	account := model.StorageAccountName.ValueString()
+	filesystem := model.Filesystem.ValueString()
+	filePath := model.Path.ValueString()
+	target := r.azure.DataLakeURL(account, filesystem, filePath)
+
+	err := func() error {
+		f, err := os.Open(model.LocalPath.ValueString())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sum, err := r.azure.UploadDataLakeFile(ctx, account, filesystem, filePath, f, model.ContentType.ValueString())
+		if err != nil {
+			return err
+		}
+		if !model.Md5Hash.IsUnknown() && sum != model.Md5Hash.ValueString() {
+			return fmt.Errorf("uploaded content has md5 %v but content_md5 is %v; the local file changed during the upload or content_md5 is stale", sum, model.Md5Hash.ValueString())
+		}
+		return nil
+	}()
+	r.audit.Record(ctx, "mrl_adls_file", action, target, err)
+	if err != nil {
+		return err
+	}
+
+	props, err := r.azure.GetDataLakeFileProperties(ctx, account, filesystem, filePath)
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(target)
+	model.FileSize = types.Int64Value(props.ContentLength)
+	model.LastModified = NewRFC3339TimeValue(props.LastModified)
+	return nil
+}
+
+// ModifyPlan flags plans that upload new content and explains why.
+func (r *AdlsFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan adlsFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		plan.ContentChanged = types.BoolValue(true)
+	} else {
+		var state adlsFileResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		changed, warning := previewContentChange(plan.LocalPath, plan.Md5Hash, state.Md5Hash, state.FileSize)
+		plan.ContentChanged = changed
+		if warning != nil {
+			resp.Diagnostics.Append(warning)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create a new resource.
+func (r *AdlsFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_file.Create")
+	defer span.End()
+
+	var plan adlsFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.upload(withAuditRequestID(ctx), &plan, auditActionCreate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading ADLS file",
+			"Could not upload "+plan.LocalPath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AdlsFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_file.Read")
+	defer span.End()
+
+	var state adlsFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ContentChanged = types.BoolValue(false)
+
+	props, err := r.azure.GetDataLakeFileProperties(ctx, state.StorageAccountName.ValueString(), state.Filesystem.ValueString(), state.Path.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ADLS file",
+			"Could not read properties of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.FileSize = types.Int64Value(props.ContentLength)
+	state.LastModified = NewRFC3339TimeValue(props.LastModified)
+	if props.ContentMD5 != "" {
+		state.Md5Hash = types.StringValue(props.ContentMD5)
+	}
+	if !state.ContentType.IsNull() {
+		state.ContentType = types.StringValue(props.ContentType)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AdlsFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_file.Update")
+	defer span.End()
+
+	var plan adlsFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.upload(withAuditRequestID(ctx), &plan, auditActionUpdate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading ADLS file",
+			"Could not upload "+plan.LocalPath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AdlsFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_file.Delete")
+	defer span.End()
+
+	var state adlsFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteDataLakePath(ctx, state.StorageAccountName.ValueString(), state.Filesystem.ValueString(), state.Path.ValueString(), false)
+	if azure.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_adls_file", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ADLS file",
+			"Could not delete "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a file by an ID of the form account|filesystem|path.
+// The next apply uploads local_path, as the imported state carries none.
+func (r *AdlsFileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "storage_account_name", "filesystem", "path")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.azure.DataLakeURL(parts[0], parts[1], parts[2]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("storage_account_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("filesystem"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), parts[2])...)
+}
@@ -0,0 +1,408 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &AdlsFilesystemResource{}
+	_ resource.ResourceWithConfigure   = &AdlsFilesystemResource{}
+	_ resource.ResourceWithImportState = &AdlsFilesystemResource{}
+)
+
+// NewAdlsFilesystemResource is a helper function to simplify the provider implementation.
+func NewAdlsFilesystemResource() resource.Resource {
+	return &AdlsFilesystemResource{}
+}
+
+// AdlsFilesystemResource is the resource implementation.
+type AdlsFilesystemResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type adlsFilesystemResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	StorageAccountName types.String `tfsdk:"storage_account_name"`
+	Name               types.String `tfsdk:"name"`
+	Owner              types.String `tfsdk:"owner"`
+	Group              types.String `tfsdk:"group"`
+	Ace                types.Set    `tfsdk:"ace"`
+}
+
+// adlsAceModel is a single POSIX access control entry.
+type adlsAceModel struct {
+	Scope       types.String `tfsdk:"scope"`
+	Type        types.String `tfsdk:"type"`
+	Id          types.String `tfsdk:"id"`
+	Permissions types.String `tfsdk:"permissions"`
+}
+
+// adlsAceAttrTypes are the attribute types of an ace element.
+var adlsAceAttrTypes = map[string]attr.Type{
+	"scope":       types.StringType,
+	"type":        types.StringType,
+	"id":          types.StringType,
+	"permissions": types.StringType,
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *AdlsFilesystemResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *AdlsFilesystemResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_adls_filesystem"
+}
+
+// Schema defines the schema for the resource.
+func (r *AdlsFilesystemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates an ADLS Gen2 filesystem and manages the access control of its root directory, including default ACLs inherited by new paths.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "DFS endpoint URL of the filesystem",
+			},
+			"storage_account_name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the storage account, which must have the hierarchical namespace enabled",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the filesystem",
+			},
+			"owner": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Object ID owning the root directory, or $superuser",
+			},
+			"group": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Object ID of the owning group of the root directory, or $superuser",
+			},
+			"ace": schema.SetNestedAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Access control entries of the root directory. When set, the list must include the user, group and other entries and replaces the whole ACL",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"scope": schema.StringAttribute{
+							Required:    true,
+							Validators:  []validator.String{StringOneOf("access", "default")},
+							Description: "access for the entry itself or default for the entry inherited by new children",
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Validators:  []validator.String{StringOneOf("user", "group", "mask", "other")},
+							Description: "Entry type: user, group, mask or other",
+						},
+						"id": schema.StringAttribute{
+							Optional:    true,
+							Description: "Object ID of the user or group, unset for the owning user, owning group, mask and other entries",
+						},
+						"permissions": schema.StringAttribute{
+							Required:    true,
+							Description: "Permissions in rwx form, such as r-x",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// formatAcl renders access control entries in the form expected by x-ms-acl.
+func formatAcl(aces []adlsAceModel) string {
+	entries := make([]string, 0, len(aces))
+	for _, ace := range aces {
+		entry := ace.Type.ValueString() + ":" + ace.Id.ValueString() + ":" + ace.Permissions.ValueString()
+		if ace.Scope.ValueString() == "default" {
+			entry = "default:" + entry
+		}
+		entries = append(entries, entry)
+	}
+	return strings.Join(entries, ",")
+}
+
+// parseAcl parses an x-ms-acl header into access control entries.
+func parseAcl(acl string) []adlsAceModel {
+	var aces []adlsAceModel
+	for _, entry := range strings.Split(acl, ",") {
+		if entry == "" {
+			continue
+		}
+		scope := "access"
+		if rest, ok := strings.CutPrefix(entry, "default:"); ok {
+			scope, entry = "default", rest
+		}
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		id := types.StringNull()
+		if parts[1] != "" {
+			id = types.StringValue(parts[1])
+		}
+		aces = append(aces, adlsAceModel{
+			Scope:       types.StringValue(scope),
+			Type:        types.StringValue(parts[0]),
+			Id:          id,
+			Permissions: types.StringValue(parts[2]),
+		})
+	}
+	return aces
+}
+
+// accessControlFromModel builds the access control to apply to the root
+// directory from the configured owner, group and ace. Fields that are unknown
+// are left empty so the service keeps its current value.
+func accessControlFromModel(ctx context.Context, model *adlsFilesystemResourceModel) (azure.AccessControl, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	ac := azure.AccessControl{}
+	if !model.Owner.IsUnknown() {
+		ac.Owner = model.Owner.ValueString()
+	}
+	if !model.Group.IsUnknown() {
+		ac.Group = model.Group.ValueString()
+	}
+	if !model.Ace.IsUnknown() && !model.Ace.IsNull() {
+		var aces []adlsAceModel
+		diags.Append(model.Ace.ElementsAs(ctx, &aces, false)...)
+		ac.ACL = formatAcl(aces)
+	}
+	return ac, diags
+}
+
+// readAccessControl refreshes owner, group and ace from the service.
+func (r *AdlsFilesystemResource) readAccessControl(ctx context.Context, model *adlsFilesystemResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	ac, err := r.azure.GetAccessControl(ctx, model.StorageAccountName.ValueString(), model.Name.ValueString(), "")
+	if err != nil {
+		diags.AddError(
+			"Error reading ADLS filesystem access control",
+			"Could not read access control of "+model.Id.ValueString()+": "+err.Error(),
+		)
+		return diags
+	}
+
+	model.Owner = types.StringValue(ac.Owner)
+	model.Group = types.StringValue(ac.Group)
+	aces, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: adlsAceAttrTypes}, parseAcl(ac.ACL))
+	diags.Append(d...)
+	model.Ace = aces
+	return diags
+}
+
+// Create a new resource.
+func (r *AdlsFilesystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_filesystem.Create")
+	defer span.End()
+
+	var plan adlsFilesystemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	account := plan.StorageAccountName.ValueString()
+	target := r.azure.DataLakeURL(account, plan.Name.ValueString(), "")
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.CreateFilesystem(ctx, account, plan.Name.ValueString())
+	r.audit.Record(ctx, "mrl_adls_filesystem", auditActionCreate, target, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating ADLS filesystem",
+			"Could not create "+target+": "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(target)
+
+	ac, diags := accessControlFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if ac != (azure.AccessControl{}) {
+		if err := r.azure.SetAccessControl(ctx, account, plan.Name.ValueString(), "", ac); err != nil {
+			resp.Diagnostics.AddError(
+				"Error setting ADLS filesystem access control",
+				"Could not set access control of "+target+": "+err.Error(),
+			)
+			return
+		}
+	}
+	resp.Diagnostics.Append(r.readAccessControl(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *AdlsFilesystemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_filesystem.Read")
+	defer span.End()
+
+	var state adlsFilesystemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.azure.GetFilesystemProperties(ctx, state.StorageAccountName.ValueString(), state.Name.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading ADLS filesystem",
+			"Could not read properties of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(r.readAccessControl(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *AdlsFilesystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_filesystem.Update")
+	defer span.End()
+
+	var plan adlsFilesystemResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ac, diags := accessControlFromModel(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.SetAccessControl(ctx, plan.StorageAccountName.ValueString(), plan.Name.ValueString(), "", ac)
+	r.audit.Record(ctx, "mrl_adls_filesystem", auditActionUpdate, plan.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error setting ADLS filesystem access control",
+			"Could not set access control of "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(r.readAccessControl(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *AdlsFilesystemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_adls_filesystem.Delete")
+	defer span.End()
+
+	var state adlsFilesystemResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteFilesystem(ctx, state.StorageAccountName.ValueString(), state.Name.ValueString())
+	if azure.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_adls_filesystem", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting ADLS filesystem",
+			"Could not delete "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a filesystem by an ID of the form account|name.
+func (r *AdlsFilesystemResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "storage_account_name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.azure.DataLakeURL(parts[0], parts[1], ""))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("storage_account_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+}
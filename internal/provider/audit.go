@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Audit actions recorded for mutating operations.
+const (
+	auditActionCreate = "create"
+	auditActionUpdate = "update"
+	auditActionDelete = "delete"
+)
+
+// auditEntry is a single line of the mutation audit log.
+type auditEntry struct {
+	Timestamp    string `json:"timestamp"`
+	ResourceType string `json:"resource_type"`
+	Action       string `json:"action"`
+	Target       string `json:"target"`
+	Result       string `json:"result"`
+	Error        string `json:"error,omitempty"`
+	RequestID    string `json:"request_id,omitempty"`
+}
+
+// auditLogger appends one JSON document per create, update or delete to the
+// file configured through the provider audit_log_path attribute. A nil
+// *auditLogger discards every entry.
+type auditLogger struct {
+	mu   sync.Mutex
+	path string
+}
+
+func newAuditLogger(path string) *auditLogger {
+	if path == "" {
+		return nil
+	}
+	return &auditLogger{path: path}
+}
+
+// Record writes an entry for the operation. The request ID is taken from the
+// last API response seen on a context prepared with withAuditRequestID.
+func (l *auditLogger) Record(ctx context.Context, resourceType, action, target string, opErr error) {
+	if l == nil {
+		return
+	}
+
+	entry := auditEntry{
+		Timestamp:    time.Now().UTC().Format(time.RFC3339Nano),
+		ResourceType: resourceType,
+		Action:       action,
+		Target:       target,
+		Result:       "success",
+	}
+	if opErr != nil {
+		entry.Result = "failure"
+		entry.Error = opErr.Error()
+	}
+	if holder, ok := ctx.Value(auditRequestIDKey{}).(*auditRequestID); ok {
+		entry.RequestID = holder.get()
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to encode audit log entry", map[string]interface{}{"error": err.Error()})
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		tflog.Warn(ctx, "Unable to open audit log", map[string]interface{}{"path": l.path, "error": err.Error()})
+		return
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		tflog.Warn(ctx, "Unable to write audit log entry", map[string]interface{}{"path": l.path, "error": err.Error()})
+	}
+}
+
+type auditRequestIDKey struct{}
+
+// auditRequestID holds the request ID of the last API response for an
+// operation.
+type auditRequestID struct {
+	mu sync.Mutex
+	id string
+}
+
+func (h *auditRequestID) get() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.id
+}
+
+func (h *auditRequestID) set(id string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.id = id
+}
+
+// diagsError returns the first error of diags as an error to record in the
+// audit log, or nil when diags has no errors.
+func diagsError(diags diag.Diagnostics) error {
+	errs := diags.Errors()
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(errs[0].Summary() + ": " + errs[0].Detail())
+}
+
+// withAuditRequestID prepares ctx so that requests made with it capture the
+// API request ID for the audit log.
+func withAuditRequestID(ctx context.Context) context.Context {
+	return context.WithValue(ctx, auditRequestIDKey{}, &auditRequestID{})
+}
+
+// requestIDHeaders lists the response headers carrying a request ID, in order
+// of preference.
+var requestIDHeaders = []string{"X-Request-Id", "X-Ms-Request-Id"}
+
+// auditTransport records response request IDs on contexts prepared with
+// withAuditRequestID.
+type auditTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if holder, ok := req.Context().Value(auditRequestIDKey{}).(*auditRequestID); ok {
+		for _, header := range requestIDHeaders {
+			if id := resp.Header.Get(header); id != "" {
+				holder.set(id)
+				break
+			}
+		}
+	}
+
+	return resp, nil
+}
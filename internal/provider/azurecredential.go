@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// Values of the auth_method provider attribute.
+const (
+	authMethodClientSecret     = "client_secret"
+	authMethodManagedIdentity  = "managed_identity"
+	authMethodAzureCLI         = "azure_cli"
+	authMethodWorkloadIdentity = "workload_identity"
+	authMethodDefault          = "default"
+)
+
+// azureAuthConfig holds the provider settings the Azure credential is built
+// from. Settings a method does not use are ignored.
+type azureAuthConfig struct {
+	method        string
+	tenantID      string
+	clientID      string
+	clientSecret  string
+	tokenFilePath string
+	// transport sends the token requests, so that they use the proxy and
+	// certificate authorities of the provider. Defaults to that of azcore.
+	transport policy.Transporter
+	// cloud sets the Microsoft Entra ID authority. The Azure CLI uses its
+	// own cloud setting.
+	cloud cloud.Configuration
+}
+
+// newAzureCredential returns the credential of the configured auth method.
+// Settings left empty fall back to the environment variables azidentity reads,
+// such as AZURE_CLIENT_ID, AZURE_TENANT_ID and AZURE_FEDERATED_TOKEN_FILE.
+func newAzureCredential(cfg azureAuthConfig) (azcore.TokenCredential, error) {
+	clientOptions := azcore.ClientOptions{Transport: cfg.transport, Cloud: cfg.cloud}
+	switch cfg.method {
+	case authMethodClientSecret:
+		return azidentity.NewClientSecretCredential(cfg.tenantID, cfg.clientID, cfg.clientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: clientOptions})
+	case authMethodManagedIdentity:
+		options := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOptions}
+		if cfg.clientID != "" {
+			// User-assigned identity; the system-assigned one otherwise.
+			options.ID = azidentity.ClientID(cfg.clientID)
+		}
+		return azidentity.NewManagedIdentityCredential(options)
+	case authMethodAzureCLI:
+		return azidentity.NewAzureCLICredential(&azidentity.AzureCLICredentialOptions{TenantID: cfg.tenantID})
+	case authMethodWorkloadIdentity:
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientOptions: clientOptions,
+			ClientID:      cfg.clientID,
+			TenantID:      cfg.tenantID,
+			TokenFilePath: cfg.tokenFilePath,
+		})
+	case authMethodDefault:
+		return azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: clientOptions, TenantID: cfg.tenantID})
+	}
+	return nil, fmt.Errorf("unsupported auth method %q", cfg.method)
+}
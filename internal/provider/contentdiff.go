@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// previewContentChange compares the planned content_md5 of a managed file
+// with the one recorded in state. It returns the planned content_changed value
+// and, when the content will be uploaded again, a warning telling reviewers
+// the local and remote hashes and how much the size changes.
+func previewContentChange(localPath types.String, planned, recorded types.String, remoteSize types.Int64) (types.Bool, diag.Diagnostic) {
+	if planned.IsUnknown() {
+		return types.BoolUnknown(), nil
+	}
+	if planned.Equal(recorded) {
+		return types.BoolValue(false), nil
+	}
+
+	remoteHash := recorded.ValueString()
+	if recorded.IsNull() {
+		remoteHash = "unknown, the remote file changed outside Terraform"
+	}
+	detail := fmt.Sprintf("%s will be uploaded again.\n\nlocal md5:  %s\nremote md5: %s",
+		localPath.ValueString(), planned.ValueString(), remoteHash)
+
+	if info, err := os.Stat(localPath.ValueString()); err == nil && !remoteSize.IsNull() && !remoteSize.IsUnknown() {
+		delta := info.Size() - remoteSize.ValueInt64()
+		detail += fmt.Sprintf("\nsize:       %d -> %d bytes (%+d)", remoteSize.ValueInt64(), info.Size(), delta)
+	}
+
+	return types.BoolValue(true), diag.NewAttributeWarningDiagnostic(path.Root("content_md5"), "File content will change", detail)
+}
+
+// planContentMD5 returns the content_md5 to plan for a managed file: the
+// configured value, or else the md5 of the local file, computed at plan time.
+// It is unknown while local_path is.
+func planContentMD5(ctx context.Context, config tfsdk.Config, localPath types.String) (types.String, diag.Diagnostics) {
+	var configured types.String
+	diags := config.GetAttribute(ctx, path.Root("content_md5"), &configured)
+	if diags.HasError() || !configured.IsNull() {
+		return configured, diags
+	}
+	if localPath.IsUnknown() {
+		return types.StringUnknown(), diags
+	}
+
+	sum, err := fileMD5(localPath.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("local_path"),
+			"Error reading local file",
+			"Could not hash "+localPath.ValueString()+": "+err.Error(),
+		)
+		return types.StringUnknown(), diags
+	}
+	return types.StringValue(sum), diags
+}
+
+// planSourceHash returns the SHA-256 of the local file, computed at plan time,
+// for the source_hash attribute of a managed file. It is unknown while
+// local_path is.
+func planSourceHash(localPath types.String) (types.String, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if localPath.IsUnknown() || localPath.IsNull() {
+		return types.StringUnknown(), diags
+	}
+
+	f, err := os.Open(localPath.ValueString())
+	if err == nil {
+		defer f.Close()
+		hash := sha256.New()
+		if _, err = io.Copy(hash, f); err == nil {
+			return types.StringValue(hex.EncodeToString(hash.Sum(nil))), diags
+		}
+	}
+	diags.AddAttributeError(
+		path.Root("local_path"),
+		"Error reading local file",
+		"Could not hash "+localPath.ValueString()+": "+err.Error(),
+	)
+	return types.StringUnknown(), diags
+}
+
+// sourceChanged reports whether the local file changed since the last upload
+// even though the planned content_md5 did not, as when content_md5 is set to
+// a fixed value. It returns the warning to show in the plan then.
+func sourceChanged(localPath, planned, recorded types.String) (bool, diag.Diagnostic) {
+	if planned.IsUnknown() || recorded.IsNull() || planned.Equal(recorded) {
+		return false, nil
+	}
+	return true, diag.NewAttributeWarningDiagnostic(
+		path.Root("source_hash"),
+		"File content will change",
+		fmt.Sprintf("%s changed since it was last uploaded and will be uploaded again.\n\nlocal sha256:    %s\nuploaded sha256: %s",
+			localPath.ValueString(), planned.ValueString(), recorded.ValueString()),
+	)
+}
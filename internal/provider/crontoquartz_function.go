@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &CronToQuartzFunction{}
+)
+
+// NewCronToQuartzFunction is a helper function to simplify the provider implementation.
+func NewCronToQuartzFunction() function.Function {
+	return &CronToQuartzFunction{}
+}
+
+// CronToQuartzFunction is the function implementation.
+type CronToQuartzFunction struct{}
+
+// cronField describes one field of a standard 5-field cron expression.
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}},
+}
+
+// quartzDaysOfWeek maps cron day-of-week numbers, where both 0 and 7 are
+// Sunday, to the names Quartz accepts.
+var quartzDaysOfWeek = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT", "SUN"}
+
+// cronToQuartz converts a standard 5-field cron expression to the 6-field
+// Quartz syntax used by Databricks job schedules. Errors name the offending
+// field.
+func cronToQuartz(expr string) (string, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return "", fmt.Errorf("expected 5 space separated fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	for i, field := range fields {
+		converted, err := convertCronField(cronFields[i], field, i == 4)
+		if err != nil {
+			return "", fmt.Errorf("field %d (%s) %q: %w", i+1, cronFields[i].name, field, err)
+		}
+		fields[i] = converted
+	}
+
+	// Quartz requires exactly one of day-of-month and day-of-week to be "?".
+	dayOfMonth, dayOfWeek := fields[2], fields[4]
+	switch {
+	case dayOfWeek == "*":
+		dayOfWeek = "?"
+	case dayOfMonth == "*":
+		dayOfMonth = "?"
+	default:
+		return "", fmt.Errorf("field 3 (day of month) and field 5 (day of week) cannot both be restricted in Quartz syntax; set one of them to \"*\"")
+	}
+
+	return strings.Join([]string{"0", fields[0], fields[1], dayOfMonth, fields[3], dayOfWeek}, " "), nil
+}
+
+// convertCronField validates a single cron field and, for the day-of-week
+// field, renumbers values to Quartz names.
+func convertCronField(f cronField, field string, dayOfWeek bool) (string, error) {
+	items := strings.Split(field, ",")
+	for i, item := range items {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			n, err := strconv.Atoi(step)
+			if err != nil || n <= 0 {
+				return "", fmt.Errorf("invalid step %q", step)
+			}
+		}
+
+		if rangePart == "*" {
+			if len(items) > 1 {
+				return "", fmt.Errorf("\"*\" cannot be combined with other values")
+			}
+			continue
+		}
+
+		bounds := strings.Split(rangePart, "-")
+		if len(bounds) > 2 {
+			return "", fmt.Errorf("invalid range %q", rangePart)
+		}
+
+		for j, bound := range bounds {
+			value, err := parseCronValue(f, bound)
+			if err != nil {
+				return "", err
+			}
+			if dayOfWeek {
+				bounds[j] = quartzDaysOfWeek[value]
+			} else {
+				bounds[j] = strconv.Itoa(value)
+				if f.names != nil {
+					bounds[j] = f.names[value-f.min]
+				}
+			}
+		}
+
+		items[i] = strings.Join(bounds, "-")
+		if hasStep {
+			items[i] += "/" + step
+		}
+	}
+
+	return strings.Join(items, ","), nil
+}
+
+// parseCronValue parses a numeric or named value and checks its range.
+func parseCronValue(f cronField, value string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(value, name) {
+			return f.min + i, nil
+		}
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, f.min, f.max)
+	}
+
+	return n, nil
+}
+
+// Metadata returns the function name.
+func (f *CronToQuartzFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "cron_to_quartz"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *CronToQuartzFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Convert a 5-field cron expression to Quartz syntax",
+		Description: "Converts a standard `minute hour day-of-month month day-of-week` cron expression to the Quartz syntax required by Databricks job schedules, adding the seconds field and the `?` placeholder Quartz expects for day-of-month or day-of-week.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "expression",
+				Description: "Standard 5-field cron expression",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run converts the expression.
+func (f *CronToQuartzFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var expr string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &expr))
+	if resp.Error != nil {
+		return
+	}
+
+	quartz, err := cronToQuartz(expr)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid cron expression: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, quartz))
+}
@@ -0,0 +1,345 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksAccessConnectorResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksAccessConnectorResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksAccessConnectorResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksAccessConnectorResource{}
+	_ resource.ResourceWithImportState    = &DatabricksAccessConnectorResource{}
+)
+
+// NewDatabricksAccessConnectorResource is a helper function to simplify the provider implementation.
+func NewDatabricksAccessConnectorResource() resource.Resource {
+	return &DatabricksAccessConnectorResource{}
+}
+
+// DatabricksAccessConnectorResource is the resource implementation.
+type DatabricksAccessConnectorResource struct {
+	azure          *azure.Client
+	subscriptionID string
+	audit          *auditLogger
+	defaultTags    map[string]string
+}
+
+type databricksAccessConnectorResourceModel struct {
+	Id                     types.String `tfsdk:"id"`
+	Name                   types.String `tfsdk:"name"`
+	ResourceGroupName      types.String `tfsdk:"resource_group_name"`
+	Location               types.String `tfsdk:"location"`
+	IdentityType           types.String `tfsdk:"identity_type"`
+	UserAssignedIdentityId types.String `tfsdk:"user_assigned_identity_id"`
+	Tags                   types.Map    `tfsdk:"tags"`
+	TagsAll                types.Map    `tfsdk:"tags_all"`
+	PrincipalId            types.String `tfsdk:"principal_id"`
+	TenantId               types.String `tfsdk:"tenant_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksAccessConnectorResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.subscriptionID = providerData.SubscriptionID
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksAccessConnectorResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_access_connector"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksAccessConnectorResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	useStateForUnknown := []planmodifier.String{
+		stringplanmodifier.UseStateForUnknown(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages an Azure Databricks access connector, the managed identity Unity Catalog storage credentials use to reach ADLS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "ARM resource ID of the access connector",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the access connector",
+			},
+			"resource_group_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Resource group in which the access connector is created",
+			},
+			"location": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Azure region of the access connector",
+			},
+			"identity_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("SystemAssigned"),
+				Validators:  []validator.String{StringOneOf("SystemAssigned", "UserAssigned")},
+				Description: "Identity of the connector: SystemAssigned or UserAssigned. Defaults to SystemAssigned",
+			},
+			"user_assigned_identity_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARM ID of the user-assigned managed identity, required when identity_type is UserAssigned",
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags of the access connector",
+			},
+			"tags_all": tagsAllAttribute(),
+			"principal_id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "Object ID of the identity, to grant storage roles to",
+			},
+			"tenant_id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "Tenant of the identity",
+			},
+		},
+	}
+}
+
+// ModifyPlan merges the provider default tags into tags_all.
+func (r *DatabricksAccessConnectorResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+}
+
+// ValidateConfig checks that a user-assigned identity is given exactly when
+// it is used.
+func (r *DatabricksAccessConnectorResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksAccessConnectorResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.IdentityType.IsUnknown() || config.UserAssignedIdentityId.IsUnknown() {
+		return
+	}
+	userAssigned := config.IdentityType.ValueString() == "UserAssigned"
+	if userAssigned == config.UserAssignedIdentityId.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user_assigned_identity_id"),
+			"Invalid identity",
+			"user_assigned_identity_id must be set when, and only when, identity_type is UserAssigned.",
+		)
+	}
+}
+
+// applyAccessConnector copies the computed attributes of the connector to the
+// model.
+func applyAccessConnector(model *databricksAccessConnectorResourceModel, connector *azure.AccessConnector) {
+	model.Id = types.StringValue(connector.ID)
+	model.PrincipalId = types.StringNull()
+	model.TenantId = types.StringNull()
+	if identity := connector.Identity; identity != nil {
+		model.IdentityType = types.StringValue(identity.Type)
+		model.TenantId = types.StringValue(identity.TenantID)
+		model.PrincipalId = types.StringValue(identity.PrincipalID)
+		for _, assigned := range identity.UserAssignedIdentities {
+			if assigned != nil {
+				model.PrincipalId = types.StringValue(assigned.PrincipalID)
+			}
+		}
+	}
+}
+
+// put creates or updates the access connector from the plan.
+func (r *DatabricksAccessConnectorResource) put(ctx context.Context, plan *databricksAccessConnectorResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	connector := azure.AccessConnector{
+		Location: plan.Location.ValueString(),
+		Identity: &azure.ManagedIdentity{Type: plan.IdentityType.ValueString()},
+	}
+	if !plan.UserAssignedIdentityId.IsNull() {
+		connector.Identity.UserAssignedIdentities = map[string]*azure.UserAssignedIdentity{
+			plan.UserAssignedIdentityId.ValueString(): {},
+		}
+	}
+	diags.Append(plan.TagsAll.ElementsAs(ctx, &connector.Tags, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	id := azure.AccessConnectorID(r.subscriptionID, plan.ResourceGroupName.ValueString(), plan.Name.ValueString())
+
+	ctx = withAuditRequestID(ctx)
+	result, err := r.azure.PutAccessConnector(ctx, id, connector)
+	r.audit.Record(ctx, "mrl_databricks_access_connector", action, id, err)
+	if err != nil {
+		diags.AddError("Error provisioning access connector", "Could not provision access connector "+id+": "+err.Error())
+		return diags
+	}
+
+	applyAccessConnector(plan, result)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksAccessConnectorResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_access_connector.Create")
+	defer span.End()
+
+	var plan databricksAccessConnectorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksAccessConnectorResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_access_connector.Read")
+	defer span.End()
+
+	var state databricksAccessConnectorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	connector, err := r.azure.GetAccessConnector(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading access connector",
+			"Could not read access connector "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	applyAccessConnector(&state, connector)
+	if state.Location.IsNull() {
+		// Imported: fill in the arguments the import ID does not carry.
+		state.Location = types.StringValue(connector.Location)
+		if identity := connector.Identity; identity != nil {
+			for id := range identity.UserAssignedIdentities {
+				state.UserAssignedIdentityId = types.StringValue(id)
+			}
+		}
+	}
+	tags, tagsAll, diags := readTags(ctx, r.defaultTags, state.Tags, connector.Tags)
+	resp.Diagnostics.Append(diags...)
+	state.Tags = tags
+	state.TagsAll = tagsAll
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksAccessConnectorResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_access_connector.Update")
+	defer span.End()
+
+	var plan databricksAccessConnectorResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksAccessConnectorResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_access_connector.Delete")
+	defer span.End()
+
+	var state databricksAccessConnectorResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteAccessConnector(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_access_connector", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting access connector",
+			"Could not delete access connector "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports an access connector by its ARM ID.
+func (r *DatabricksAccessConnectorResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportARMID(req.ID, "Microsoft.Databricks", "accessConnectors")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_group_name"), id.ResourceGroup)...)
+}
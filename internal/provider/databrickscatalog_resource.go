@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksCatalogResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksCatalogResource{}
+	_ resource.ResourceWithImportState = &DatabricksCatalogResource{}
+)
+
+// NewDatabricksCatalogResource is a helper function to simplify the provider implementation.
+func NewDatabricksCatalogResource() resource.Resource {
+	return &DatabricksCatalogResource{}
+}
+
+// DatabricksCatalogResource is the resource implementation.
+type DatabricksCatalogResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksCatalogResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl    URLValue     `tfsdk:"workspace_url"`
+	Name            types.String `tfsdk:"name"`
+	Comment         types.String `tfsdk:"comment"`
+	Owner           types.String `tfsdk:"owner"`
+	StorageRoot     types.String `tfsdk:"storage_root"`
+	StorageLocation types.String `tfsdk:"storage_location"`
+	IsolationMode   types.String `tfsdk:"isolation_mode"`
+	MetastoreId     types.String `tfsdk:"metastore_id"`
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksCatalogResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksCatalogResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_catalog"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksCatalogResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a Unity Catalog catalog of the metastore assigned to a Databricks workspace.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Name of the catalog",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the catalog",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the catalog",
+			},
+			"owner": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "User, group or service principal owning the catalog. Defaults to the identity the provider authenticates with",
+			},
+			"storage_root": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Storage URL of the managed tables and volumes of the catalog, e.g. abfss://container@account.dfs.core.windows.net/catalog. It must be covered by an external location. Defaults to the storage root of the metastore",
+			},
+			"storage_location": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Storage URL Unity Catalog resolved for the managed data of the catalog",
+			},
+			"isolation_mode": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(databricks.IsolationModeOpen),
+				Validators:  []validator.String{StringOneOf(databricks.IsolationModeOpen, databricks.IsolationModeIsolated)},
+				Description: "OPEN to make the catalog reachable from all workspaces of the metastore, or ISOLATED to restrict it to the workspaces bound to it. Defaults to OPEN",
+			},
+			"metastore_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the metastore of the catalog",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether destroying the catalog also deletes its schemas, tables and volumes. Otherwise only an empty catalog can be destroyed. Defaults to false",
+			},
+		},
+	}
+}
+
+// readCatalog fills the computed attributes of model from the metastore.
+func readCatalog(ctx context.Context, client *databricks.Client, model *databricksCatalogResourceModel) error {
+	catalog, err := client.GetCatalog(ctx, model.Id.ValueString())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(catalog.Name)
+	// Unity Catalog lowercases names; keep the configured spelling.
+	if model.Name.IsNull() {
+		model.Name = types.StringValue(catalog.Name)
+	}
+	if catalog.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(catalog.Comment)
+	}
+	model.Owner = types.StringValue(catalog.Owner)
+	model.StorageRoot = optionalString(catalog.StorageRoot)
+	model.StorageLocation = types.StringValue(catalog.StorageLocation)
+	if catalog.IsolationMode != "" {
+		model.IsolationMode = types.StringValue(catalog.IsolationMode)
+	} else {
+		model.IsolationMode = types.StringValue(databricks.IsolationModeOpen)
+	}
+	model.MetastoreId = types.StringValue(catalog.MetastoreID)
+	return nil
+}
+
+// catalogUpdate returns the update setting the comment, owner and isolation
+// mode of plan. An unknown owner is left to the metastore.
+func catalogUpdate(plan databricksCatalogResourceModel) databricks.SecurableUpdate {
+	update := databricks.SecurableUpdate{
+		Comment:       plan.Comment.ValueString(),
+		IsolationMode: plan.IsolationMode.ValueString(),
+	}
+	if !plan.Owner.IsUnknown() {
+		update.Owner = plan.Owner.ValueString()
+	}
+	return update
+}
+
+// Create a new resource.
+func (r *DatabricksCatalogResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_catalog.Create")
+	defer span.End()
+
+	var plan databricksCatalogResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	name := plan.Name.ValueString()
+	_, err = client.CreateCatalog(ctx, databricks.CatalogInfo{
+		Name:        name,
+		Comment:     plan.Comment.ValueString(),
+		StorageRoot: plan.StorageRoot.ValueString(),
+	})
+	// The owner and isolation mode can only be set once the catalog exists.
+	if err == nil && (!plan.Owner.IsUnknown() || plan.IsolationMode.ValueString() != databricks.IsolationModeOpen) {
+		err = client.UpdateCatalog(ctx, name, catalogUpdate(plan))
+		if err != nil {
+			// Do not leave an unmanaged catalog behind.
+			_ = client.DeleteCatalog(ctx, name, false)
+		}
+	}
+	r.audit.Record(ctx, "mrl_databricks_catalog", auditActionCreate, name, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating catalog",
+			"Could not create catalog "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(name)
+	if err := readCatalog(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading catalog",
+			"Could not read catalog "+name+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksCatalogResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_catalog.Read")
+	defer span.End()
+
+	var state databricksCatalogResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readCatalog(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading catalog",
+			"Could not read catalog "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksCatalogResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_catalog.Update")
+	defer span.End()
+
+	var plan, state databricksCatalogResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if !plan.Comment.Equal(state.Comment) || !plan.Owner.Equal(state.Owner) || !plan.IsolationMode.Equal(state.IsolationMode) {
+		err = client.UpdateCatalog(ctx, state.Id.ValueString(), catalogUpdate(plan))
+	}
+	r.audit.Record(ctx, "mrl_databricks_catalog", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating catalog",
+			"Could not update catalog "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readCatalog(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading catalog",
+			"Could not read catalog "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksCatalogResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_catalog.Delete")
+	defer span.End()
+
+	var state databricksCatalogResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteCatalog(ctx, state.Id.ValueString(), state.ForceDestroy.ValueBool())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_catalog", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting catalog",
+			"Could not delete catalog "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a catalog by an ID of the form workspace_url|name.
+func (r *DatabricksCatalogResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "workspace_url", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksClusterDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksClusterDataSource{}
+)
+
+// NewDatabricksClusterDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksClusterDataSource() datasource.DataSource {
+	return &DatabricksClusterDataSource{}
+}
+
+// DatabricksClusterDataSource is the data source implementation.
+type DatabricksClusterDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksClusterDataSourceModel maps the data source schema data.
+type databricksClusterDataSourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	AdbId            types.String `tfsdk:"adb_id"`
+	WorkspaceUrl     types.String `tfsdk:"workspace_url"`
+	Token            types.String `tfsdk:"token"`
+	ClusterId        types.String `tfsdk:"cluster_id"`
+	ClusterName      types.String `tfsdk:"cluster_name"`
+	State            types.String `tfsdk:"state"`
+	SparkVersion     types.String `tfsdk:"spark_version"`
+	NodeTypeId       types.String `tfsdk:"node_type_id"`
+	DriverNodeTypeId types.String `tfsdk:"driver_node_type_id"`
+	InstancePoolId   types.String `tfsdk:"instance_pool_id"`
+	NumWorkers       types.Int64  `tfsdk:"num_workers"`
+	CustomTags       types.Map    `tfsdk:"custom_tags"`
+	ClusterSource    types.String `tfsdk:"cluster_source"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksClusterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksClusterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_cluster"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksClusterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an existing cluster by ID or by name, e.g. to attach libraries to a cluster created outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the cluster",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"cluster_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ID of the cluster. Exactly one of cluster_id and cluster_name must be set",
+			},
+			"cluster_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the cluster, which must match exactly one cluster of the workspace",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the cluster, such as RUNNING or TERMINATED",
+			},
+			"spark_version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Databricks runtime version of the cluster",
+			},
+			"node_type_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Node type of the workers",
+			},
+			"driver_node_type_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Node type of the driver",
+			},
+			"instance_pool_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the instance pool the cluster takes its nodes from, empty if none",
+			},
+			"num_workers": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of workers of a fixed size cluster",
+			},
+			"custom_tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Custom tags of the cluster",
+			},
+			"cluster_source": schema.StringAttribute{
+				Computed:    true,
+				Description: "What created the cluster, such as UI, API or JOB",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_cluster.Read")
+	defer span.End()
+
+	var state databricksClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ClusterId.IsNull() == state.ClusterName.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("cluster_id"), "Invalid cluster", "Exactly one of cluster_id and cluster_name must be set.")
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+
+	var info *databricks.ClusterInfo
+	if !state.ClusterId.IsNull() {
+		info, err = client.GetClusterInfo(ctx, state.ClusterId.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading cluster",
+				"Could not read cluster "+state.ClusterId.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	} else {
+		clusters, err := client.ListClusters(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError("Error listing clusters", "Could not list the clusters of the workspace: "+err.Error())
+			return
+		}
+		var matches []databricks.ClusterInfo
+		for _, cluster := range clusters {
+			if cluster.ClusterName == state.ClusterName.ValueString() {
+				matches = append(matches, cluster)
+			}
+		}
+		if len(matches) != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cluster_name"),
+				"Cluster not found",
+				fmt.Sprintf("Expected one cluster named %q, found %d.", state.ClusterName.ValueString(), len(matches)),
+			)
+			return
+		}
+		info = &matches[0]
+	}
+
+	tags := info.CustomTags
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	customTags, diags := types.MapValueFrom(ctx, types.StringType, tags)
+	resp.Diagnostics.Append(diags...)
+
+	state.Id = types.StringValue(info.ClusterID)
+	state.ClusterId = types.StringValue(info.ClusterID)
+	state.ClusterName = types.StringValue(info.ClusterName)
+	state.State = types.StringValue(info.State)
+	state.SparkVersion = types.StringValue(info.SparkVersion)
+	state.NodeTypeId = types.StringValue(info.NodeTypeID)
+	state.DriverNodeTypeId = types.StringValue(info.DriverNodeTypeID)
+	state.InstancePoolId = types.StringValue(info.InstancePoolID)
+	state.NumWorkers = types.Int64Value(info.NumWorkers)
+	state.CustomTags = customTags
+	state.ClusterSource = types.StringValue(info.ClusterSource)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &DatabricksClusterListResource{}
+	_ list.ListResourceWithConfigure = &DatabricksClusterListResource{}
+)
+
+// NewDatabricksClusterListResource is a helper function to simplify the provider implementation.
+func NewDatabricksClusterListResource() list.ListResource {
+	return &DatabricksClusterListResource{}
+}
+
+// DatabricksClusterListResource lists the all-purpose clusters of a workspace
+// as mrl_databricks_cluster instances, for bulk import of clusters created
+// outside Terraform.
+type DatabricksClusterListResource struct {
+	httpClient  *http.Client
+	workspace   databricksWorkspace
+	defaultTags map[string]string
+}
+
+// databricksClusterListConfigModel maps the list configuration.
+type databricksClusterListConfigModel struct {
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (l *DatabricksClusterListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.httpClient = providerData.HTTPClient
+	l.workspace = providerData.Databricks
+	l.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the type name of the listed resource.
+func (l *DatabricksClusterListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_cluster"
+}
+
+// ListResourceConfigSchema defines the schema of list blocks.
+func (l *DatabricksClusterListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the all-purpose clusters of a workspace. Job clusters are left out. Authenticates with the provider databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of the provider Azure service principal.",
+		Attributes: map[string]schema.Attribute{
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+		},
+	}
+}
+
+// List streams the all-purpose clusters of the workspace.
+func (l *DatabricksClusterListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, span := tracing.Start(ctx, "list.mrl_databricks_cluster.List")
+	defer span.End()
+
+	var config databricksClusterListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	adbID, token, err := l.workspace.resolve(workspaceURL(config.WorkspaceUrl, config.AdbId), types.StringNull())
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	client := l.workspace.newClient(l.httpClient, adbID, token)
+	clusters, err := client.ListClusters(ctx)
+	if err != nil {
+		diags.AddError("Error listing clusters", "Could not list the clusters: "+err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		var n int64
+		for i := range clusters {
+			info := &clusters[i]
+			if info.ClusterSource == databricks.ClusterSourceJob {
+				continue
+			}
+			if req.Limit > 0 && n >= req.Limit {
+				return
+			}
+			n++
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = info.ClusterName
+			result.Diagnostics.Append(setClusterIdentity(ctx, result.Identity, adbID, info.ClusterID)...)
+			if req.IncludeResource {
+				model := databricksClusterResourceModel{
+					WorkspaceUrl: NewURLValue(adbID),
+					Token:        types.StringNull(),
+					SparkConf:    types.MapNull(types.StringType),
+					CustomTags:   types.MapNull(types.StringType),
+					InitScripts:  types.ListNull(DbfsPathType{}),
+				}
+				result.Diagnostics.Append(setClusterInfo(ctx, &model, info, l.defaultTags)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,649 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// databricksClusterTimeout bounds the wait for a cluster to start.
+const databricksClusterTimeout = 30 * time.Minute
+
+// databricksClusterTimeouts are the default timeouts of clusters, long
+// enough for a cluster to start or restart.
+var databricksClusterTimeouts = timeoutDefaults{
+	Create: databricksClusterTimeout,
+	Read:   defaultReadTimeout,
+	Update: databricksClusterTimeout,
+	Delete: defaultDeleteTimeout,
+}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksClusterResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksClusterResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksClusterResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksClusterResource{}
+	_ resource.ResourceWithImportState    = &DatabricksClusterResource{}
+	_ resource.ResourceWithIdentity       = &DatabricksClusterResource{}
+)
+
+// NewDatabricksClusterResource is a helper function to simplify the provider implementation.
+func NewDatabricksClusterResource() resource.Resource {
+	return &DatabricksClusterResource{}
+}
+
+// DatabricksClusterResource is the resource implementation.
+type DatabricksClusterResource struct {
+	httpClient  *http.Client
+	workspace   databricksWorkspace
+	audit       *auditLogger
+	defaultTags map[string]string
+}
+
+type databricksClusterResourceModel struct {
+	Id                     types.String           `tfsdk:"id"`
+	AdbId                  URLValue               `tfsdk:"adb_id"`
+	WorkspaceUrl           URLValue               `tfsdk:"workspace_url"`
+	Token                  types.String           `tfsdk:"token"`
+	ClusterName            types.String           `tfsdk:"cluster_name"`
+	SparkVersion           types.String           `tfsdk:"spark_version"`
+	NodeTypeId             types.String           `tfsdk:"node_type_id"`
+	DriverNodeTypeId       types.String           `tfsdk:"driver_node_type_id"`
+	InstancePoolId         types.String           `tfsdk:"instance_pool_id"`
+	PolicyId               types.String           `tfsdk:"policy_id"`
+	NumWorkers             types.Int64            `tfsdk:"num_workers"`
+	Autoscale              *clusterAutoscaleModel `tfsdk:"autoscale"`
+	AutoterminationMinutes types.Int64            `tfsdk:"autotermination_minutes"`
+	SparkConf              types.Map              `tfsdk:"spark_conf"`
+	CustomTags             types.Map              `tfsdk:"custom_tags"`
+	CustomTagsAll          types.Map              `tfsdk:"custom_tags_all"`
+	InitScripts            types.List             `tfsdk:"init_scripts"`
+	State                  types.String           `tfsdk:"state"`
+	Timeouts               *timeoutsModel         `tfsdk:"timeouts"`
+}
+
+// databricksClusterResourceIdentityModel identifies a cluster across
+// workspaces.
+type databricksClusterResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	ClusterId    types.String `tfsdk:"cluster_id"`
+}
+
+// clusterAutoscaleModel maps the autoscale bounds.
+type clusterAutoscaleModel struct {
+	MinWorkers types.Int64 `tfsdk:"min_workers"`
+	MaxWorkers types.Int64 `tfsdk:"max_workers"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksClusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_cluster"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Databricks all-purpose cluster. Init scripts can point at files uploaded with mrl_databricks_dbfs_file.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the cluster",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"cluster_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the cluster",
+			},
+			"spark_version": schema.StringAttribute{
+				Required:    true,
+				Description: "Databricks Runtime version key, such as 15.4.x-scala2.12",
+			},
+			"node_type_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Node type of the workers, such as Standard_DS3_v2. Exactly one of node_type_id and instance_pool_id must be set",
+			},
+			"driver_node_type_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Node type of the driver. Defaults to node_type_id",
+			},
+			"instance_pool_id": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the instance pool the driver and workers are taken from, such as the id of a mrl_databricks_instance_pool. The node types are those of the pool",
+			},
+			"policy_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the cluster policy the cluster is created with and must comply with, such as the policy_id of a mrl_databricks_cluster_policy",
+			},
+			"num_workers": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(0, 100000)},
+				Description: "Fixed number of workers. Conflicts with autoscale. A cluster with neither has no workers and runs Spark on the driver only",
+			},
+			"autoscale": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"min_workers": schema.Int64Attribute{
+						Required:    true,
+						Validators:  []validator.Int64{Int64Between(0, 100000)},
+						Description: "Minimum number of workers",
+					},
+					"max_workers": schema.Int64Attribute{
+						Required:    true,
+						Validators:  []validator.Int64{Int64Between(1, 100000)},
+						Description: "Maximum number of workers",
+					},
+				},
+				Description: "Bounds the number of workers the cluster scales between. Conflicts with num_workers",
+			},
+			"autotermination_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(60),
+				Validators:  []validator.Int64{Int64Between(0, 10000)},
+				Description: "Minutes of inactivity after which the cluster terminates. Either 0, which disables auto termination, or between 10 and 10000. Defaults to 60",
+			},
+			"spark_conf": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Spark configuration key-value pairs",
+			},
+			"custom_tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags added to the cluster and to the cloud resources it runs on",
+			},
+			"custom_tags_all": mergedTagsAttribute("cluster", "custom_tags"),
+			"init_scripts": schema.ListAttribute{
+				Optional:    true,
+				ElementType: DbfsPathType{},
+				Description: "DBFS paths of the scripts run on every node when the cluster starts, in order, such as the dbfs_path of a mrl_databricks_dbfs_file",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the cluster, such as RUNNING or TERMINATED",
+			},
+			"timeouts": databricksClusterTimeouts.attribute(),
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a cluster: the workspace and the
+// cluster ID.
+func (r *DatabricksClusterResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"cluster_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the cluster",
+			},
+		},
+	}
+}
+
+// setClusterIdentity records the identity of the cluster clusterID in the
+// workspace adbID. Terraform versions without identity support pass a nil
+// identity.
+func setClusterIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, clusterID string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksClusterResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		ClusterId:    types.StringValue(clusterID),
+	})
+}
+
+// ImportState imports a cluster by workspace_url|cluster_id or by identity. Read
+// fills in its configuration.
+func (r *DatabricksClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksClusterResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "cluster_id")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.ClusterId = types.StringValue(parts[1])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ClusterId.ValueString())...)
+}
+
+// ModifyPlan merges the provider default tags into custom_tags_all.
+func (r *DatabricksClusterResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMergedTags(ctx, r.defaultTags, path.Root("custom_tags"), path.Root("custom_tags_all"), req, resp)
+}
+
+// ValidateConfig checks that exactly one of node_type_id and instance_pool_id
+// is set, and that num_workers and autoscale are not both set.
+func (r *DatabricksClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksClusterResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.NodeTypeId.IsUnknown() && !config.InstancePoolId.IsUnknown() && config.NodeTypeId.IsNull() == config.InstancePoolId.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("instance_pool_id"),
+			"Invalid cluster node type",
+			"Exactly one of node_type_id and instance_pool_id must be set.",
+		)
+	}
+	if !config.InstancePoolId.IsNull() && !config.DriverNodeTypeId.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("driver_node_type_id"),
+			"Invalid cluster node type",
+			"driver_node_type_id cannot be set with instance_pool_id.",
+		)
+	}
+	if !config.NumWorkers.IsNull() && config.Autoscale != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("autoscale"),
+			"Conflicting cluster size",
+			"Only one of num_workers and autoscale can be set.",
+		)
+	}
+	if a := config.Autoscale; a != nil && !a.MinWorkers.IsUnknown() && !a.MaxWorkers.IsUnknown() && a.MinWorkers.ValueInt64() > a.MaxWorkers.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("autoscale").AtName("min_workers"),
+			"Invalid autoscale",
+			"min_workers must not be greater than max_workers.",
+		)
+	}
+}
+
+// clusterSpec builds the create and edit request from the plan.
+func clusterSpec(ctx context.Context, plan *databricksClusterResourceModel) (databricks.ClusterSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	spec := databricks.ClusterSpec{
+		ClusterID:              plan.Id.ValueString(),
+		ClusterName:            plan.ClusterName.ValueString(),
+		SparkVersion:           plan.SparkVersion.ValueString(),
+		NodeTypeID:             plan.NodeTypeId.ValueString(),
+		DriverNodeTypeID:       plan.DriverNodeTypeId.ValueString(),
+		InstancePoolID:         plan.InstancePoolId.ValueString(),
+		PolicyID:               plan.PolicyId.ValueString(),
+		NumWorkers:             plan.NumWorkers.ValueInt64(),
+		AutoterminationMinutes: plan.AutoterminationMinutes.ValueInt64(),
+	}
+	// Pool clusters take their node types from the pool.
+	if spec.InstancePoolID != "" {
+		spec.NodeTypeID = ""
+		spec.DriverNodeTypeID = ""
+	}
+	if plan.Autoscale != nil {
+		spec.Autoscale = &databricks.Autoscale{
+			MinWorkers: plan.Autoscale.MinWorkers.ValueInt64(),
+			MaxWorkers: plan.Autoscale.MaxWorkers.ValueInt64(),
+		}
+	}
+	diags.Append(plan.SparkConf.ElementsAs(ctx, &spec.SparkConf, false)...)
+	diags.Append(plan.CustomTagsAll.ElementsAs(ctx, &spec.CustomTags, false)...)
+
+	var scripts []DbfsPathValue
+	diags.Append(plan.InitScripts.ElementsAs(ctx, &scripts, false)...)
+	for _, script := range scripts {
+		spec.InitScripts = append(spec.InitScripts, databricks.InitScript{
+			Dbfs: &databricks.InitScriptDestination{Destination: "dbfs:" + script.ValueNormalized()},
+		})
+	}
+	return spec, diags
+}
+
+// setClusterInfo copies the cluster description into the model. Empty maps
+// and lists stay null when they were not configured, and custom tags equal to
+// the provider defaults are only kept in custom_tags_all.
+func setClusterInfo(ctx context.Context, model *databricksClusterResourceModel, info *databricks.ClusterInfo, defaultTags map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.Id = types.StringValue(info.ClusterID)
+	model.ClusterName = types.StringValue(info.ClusterName)
+	model.SparkVersion = types.StringValue(info.SparkVersion)
+	model.NodeTypeId = types.StringValue(info.NodeTypeID)
+	model.DriverNodeTypeId = types.StringValue(info.DriverNodeTypeID)
+	if !model.InstancePoolId.IsNull() || info.InstancePoolID != "" {
+		model.InstancePoolId = types.StringValue(info.InstancePoolID)
+	}
+	if !model.PolicyId.IsNull() || info.PolicyID != "" {
+		model.PolicyId = types.StringValue(info.PolicyID)
+	}
+	model.AutoterminationMinutes = types.Int64Value(info.AutoterminationMinutes)
+	model.State = types.StringValue(info.State)
+
+	if info.Autoscale != nil {
+		model.Autoscale = &clusterAutoscaleModel{
+			MinWorkers: types.Int64Value(info.Autoscale.MinWorkers),
+			MaxWorkers: types.Int64Value(info.Autoscale.MaxWorkers),
+		}
+		model.NumWorkers = types.Int64Null()
+	} else {
+		model.Autoscale = nil
+		if !model.NumWorkers.IsNull() || info.NumWorkers != 0 {
+			model.NumWorkers = types.Int64Value(info.NumWorkers)
+		}
+	}
+
+	var d diag.Diagnostics
+	model.SparkConf, d = stringMapValue(ctx, model.SparkConf, info.SparkConf)
+	diags.Append(d...)
+	model.CustomTags, model.CustomTagsAll, d = readTags(ctx, defaultTags, model.CustomTags, info.CustomTags)
+	diags.Append(d...)
+
+	var scripts []string
+	for _, script := range info.InitScripts {
+		if script.Dbfs != nil {
+			scripts = append(scripts, script.Dbfs.Destination)
+		}
+	}
+	model.InitScripts, d = dbfsPathListValue(ctx, model.InitScripts, scripts)
+	diags.Append(d...)
+	return diags
+}
+
+// stringMapValue returns m as a map value, or null when m is empty and prior,
+// the configured value, is null.
+func stringMapValue(ctx context.Context, prior types.Map, m map[string]string) (types.Map, diag.Diagnostics) {
+	if len(m) == 0 && prior.IsNull() {
+		return prior, nil
+	}
+	return types.MapValueFrom(ctx, types.StringType, m)
+}
+
+// dbfsPathListValue returns paths as a list of DBFS paths, or null when paths
+// is empty and prior, the configured value, is null. Paths that name the same
+// location as the configured one at the same index keep its spelling.
+func dbfsPathListValue(ctx context.Context, prior types.List, paths []string) (types.List, diag.Diagnostics) {
+	if len(paths) == 0 && prior.IsNull() {
+		return prior, nil
+	}
+	var diags diag.Diagnostics
+	var configured []DbfsPathValue
+	diags.Append(prior.ElementsAs(ctx, &configured, false)...)
+
+	values := make([]DbfsPathValue, len(paths))
+	for i, p := range paths {
+		values[i] = NewDbfsPathValue(p)
+		if i < len(configured) && configured[i].ValueNormalized() == values[i].ValueNormalized() {
+			values[i] = configured[i]
+		}
+	}
+	list, d := types.ListValueFrom(ctx, DbfsPathType{}, values)
+	diags.Append(d...)
+	return list, diags
+}
+
+// Create a new resource.
+func (r *DatabricksClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster.Create")
+	defer span.End()
+
+	var plan databricksClusterResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := clusterSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.createOr(ctx, databricksClusterTimeouts)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	clusterID, err := client.CreateCluster(ctx, spec)
+	r.audit.Record(ctx, "mrl_databricks_cluster", auditActionCreate, clusterID, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster",
+			"Could not create cluster "+plan.ClusterName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID first so a cluster that fails to start is not orphaned.
+	plan.Id = types.StringValue(clusterID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+	resp.Diagnostics.Append(setClusterIdentity(ctx, resp.Identity, host, clusterID)...)
+
+	info, err := client.WaitCluster(ctx, clusterID, 0)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster",
+			"Cluster "+clusterID+" did not start: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setClusterInfo(ctx, &plan, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster.Read")
+	defer span.End()
+
+	var state databricksClusterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.readOr(ctx, databricksClusterTimeouts)
+	defer cancel()
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the cluster is gone.
+	resp.Diagnostics.Append(setClusterIdentity(ctx, resp.Identity, host, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	info, err := client.GetClusterInfo(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster",
+			"Could not read cluster "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setClusterInfo(ctx, &state, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster.Update")
+	defer span.End()
+
+	var plan databricksClusterResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := clusterSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.updateOr(ctx, databricksClusterTimeouts)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(setClusterIdentity(ctx, resp.Identity, host, plan.Id.ValueString())...)
+	client := r.workspace.newClient(r.httpClient, host, token)
+	err = client.EditCluster(ctx, spec)
+	r.audit.Record(ctx, "mrl_databricks_cluster", auditActionUpdate, plan.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster",
+			"Could not edit cluster "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// A terminated cluster stays terminated after an edit; a running one
+	// restarts with the new configuration.
+	info, err := client.GetClusterInfo(ctx, plan.Id.ValueString())
+	if err == nil && info.State != databricks.ClusterTerminated {
+		info, err = client.WaitCluster(ctx, plan.Id.ValueString(), 0)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster",
+			"Cluster "+plan.Id.ValueString()+" did not restart: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setClusterInfo(ctx, &plan, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster.Delete")
+	defer span.End()
+
+	var state databricksClusterResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.deleteOr(ctx, databricksClusterTimeouts)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.PermanentDeleteCluster(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_cluster", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting cluster",
+			"Could not delete cluster "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
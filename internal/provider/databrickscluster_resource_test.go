@@ -0,0 +1,205 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksClusterTypeName = "mrl_databricks_cluster"
+
+// mockClusters serves the clusters API of a mock workspace: create, get,
+// list, edit and permanent-delete. Clusters start running right away.
+type mockClusters struct {
+	clusters map[string]map[string]interface{}
+	next     int
+}
+
+// newMockClusters adds the clusters API to m.
+func newMockClusters(m *mockDbfs) *mockClusters {
+	clusters := &mockClusters{clusters: map[string]map[string]interface{}{}}
+	m.route("/api/2.1/clusters/", clusters.serveHTTP)
+	return clusters
+}
+
+func (m *mockClusters) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	id, _ := body["cluster_id"].(string)
+	if r.Method == http.MethodGet {
+		id = r.URL.Query().Get("cluster_id")
+	}
+
+	switch r.URL.Path {
+	case "/api/2.1/clusters/create":
+		m.next++
+		id = "cluster-" + strconv.Itoa(m.next)
+		m.add(id, "UI", body)
+		writeMockJSON(w, map[string]interface{}{"cluster_id": id})
+		return
+	case "/api/2.1/clusters/list":
+		ids := make([]string, 0, len(m.clusters))
+		for id := range m.clusters {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		list := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			list = append(list, m.clusters[id])
+		}
+		writeMockJSON(w, map[string]interface{}{"clusters": list})
+		return
+	}
+
+	cluster, ok := m.clusters[id]
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster "+id+" does not exist")
+		return
+	}
+	switch r.URL.Path {
+	case "/api/2.1/clusters/get":
+		writeMockJSON(w, cluster)
+	case "/api/2.1/clusters/edit":
+		m.add(id, cluster["cluster_source"].(string), body)
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.1/clusters/permanent-delete":
+		delete(m.clusters, id)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+// add stores the running cluster id with the given source and spec, as the
+// API describes it.
+func (m *mockClusters) add(id, source string, spec map[string]interface{}) {
+	cluster := map[string]interface{}{}
+	for k, v := range spec {
+		cluster[k] = v
+	}
+	if _, ok := cluster["driver_node_type_id"]; !ok {
+		cluster["driver_node_type_id"] = cluster["node_type_id"]
+	}
+	cluster["cluster_id"] = id
+	cluster["cluster_source"] = source
+	cluster["state"] = "RUNNING"
+	m.clusters[id] = cluster
+}
+
+func TestDatabricksClusterResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	clusters := newMockClusters(m)
+	clusters.add("job-cluster", "JOB", map[string]interface{}{"cluster_name": "job-1-run-1", "spark_version": "15.4.x-scala2.12"})
+	initScripts := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "/FileStore/init/setup.sh"),
+	})
+	config := func(numWorkers int) map[string]interface{} {
+		return map[string]interface{}{
+			"cluster_name":  "etl",
+			"spark_version": "15.4.x-scala2.12",
+			"node_type_id":  "Standard_DS3_v2",
+			"num_workers":   numWorkers,
+			"init_scripts":  initScripts,
+		}
+	}
+	var id string
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksClusterTypeName,
+		config:   config(2),
+		created: func(t *testing.T, state tftypes.Value) {
+			id = stringAttr(t, state, "id")
+			cluster, ok := clusters.clusters[id]
+			if !ok {
+				t.Fatalf("cluster %s not created", id)
+			}
+			if got := cluster["init_scripts"]; !strings.Contains(mustJSON(t, got), "dbfs:/FileStore/init/setup.sh") {
+				t.Errorf("created with init_scripts %s, want dbfs:/FileStore/init/setup.sh", mustJSON(t, got))
+			}
+			if got := stringAttr(t, state, "state"); got != "RUNNING" {
+				t.Errorf("state is %q, want RUNNING", got)
+			}
+		},
+		identity: true,
+		updates: []lifecycleUpdate{{
+			config: config(3),
+			check: func(t *testing.T, _ tftypes.Value) {
+				if got := clusters.clusters[id]["num_workers"]; got != float64(3) {
+					t.Errorf("num_workers is %v after update, want 3", got)
+				}
+			},
+		}},
+		importID:     func(tftypes.Value) string { return m.server.URL + "|" + id },
+		importVerify: []string{"cluster_name", "spark_version", "node_type_id", "num_workers"},
+		listed:       "etl",
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := clusters.clusters[id]; ok {
+				t.Error("cluster still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksClusterResource_defaultTags(t *testing.T) {
+	m := newMockDbfs(t)
+	clusters := newMockClusters(m)
+	p := newTestProvider(t, map[string]interface{}{
+		"default_tags": map[string]string{"team": "data", "env": "prod"},
+		"databricks": map[string]interface{}{
+			"host":  m.server.URL,
+			"token": mockDatabricksToken,
+		},
+	})
+	typeName := databricksClusterTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	attrs := map[string]interface{}{
+		"cluster_name":  "etl",
+		"spark_version": "15.4.x-scala2.12",
+		"node_type_id":  "Standard_DS3_v2",
+		"num_workers":   2,
+		"custom_tags":   map[string]string{"env": "dev"},
+	}
+
+	state := p.apply(typeName, null, p.config(typeName, attrs))
+	id := stringAttr(t, state, "id")
+	if got := mustJSON(t, clusters.clusters[id]["custom_tags"]); got != `{"env":"dev","team":"data"}` {
+		t.Errorf("created with custom_tags %s, want the defaults merged with the resource tags", got)
+	}
+	if got := mapAttr(t, state, "custom_tags"); len(got) != 1 || got["env"] != "dev" {
+		t.Errorf("custom_tags is %v, want only the configured env", got)
+	}
+	if got := mapAttr(t, state, "custom_tags_all"); len(got) != 2 || got["team"] != "data" || got["env"] != "dev" {
+		t.Errorf("custom_tags_all is %v, want team data and env dev", got)
+	}
+	if refreshed := p.refresh(typeName, state); !refreshed.Equal(state) {
+		t.Errorf("refresh changed the state to %s, want %s", refreshed, state)
+	}
+	if planned, diags := p.plan(typeName, state, p.config(typeName, attrs)); errorDiagnostics(diags) != "" || !planned.Equal(state) {
+		t.Errorf("planned %s with %s, want no change", planned, errorDiagnostics(diags))
+	}
+
+	imported := p.importState(typeName, m.server.URL+"|"+id)
+	if got := mapAttr(t, imported, "custom_tags"); len(got) != 1 || got["env"] != "dev" {
+		t.Errorf("imported custom_tags is %v, want only env, which differs from the default", got)
+	}
+}
+
+// mustJSON returns the JSON encoding of v.
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksClusterEventsDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksClusterEventsDataSource{}
+)
+
+// defaultClusterEventsLimit is the number of events read when limit is not
+// set.
+const defaultClusterEventsLimit = 50
+
+// NewDatabricksClusterEventsDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksClusterEventsDataSource() datasource.DataSource {
+	return &DatabricksClusterEventsDataSource{}
+}
+
+// DatabricksClusterEventsDataSource is the data source implementation.
+type DatabricksClusterEventsDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksClusterEventsDataSourceModel maps the data source schema data.
+type databricksClusterEventsDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	ClusterId    types.String `tfsdk:"cluster_id"`
+	EventTypes   types.Set    `tfsdk:"event_types"`
+	Since        RFC3339Value `tfsdk:"since"`
+	Limit        types.Int64  `tfsdk:"limit"`
+	Events       types.List   `tfsdk:"events"`
+}
+
+// clusterEventAttrTypes are the attribute types of an events element.
+var clusterEventAttrTypes = map[string]attr.Type{
+	"timestamp": RFC3339Type{},
+	"type":      types.StringType,
+	"details":   NormalizedJSONType{},
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksClusterEventsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksClusterEventsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_cluster_events"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksClusterEventsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the most recent entries of the event log of a cluster, e.g. to find out why it terminated.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the cluster",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the cluster",
+			},
+			"event_types": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Event types to return, e.g. TERMINATING or DRIVER_NOT_RESPONDING. Defaults to every type",
+			},
+			"since": schema.StringAttribute{
+				CustomType:  RFC3339Type{},
+				Optional:    true,
+				Description: "Only return events after this time, in RFC3339 format",
+			},
+			"limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of events to return, at most 500. Defaults to 50",
+			},
+			"events": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Events, most recent first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"timestamp": schema.StringAttribute{
+							CustomType:  RFC3339Type{},
+							Computed:    true,
+							Description: "Time of the event in RFC3339 format",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Type of the event",
+						},
+						"details": schema.StringAttribute{
+							CustomType:  NormalizedJSONType{},
+							Computed:    true,
+							Description: "Details of the event as JSON, e.g. the termination reason",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksClusterEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_cluster_events.Read")
+	defer span.End()
+
+	var state databricksClusterEventsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	eventsReq := databricks.ClusterEventsRequest{
+		ClusterID: state.ClusterId.ValueString(),
+		Order:     "DESC",
+		Limit:     defaultClusterEventsLimit,
+	}
+	if !state.Limit.IsNull() {
+		if state.Limit.ValueInt64() < 1 || state.Limit.ValueInt64() > 500 {
+			resp.Diagnostics.AddAttributeError(path.Root("limit"), "Invalid limit", "limit must be between 1 and 500.")
+			return
+		}
+		eventsReq.Limit = state.Limit.ValueInt64()
+	}
+	if !state.Since.IsNull() {
+		since, diags := state.Since.ValueRFC3339Time()
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		eventsReq.StartTime = since.UnixMilli()
+	}
+	resp.Diagnostics.Append(state.EventTypes.ElementsAs(ctx, &eventsReq.EventTypes, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	events, err := client.ClusterEvents(ctx, eventsReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster events",
+			"Could not read the events of cluster "+state.ClusterId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	values := make([]attr.Value, 0, len(events))
+	for _, event := range events {
+		values = append(values, types.ObjectValueMust(clusterEventAttrTypes, map[string]attr.Value{
+			"timestamp": NewRFC3339TimeValue(time.UnixMilli(event.Timestamp)),
+			"type":      types.StringValue(event.Type),
+			"details":   NewNormalizedJSONValue(string(event.Details)),
+		}))
+	}
+
+	state.Id = types.StringValue(state.ClusterId.ValueString())
+	state.Events = types.ListValueMust(types.ObjectType{AttrTypes: clusterEventAttrTypes}, values)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
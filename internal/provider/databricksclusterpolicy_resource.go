@@ -0,0 +1,470 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksClusterPolicyResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksClusterPolicyResource{}
+	_ resource.ResourceWithImportState    = &DatabricksClusterPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksClusterPolicyResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksClusterPolicyResource{}
+	_ resource.ResourceWithIdentity       = &DatabricksClusterPolicyResource{}
+)
+
+// NewDatabricksClusterPolicyResource is a helper function to simplify the provider implementation.
+func NewDatabricksClusterPolicyResource() resource.Resource {
+	return &DatabricksClusterPolicyResource{}
+}
+
+// DatabricksClusterPolicyResource is the resource implementation.
+type DatabricksClusterPolicyResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksClusterPolicyResourceModel struct {
+	Id                              types.String        `tfsdk:"id"`
+	AdbId                           URLValue            `tfsdk:"adb_id"`
+	WorkspaceUrl                    URLValue            `tfsdk:"workspace_url"`
+	Name                            types.String        `tfsdk:"name"`
+	Definition                      NormalizedJSONValue `tfsdk:"definition"`
+	Description                     types.String        `tfsdk:"description"`
+	MaxClustersPerUser              types.Int64         `tfsdk:"max_clusters_per_user"`
+	PolicyFamilyId                  types.String        `tfsdk:"policy_family_id"`
+	PolicyFamilyDefinitionOverrides NormalizedJSONValue `tfsdk:"policy_family_definition_overrides"`
+	PolicyId                        types.String        `tfsdk:"policy_id"`
+}
+
+// databricksClusterPolicyResourceIdentityModel identifies a cluster policy
+// across workspaces.
+type databricksClusterPolicyResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	PolicyId     types.String `tfsdk:"policy_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksClusterPolicyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksClusterPolicyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_cluster_policy"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksClusterPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Databricks cluster policy, the rules limiting the attributes of the clusters created with it. Set its policy_id as policy_id of mrl_databricks_cluster resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the cluster policy",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the cluster policy, unique in the workspace",
+			},
+			"definition": schema.StringAttribute{
+				CustomType:  NormalizedJSONType{},
+				Optional:    true,
+				Computed:    true,
+				Description: "Policy definition, a JSON document of the rules of the policy by cluster attribute path, such as jsonencode({ \"spark_version\" = { type = \"fixed\", value = \"15.4.x-scala2.12\" } }). Key order and whitespace are ignored. Conflicts with policy_family_id, and is the family definition with its overrides applied when policy_family_id is set",
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the cluster policy",
+			},
+			"max_clusters_per_user": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(1, 100000)},
+				Description: "Maximum number of clusters a user can create with the policy. Unlimited when unset",
+			},
+			"policy_family_id": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the policy family, such as personal-vm or job-cluster, whose definition the policy uses. Conflicts with definition",
+			},
+			"policy_family_definition_overrides": schema.StringAttribute{
+				CustomType:  NormalizedJSONType{},
+				Optional:    true,
+				Description: "JSON document of the rules added to or replacing those of the policy family definition. Key order and whitespace are ignored. Requires policy_family_id",
+			},
+			"policy_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the cluster policy, to set as policy_id of clusters",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the policy has either a definition or a policy
+// family, and overrides only with a policy family.
+func (r *DatabricksClusterPolicyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksClusterPolicyResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Definition.IsUnknown() || config.PolicyFamilyId.IsUnknown() {
+		return
+	}
+	switch {
+	case !config.Definition.IsNull() && !config.PolicyFamilyId.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Conflicting cluster policy definition",
+			"definition cannot be set together with policy_family_id. Use policy_family_definition_overrides to change the rules of the family.",
+		)
+	case config.Definition.IsNull() && config.PolicyFamilyId.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("definition"),
+			"Missing cluster policy definition",
+			"Either definition or policy_family_id must be set.",
+		)
+	case !config.PolicyFamilyDefinitionOverrides.IsNull() && config.PolicyFamilyId.IsNull():
+		resp.Diagnostics.AddAttributeError(
+			path.Root("policy_family_definition_overrides"),
+			"Missing policy family",
+			"policy_family_definition_overrides requires policy_family_id.",
+		)
+	}
+}
+
+// ModifyPlan keeps the definition of a policy family policy whose family and
+// overrides do not change, rather than showing it as known after apply.
+func (r *DatabricksClusterPolicyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state databricksClusterPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.Definition.IsUnknown() && !plan.PolicyFamilyId.IsNull() &&
+		plan.PolicyFamilyId.Equal(state.PolicyFamilyId) &&
+		plan.PolicyFamilyDefinitionOverrides.Equal(state.PolicyFamilyDefinitionOverrides) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("definition"), state.Definition)...)
+	}
+}
+
+// clusterPolicy returns the policy described by plan.
+func clusterPolicy(plan databricksClusterPolicyResourceModel) databricks.ClusterPolicy {
+	policy := databricks.ClusterPolicy{
+		Name:               plan.Name.ValueString(),
+		Description:        plan.Description.ValueString(),
+		MaxClustersPerUser: plan.MaxClustersPerUser.ValueInt64(),
+		PolicyFamilyID:     plan.PolicyFamilyId.ValueString(),
+	}
+	// A policy family policy must not send the definition, which is computed.
+	if plan.PolicyFamilyId.IsNull() {
+		policy.Definition = plan.Definition.ValueString()
+	} else {
+		policy.PolicyFamilyDefinitionOverrides = plan.PolicyFamilyDefinitionOverrides.ValueString()
+	}
+	return policy
+}
+
+// readClusterPolicy fills model from the policy model.PolicyId.
+func readClusterPolicy(ctx context.Context, client *databricks.Client, model *databricksClusterPolicyResourceModel) error {
+	policy, err := client.GetClusterPolicy(ctx, model.PolicyId.ValueString())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(policy.PolicyID)
+	model.PolicyId = types.StringValue(policy.PolicyID)
+	model.Name = types.StringValue(policy.Name)
+	model.Definition = NewNormalizedJSONValue(policy.Definition)
+	model.Description = optionalString(policy.Description)
+	if policy.MaxClustersPerUser > 0 {
+		model.MaxClustersPerUser = types.Int64Value(policy.MaxClustersPerUser)
+	} else {
+		model.MaxClustersPerUser = types.Int64Null()
+	}
+	model.PolicyFamilyId = optionalString(policy.PolicyFamilyID)
+	if policy.PolicyFamilyDefinitionOverrides != "" {
+		model.PolicyFamilyDefinitionOverrides = NewNormalizedJSONValue(policy.PolicyFamilyDefinitionOverrides)
+	} else {
+		model.PolicyFamilyDefinitionOverrides = NewNormalizedJSONNull()
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksClusterPolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster_policy.Create")
+	defer span.End()
+
+	var plan databricksClusterPolicyResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	policyID, err := client.CreateClusterPolicy(ctx, clusterPolicy(plan))
+	r.audit.Record(ctx, "mrl_databricks_cluster_policy", auditActionCreate, policyID, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating cluster policy",
+			"Could not create cluster policy "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.PolicyId = types.StringValue(policyID)
+	resp.Diagnostics.Append(setClusterPolicyIdentity(ctx, resp.Identity, host, policyID)...)
+	if err := readClusterPolicy(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster policy",
+			"Could not read cluster policy "+policyID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksClusterPolicyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster_policy.Read")
+	defer span.End()
+
+	var state databricksClusterPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the policy is gone.
+	resp.Diagnostics.Append(setClusterPolicyIdentity(ctx, resp.Identity, host, state.PolicyId.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	if err := readClusterPolicy(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading cluster policy",
+			"Could not read cluster policy "+state.PolicyId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksClusterPolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster_policy.Update")
+	defer span.End()
+
+	var plan, state databricksClusterPolicyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	policyID := state.PolicyId.ValueString()
+	policy := clusterPolicy(plan)
+	policy.PolicyID = policyID
+	err = client.EditClusterPolicy(ctx, policy)
+	r.audit.Record(ctx, "mrl_databricks_cluster_policy", auditActionUpdate, policyID, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating cluster policy",
+			"Could not update cluster policy "+policyID+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.PolicyId = state.PolicyId
+	if err := readClusterPolicy(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading cluster policy",
+			"Could not read cluster policy "+policyID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksClusterPolicyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_cluster_policy.Delete")
+	defer span.End()
+
+	var state databricksClusterPolicyResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteClusterPolicy(ctx, state.PolicyId.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_cluster_policy", auditActionDelete, state.PolicyId.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting cluster policy",
+			"Could not delete cluster policy "+state.PolicyId.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// IdentitySchema defines the identity of a cluster policy: the workspace and
+// the policy ID.
+func (r *DatabricksClusterPolicyResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"policy_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the cluster policy",
+			},
+		},
+	}
+}
+
+// setClusterPolicyIdentity records the identity of the policy policyID in
+// the workspace adbID. Terraform versions without identity support pass a
+// nil identity.
+func setClusterPolicyIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, policyID string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksClusterPolicyResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		PolicyId:     types.StringValue(policyID),
+	})
+}
+
+// ImportState imports a cluster policy by an ID of the form workspace_url|policy_id
+// or by identity.
+func (r *DatabricksClusterPolicyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksClusterPolicyResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "policy_id")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.PolicyId = types.StringValue(parts[1])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.PolicyId.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy_id"), identity.PolicyId.ValueString())...)
+}
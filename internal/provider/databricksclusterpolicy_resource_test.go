@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksClusterPolicyTypeName = "mrl_databricks_cluster_policy"
+
+// mockClusterPolicies serves the cluster policies API of a mock workspace:
+// create, get, edit and delete. Definitions are returned indented, as they
+// are not stored the way they were sent.
+type mockClusterPolicies struct {
+	policies map[string]databricks.ClusterPolicy
+	next     int
+}
+
+// newMockClusterPolicies adds the cluster policies API to m.
+func newMockClusterPolicies(m *mockDbfs) *mockClusterPolicies {
+	policies := &mockClusterPolicies{policies: map[string]databricks.ClusterPolicy{}}
+	m.route("/api/2.0/policies/clusters/", policies.serveHTTP)
+	return policies
+}
+
+func (m *mockClusterPolicies) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var policy databricks.ClusterPolicy
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	if r.Method == http.MethodGet {
+		policy.PolicyID = r.URL.Query().Get("policy_id")
+	}
+	var indented bytes.Buffer
+	if policy.Definition != "" {
+		if err := json.Indent(&indented, []byte(policy.Definition), "", "  "); err != nil {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Invalid policy definition: "+err.Error())
+			return
+		}
+		policy.Definition = indented.String()
+	}
+
+	if r.URL.Path == "/api/2.0/policies/clusters/create" {
+		m.next++
+		policy.PolicyID = "POLICY" + strconv.Itoa(m.next)
+		m.policies[policy.PolicyID] = policy
+		writeMockJSON(w, map[string]interface{}{"policy_id": policy.PolicyID})
+		return
+	}
+
+	current, ok := m.policies[policy.PolicyID]
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster policy "+policy.PolicyID+" does not exist")
+		return
+	}
+	switch r.URL.Path {
+	case "/api/2.0/policies/clusters/get":
+		writeMockJSON(w, current)
+	case "/api/2.0/policies/clusters/edit":
+		m.policies[policy.PolicyID] = policy
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/policies/clusters/delete":
+		delete(m.policies, policy.PolicyID)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+func TestDatabricksClusterPolicyResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	policies := newMockClusterPolicies(m)
+	definition := `{"spark_version":{"type":"fixed","value":"15.4.x-scala2.12"}}`
+	var id string
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksClusterPolicyTypeName,
+		config:   map[string]interface{}{"name": "etl", "definition": definition},
+		created: func(t *testing.T, state tftypes.Value) {
+			id = stringAttr(t, state, "policy_id")
+			if _, ok := policies.policies[id]; !ok {
+				t.Fatalf("policy %s not created", id)
+			}
+			if got := stringAttr(t, state, "definition"); got != definition {
+				t.Errorf("definition is %q, want the configured %q", got, definition)
+			}
+		},
+		identity: true,
+		updates: []lifecycleUpdate{{
+			config: map[string]interface{}{
+				"name":                  "etl",
+				"definition":            definition,
+				"description":           "Clusters of the ETL jobs",
+				"max_clusters_per_user": 2,
+			},
+			check: func(t *testing.T, _ tftypes.Value) {
+				if got := policies.policies[id]; got.Description != "Clusters of the ETL jobs" || got.MaxClustersPerUser != 2 {
+					t.Errorf("policy is %+v after update, want the new description and max_clusters_per_user 2", got)
+				}
+			},
+		}},
+		importID:     func(tftypes.Value) string { return m.server.URL + "|" + id },
+		importVerify: []string{"id", "name", "description", "max_clusters_per_user"},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := policies.policies[id]; ok {
+				t.Error("policy still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksClusterPolicyResource_trailingSlash(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	policies := newMockClusterPolicies(m)
+	typeName := databricksClusterPolicyTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	attrs := map[string]interface{}{
+		"workspace_url": m.server.URL + "/",
+		"name":          "etl",
+		"definition":    `{"spark_version":{"type":"fixed","value":"15.4.x-scala2.12"}}`,
+	}
+
+	state := p.apply(typeName, null, p.config(typeName, attrs))
+	id := stringAttr(t, state, "policy_id")
+
+	// Dropping the trailing slash names the same workspace, so the policy is
+	// kept, and the plan still has the workspace_url of the configuration.
+	attrs["workspace_url"] = m.server.URL
+	planned, replace, diags := p.planReplace(typeName, state, p.config(typeName, attrs))
+	if errorDiagnostics(diags) != "" {
+		t.Fatalf("plan: %s", errorDiagnostics(diags))
+	}
+	if len(replace) != 0 {
+		t.Errorf("plan replaces the policy for %v, want an update", replace)
+	}
+	if got := stringAttr(t, planned, "workspace_url"); got != m.server.URL {
+		t.Errorf("planned workspace_url is %q, want the configured %q", got, m.server.URL)
+	}
+
+	state = p.apply(typeName, state, p.config(typeName, attrs))
+	if got := stringAttr(t, state, "policy_id"); got != id {
+		t.Errorf("policy_id is %q after the update, want %q", got, id)
+	}
+	if len(policies.policies) != 1 {
+		t.Errorf("%d policies after the update, want 1", len(policies.policies))
+	}
+}
+
+func TestDatabricksClusterPolicyResource_adbID(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	policies := newMockClusterPolicies(m)
+	other := newMockDbfs(t)
+	otherPolicies := newMockClusterPolicies(other)
+	typeName := databricksClusterPolicyTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	attrs := map[string]interface{}{
+		"adb_id":     other.server.URL,
+		"name":       "etl",
+		"definition": `{"spark_version":{"type":"fixed","value":"15.4.x-scala2.12"}}`,
+	}
+
+	if diags := p.validate(typeName, p.config(typeName, attrs)); !strings.Contains(warningDiagnostics(diags), "renamed workspace_url") {
+		t.Errorf("got warnings %q, want the deprecation of adb_id", warningDiagnostics(diags))
+	}
+	state := p.apply(typeName, null, p.config(typeName, attrs))
+	if id := stringAttr(t, state, "policy_id"); len(otherPolicies.policies) != 1 || len(policies.policies) != 0 {
+		t.Errorf("policy %s created in the workspace of the provider, want that of adb_id", id)
+	}
+
+	attrs["workspace_url"] = other.server.URL
+	if diags := p.validate(typeName, p.config(typeName, attrs)); !strings.Contains(errorDiagnostics(diags), "cannot be set together with adb_id") {
+		t.Errorf("got diagnostics %q, want a conflict of workspace_url and adb_id", errorDiagnostics(diags))
+	}
+}
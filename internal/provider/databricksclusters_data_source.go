@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksClustersDataSource{}
+)
+
+// NewDatabricksClustersDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksClustersDataSource() datasource.DataSource {
+	return &DatabricksClustersDataSource{}
+}
+
+// DatabricksClustersDataSource is the data source implementation.
+type DatabricksClustersDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksClustersDataSourceModel maps the data source schema data.
+type databricksClustersDataSourceModel struct {
+	Id                  types.String `tfsdk:"id"`
+	AdbId               types.String `tfsdk:"adb_id"`
+	WorkspaceUrl        types.String `tfsdk:"workspace_url"`
+	Token               types.String `tfsdk:"token"`
+	ClusterNameContains types.String `tfsdk:"cluster_name_contains"`
+	IncludeJobClusters  types.Bool   `tfsdk:"include_job_clusters"`
+	Ids                 types.Set    `tfsdk:"ids"`
+	Clusters            types.List   `tfsdk:"clusters"`
+}
+
+// clusterSummaryAttrTypes are the attribute types of a clusters element.
+var clusterSummaryAttrTypes = map[string]attr.Type{
+	"cluster_id":     types.StringType,
+	"cluster_name":   types.StringType,
+	"state":          types.StringType,
+	"spark_version":  types.StringType,
+	"node_type_id":   types.StringType,
+	"custom_tags":    types.MapType{ElemType: types.StringType},
+	"cluster_source": types.StringType,
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksClustersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksClustersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_clusters"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksClustersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the clusters of a workspace, optionally filtered by name.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Sorted IDs of the clusters, joined by commas",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"cluster_name_contains": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only return clusters whose name contains this string, ignoring case",
+			},
+			"include_job_clusters": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also return the clusters created by job runs. Defaults to false",
+			},
+			"ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "IDs of the clusters",
+			},
+			"clusters": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Clusters, sorted by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"cluster_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the cluster",
+						},
+						"cluster_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the cluster",
+						},
+						"state": schema.StringAttribute{
+							Computed:    true,
+							Description: "State of the cluster, such as RUNNING or TERMINATED",
+						},
+						"spark_version": schema.StringAttribute{
+							Computed:    true,
+							Description: "Databricks runtime version of the cluster",
+						},
+						"node_type_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "Node type of the workers",
+						},
+						"custom_tags": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Custom tags of the cluster",
+						},
+						"cluster_source": schema.StringAttribute{
+							Computed:    true,
+							Description: "What created the cluster, such as UI, API or JOB",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_clusters.Read")
+	defer span.End()
+
+	var state databricksClustersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	clusters, err := client.ListClusters(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing clusters", "Could not list the clusters of the workspace: "+err.Error())
+		return
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].ClusterName != clusters[j].ClusterName {
+			return clusters[i].ClusterName < clusters[j].ClusterName
+		}
+		return clusters[i].ClusterID < clusters[j].ClusterID
+	})
+
+	contains := strings.ToLower(state.ClusterNameContains.ValueString())
+	ids := []string{}
+	values := []attr.Value{}
+	for _, cluster := range clusters {
+		if cluster.ClusterSource == databricks.ClusterSourceJob && !state.IncludeJobClusters.ValueBool() {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(cluster.ClusterName), contains) {
+			continue
+		}
+		tags := map[string]attr.Value{}
+		for k, v := range cluster.CustomTags {
+			tags[k] = types.StringValue(v)
+		}
+		ids = append(ids, cluster.ClusterID)
+		values = append(values, types.ObjectValueMust(clusterSummaryAttrTypes, map[string]attr.Value{
+			"cluster_id":     types.StringValue(cluster.ClusterID),
+			"cluster_name":   types.StringValue(cluster.ClusterName),
+			"state":          types.StringValue(cluster.State),
+			"spark_version":  types.StringValue(cluster.SparkVersion),
+			"node_type_id":   types.StringValue(cluster.NodeTypeID),
+			"custom_tags":    types.MapValueMust(types.StringType, tags),
+			"cluster_source": types.StringValue(cluster.ClusterSource),
+		}))
+	}
+
+	idValues := make([]attr.Value, 0, len(ids))
+	for _, id := range ids {
+		idValues = append(idValues, types.StringValue(id))
+	}
+	sort.Strings(ids)
+
+	state.Id = types.StringValue(strings.Join(ids, ","))
+	state.Ids = types.SetValueMust(types.StringType, idValues)
+	state.Clusters = types.ListValueMust(types.ObjectType{AttrTypes: clusterSummaryAttrTypes}, values)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksCurrentMetastoreDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksCurrentMetastoreDataSource{}
+)
+
+// NewDatabricksCurrentMetastoreDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksCurrentMetastoreDataSource() datasource.DataSource {
+	return &DatabricksCurrentMetastoreDataSource{}
+}
+
+// DatabricksCurrentMetastoreDataSource is the data source implementation.
+type DatabricksCurrentMetastoreDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksCurrentMetastoreDataSourceModel maps the data source schema data.
+type databricksCurrentMetastoreDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	Name         types.String `tfsdk:"name"`
+	Region       types.String `tfsdk:"region"`
+	Owner        types.String `tfsdk:"owner"`
+	StorageRoot  types.String `tfsdk:"storage_root"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksCurrentMetastoreDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksCurrentMetastoreDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_current_metastore"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksCurrentMetastoreDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the Unity Catalog metastore assigned to a workspace.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the metastore",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Name of the metastore",
+			},
+			"region": schema.StringAttribute{
+				Computed:    true,
+				Description: "Azure region of the metastore",
+			},
+			"owner": schema.StringAttribute{
+				Computed:    true,
+				Description: "Owner of the metastore",
+			},
+			"storage_root": schema.StringAttribute{
+				Computed:    true,
+				Description: "Root storage location of the metastore, empty when it has none",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksCurrentMetastoreDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_current_metastore.Read")
+	defer span.End()
+
+	var state databricksCurrentMetastoreDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	metastore, err := client.CurrentMetastore(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading current metastore",
+			"Could not read the metastore assigned to the workspace: "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(metastore.MetastoreID)
+	state.Name = types.StringValue(metastore.Name)
+	state.Region = types.StringValue(metastore.Region)
+	state.Owner = types.StringValue(metastore.Owner)
+	state.StorageRoot = types.StringValue(metastore.StorageRoot)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,197 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksCurrentUserDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksCurrentUserDataSource{}
+)
+
+// nonAlphanumeric matches the characters replaced in alphanumeric user
+// names.
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// NewDatabricksCurrentUserDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksCurrentUserDataSource() datasource.DataSource {
+	return &DatabricksCurrentUserDataSource{}
+}
+
+// DatabricksCurrentUserDataSource is the data source implementation.
+type DatabricksCurrentUserDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksCurrentUserDataSourceModel maps the data source schema data.
+type databricksCurrentUserDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	AdbId              types.String `tfsdk:"adb_id"`
+	Token              types.String `tfsdk:"token"`
+	UserName           types.String `tfsdk:"user_name"`
+	DisplayName        types.String `tfsdk:"display_name"`
+	Alphanumeric       types.String `tfsdk:"alphanumeric"`
+	Home               types.String `tfsdk:"home"`
+	Repos              types.String `tfsdk:"repos"`
+	IsServicePrincipal types.Bool   `tfsdk:"is_service_principal"`
+	Groups             types.Set    `tfsdk:"groups"`
+	WorkspaceId        types.String `tfsdk:"workspace_id"`
+	WorkspaceUrl       types.String `tfsdk:"workspace_url"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksCurrentUserDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksCurrentUserDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_current_user"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksCurrentUserDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the user or service principal the provider authenticates to the workspace as, through the SCIM Me endpoint, and the ID of the workspace, so configurations can build per-principal paths or branch on the principal.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "SCIM ID of the authenticated user or service principal",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"user_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "User name of the authenticated principal, the application ID for a service principal",
+			},
+			"display_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Display name of the authenticated principal",
+			},
+			"alphanumeric": schema.StringAttribute{
+				Computed:    true,
+				Description: "user_name before any @, with runs of other characters than letters and digits replaced by _, for use in names of clusters, jobs or schemas",
+			},
+			"home": schema.StringAttribute{
+				Computed:    true,
+				Description: "Workspace home directory of the principal, such as /Users/jane@example.com",
+			},
+			"repos": schema.StringAttribute{
+				Computed:    true,
+				Description: "Git folder directory of the principal, such as /Repos/jane@example.com",
+			},
+			"is_service_principal": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the principal is a service principal rather than a user",
+			},
+			"groups": schema.SetAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Display names of the workspace groups the principal is a direct member of",
+			},
+			"workspace_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Numeric ID of the workspace. Null when the workspace does not report it",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block, and is set to the URL of the workspace when not configured",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksCurrentUserDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_current_user.Read")
+	defer span.End()
+
+	var state databricksCurrentUserDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := d.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := d.workspace.newClient(d.httpClient, host, token)
+	user, err := client.CurrentUser(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading current user", "Could not read the authenticated principal: "+err.Error())
+		return
+	}
+
+	groups := make([]string, 0, len(user.Groups))
+	for _, group := range user.Groups {
+		groups = append(groups, group.Display)
+	}
+	groupSet, diags := types.SetValueFrom(ctx, types.StringType, groups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, _, _ := strings.Cut(user.UserName, "@")
+	state.Id = types.StringValue(user.ID)
+	state.UserName = types.StringValue(user.UserName)
+	state.DisplayName = types.StringValue(user.DisplayName)
+	state.Alphanumeric = types.StringValue(nonAlphanumeric.ReplaceAllString(name, "_"))
+	state.Home = types.StringValue("/Users/" + user.UserName)
+	state.Repos = types.StringValue("/Repos/" + user.UserName)
+	// Service principals are named by their application ID, users by
+	// their email address.
+	state.IsServicePrincipal = types.BoolValue(uuidPattern.MatchString(user.UserName))
+	state.Groups = groupSet
+	state.WorkspaceId = types.StringNull()
+	if user.WorkspaceID != "" {
+		state.WorkspaceId = types.StringValue(user.WorkspaceID)
+	}
+	if state.WorkspaceUrl.IsNull() {
+		state.WorkspaceUrl = types.StringValue(databricks.WorkspaceURL(host))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
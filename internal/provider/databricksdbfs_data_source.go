@@ -2,17 +2,18 @@ package provider
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"path"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -21,14 +22,28 @@ var (
 	_ datasource.DataSourceWithConfigure = &DatabricksDbfsSource{}
 )
 
+// Type names of the DBFS listing data source. mrl_databricks_dbfs is the
+// original name and is kept as a deprecated alias of mrl_databricks_dbfs_files.
+const (
+	databricksDbfsFilesDataSourceTypeName  = "mrl_databricks_dbfs_files"
+	databricksDbfsLegacyDataSourceTypeName = "mrl_databricks_dbfs"
+)
+
 // NewCoffeesDataSource is a helper function to simplify the provider implementation.
 func NewDatabricksDbfs() datasource.DataSource {
-	return &DatabricksDbfsSource{}
+	return &DatabricksDbfsSource{typeName: databricksDbfsLegacyDataSourceTypeName}
+}
+
+// NewDatabricksDbfsFilesDataSource returns the mrl_databricks_dbfs_files data
+// source.
+func NewDatabricksDbfsFilesDataSource() datasource.DataSource {
+	return &DatabricksDbfsSource{typeName: databricksDbfsFilesDataSourceTypeName}
 }
 
 // coffeesDataSource is the data source implementation.
 type DatabricksDbfsSource struct {
-	credential *azidentity.ClientSecretCredential
+	typeName string
+	clients  ClientBundle
 }
 
 // Configure implements datasource.DataSourceWithConfigure.
@@ -38,89 +53,132 @@ func (d *DatabricksDbfsSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	credential, ok := req.ProviderData.(*azidentity.ClientSecretCredential)
+	providerData, ok := req.ProviderData.(*ClientBundle)
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.credential = credential
+	d.clients = *providerData
 }
 
 // Metadata returns the data source type name.
 func (d *DatabricksDbfsSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs"
+	resp.TypeName = req.ProviderTypeName + strings.TrimPrefix(d.typeName, "mrl")
 }
 
-// Schema defines the schema for the data source.
 // Schema defines the schema for the data source.
 func (d *DatabricksDbfsSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Description: "Lists the files and directories of a DBFS directory, descending into subdirectories when recursive is set. Directories are listed a page at a time and concurrently, so that trees of tens of thousands of files are listed within the request timeouts",
 		Attributes: map[string]schema.Attribute{
 			"adb_id": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "URL of the azure databricks instance",
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
 			},
 			"token": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "Access token for the azure databricks instance",
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
 			},
 			"root_path": schema.StringAttribute{
 				Required:    true,
-				Description: "Local path from where the file needs to be read",
+				Description: "DBFS directory to list",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list subdirectories too. Defaults to false",
+			},
+			"pattern": schema.StringAttribute{
+				Optional:    true,
+				Description: "Glob, such as *.jar, the name of an entry must match to be listed. Directories are still descended into when recursive is set",
+			},
+			"file_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of listed files, directories excluded",
+			},
+			"total_size_bytes": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Sum of the sizes in bytes of the listed files",
+			},
+			"total_size": schema.Int64Attribute{
+				Computed:           true,
+				DeprecationMessage: "Use total_size_bytes instead.",
+				Description:        "Sum of the sizes of the listed files, as total_size_bytes",
 			},
 			"files": schema.ListNestedAttribute{
-				Computed: true,
+				Computed:    true,
+				Description: "Listed files and directories, sorted by path",
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"path": schema.StringAttribute{
-							Optional:    true,
+							CustomType:  DbfsPathType{},
+							Computed:    true,
 							Description: "Path in dbfs where the file is present",
 						},
 						"is_dir": schema.BoolAttribute{
-							Optional:    true,
+							Computed:    true,
 							Description: "Type of the path dir/file",
 						},
 						"file_size": schema.Int64Attribute{
-							Optional:    true,
+							Computed:    true,
 							Description: "Size of the file being managed",
 						},
 						"modification_time": schema.StringAttribute{
-							Optional:    true,
-							Description: "Last modified time of the file being managed",
+							CustomType:  RFC3339Type{},
+							Computed:    true,
+							Description: "Last modified time of the file being managed, in RFC3339 format",
 						},
 					},
 				},
 			},
 		},
 	}
+	if d.typeName == databricksDbfsLegacyDataSourceTypeName {
+		resp.Schema.Description = "Deprecated alias of mrl_databricks_dbfs_files"
+		resp.Schema.DeprecationMessage = "Use the mrl_databricks_dbfs_files data source instead, which takes the same arguments."
+	}
 }
 
 // coffeesDataSourceModel maps the data source schema data.
 type databricksDbfsDataSourceModel struct {
-	AdbId    string           `tfsdk:"adb_id"`
-	Token    string           `tfsdk:"token"`
-	RootPath string           `tfsdk:"root_path"`
-	Files    []dbfsFilesModel `tfsdk:"files"`
+	AdbId          types.String     `tfsdk:"adb_id"`
+	WorkspaceUrl   types.String     `tfsdk:"workspace_url"`
+	Token          types.String     `tfsdk:"token"`
+	RootPath       string           `tfsdk:"root_path"`
+	Recursive      types.Bool       `tfsdk:"recursive"`
+	Pattern        types.String     `tfsdk:"pattern"`
+	FileCount      types.Int64      `tfsdk:"file_count"`
+	TotalSizeBytes types.Int64      `tfsdk:"total_size_bytes"`
+	TotalSize      types.Int64      `tfsdk:"total_size"`
+	Files          []dbfsFilesModel `tfsdk:"files"`
 }
 
 // coffeesModel maps coffees schema data.
 type dbfsFilesModel struct {
-	Path         types.String `tfsdk:"path"`
-	IsDirectory  types.Bool   `tfsdk:"is_dir"`
-	FileSize     types.Int64  `tfsdk:"file_size"`
-	LastModified types.String `tfsdk:"modification_time"`
+	Path         DbfsPathValue `tfsdk:"path"`
+	IsDirectory  types.Bool    `tfsdk:"is_dir"`
+	FileSize     types.Int64   `tfsdk:"file_size"`
+	LastModified RFC3339Value  `tfsdk:"modification_time"`
 }
 
-// Read refreshes the Terraform state with the latest data.
 // Read refreshes the Terraform state with the latest data.
 func (d *DatabricksDbfsSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data."+d.typeName+".Read")
+	defer span.End()
+
 	var state databricksDbfsDataSourceModel
 	diags := req.Config.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -128,56 +186,55 @@ func (d *DatabricksDbfsSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	adburl := state.AdbId
-	token := state.Token
-	path := state.RootPath
-	endpoint := fmt.Sprintf("%v/api/2.0/dbfs/list?path=%v", adburl, path)
-
-	client := http.Client{}
-	getFilesHttpRequest, _ := http.NewRequest("GET", endpoint, nil)
-
-	getFilesHttpRequest.Header = http.Header{
-		"Authorization": {fmt.Sprintf("Bearer %v", token)},
+	pattern := state.Pattern.ValueString()
+	if _, err := path.Match(pattern, ""); err != nil {
+		resp.Diagnostics.AddError("Invalid pattern", fmt.Sprintf("%q is not a valid glob: %v", pattern, err))
+		return
 	}
 
-	getFilesHttpResult, _ := client.Do(getFilesHttpRequest)
-
-	body, _ := io.ReadAll(getFilesHttpResult.Body)
-
-	fmt.Println(json.Valid(body))
-
-	getFilesHttpResponse := struct {
-		Files []struct {
-			Path         string `json:"path"`
-			IsDirectory  bool   `json:"is_dir"`
-			FileSize     int64  `json:"file_size"`
-			LastModified int    `json:"modification_time"`
-		} `json:"files"`
-	}{}
+	client, err := d.clients.Databricks.client(d.clients.HTTPClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
 
-	err := json.Unmarshal(body, &getFilesHttpResponse)
+	// The listing is rebuilt from scratch on every read.
+	state.Files = []dbfsFilesModel{}
+	var fileCount, totalSize int64
+	err = client.DbfsWalk(ctx, state.RootPath, state.Recursive.ValueBool(), func(page []databricks.FileInfo) error {
+		for _, file := range page {
+			if pattern != "" {
+				if ok, _ := path.Match(pattern, path.Base(file.Path)); !ok {
+					continue
+				}
+			}
+			if !file.IsDir {
+				fileCount++
+				totalSize += file.FileSize
+			}
+			state.Files = append(state.Files, dbfsFilesModel{
+				Path:         NewDbfsPathValue(file.Path),
+				IsDirectory:  types.BoolValue(file.IsDir),
+				FileSize:     types.Int64Value(file.FileSize),
+				LastModified: NewRFC3339TimeValue(time.UnixMilli(file.ModificationTime)),
+			})
+		}
+		return nil
+	})
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error creating order",
-			"Could not create order, unexpected error: "+err.Error(),
+			"Error listing DBFS files",
+			"Could not list "+state.RootPath+": "+err.Error(),
 		)
 		return
 	}
-	for i := 0; i < len(getFilesHttpResponse.Files); i++ {
-		dbfsFile := dbfsFilesModel{
-			Path:         types.StringValue(getFilesHttpResponse.Files[i].Path),
-			IsDirectory:  types.BoolValue(getFilesHttpResponse.Files[i].IsDirectory),
-			FileSize:     types.Int64Value(getFilesHttpResponse.Files[i].FileSize),
-			LastModified: types.StringValue(time.UnixMilli(int64(getFilesHttpResponse.Files[i].LastModified)).UTC().Format(time.RFC3339)),
-		}
-		fmt.Println(dbfsFile)
-		tflog.Info(ctx, "Configuring HashiCups client")
-		state.Files = append(state.Files, dbfsFile)
-	}
+	sort.Slice(state.Files, func(i, j int) bool {
+		return state.Files[i].Path.ValueString() < state.Files[j].Path.ValueString()
+	})
+	state.FileCount = types.Int64Value(fileCount)
+	state.TotalSizeBytes = types.Int64Value(totalSize)
+	state.TotalSize = types.Int64Value(totalSize)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
 }
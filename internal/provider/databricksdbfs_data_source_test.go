@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDatabricksDbfsDataSource_recursive(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	m.put("/FileStore/jars/a.jar", []byte("aaa"))
+	m.put("/FileStore/jars/lib/b.jar", []byte("bbbbb"))
+	m.put("/FileStore/jars/lib/deep/c.txt", []byte("cc"))
+	m.put("/FileStore/other/d.jar", []byte("d"))
+
+	for _, typeName := range []string{databricksDbfsFilesDataSourceTypeName, databricksDbfsLegacyDataSourceTypeName} {
+		config := map[string]interface{}{
+			"root_path": "/FileStore/jars",
+			"recursive": true,
+			"pattern":   "*.jar",
+		}
+		// Reading again must not accumulate entries.
+		p.readDataSource(typeName, config)
+		state := p.readDataSource(typeName, config)
+
+		if got := dbfsListedPaths(t, state); len(got) != 2 || got[0] != "/FileStore/jars/a.jar" || got[1] != "/FileStore/jars/lib/b.jar" {
+			t.Errorf("%s: listed %v, want the two jars sorted by path", typeName, got)
+		}
+		if got := int64Attr(t, state, "file_count"); got != 2 {
+			t.Errorf("%s: file_count is %d, want 2", typeName, got)
+		}
+		if got := int64Attr(t, state, "total_size_bytes"); got != 8 {
+			t.Errorf("%s: total_size_bytes is %d, want 8", typeName, got)
+		}
+		if got := int64Attr(t, state, "total_size"); got != 8 {
+			t.Errorf("%s: total_size is %d, want 8", typeName, got)
+		}
+	}
+}
+
+func TestDatabricksDbfsDataSource_directoriesNotCounted(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	m.put("/FileStore/jars/a.jar", []byte("aaa"))
+	m.put("/FileStore/jars/lib/b.jar", []byte("bbbbb"))
+
+	state := p.readDataSource(databricksDbfsFilesDataSourceTypeName, map[string]interface{}{
+		"root_path": "/FileStore/jars",
+	})
+	if got := dbfsListedPaths(t, state); len(got) != 2 || got[1] != "/FileStore/jars/lib" {
+		t.Errorf("listed %v, want a.jar and the lib directory", got)
+	}
+	if got := int64Attr(t, state, "file_count"); got != 1 {
+		t.Errorf("file_count is %d, want 1", got)
+	}
+	if got := int64Attr(t, state, "total_size_bytes"); got != 3 {
+		t.Errorf("total_size_bytes is %d, want 3", got)
+	}
+}
+
+// dbfsListedPaths returns the paths of the files attribute of a DBFS listing.
+func dbfsListedPaths(t *testing.T, state tftypes.Value) []string {
+	t.Helper()
+	var files []tftypes.Value
+	if err := stateAttr(t, state, "files").As(&files); err != nil {
+		t.Fatal(err)
+	}
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		paths = append(paths, stringAttr(t, file, "path"))
+	}
+	return paths
+}
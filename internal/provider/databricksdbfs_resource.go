@@ -1,64 +1,167 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-// Ensure the implementation satisfies the expected interfaces.
+// Drift detection modes of file resources.
+const (
+	// driftDetectionNone trusts the state and never refreshes the remote file.
+	driftDetectionNone = "none"
+	// driftDetectionMetadata compares the remote size and modification time
+	// with the state.
+	driftDetectionMetadata = "metadata"
+	// driftDetectionContent downloads and hashes the remote content.
+	driftDetectionContent = "content"
+)
+
+// dbfsReadChunkSize is the largest length the DBFS read API returns at once.
+const dbfsReadChunkSize = 1 << 20
+
+// Checksum algorithms of remote_checksum.
+const (
+	checksumMD5    = "md5"
+	checksumSHA256 = "sha256"
+)
+
+// newChecksum returns a new hash of the checksum algorithm, md5 or sha256.
+func newChecksum(algorithm string) hash.Hash {
+	if algorithm == checksumSHA256 {
+		return sha256.New()
+	}
+	return md5.New()
+}
+
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &DatabricksDbfsResource{}
-	_ resource.ResourceWithConfigure   = &DatabricksDbfsResource{}
-	_ resource.ResourceWithImportState = &DatabricksDbfsResource{}
+	_ resource.Resource                     = &DatabricksDbfsResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksDbfsResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksDbfsResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksDbfsResource{}
+	_ resource.ResourceWithImportState      = &DatabricksDbfsResource{}
+	_ resource.ResourceWithMoveState        = &DatabricksDbfsResource{}
+	_ resource.ResourceWithUpgradeState     = &DatabricksDbfsResource{}
+	_ resource.ResourceWithIdentity         = &DatabricksDbfsResource{}
+)
+
+// Type names of the DBFS file resource. mrl_databricks_dbfs is the original
+// name and is kept as a deprecated alias of mrl_databricks_dbfs_file.
+const (
+	databricksDbfsFileTypeName   = "mrl_databricks_dbfs_file"
+	databricksDbfsLegacyTypeName = "mrl_databricks_dbfs"
 )
 
-// NewcontainerResource is a helper function to simplify the provider implementation.
+// NewDatabricksDbfsResource returns the deprecated mrl_databricks_dbfs resource.
 func NewDatabricksDbfsResource() resource.Resource {
-	return &DatabricksDbfsResource{}
+	return &DatabricksDbfsResource{typeName: databricksDbfsLegacyTypeName}
+}
 
+// NewDatabricksDbfsFileResource returns the mrl_databricks_dbfs_file resource.
+func NewDatabricksDbfsFileResource() resource.Resource {
+	return &DatabricksDbfsResource{typeName: databricksDbfsFileTypeName}
 }
 
-// orderResource is the resource implementation.
+// DatabricksDbfsResource is the resource implementation.
 type DatabricksDbfsResource struct {
-	credential *azidentity.ClientSecretCredential
+	clients ClientBundle
+	// typeName is the full resource type name, either
+	// databricksDbfsFileTypeName or databricksDbfsLegacyTypeName.
+	typeName string
 }
 
-// ImportState implements resource.ResourceWithImportState.
+// ImportState imports a DBFS file by workspace_url|dbfs_path or by identity.
+// The workspace is accessed with the provider OAuth credentials, and Read
+// fills in the size, modification time and content hash. local_path cannot be
+// known and is left for the configuration to set.
 func (*DatabricksDbfsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksDbfsResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "dbfs_path")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.DbfsPath = types.StringValue(parts[1])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	dbfsPath := NewDbfsPathValue(identity.DbfsPath.ValueString())
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), dbfsPath.ValueNormalized())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dbfs_path"), dbfsPath.ValueNormalized())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("drift_detection"), driftDetectionMetadata)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("upload_block_size"), int64(databricks.DbfsBlockSize))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("overwrite"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("skip_destroy"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("recursive_delete"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("checksum_algorithm"), checksumMD5)...)
 }
 
 type databricksDbfsResourceModel struct {
-	AdbId        types.String `tfsdk:"adb_id"`
-	Token        types.String `tfsdk:"token"`
-	LocalPath    types.String `tfsdk:"local_path"`
-	DbfsPath     types.String `tfsdk:"dbfs_path"`
-	FileSize     types.Int64  `tfsdk:"file_size"`
-	LastModified types.String `tfsdk:"modification_time"`
-	Md5Hash      types.String `tfsdk:"content_md5"`
+	Id                types.String                   `tfsdk:"id"`
+	WorkspaceUrl      URLValue                       `tfsdk:"workspace_url"`
+	Token             types.String                   `tfsdk:"token"`
+	LocalPath         types.String                   `tfsdk:"local_path"`
+	DbfsPath          DbfsPathValue                  `tfsdk:"dbfs_path"`
+	DbfsPathTemplate  types.String                   `tfsdk:"dbfs_path_prefix_template"`
+	FileSize          types.Int64                    `tfsdk:"file_size"`
+	LastModified      RFC3339Value                   `tfsdk:"modification_time"`
+	Md5Hash           types.String                   `tfsdk:"content_md5"`
+	ChecksumAlgorithm types.String                   `tfsdk:"checksum_algorithm"`
+	RemoteChecksum    types.String                   `tfsdk:"remote_checksum"`
+	Drift             types.String                   `tfsdk:"drift_detection"`
+	ContentChanged    types.Bool                     `tfsdk:"content_changed"`
+	SourceHash        types.String                   `tfsdk:"source_hash"`
+	BlockSize         types.Int64                    `tfsdk:"upload_block_size"`
+	Overwrite         types.Bool                     `tfsdk:"overwrite"`
+	SkipDestroy       types.Bool                     `tfsdk:"skip_destroy"`
+	RecursiveDelete   types.Bool                     `tfsdk:"recursive_delete"`
+	Workspace         *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+	Timeouts          *timeoutsModel                 `tfsdk:"timeouts"`
 }
-type createRequestBody struct {
-	Path      string `json:"path"`
-	Contents  string `json:"contents"`
-	Overwrite string `json:"overwrite"`
+
+// databricksDbfsResourceIdentityModel identifies a DBFS file across
+// workspaces.
+type databricksDbfsResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	DbfsPath     types.String `tfsdk:"dbfs_path"`
 }
+
 type fileUploadStatusResponseModel struct {
 	Path         string `json:"path"`
 	IsDirectory  bool   `json:"is_dir"`
@@ -72,247 +175,863 @@ func (r *DatabricksDbfsResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	credential, ok := req.ProviderData.(*azidentity.ClientSecretCredential)
+	providerData, ok := req.ProviderData.(*ClientBundle)
 	if !ok {
 		resp.Diagnostics.AddError(
-			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *hashicups.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.credential = credential
+	r.clients = *providerData
+}
+
+// dbfsTargets records the DBFS files planned by the DBFS file resources of a
+// provider, so that two instances uploading to the same path fail at plan
+// time instead of overwriting each other during apply.
+type dbfsTargets struct {
+	mu     sync.Mutex
+	owners map[string]string
+}
+
+// newDbfsTargets returns an empty dbfsTargets.
+func newDbfsTargets() *dbfsTargets {
+	return &dbfsTargets{owners: map[string]string{}}
+}
+
+// claim records that the local file owner is planned to be uploaded to the
+// DBFS file key. When another local file already claimed key, claim returns
+// it and false. Planning the same instance again claims key with the same
+// owner, which succeeds. A nil dbfsTargets accepts every claim.
+func (t *dbfsTargets) claim(key, owner string) (string, bool) {
+	if t == nil {
+		return "", true
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if prior, ok := t.owners[key]; ok && prior != owner {
+		return prior, false
+	}
+	t.owners[key] = owner
+	return "", true
+}
+
+// ConfigValidators checks at validation time that local_path, when it
+// exists, is a regular file. A missing file fails the plan rather than
+// validation, so that refresh-only plans work without it. Files of any size
+// are streamed in blocks, so no size limit applies.
+func (r *DatabricksDbfsResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		LocalFileIfExists(path.Root("local_path"), 0),
+		WorkspaceBlock(path.Root("workspace_url")),
+	}
 }
 
 // Metadata returns the resource type name.
 func (r *DatabricksDbfsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
-	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs"
+	resp.TypeName = req.ProviderTypeName + strings.TrimPrefix(r.typeName, "mrl")
 }
 
 // Schema defines the schema for the resource.
 func (r *DatabricksDbfsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{
+	resp.Schema = databricksDbfsFileSchema()
+	resp.Schema.Description = "Uploads a local file to DBFS, by default under /FileStore/jars/init-libs"
+	if r.typeName == databricksDbfsLegacyTypeName {
+		resp.Schema.Description = "Deprecated alias of mrl_databricks_dbfs_file"
+		resp.Schema.DeprecationMessage = "Use mrl_databricks_dbfs_file instead. Existing instances can be moved without re-uploading with a moved block from mrl_databricks_dbfs to mrl_databricks_dbfs_file (Terraform 1.8 or later)."
+	}
+}
+
+// databricksDbfsFileSchema returns the schema shared by
+// mrl_databricks_dbfs_file and its deprecated mrl_databricks_dbfs alias.
+func databricksDbfsFileSchema() schema.Schema {
+	return schema.Schema{
+		// Version 1 renamed adb_id to workspace_url and made
+		// modification_time computed only.
+		Version: 1,
 		Attributes: map[string]schema.Attribute{
-			"adb_id": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "URL of the azure databricks instance",
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Normalized DBFS path of the file",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType:  URLType{},
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL()},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
 			},
 			"token": schema.StringAttribute{
-				Required:    true,
-				Sensitive:   true,
-				Description: "Access token for the azure databricks instance",
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
 			},
 			"local_path": schema.StringAttribute{
 				Required:    true,
 				Description: "Local path from where the file needs to be read",
 			},
 			"dbfs_path": schema.StringAttribute{
-				Optional: true,
-				Computed: true,
+				CustomType: DbfsPathType{},
+				Optional:   true,
+				Computed:   true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					NormalizedPath(false),
 				},
-				Description: "Path in dbfs where the file should be uploaded",
+				Validators:  []validator.String{DbfsPathNormalized()},
+				Description: "Absolute, normalized path in DBFS the file is uploaded to. Changing it uploads the file to the new path and deletes it from the old one. Defaults to the path rendered from dbfs_path_prefix_template, or else /FileStore/jars/init-libs/ followed by the name of the local file",
+			},
+			"dbfs_path_prefix_template": schema.StringAttribute{
+				Optional:    true,
+				Description: "Template of dbfs_path when it is not set, overriding dbfs_path_prefix_template of the provider databricks block, e.g. /FileStore/jars/{env}/{md5}. {filename} is the name of the local file, {md5} its md5 hash, and other placeholders such as {env} take their values from dbfs_path_variables of the provider databricks block. Without {filename}, the template is a directory the file is uploaded to. The path is known at plan time",
 			},
 			"file_size": schema.Int64Attribute{
 				Optional:    true,
 				Computed:    true,
-				Description: "Size of the file being managed",
-				//Default:  int64default.StaticInt64(1),
+				Description: "Size of the file being managed. Known at plan time: that of local_path when the file is uploaded, and unchanged otherwise",
 			},
 			"modification_time": schema.StringAttribute{
-				Optional:    true,
+				CustomType:  RFC3339Type{},
 				Computed:    true,
-				Description: "Last modified time of the file being managed",
-				//Default:  stringdefault.StaticString("null"),
+				Description: "Last modified time of the file being managed, in RFC3339 format. Unchanged by plans that do not upload the file",
 			},
 			"content_md5": schema.StringAttribute{
-				Required:    true,
-				Description: "md5 hash of the file",
+				Optional:    true,
+				Computed:    true,
+				Description: "md5 hash of the file. Defaults to the md5 of local_path, computed at plan time",
+			},
+			"checksum_algorithm": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(checksumMD5),
+				Validators:  []validator.String{StringOneOf(checksumMD5, checksumSHA256)},
+				Description: "Algorithm of remote_checksum, md5 or sha256. Changing it reads the file back to compute the new checksum, without uploading it again. Defaults to md5",
+			},
+			"remote_checksum": schema.StringAttribute{
+				Computed:    true,
+				Description: "Hex checksum of the file in DBFS with checksum_algorithm. Every upload reads the file back and fails the apply when its checksum differs from that of the content sent, leaving the resource tainted so that the next apply uploads it again. Refreshed when drift_detection is content, and null after a change outside Terraform is detected",
+			},
+			"content_changed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the planned change uploads new content. The plan also shows a warning with the local and remote hashes and the size delta",
+			},
+			"source_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 of local_path, computed at plan time. A change uploads the file again, even when content_md5 is set",
+			},
+			"drift_detection": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(driftDetectionMetadata),
+				Validators:  []validator.String{StringOneOf(driftDetectionNone, driftDetectionMetadata, driftDetectionContent)},
+				Description: "How refresh detects changes made outside Terraform: none skips the remote check, metadata compares size and modification time and hashes the file when they changed, content downloads and hashes the file every time. Defaults to metadata",
+			},
+			"upload_block_size": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(databricks.DbfsBlockSize),
+				Validators:  []validator.Int64{Int64Between(1, databricks.DbfsBlockSize)},
+				Description: "Size in bytes of the blocks the file is streamed in, at most 1048576. Files of any size are uploaded holding a single block in memory. Defaults to 1048576",
+			},
+			"overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Replace a file that already exists at dbfs_path with different content when the resource is created. The plan then warns with the hash, size and modification time of the file replaced. Without it, plan and apply fail and suggest importing the file instead. Defaults to false",
 			},
+			"skip_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Leave the file in DBFS when the resource is destroyed or replaced, only removing it from the Terraform state, for files shared with other configurations. Defaults to false",
+			},
+			"recursive_delete": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Delete dbfs_path recursively when the resource is destroyed, so that a directory created at dbfs_path outside Terraform is removed with its content instead of failing the destroy. Defaults to false",
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
 		},
 	}
 }
 
-// Create a new resource.
-func (r *DatabricksDbfsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+// IdentitySchema defines the identity of a DBFS file: the workspace and the
+// path of the file in it.
+func (r *DatabricksDbfsResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"dbfs_path": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Path of the file in DBFS",
+			},
+		},
+	}
+}
+
+// setDbfsIdentity records the identity of the file at dbfsPath in the
+// workspace adbID. Terraform versions without identity support pass a nil
+// identity.
+func setDbfsIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, dbfsPath string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksDbfsResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		DbfsPath:     types.StringValue(dbfsPath),
+	})
+}
+
+// MoveState moves mrl_databricks_dbfs instances to mrl_databricks_dbfs_file.
+// The schemas are identical, so the state is carried over unchanged, after
+// upgrading it when the source is still at schema version 0.
+func (r *DatabricksDbfsResource) MoveState(_ context.Context) []resource.StateMover {
+	if r.typeName != databricksDbfsFileTypeName {
+		return nil
+	}
+
+	sourceSchema := databricksDbfsFileSchema()
+	sourceSchemaV0 := databricksDbfsFileSchemaV0()
+	return []resource.StateMover{
+		{
+			SourceSchema: &sourceSchema,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !r.isLegacySource(req) || req.SourceSchemaVersion != sourceSchema.Version {
+					return
+				}
+
+				var state databricksDbfsResourceModel
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &state)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				r.moveState(ctx, state, resp)
+			},
+		},
+		{
+			SourceSchema: &sourceSchemaV0,
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if !r.isLegacySource(req) || req.SourceSchemaVersion != 0 {
+					return
+				}
+
+				var prior databricksDbfsResourceModelV0
+				resp.Diagnostics.Append(req.SourceState.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				r.moveState(ctx, prior.upgrade(), resp)
+			},
+		},
+	}
+}
+
+// isLegacySource reports whether req moves an mrl_databricks_dbfs instance of
+// this provider.
+func (r *DatabricksDbfsResource) isLegacySource(req resource.MoveStateRequest) bool {
+	return req.SourceTypeName == databricksDbfsLegacyTypeName && strings.HasSuffix(req.SourceProviderAddress, "/mrl")
+}
+
+// moveState sets the target state and identity of a moved instance.
+func (r *DatabricksDbfsResource) moveState(ctx context.Context, state databricksDbfsResourceModel, resp *resource.MoveStateResponse) {
+	if state.Drift.IsNull() {
+		state.Drift = types.StringValue(driftDetectionMetadata)
+	}
+	if state.Overwrite.IsNull() {
+		state.Overwrite = types.BoolValue(false)
+	}
+	if state.SkipDestroy.IsNull() {
+		state.SkipDestroy = types.BoolValue(false)
+	}
+	if state.RecursiveDelete.IsNull() {
+		state.RecursiveDelete = types.BoolValue(false)
+	}
+	if state.ChecksumAlgorithm.IsNull() {
+		state.ChecksumAlgorithm = types.StringValue(checksumMD5)
+	}
+	state.ContentChanged = types.BoolValue(false)
+	state.Id = types.StringValue(dbfsTargetPath(state.DbfsPath, state.LocalPath.ValueString()))
+
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &state)...)
+	host, _, _ := r.clients.Databricks.override(state.Workspace).resolve(state.WorkspaceUrl, state.Token)
+	resp.Diagnostics.Append(setDbfsIdentity(ctx, resp.TargetIdentity, host, state.DbfsPath.ValueString())...)
+}
+
+// UpgradeState upgrades states of schema version 0, which named the workspace
+// adb_id and let modification_time be configured.
+func (r *DatabricksDbfsResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	priorSchema := databricksDbfsFileSchemaV0()
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &priorSchema,
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior databricksDbfsResourceModelV0
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				resp.Diagnostics.Append(resp.State.Set(ctx, prior.upgrade())...)
+			},
+		},
+	}
+}
+
+// databricksDbfsFileSchemaV0 returns version 0 of the DBFS file schema, from
+// before adb_id was renamed workspace_url.
+func databricksDbfsFileSchemaV0() schema.Schema {
+	current := databricksDbfsFileSchema()
+	s := schema.Schema{
+		Version:    0,
+		Attributes: map[string]schema.Attribute{},
+	}
+	// Version 0 states hold these attributes only; those added since are
+	// left out of the list rather than removed one by one, so that adding
+	// an attribute cannot break the upgrade.
+	for _, name := range []string{
+		"id", "token", "local_path", "dbfs_path", "file_size", "content_md5", "drift_detection",
+		"content_changed", "source_hash", "upload_block_size", "overwrite", "timeouts",
+	} {
+		s.Attributes[name] = current.Attributes[name]
+	}
+	s.Attributes["adb_id"] = current.Attributes["workspace_url"]
+	// Read as a plain string, so that upgrade can fix values that are not
+	// RFC3339 timestamps.
+	s.Attributes["modification_time"] = schema.StringAttribute{
+		Optional: true,
+		Computed: true,
+	}
+	return s
+}
+
+type databricksDbfsResourceModelV0 struct {
+	Id             types.String   `tfsdk:"id"`
+	AdbId          URLValue       `tfsdk:"adb_id"`
+	Token          types.String   `tfsdk:"token"`
+	LocalPath      types.String   `tfsdk:"local_path"`
+	DbfsPath       DbfsPathValue  `tfsdk:"dbfs_path"`
+	FileSize       types.Int64    `tfsdk:"file_size"`
+	LastModified   types.String   `tfsdk:"modification_time"`
+	Md5Hash        types.String   `tfsdk:"content_md5"`
+	Drift          types.String   `tfsdk:"drift_detection"`
+	ContentChanged types.Bool     `tfsdk:"content_changed"`
+	SourceHash     types.String   `tfsdk:"source_hash"`
+	BlockSize      types.Int64    `tfsdk:"upload_block_size"`
+	Overwrite      types.Bool     `tfsdk:"overwrite"`
+	Timeouts       *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// upgrade returns the version 1 state of m. A modification_time that is not
+// an RFC3339 timestamp, as written by old releases, becomes the timestamp of
+// the Unix milliseconds it holds, or else null until the next refresh.
+func (m databricksDbfsResourceModelV0) upgrade() databricksDbfsResourceModel {
+	lastModified := NewRFC3339Null()
+	if value := m.LastModified.ValueString(); value != "" {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			lastModified = NewRFC3339TimeValue(t)
+		} else if millis, err := strconv.ParseInt(value, 10, 64); err == nil {
+			lastModified = NewRFC3339TimeValue(time.UnixMilli(millis))
+		}
+	}
+
+	return databricksDbfsResourceModel{
+		Id:                m.Id,
+		WorkspaceUrl:      m.AdbId,
+		Token:             m.Token,
+		LocalPath:         m.LocalPath,
+		DbfsPath:          m.DbfsPath,
+		DbfsPathTemplate:  types.StringNull(),
+		FileSize:          m.FileSize,
+		LastModified:      lastModified,
+		Md5Hash:           m.Md5Hash,
+		ChecksumAlgorithm: types.StringValue(checksumMD5),
+		RemoteChecksum:    types.StringNull(),
+		Drift:             m.Drift,
+		ContentChanged:    m.ContentChanged,
+		SourceHash:        m.SourceHash,
+		BlockSize:         m.BlockSize,
+		Overwrite:         m.Overwrite,
+		SkipDestroy:       types.BoolValue(false),
+		RecursiveDelete:   types.BoolValue(false),
+		Timeouts:          m.Timeouts,
+	}
+}
+
+// ModifyPlan flags plans that upload new content and explains why. Plans
+// that create the resource also check the DBFS path, failing or, with
+// overwrite, warning when a file Terraform does not manage is there.
+func (r *DatabricksDbfsResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
 
-	// Retrieve values from plan
 	var plan databricksDbfsResourceModel
-	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var configDbfsPath DbfsPathValue
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("dbfs_path"), &configDbfsPath)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	md5Hash, diags := planContentMD5(ctx, req.Config, plan.LocalPath)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	plan.Md5Hash = md5Hash
+
+	if configDbfsPath.IsNull() {
+		dbfsPath, diags := r.defaultDbfsPath(plan)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		plan.DbfsPath = dbfsPath
+	}
+	plan.Id = types.StringUnknown()
+	if !plan.DbfsPath.IsUnknown() {
+		plan.Id = types.StringValue(plan.DbfsPath.ValueNormalized())
+	}
 
-	adburl := plan.AdbId.ValueString()
-	token := plan.Token.ValueString()
+	sourceHash, diags := planSourceHash(plan.LocalPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SourceHash = sourceHash
 
-	localPath := plan.LocalPath.ValueString()
-	uploadEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/put", adburl)
-	pingEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/get-status?path=", adburl)
+	resp.Diagnostics.Append(r.claimTarget(&plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	_, err := FileUpload(localPath, uploadEndpoint, token)
-	if err != nil {
-		fmt.Println(err)
+	if req.State.Raw.IsNull() {
+		plan.ContentChanged = types.BoolValue(true)
+		if !plan.WorkspaceUrl.IsUnknown() && !plan.Token.IsUnknown() && !plan.DbfsPath.IsUnknown() && !plan.Md5Hash.IsUnknown() {
+			resp.Diagnostics.Append(r.checkExisting(ctx, &plan)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+	} else {
+		var state databricksDbfsResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		// A path rendered from a template is unknown until its values are,
+		// and may then differ.
+		if (plan.DbfsPath.IsUnknown() && configDbfsPath.IsNull()) || (!plan.DbfsPath.IsUnknown() && plan.DbfsPath.ValueNormalized() != dbfsStatePath(state)) {
+			resp.RequiresReplace = append(resp.RequiresReplace, path.Root("dbfs_path"))
+		}
+
+		changed, warning := previewContentChange(plan.LocalPath, plan.Md5Hash, state.Md5Hash, state.FileSize)
+		if warning == nil {
+			if stale, sourceWarning := sourceChanged(plan.LocalPath, plan.SourceHash, state.SourceHash); stale {
+				changed, warning = types.BoolValue(true), sourceWarning
+			}
+		}
+		if sameWorkspace, _ := plan.WorkspaceUrl.StringSemanticEquals(ctx, state.WorkspaceUrl); !sameWorkspace {
+			// The file is uploaded to the new workspace.
+			changed = types.BoolValue(true)
+		}
+		plan.ContentChanged = changed
+		if warning != nil {
+			resp.Diagnostics.Append(warning)
+		}
+
+		// Update only uploads changed content, so a file that is not
+		// uploaded again keeps its size and modification time.
+		plan.RemoteChecksum = types.StringUnknown()
+		if !changed.IsUnknown() && !changed.ValueBool() {
+			plan.FileSize = state.FileSize
+			plan.LastModified = state.LastModified
+			// Nor does its checksum change, unless computed with another
+			// algorithm.
+			if plan.ChecksumAlgorithm.Equal(state.ChecksumAlgorithm) {
+				plan.RemoteChecksum = state.RemoteChecksum
+			}
+		} else {
+			// The upload gives the file a new modification time.
+			plan.LastModified = NewRFC3339Unknown()
+		}
 	}
 
-	fileInfo, err := FileStatus(localPath, pingEndpoint, token)
-	if err != nil {
-		fmt.Println(err)
+	// The size of an uploaded file is that of the local file, known now.
+	if plan.ContentChanged.ValueBool() {
+		var configFileSize types.Int64
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("file_size"), &configFileSize)...)
+		if configFileSize.IsNull() && !plan.LocalPath.IsUnknown() {
+			if info, err := os.Stat(plan.LocalPath.ValueString()); err == nil {
+				plan.FileSize = types.Int64Value(info.Size())
+			}
+		}
 	}
 
-	plan.DbfsPath = types.StringValue(fileInfo.Path)
-	plan.FileSize = types.Int64Value(fileInfo.FileSize)
-	plan.LastModified = types.StringValue(time.UnixMilli(int64(fileInfo.LastModified)).UTC().Format(time.RFC3339))
-	fmt.Println(plan)
-	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create a new resource.
+func (r *DatabricksDbfsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, r.typeName+".Create")
+	defer span.End()
+
+	// Retrieve values from plan
+	var plan databricksDbfsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	ctx, cancel := plan.Timeouts.create(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	// Checked again in case the file appeared since the plan, or the plan
+	// could not check it. The plan already warned of files overwritten.
+	if !plan.Overwrite.ValueBool() {
+		resp.Diagnostics.Append(r.checkExisting(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	uploaded, diags := r.upload(ctx, &plan, auditActionCreate)
+	resp.Diagnostics.Append(diags...)
+	if !uploaded {
+		return
+	}
+
+	// A file that failed verification is saved with the error, so that
+	// Terraform taints it and the next apply replaces it.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	host, _, _ := r.clients.Databricks.override(plan.Workspace).resolve(plan.WorkspaceUrl, plan.Token)
+	resp.Diagnostics.Append(setDbfsIdentity(ctx, resp.Identity, host, plan.DbfsPath.ValueString())...)
 }
 
-func FileStatus(fp string, p string, t string) (fileUploadStatusResponseModel, error) {
+// defaultDbfsPath returns the DBFS path of plan when dbfs_path is not set:
+// that rendered from the path template of the resource or of the provider,
+// or else the path under dbfsLibDir. It is unknown until the values the
+// template needs are.
+func (r *DatabricksDbfsResource) defaultDbfsPath(plan databricksDbfsResourceModel) (DbfsPathValue, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	template := r.clients.Databricks.dbfsPathTemplate
+	if !plan.DbfsPathTemplate.IsNull() {
+		template = plan.DbfsPathTemplate.ValueString()
+	}
+	if template == "" && !plan.DbfsPathTemplate.IsUnknown() {
+		if plan.LocalPath.IsUnknown() {
+			return plan.DbfsPath, diags
+		}
+		return NewDbfsPathValue(dbfsLibPath(plan.LocalPath.ValueString())), diags
+	}
+	if plan.LocalPath.IsUnknown() || plan.DbfsPathTemplate.IsUnknown() || (strings.Contains(template, "{md5}") && plan.Md5Hash.IsUnknown()) {
+		return NewDbfsPathUnknown(), diags
+	}
 
-	fileInfo, err := os.Lstat(fp)
+	dbfsPath, err := renderDbfsPathTemplate(template, r.clients.Databricks.dbfsPathVariables, plan.LocalPath.ValueString(), plan.Md5Hash.ValueString())
 	if err != nil {
-		return fileUploadStatusResponseModel{}, fmt.Errorf("file read failed")
+		diags.AddAttributeError(path.Root("dbfs_path_prefix_template"), "Invalid DBFS path template", err.Error())
 	}
+	return NewDbfsPathValue(dbfsPath), diags
+}
 
-	libpath := fmt.Sprintf("/FileStore/jars/init-libs/%v", fileInfo.Name())
-
-	httpClient := http.Client{}
-	httpRequest, err := http.NewRequest("GET", fmt.Sprintf("%v%v", p, libpath), nil)
+// claimTarget reports an error when another instance planned in the same run
+// uploads a different local file to the DBFS path of plan.
+func (r *DatabricksDbfsResource) claimTarget(plan *databricksDbfsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.WorkspaceUrl.IsUnknown() || plan.Token.IsUnknown() || plan.DbfsPath.IsUnknown() || plan.LocalPath.IsUnknown() {
+		return diags
+	}
+	adburl, _, err := r.clients.Databricks.override(plan.Workspace).resolve(plan.WorkspaceUrl, plan.Token)
 	if err != nil {
-		return fileUploadStatusResponseModel{}, fmt.Errorf("request creation failed")
+		// Reported when the workspace is accessed.
+		return diags
 	}
+	dbfsPath := dbfsTargetPath(plan.DbfsPath, plan.LocalPath.ValueString())
 
-	httpRequest.Header = http.Header{
-		"Authorization": {fmt.Sprintf("Bearer %v", t)},
+	if prior, ok := r.clients.DbfsTargets.claim(adburl+"|"+dbfsPath, plan.LocalPath.ValueString()); !ok {
+		diags.AddAttributeError(
+			path.Root("dbfs_path"),
+			"Conflicting DBFS path",
+			fmt.Sprintf("%s is also the DBFS path of another resource, which uploads %s there. Give each resource its own dbfs_path.", dbfsPath, prior),
+		)
 	}
+	return diags
+}
 
-	httpResponse, err := httpClient.Do(httpRequest)
+// checkExisting looks for a file with content other than content_md5 at the
+// DBFS path of plan, which creating the resource would replace although
+// Terraform does not manage it. Without overwrite it fails, so that the file
+// is not silently replaced; with overwrite it warns, so that the plan shows
+// what the apply replaces.
+func (r *DatabricksDbfsResource) checkExisting(ctx context.Context, plan *databricksDbfsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	workspace := r.clients.Databricks.override(plan.Workspace)
+	adburl, token, err := workspace.resolve(plan.WorkspaceUrl, plan.Token)
 	if err != nil {
-		return fileUploadStatusResponseModel{}, fmt.Errorf("request call failed")
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return diags
 	}
+	dbfsPath := dbfsTargetPath(plan.DbfsPath, plan.LocalPath.ValueString())
 
-	httpResponseBody, err := io.ReadAll(httpResponse.Body)
+	fileInfo, err := FileStatus(ctx, workspace.http(r.clients.HTTPClient, adburl, token), adburl, dbfsPath, token)
 	if err != nil {
-		return fileUploadStatusResponseModel{}, fmt.Errorf("read response body failed")
+		if !databricks.IsNotFound(err) {
+			diags.AddError("Error reading DBFS file", "Could not check whether "+dbfsPath+" already exists: "+err.Error())
+		}
+		return diags
 	}
-
-	var pingResponse fileUploadStatusResponseModel
-
-	err = json.Unmarshal(httpResponseBody, &pingResponse)
+	remoteMd5, err := FileContentMD5(ctx, workspace.http(r.clients.HTTPClient, adburl, token), adburl, dbfsPath, token)
 	if err != nil {
-		return fileUploadStatusResponseModel{}, fmt.Errorf("unmarshal failed")
+		diags.AddError("Error reading DBFS file", "Could not hash the existing file "+dbfsPath+": "+err.Error())
+		return diags
+	}
+	if remoteMd5 == plan.Md5Hash.ValueString() {
+		return diags
 	}
 
-	return pingResponse, nil
-
+	if plan.Overwrite.ValueBool() {
+		modified := time.UnixMilli(int64(fileInfo.LastModified)).UTC().Format(time.RFC3339)
+		diags.AddAttributeWarning(
+			path.Root("dbfs_path"),
+			"DBFS file will be overwritten",
+			fmt.Sprintf("%s already exists and is not managed by Terraform (md5 %s, %d bytes, modified %s). As overwrite is set, applying replaces it with %s (md5 %s).",
+				dbfsPath, remoteMd5, fileInfo.FileSize, modified, plan.LocalPath.ValueString(), plan.Md5Hash.ValueString()),
+		)
+		return diags
+	}
+	diags.AddAttributeError(
+		path.Root("dbfs_path"),
+		"DBFS file already exists",
+		fmt.Sprintf("%s already exists with different content (md5 %s, local %s). Import it with the ID %s|%s to manage it, or set overwrite to true to replace it.",
+			dbfsPath, remoteMd5, plan.Md5Hash.ValueString(), adburl, dbfsPath),
+	)
+	return diags
 }
-func FileUpload(fp string, e string, t string) (bool, error) {
 
-	fileInfo, err := os.Lstat(fp)
+// upload uploads the local file of plan to its DBFS path, records the
+// resulting status in plan and verifies the uploaded file by reading it back.
+// It reports whether the file was uploaded, which it also is when the
+// verification fails.
+func (r *DatabricksDbfsResource) upload(ctx context.Context, plan *databricksDbfsResourceModel, action string) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
 
+	workspace := r.clients.Databricks.override(plan.Workspace)
+	adburl, token, err := workspace.resolve(plan.WorkspaceUrl, plan.Token)
 	if err != nil {
-		return false, err
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return false, diags
 	}
+	localPath := plan.LocalPath.ValueString()
+	dbfsPath := dbfsTargetPath(plan.DbfsPath, localPath)
 
-	fileContent, err := os.ReadFile(fp)
-
-	fmt.Println(err)
-	encodedString := base64.StdEncoding.EncodeToString(fileContent)
+	client := workspace.newClient(r.clients.HTTPClient, adburl, token)
+	sent := newChecksum(plan.ChecksumAlgorithm.ValueString())
+	err = fileUploadHashed(ctx, client, localPath, dbfsPath, int(plan.BlockSize.ValueInt64()), sent)
+	r.clients.Audit.Record(ctx, r.typeName, action, dbfsPath, err)
+	if err != nil {
+		diags.AddError(
+			"Error uploading DBFS file",
+			"Could not upload "+localPath+" to "+dbfsPath+": "+err.Error(),
+		)
+		return false, diags
+	}
 
-	jsonbody := createRequestBody{
-		Path:      fmt.Sprintf("/FileStore/jars/init-libs/%v", fileInfo.Name()),
-		Contents:  encodedString,
-		Overwrite: "true",
+	fileInfo, err := FileStatus(ctx, workspace.http(r.clients.HTTPClient, adburl, token), adburl, dbfsPath, token)
+	if err != nil {
+		diags.AddError(
+			"Error reading DBFS file",
+			"Could not read the status of "+dbfsPath+" after uploading it: "+err.Error(),
+		)
+		return false, diags
 	}
 
-	jsonData, err := json.Marshal(jsonbody)
+	plan.Id = types.StringValue(fileInfo.Path)
+	plan.DbfsPath = NewDbfsPathValue(fileInfo.Path)
+	plan.FileSize = types.Int64Value(fileInfo.FileSize)
+	plan.LastModified = NewRFC3339TimeValue(time.UnixMilli(int64(fileInfo.LastModified)))
+
+	diags.Append(r.readRemoteChecksum(ctx, plan)...)
+	if diags.HasError() {
+		return true, diags
+	}
+	if want := hex.EncodeToString(sent.Sum(nil)); plan.RemoteChecksum.ValueString() != want {
+		diags.AddAttributeError(
+			path.Root("remote_checksum"),
+			"DBFS file verification failed",
+			fmt.Sprintf("%s was read back with %s %s, but the content uploaded from %s has %s. The resource is tainted and the next apply uploads the file again.",
+				dbfsPath, plan.ChecksumAlgorithm.ValueString(), plan.RemoteChecksum.ValueString(), localPath, want),
+		)
+	}
+	return true, diags
+}
 
-	fmt.Println(err)
+// readRemoteChecksum reads back the DBFS file of plan and records its
+// checksum in remote_checksum.
+func (r *DatabricksDbfsResource) readRemoteChecksum(ctx context.Context, plan *databricksDbfsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	plan.RemoteChecksum = types.StringNull()
 
-	httpClient := http.Client{}
+	workspace := r.clients.Databricks.override(plan.Workspace)
+	adburl, token, err := workspace.resolve(plan.WorkspaceUrl, plan.Token)
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return diags
+	}
+	dbfsPath := dbfsTargetPath(plan.DbfsPath, plan.LocalPath.ValueString())
 
-	httpRequest, err := http.NewRequest("POST", e, bytes.NewBuffer(jsonData))
+	client := workspace.newClient(r.clients.HTTPClient, adburl, token)
+	sums, err := dbfsChecksums(ctx, client, dbfsPath, newChecksum(plan.ChecksumAlgorithm.ValueString()))
 	if err != nil {
-		fmt.Println(err)
-		return false, err
+		diags.AddError(
+			"Error reading DBFS file",
+			"Could not read back "+dbfsPath+" to verify its checksum: "+err.Error(),
+		)
+		return diags
 	}
+	plan.RemoteChecksum = types.StringValue(sums[0])
+	return diags
+}
 
-	httpRequest.Header.Set("Authorization", fmt.Sprintf("Bearer %v", t))
+// dbfsLibDir is the DBFS directory local files are uploaded to when
+// dbfs_path is not set.
+const dbfsLibDir = "/FileStore/jars/init-libs"
 
-	httpRequest.Header.Set("Content-Type", "application/json")
+// dbfsLibPath returns the DBFS path a local file is uploaded to when
+// dbfs_path is not set.
+func dbfsLibPath(fp string) string {
+	return fmt.Sprintf("%s/%v", dbfsLibDir, filepath.Base(fp))
+}
 
-	httpResponse, err := httpClient.Do(httpRequest)
-	if err != nil {
-		return false, err
+// dbfsStatePath returns the DBFS path of the file recorded in state, its ID.
+// Only states without one, written before the ID was recorded, fall back to
+// dbfs_path and then to the path derived from local_path.
+func dbfsStatePath(state databricksDbfsResourceModel) string {
+	if id := state.Id.ValueString(); id != "" {
+		return NewDbfsPathValue(id).ValueNormalized()
 	}
+	return dbfsTargetPath(state.DbfsPath, state.LocalPath.ValueString())
+}
 
-	defer httpResponse.Body.Close()
-	fmt.Println(httpResponse)
-	if httpResponse.StatusCode == 200 {
-		return true, nil
+// dbfsTargetPath returns the normalized DBFS path of the file, falling back
+// to the default path of the local file fp when dbfsPath is not known.
+func dbfsTargetPath(dbfsPath DbfsPathValue, fp string) string {
+	if dbfsPath.IsNull() || dbfsPath.IsUnknown() || dbfsPath.ValueString() == "" {
+		return dbfsLibPath(fp)
 	}
+	return dbfsPath.ValueNormalized()
+}
 
-	return false, nil
+// FileStatus returns the status of the DBFS file at dbfsPath. A missing file
+// gives an error for which databricks.IsNotFound reports true.
+func FileStatus(ctx context.Context, httpClient *http.Client, adburl string, dbfsPath string, t string) (fileUploadStatusResponseModel, error) {
+	client := databricks.NewClient(httpClient, adburl, t)
+	info, err := client.DbfsGetStatus(ctx, dbfsPath)
+	if err != nil {
+		return fileUploadStatusResponseModel{}, err
+	}
+	if info.IsDir {
+		return fileUploadStatusResponseModel{}, fmt.Errorf("%v is a directory", dbfsPath)
+	}
 
+	return fileUploadStatusResponseModel{
+		Path:         info.Path,
+		IsDirectory:  info.IsDir,
+		FileSize:     info.FileSize,
+		LastModified: int(info.ModificationTime),
+	}, nil
 }
 
-func FileDelete(fp string, e string, t string) (bool, error) {
+// FileUpload streams the local file fp to dbfsPath in blocks of blockSize
+// bytes, overwriting an existing file.
+func FileUpload(ctx context.Context, httpClient *http.Client, fp string, dbfsPath string, adburl string, t string, blockSize int) error {
+	return fileUpload(ctx, databricks.NewClient(httpClient, adburl, t), fp, dbfsPath, blockSize)
+}
 
-	fileInfo, err := os.Lstat(fp)
+// fileUpload is FileUpload through client, which may limit and wait for
+// the upload.
+func fileUpload(ctx context.Context, client *databricks.Client, fp string, dbfsPath string, blockSize int) error {
+	return fileUploadHashed(ctx, client, fp, dbfsPath, blockSize, nil)
+}
 
+// fileUploadHashed is fileUpload that also writes the uploaded content to
+// sum, unless nil, so that sum is that of the bytes sent even when the local
+// file changes meanwhile.
+func fileUploadHashed(ctx context.Context, client *databricks.Client, fp string, dbfsPath string, blockSize int, sum hash.Hash) error {
+	f, err := os.Open(fp)
 	if err != nil {
-		return false, err
+		return err
 	}
+	defer f.Close()
 
-	jsonBody := struct {
-		Path      string `json:"path"`
-		Recursive bool   `json:"recursive"`
-	}{
-		Path:      fmt.Sprintf("/FileStore/jars/init-libs/%v", fileInfo.Name()),
-		Recursive: false,
+	var content io.Reader = f
+	if sum != nil {
+		content = io.TeeReader(f, sum)
 	}
+	_, err = client.DbfsPutBlocks(ctx, dbfsPath, content, blockSize)
+	return err
+}
 
-	jsonData, err := json.Marshal(jsonBody)
+// FileDelete deletes the DBFS file at dbfsPath.
+func FileDelete(ctx context.Context, httpClient *http.Client, adburl string, dbfsPath string, t string) error {
+	client := databricks.NewClient(httpClient, adburl, t)
+	return client.DbfsDelete(ctx, dbfsPath, false)
+}
 
+// FileContentMD5 downloads a DBFS file in chunks and returns the hex md5 of
+// its content.
+func FileContentMD5(ctx context.Context, httpClient *http.Client, adburl string, dbfsPath string, t string) (string, error) {
+	sums, err := dbfsChecksums(ctx, databricks.NewClient(httpClient, adburl, t), dbfsPath, md5.New())
 	if err != nil {
-		return false, fmt.Errorf("json marshal failed")
+		return "", err
 	}
+	return sums[0], nil
+}
 
-	httpClient := http.Client{}
-	httpRequest, _ := http.NewRequest("POST", e, bytes.NewBuffer(jsonData))
-
-	httpRequest.Header.Set("Authorization", fmt.Sprintf("Bearer %v", t))
-
-	httpRequest.Header.Set("Content-Type", "application/json")
-
-	httpResponse, err := httpClient.Do(httpRequest)
-
-	if httpResponse.StatusCode != 200 || err != nil {
-
-		return false, fmt.Errorf("api call failed")
+// dbfsChecksums downloads the DBFS file at dbfsPath once, in chunks, and
+// returns the hex checksum of its content with each of hashes.
+func dbfsChecksums(ctx context.Context, client *databricks.Client, dbfsPath string, hashes ...hash.Hash) ([]string, error) {
+	var offset int64
+	for {
+		data, err := client.DbfsRead(ctx, dbfsPath, offset, dbfsReadChunkSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range hashes {
+			h.Write(data)
+		}
+		offset += int64(len(data))
+		if len(data) < dbfsReadChunkSize {
+			break
+		}
 	}
 
-	defer httpResponse.Body.Close()
-	fmt.Println(httpResponse)
-	if httpResponse.StatusCode == 200 {
-		return true, nil
+	sums := make([]string, len(hashes))
+	for i, h := range hashes {
+		sums[i] = hex.EncodeToString(h.Sum(nil))
 	}
-
-	return false, err
-
+	return sums, nil
 }
 
 // Read refreshes the Terraform state with the latest data.
 func (r *DatabricksDbfsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, r.typeName+".Read")
+	defer span.End()
 
 	var state databricksDbfsResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -320,32 +1039,105 @@ func (r *DatabricksDbfsResource) Read(ctx context.Context, req resource.ReadRequ
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	state.ContentChanged = types.BoolValue(false)
+
+	ctx, cancel := state.Timeouts.read(ctx)
+	defer cancel()
+	drift := state.Drift.ValueString()
+	if state.Drift.IsNull() {
+		// State written before drift_detection existed.
+		drift = driftDetectionMetadata
+		state.Drift = types.StringValue(drift)
+	}
+	if state.Overwrite.IsNull() {
+		// State written before overwrite existed.
+		state.Overwrite = types.BoolValue(false)
+	}
+	if state.SkipDestroy.IsNull() {
+		// State written before skip_destroy and recursive_delete existed.
+		state.SkipDestroy = types.BoolValue(false)
+		state.RecursiveDelete = types.BoolValue(false)
+	}
+	if state.ChecksumAlgorithm.IsNull() {
+		// State written before checksum_algorithm existed.
+		state.ChecksumAlgorithm = types.StringValue(checksumMD5)
+	}
+	workspace := r.clients.Databricks.override(state.Workspace)
+	adburl, token, err := workspace.resolve(state.WorkspaceUrl, state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// The file is looked up by the path recorded in state, never by
+	// local_path, which may have been renamed or removed since.
+	dbfsPath := dbfsStatePath(state)
+	if state.DbfsPath.IsNull() {
+		state.DbfsPath = NewDbfsPathValue(dbfsPath)
+	}
+	// Terraform requires an identity even when the file is gone and the
+	// resource is removed from state, and sends none for states written
+	// before identities existed.
+	resp.Diagnostics.Append(setDbfsIdentity(ctx, resp.Identity, adburl, dbfsPath)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if drift == driftDetectionNone {
+		state.Id = types.StringValue(dbfsPath)
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
 
-	adburl := state.AdbId.ValueString()
-	token := state.Token.ValueString()
-
-	localPath := state.LocalPath.ValueString()
-	pingEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/get-status?path=", adburl)
-
-	fileInfo, err := FileStatus(localPath, pingEndpoint, token)
+	fileInfo, err := FileStatus(ctx, workspace.http(r.clients.HTTPClient, adburl, token), adburl, dbfsPath, token)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
-		fmt.Println(err)
+		resp.Diagnostics.AddError(
+			"Error reading DBFS file",
+			"Could not read "+dbfsPath+": "+err.Error(),
+		)
+		return
+	}
+
+	// The hashes are those of the remote content: always with content drift
+	// detection, and with metadata drift detection once the file was
+	// rewritten outside Terraform, so that the next plan uploads the local
+	// file again only when its content differs. Imported files, and states
+	// that lost their hashes, record them too.
+	lastModified := NewRFC3339TimeValue(time.UnixMilli(int64(fileInfo.LastModified)))
+	rewritten := !state.FileSize.Equal(types.Int64Value(fileInfo.FileSize)) || !state.LastModified.Equal(lastModified)
+	if drift == driftDetectionContent || rewritten || state.Md5Hash.IsNull() || state.RemoteChecksum.IsNull() {
+		client := workspace.newClient(r.clients.HTTPClient, adburl, token)
+		sums, err := dbfsChecksums(ctx, client, fileInfo.Path, md5.New(), newChecksum(state.ChecksumAlgorithm.ValueString()))
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading DBFS file",
+				"Could not hash the content of "+fileInfo.Path+": "+err.Error(),
+			)
+			return
+		}
+		state.Md5Hash = types.StringValue(sums[0])
+		state.RemoteChecksum = types.StringValue(sums[1])
 	}
 
-	state.DbfsPath = types.StringValue(fileInfo.Path)
+	state.Id = types.StringValue(fileInfo.Path)
+	state.DbfsPath = NewDbfsPathValue(fileInfo.Path)
 	state.FileSize = types.Int64Value(fileInfo.FileSize)
-	state.LastModified = types.StringValue(time.UnixMilli(int64(fileInfo.LastModified)).UTC().Format(time.RFC3339))
-	fmt.Println(state)
+	state.LastModified = lastModified
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
 }
 
 // Update updates the resource and sets the updated Terraform state on success.
 func (r *DatabricksDbfsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, r.typeName+".Update")
+	defer span.End()
 
 	var plan databricksDbfsResourceModel
 	diags := req.Plan.Get(ctx, &plan)
@@ -354,37 +1146,37 @@ func (r *DatabricksDbfsResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	adburl := plan.AdbId.ValueString()
-	token := plan.Token.ValueString()
-
-	localPath := plan.LocalPath.ValueString()
-	uploadEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/put", adburl)
-	pingEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/get-status?path=", adburl)
-
-	_, err := FileUpload(localPath, uploadEndpoint, token)
-	if err != nil {
-		fmt.Println(err)
-	}
-
-	fileInfo, err := FileStatus(localPath, pingEndpoint, token)
-	if err != nil {
-		fmt.Println(err)
+	ctx, cancel := plan.Timeouts.update(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	// ModifyPlan kept the size and modification time of unchanged content,
+	// so only changed content is uploaded.
+	if plan.ContentChanged.ValueBool() {
+		uploaded, diags := r.upload(ctx, &plan, auditActionUpdate)
+		resp.Diagnostics.Append(diags...)
+		if !uploaded {
+			return
+		}
+	} else if plan.RemoteChecksum.IsUnknown() {
+		// checksum_algorithm changed; hash the file already in DBFS.
+		resp.Diagnostics.Append(r.readRemoteChecksum(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	plan.DbfsPath = types.StringValue(fileInfo.Path)
-	plan.FileSize = types.Int64Value(fileInfo.FileSize)
-	plan.LastModified = types.StringValue(time.UnixMilli(int64(fileInfo.LastModified)).UTC().Format(time.RFC3339))
-	fmt.Println(plan)
 	diags = resp.State.Set(ctx, plan)
 	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
+	host, _, _ := r.clients.Databricks.override(plan.Workspace).resolve(plan.WorkspaceUrl, plan.Token)
+	resp.Diagnostics.Append(setDbfsIdentity(ctx, resp.Identity, host, plan.DbfsPath.ValueString())...)
 }
 
-// Delete deletes the resource and removes the Terraform state on success.
+// Delete deletes the resource and removes the Terraform state on success. A
+// file that is already gone is not an error. With skip_destroy set, the file
+// is left in DBFS.
 func (r *DatabricksDbfsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, r.typeName+".Delete")
+	defer span.End()
 
 	var state databricksDbfsResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -393,17 +1185,28 @@ func (r *DatabricksDbfsResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	adburl := state.AdbId.ValueString()
-	token := state.Token.ValueString()
-
-	localPath := state.LocalPath.ValueString()
-	deleteEndpoint := fmt.Sprintf("%v/api/2.0/dbfs/delete", adburl)
-
-	isOK, err := FileDelete(localPath, deleteEndpoint, token)
-	if err != nil && !isOK {
-		fmt.Println(err)
-		panic(fmt.Errorf("delete failed"))
-
+	workspace := r.clients.Databricks.override(state.Workspace)
+	adburl, token, err := workspace.resolve(state.WorkspaceUrl, state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	dbfsPath := dbfsStatePath(state)
+	if state.SkipDestroy.ValueBool() {
+		tflog.Info(ctx, "Leaving DBFS file in place as skip_destroy is set", map[string]interface{}{"dbfs_path": dbfsPath})
+		return
 	}
 
+	ctx, cancel := state.Timeouts.delete(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client := workspace.newClient(r.clients.HTTPClient, adburl, token)
+	err = client.DbfsDelete(ctx, dbfsPath, state.RecursiveDelete.ValueBool())
+	r.clients.Audit.Record(ctx, r.typeName, auditActionDelete, dbfsPath, err)
+	if err != nil && !databricks.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting DBFS file",
+			"Could not delete "+dbfsPath+": "+err.Error(),
+		)
+	}
 }
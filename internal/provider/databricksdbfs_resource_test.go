@@ -0,0 +1,804 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// writeLocalFile writes content to a file named name in a temporary
+// directory and returns its path.
+func writeLocalFile(t *testing.T, name, content string) string {
+	t.Helper()
+	fp := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(fp, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return fp
+}
+
+func md5Hex(data []byte) string {
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFileUpload(t *testing.T) {
+	tests := map[string]struct {
+		content   string
+		blockSize int
+		blocks    int
+	}{
+		"empty file":           {content: "", blockSize: 4, blocks: 0},
+		"single block":         {content: "abc", blockSize: 4, blocks: 1},
+		"exact block multiple": {content: "abcdefgh", blockSize: 4, blocks: 2},
+		"partial last block":   {content: "abcdefghij", blockSize: 4, blocks: 3},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			m := newMockDbfs(t)
+			fp := writeLocalFile(t, "lib.jar", tt.content)
+
+			err := FileUpload(context.Background(), m.server.Client(), fp, "/FileStore/lib.jar", m.server.URL, mockDatabricksToken, tt.blockSize)
+			if err != nil {
+				t.Fatalf("FileUpload: %v", err)
+			}
+			data, ok := m.file("/FileStore/lib.jar")
+			if !ok || string(data) != tt.content {
+				t.Errorf("uploaded %q (exists: %v), want %q", data, ok, tt.content)
+			}
+			if got := m.callCount("add-block"); got != tt.blocks {
+				t.Errorf("add-block called %d times, want %d", got, tt.blocks)
+			}
+		})
+	}
+}
+
+func TestFileUpload_overwrites(t *testing.T) {
+	m := newMockDbfs(t)
+	m.put("/FileStore/lib.jar", []byte("old content"))
+	fp := writeLocalFile(t, "lib.jar", "new")
+
+	if err := FileUpload(context.Background(), m.server.Client(), fp, "/FileStore/lib.jar", m.server.URL, mockDatabricksToken, databricks.DbfsBlockSize); err != nil {
+		t.Fatalf("FileUpload: %v", err)
+	}
+	if data, _ := m.file("/FileStore/lib.jar"); string(data) != "new" {
+		t.Errorf("content is %q, want %q", data, "new")
+	}
+}
+
+func TestFileUpload_missingLocalFile(t *testing.T) {
+	m := newMockDbfs(t)
+
+	err := FileUpload(context.Background(), m.server.Client(), filepath.Join(t.TempDir(), "missing.jar"), "/FileStore/lib.jar", m.server.URL, mockDatabricksToken, databricks.DbfsBlockSize)
+	if !os.IsNotExist(err) {
+		t.Errorf("got error %v, want a missing file error", err)
+	}
+	if got := m.callCount("create"); got != 0 {
+		t.Errorf("create called %d times, want 0", got)
+	}
+}
+
+func TestFileUpload_apiError(t *testing.T) {
+	m := newMockDbfs(t)
+	m.failNext("add-block", http.StatusBadRequest)
+	fp := writeLocalFile(t, "lib.jar", "content")
+
+	err := FileUpload(context.Background(), m.server.Client(), fp, "/FileStore/lib.jar", m.server.URL, mockDatabricksToken, databricks.DbfsBlockSize)
+	if err == nil || !strings.Contains(err.Error(), "INTERNAL_ERROR") {
+		t.Errorf("got error %v, want the API error code", err)
+	}
+	// The upload handle is closed rather than leaked.
+	if got := m.callCount("close"); got != 1 {
+		t.Errorf("close called %d times, want 1", got)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.uploads) != 0 {
+		t.Errorf("%d uploads left open", len(m.uploads))
+	}
+}
+
+func TestFileUpload_invalidToken(t *testing.T) {
+	m := newMockDbfs(t)
+	fp := writeLocalFile(t, "lib.jar", "content")
+
+	err := FileUpload(context.Background(), m.server.Client(), fp, "/FileStore/lib.jar", m.server.URL, "wrong", databricks.DbfsBlockSize)
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("got error %v, want status 401", err)
+	}
+}
+
+func TestFileUpload_waitsForStatus(t *testing.T) {
+	m := newMockDbfs(t)
+	m.failNext("get-status", http.StatusNotFound)
+	fp := writeLocalFile(t, "lib.jar", "content")
+
+	client := databricks.NewClient(m.server.Client(), m.server.URL, mockDatabricksToken).WithConsistencyTimeout(5 * time.Second)
+	if err := fileUpload(context.Background(), client, fp, "/FileStore/lib.jar", databricks.DbfsBlockSize); err != nil {
+		t.Fatalf("fileUpload: %v", err)
+	}
+	if got := m.callCount("get-status"); got != 2 {
+		t.Errorf("get-status called %d times, want 2", got)
+	}
+}
+
+func TestFileStatus(t *testing.T) {
+	m := newMockDbfs(t)
+	m.put("/FileStore/jars/lib.jar", []byte("content"))
+
+	status, err := FileStatus(context.Background(), m.server.Client(), m.server.URL, "/FileStore/jars/lib.jar", mockDatabricksToken)
+	if err != nil {
+		t.Fatalf("FileStatus: %v", err)
+	}
+	if status.Path != "/FileStore/jars/lib.jar" || status.FileSize != 7 || status.LastModified == 0 {
+		t.Errorf("got status %+v", status)
+	}
+}
+
+func TestFileStatus_notFound(t *testing.T) {
+	m := newMockDbfs(t)
+
+	_, err := FileStatus(context.Background(), m.server.Client(), m.server.URL, "/FileStore/missing.jar", mockDatabricksToken)
+	if !databricks.IsNotFound(err) {
+		t.Errorf("got error %v, want a not found error", err)
+	}
+}
+
+func TestFileStatus_directory(t *testing.T) {
+	m := newMockDbfs(t)
+	m.put("/FileStore/jars/lib.jar", []byte("content"))
+
+	_, err := FileStatus(context.Background(), m.server.Client(), m.server.URL, "/FileStore/jars", mockDatabricksToken)
+	if err == nil || databricks.IsNotFound(err) {
+		t.Errorf("got error %v, want a directory error", err)
+	}
+}
+
+func TestFileDelete(t *testing.T) {
+	m := newMockDbfs(t)
+	m.put("/FileStore/lib.jar", []byte("content"))
+
+	if err := FileDelete(context.Background(), m.server.Client(), m.server.URL, "/FileStore/lib.jar", mockDatabricksToken); err != nil {
+		t.Fatalf("FileDelete: %v", err)
+	}
+	if _, ok := m.file("/FileStore/lib.jar"); ok {
+		t.Error("file still exists")
+	}
+}
+
+func TestFileDelete_notFound(t *testing.T) {
+	m := newMockDbfs(t)
+
+	err := FileDelete(context.Background(), m.server.Client(), m.server.URL, "/FileStore/missing.jar", mockDatabricksToken)
+	if !databricks.IsNotFound(err) {
+		t.Errorf("got error %v, want a not found error", err)
+	}
+}
+
+func TestFileContentMD5(t *testing.T) {
+	m := newMockDbfs(t)
+	// Larger than a read chunk, so the content is read in two calls.
+	content := bytes.Repeat([]byte("0123456789abcdef"), dbfsReadChunkSize/16+100)
+	m.put("/FileStore/lib.jar", content)
+
+	sum, err := FileContentMD5(context.Background(), m.server.Client(), m.server.URL, "/FileStore/lib.jar", mockDatabricksToken)
+	if err != nil {
+		t.Fatalf("FileContentMD5: %v", err)
+	}
+	if sum != md5Hex(content) {
+		t.Errorf("got md5 %s, want %s", sum, md5Hex(content))
+	}
+	if got := m.callCount("read"); got != 2 {
+		t.Errorf("read called %d times, want 2", got)
+	}
+}
+
+// testDbfsProvider returns a provider whose databricks block points at a
+// new mock workspace.
+func testDbfsProvider(t *testing.T) (*testProvider, *mockDbfs) {
+	t.Helper()
+	m := newMockDbfs(t)
+	p := newTestProvider(t, map[string]interface{}{
+		"databricks": map[string]interface{}{
+			"host":  m.server.URL,
+			"token": mockDatabricksToken,
+		},
+	})
+	return p, m
+}
+
+func TestDatabricksDbfsFileResource_lifecycle(t *testing.T) {
+	for _, typeName := range []string{databricksDbfsFileTypeName, databricksDbfsLegacyTypeName} {
+		t.Run(typeName, func(t *testing.T) {
+			p, m := testDbfsProvider(t)
+			const dbfsPath = "/FileStore/test/app.jar"
+			localPath := writeLocalFile(t, "app.jar", "version 1")
+			config := map[string]interface{}{
+				"local_path": localPath,
+				"dbfs_path":  dbfsPath,
+			}
+
+			testLifecycle(t, p, lifecycleTest{
+				typeName: typeName,
+				config:   config,
+				created: func(t *testing.T, state tftypes.Value) {
+					if data, _ := m.file(dbfsPath); string(data) != "version 1" {
+						t.Fatalf("uploaded %q, want %q", data, "version 1")
+					}
+					if got := stringAttr(t, state, "id"); got != dbfsPath {
+						t.Errorf("id is %q, want %q", got, dbfsPath)
+					}
+					if got := int64Attr(t, state, "file_size"); got != 9 {
+						t.Errorf("file_size is %d, want 9", got)
+					}
+					if got := stringAttr(t, state, "content_md5"); got != md5Hex([]byte("version 1")) {
+						t.Errorf("content_md5 is %q, want the md5 of the local file", got)
+					}
+				},
+				identity:      true,
+				refreshResets: []string{"content_changed"},
+				updates: []lifecycleUpdate{{
+					prepare: func(t *testing.T) {
+						if err := os.WriteFile(localPath, []byte("version 2, longer"), 0o600); err != nil {
+							t.Fatal(err)
+						}
+					},
+					config: config,
+					planned: func(t *testing.T, planned tftypes.Value) {
+						if !boolAttr(t, planned, "content_changed") {
+							t.Error("content_changed is false after a local change")
+						}
+					},
+					check: func(t *testing.T, state tftypes.Value) {
+						if data, _ := m.file(dbfsPath); string(data) != "version 2, longer" {
+							t.Fatalf("uploaded %q, want %q", data, "version 2, longer")
+						}
+						if got := int64Attr(t, state, "file_size"); got != 17 {
+							t.Errorf("file_size is %d, want 17", got)
+						}
+					},
+				}},
+				importID:     func(tftypes.Value) string { return m.server.URL + "|" + dbfsPath },
+				importVerify: []string{"dbfs_path", "id", "file_size", "content_md5"},
+				destroyed: func(t *testing.T, _ tftypes.Value) {
+					if _, ok := m.file(dbfsPath); ok {
+						t.Error("file still exists after destroy")
+					}
+				},
+			})
+		})
+	}
+}
+
+func TestDatabricksDbfsFileResource_skipDestroy(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/shared.jar"
+	config := p.config(typeName, map[string]interface{}{
+		"local_path":   writeLocalFile(t, "shared.jar", "shared"),
+		"dbfs_path":    dbfsPath,
+		"skip_destroy": true,
+	})
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	state := p.apply(typeName, null, config)
+	state = p.apply(typeName, state, null)
+	if !state.IsNull() {
+		t.Errorf("state after destroy is %s, want null", state)
+	}
+	if _, ok := m.file(dbfsPath); !ok {
+		t.Error("file deleted by destroy with skip_destroy set")
+	}
+	if n := m.callCount("delete"); n != 0 {
+		t.Errorf("destroy called delete %d times, want 0", n)
+	}
+}
+
+func TestDatabricksDbfsFileResource_recursiveDelete(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/app.jar"
+	config := p.config(typeName, map[string]interface{}{
+		"local_path":       writeLocalFile(t, "app.jar", "content"),
+		"dbfs_path":        dbfsPath,
+		"recursive_delete": true,
+	})
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	state := p.apply(typeName, null, config)
+	// A directory replaced the file outside Terraform.
+	m.remove(dbfsPath)
+	m.put(dbfsPath+"/part-0", []byte("part"))
+
+	p.apply(typeName, state, null)
+	if _, ok := m.file(dbfsPath + "/part-0"); ok {
+		t.Error("directory still exists after destroy with recursive_delete set")
+	}
+}
+
+func TestDatabricksDbfsFileResource_knownSizeInPlan(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	localPath := writeLocalFile(t, "app.jar", "version 1")
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+	})
+	planned, diags := p.plan(typeName, null, config)
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Fatalf("plan: %s", msg)
+	}
+	if got := int64Attr(t, planned, "file_size"); got != 9 {
+		t.Errorf("planned file_size is %d, want the size of the local file", got)
+	}
+	state := p.apply(typeName, null, config)
+
+	// A change that does not touch the content keeps the size and
+	// modification time, and does not upload the file again.
+	config = p.config(typeName, map[string]interface{}{
+		"local_path":      localPath,
+		"drift_detection": driftDetectionContent,
+	})
+	planned, diags = p.plan(typeName, state, config)
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Fatalf("plan: %s", msg)
+	}
+	if !stateAttr(t, planned, "modification_time").Equal(stateAttr(t, state, "modification_time")) {
+		t.Errorf("planned modification_time is %s, want that of the state", stateAttr(t, planned, "modification_time"))
+	}
+	if got := int64Attr(t, planned, "file_size"); got != 9 {
+		t.Errorf("planned file_size is %d, want that of the state", got)
+	}
+	state = p.apply(typeName, state, config)
+	if got := m.callCount("create"); got != 1 {
+		t.Errorf("%d uploads, want only that of create", got)
+	}
+
+	// New content is planned with the size of the new local file and a
+	// modification time known only once it is uploaded.
+	if err := os.WriteFile(localPath, []byte("version 2, longer"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	planned, diags = p.plan(typeName, state, config)
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Fatalf("plan: %s", msg)
+	}
+	if got := int64Attr(t, planned, "file_size"); got != 17 {
+		t.Errorf("planned file_size is %d, want the size of the new local file", got)
+	}
+	if got := stateAttr(t, planned, "modification_time"); got.IsKnown() {
+		t.Errorf("planned modification_time is %s, want unknown", got)
+	}
+}
+
+func TestDatabricksDbfsFileResource_deletedOutsideTerraform(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": writeLocalFile(t, "app.jar", "content"),
+	})
+
+	state := p.apply(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+	if got := stringAttr(t, state, "dbfs_path"); got != "/FileStore/jars/init-libs/app.jar" {
+		t.Errorf("dbfs_path is %q, want the default path", got)
+	}
+	m.mu.Lock()
+	delete(m.files, "/FileStore/jars/init-libs/app.jar")
+	m.mu.Unlock()
+
+	if state = p.refresh(typeName, state); !state.IsNull() {
+		t.Errorf("refreshed state is %s, want null", state)
+	}
+}
+
+func TestDatabricksDbfsFileResource_missingLocalFile(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": filepath.Join(t.TempDir(), "missing.jar"),
+	})
+
+	_, diags := p.plan(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "missing.jar") {
+		t.Errorf("got diagnostics %q, want an error naming the local file", msg)
+	}
+}
+
+func TestDatabricksDbfsFileResource_refreshWithoutLocalFile(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	localPath := writeLocalFile(t, "app.jar", "content")
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+	})
+	state := p.apply(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+	const dbfsPath = "/FileStore/jars/init-libs/app.jar"
+
+	// The local file is gone and the remote one was rewritten: refresh
+	// reads the file recorded in state and records the remote content.
+	if err := os.Remove(localPath); err != nil {
+		t.Fatal(err)
+	}
+	m.put(dbfsPath, []byte("rewritten remotely"))
+	if msg := errorDiagnostics(p.validate(typeName, config)); msg != "" {
+		t.Errorf("validate: %s", msg)
+	}
+	state = p.refresh(typeName, state)
+	if got := stringAttr(t, state, "id"); got != dbfsPath {
+		t.Errorf("id is %q, want %q", got, dbfsPath)
+	}
+	if got := stringAttr(t, state, "dbfs_path"); got != dbfsPath {
+		t.Errorf("dbfs_path is %q, want %q", got, dbfsPath)
+	}
+	if got := int64Attr(t, state, "file_size"); got != 18 {
+		t.Errorf("file_size is %d, want the size of the remote file", got)
+	}
+	if got := stringAttr(t, state, "content_md5"); got != md5Hex([]byte("rewritten remotely")) {
+		t.Errorf("content_md5 is %q, want the md5 of the remote file", got)
+	}
+
+	// Planning an upload still needs the local file.
+	_, diags := p.plan(typeName, state, config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "app.jar") {
+		t.Errorf("got diagnostics %q, want an error naming the local file", msg)
+	}
+}
+
+func TestDatabricksDbfsFileResource_upgradeStateV0(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	for _, tc := range []struct {
+		modified, want string
+	}{
+		{"2024-03-01T12:00:00+01:00", "2024-03-01T11:00:00Z"},
+		{"1709290800000", "2024-03-01T11:00:00Z"},
+		{"null", ""},
+	} {
+		for _, typeName := range []string{databricksDbfsFileTypeName, databricksDbfsLegacyTypeName} {
+			state := p.upgradeState(typeName, 0, `{
+				"id": "/FileStore/test/app.jar",
+				"adb_id": "https://adb-1234.5.azuredatabricks.net",
+				"local_path": "app.jar",
+				"dbfs_path": "/FileStore/test/app.jar",
+				"file_size": 7,
+				"modification_time": "`+tc.modified+`",
+				"content_md5": "`+md5Hex([]byte("content"))+`",
+				"drift_detection": "metadata",
+				"content_changed": false,
+				"upload_block_size": 1048576,
+				"overwrite": false
+			}`)
+			if got := stringAttr(t, state, "workspace_url"); got != "https://adb-1234.5.azuredatabricks.net" {
+				t.Errorf("%s: workspace_url is %q, want the former adb_id", typeName, got)
+			}
+			if got := stateAttr(t, state, "modification_time"); tc.want == "" && !got.IsNull() {
+				t.Errorf("%s: modification_time %q upgraded to %s, want null", typeName, tc.modified, got)
+			} else if tc.want != "" && stringAttr(t, state, "modification_time") != tc.want {
+				t.Errorf("%s: modification_time %q upgraded to %s, want %q", typeName, tc.modified, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestDatabricksDbfsFileResource_localPathIsDirectory(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": t.TempDir(),
+		"dbfs_path":  "/FileStore/test/app.jar",
+	})
+
+	_, diags := p.plan(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "not a regular file") {
+		t.Errorf("got diagnostics %q, want an error about the local path", msg)
+	}
+}
+
+func TestDatabricksDbfsFileResource_conflictingDbfsPath(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/app.jar"
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	first := p.config(typeName, map[string]interface{}{
+		"local_path": writeLocalFile(t, "app.jar", "first"),
+		"dbfs_path":  dbfsPath,
+	})
+	if _, diags := p.plan(typeName, null, first); errorDiagnostics(diags) != "" {
+		t.Fatalf("plan: %s", errorDiagnostics(diags))
+	}
+	second := p.config(typeName, map[string]interface{}{
+		"local_path": writeLocalFile(t, "other.jar", "second"),
+		"dbfs_path":  dbfsPath,
+	})
+	_, diags := p.plan(typeName, null, second)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "Conflicting DBFS path") {
+		t.Errorf("got diagnostics %q, want a conflicting path error", msg)
+	}
+
+	// Planning the first instance again is no conflict.
+	if _, diags := p.plan(typeName, null, first); errorDiagnostics(diags) != "" {
+		t.Errorf("second plan: %s", errorDiagnostics(diags))
+	}
+}
+
+func TestDatabricksDbfsFileResource_invalidDbfsPath(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	localPath := writeLocalFile(t, "app.jar", "content")
+	for _, tc := range []struct {
+		dbfsPath, want string
+	}{
+		{"FileStore/app.jar", "is not an absolute DBFS path"},
+		{"/FileStore//app.jar", "normalized"},
+	} {
+		config := p.config(typeName, map[string]interface{}{
+			"local_path": localPath,
+			"dbfs_path":  tc.dbfsPath,
+		})
+		_, diags := p.plan(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+		if msg := errorDiagnostics(diags); !strings.Contains(msg, tc.want) {
+			t.Errorf("%s: got diagnostics %q, want an error containing %q", tc.dbfsPath, msg, tc.want)
+		}
+	}
+}
+
+func TestDatabricksDbfsFileResource_existingFile(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/app.jar"
+	localPath := writeLocalFile(t, "app.jar", "local")
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+		"dbfs_path":  dbfsPath,
+	})
+	m.put(dbfsPath, []byte("remote"))
+	_, diags := p.plan(typeName, null, config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "already exists") || !strings.Contains(msg, "|"+dbfsPath) {
+		t.Errorf("got diagnostics %q, want an error suggesting import", msg)
+	}
+
+	// The same content is adopted.
+	m.put(dbfsPath, []byte("local"))
+	if _, diags := p.plan(typeName, null, config); errorDiagnostics(diags) != "" {
+		t.Errorf("plan with identical content: %s", errorDiagnostics(diags))
+	}
+
+	m.put(dbfsPath, []byte("remote"))
+	config = p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+		"dbfs_path":  dbfsPath,
+		"overwrite":  true,
+	})
+	_, diags = p.plan(typeName, null, config)
+	if msg := warningDiagnostics(diags); !strings.Contains(msg, "will be overwritten") || !strings.Contains(msg, md5Hex([]byte("remote"))) {
+		t.Errorf("got warnings %q, want a warning with the md5 of the remote file", msg)
+	}
+	p.apply(typeName, null, config)
+	if data, _ := m.file(dbfsPath); string(data) != "local" {
+		t.Errorf("uploaded %q, want %q", data, "local")
+	}
+}
+
+func TestDatabricksDbfsFileResource_dbfsPathTemplate(t *testing.T) {
+	m := newMockDbfs(t)
+	p := newTestProvider(t, map[string]interface{}{
+		"databricks": map[string]interface{}{
+			"host":                      m.server.URL,
+			"token":                     mockDatabricksToken,
+			"dbfs_path_prefix_template": "/FileStore/jars/{env}/{md5}",
+			"dbfs_path_variables": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"env": tftypes.NewValue(tftypes.String, "prod"),
+			}),
+		},
+	})
+	typeName := databricksDbfsFileTypeName
+	localPath := writeLocalFile(t, "app.jar", "content")
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	// The provider template is a directory, known at plan time.
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+	})
+	want := "/FileStore/jars/prod/" + md5Hex([]byte("content")) + "/app.jar"
+	planned, diags := p.plan(typeName, null, config)
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Fatalf("plan: %s", msg)
+	}
+	if got := stringAttr(t, planned, "dbfs_path"); got != want {
+		t.Errorf("planned dbfs_path is %q, want %q", got, want)
+	}
+	p.apply(typeName, null, config)
+	if data, _ := m.file(want); string(data) != "content" {
+		t.Errorf("uploaded %q to %s, want %q", data, want, "content")
+	}
+
+	// The template of the resource overrides that of the provider.
+	config = p.config(typeName, map[string]interface{}{
+		"local_path":                writeLocalFile(t, "lib.jar", "lib"),
+		"dbfs_path_prefix_template": "/FileStore/{env}/lib-{filename}",
+	})
+	planned, _ = p.plan(typeName, null, config)
+	if got := stringAttr(t, planned, "dbfs_path"); got != "/FileStore/prod/lib-lib.jar" {
+		t.Errorf("planned dbfs_path is %q, want %q", got, "/FileStore/prod/lib-lib.jar")
+	}
+
+	config = p.config(typeName, map[string]interface{}{
+		"local_path":                localPath,
+		"dbfs_path_prefix_template": "/FileStore/jars/{version}",
+	})
+	_, diags = p.plan(typeName, null, config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "{version}") {
+		t.Errorf("got diagnostics %q, want an error naming the missing placeholder", msg)
+	}
+}
+
+func TestDatabricksDbfsFileResource_remoteChecksum(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/app.jar"
+	localPath := writeLocalFile(t, "app.jar", "content")
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": localPath,
+		"dbfs_path":  dbfsPath,
+	})
+	state := p.apply(typeName, null, config)
+	if got := stringAttr(t, state, "remote_checksum"); got != md5Hex([]byte("content")) {
+		t.Errorf("remote_checksum is %q, want the md5 of the uploaded file", got)
+	}
+
+	// Changing the algorithm hashes the file again without uploading it.
+	config = p.config(typeName, map[string]interface{}{
+		"local_path":         localPath,
+		"dbfs_path":          dbfsPath,
+		"checksum_algorithm": "sha256",
+	})
+	blocks := m.callCount("add-block")
+	state = p.apply(typeName, state, config)
+	sum := sha256.Sum256([]byte("content"))
+	if got := stringAttr(t, state, "remote_checksum"); got != hex.EncodeToString(sum[:]) {
+		t.Errorf("remote_checksum is %q, want the sha256 of the uploaded file", got)
+	}
+	if n := m.callCount("add-block"); n != blocks {
+		t.Errorf("changing checksum_algorithm uploaded %d blocks, want none", n-blocks)
+	}
+}
+
+func TestDatabricksDbfsFileResource_verificationFails(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	typeName := databricksDbfsFileTypeName
+	config := p.config(typeName, map[string]interface{}{
+		"local_path":         writeLocalFile(t, "app.jar", "content"),
+		"dbfs_path":          "/FileStore/test/app.jar",
+		"checksum_algorithm": "sha256",
+	})
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	m.corruptNext()
+	state, diags := p.applyDiagnostics(typeName, null, config)
+	if msg := errorDiagnostics(diags); !strings.Contains(msg, "verification failed") {
+		t.Errorf("got diagnostics %q, want a verification error", msg)
+	}
+	// The file is kept in state, for Terraform to taint and replace.
+	if state.IsNull() {
+		t.Error("state is null after a failed verification, want the uploaded file")
+	}
+}
+
+func TestDatabricksDbfsFileResource_apiMetrics(t *testing.T) {
+	statsd, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer statsd.Close()
+
+	m := newMockDbfs(t)
+	p := newTestProvider(t, map[string]interface{}{
+		"api_metrics":                true,
+		"api_metrics_statsd_address": statsd.LocalAddr().String(),
+		"databricks": map[string]interface{}{
+			"host":  m.server.URL,
+			"token": mockDatabricksToken,
+		},
+	})
+	typeName := databricksDbfsFileTypeName
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": writeLocalFile(t, "app.jar", "content"),
+		"dbfs_path":  "/FileStore/test/app.jar",
+	})
+
+	// The first close is retried.
+	m.failNext("close", http.StatusServiceUnavailable)
+	p.apply(typeName, tftypes.NewValue(p.resourceType(typeName), nil), config)
+
+	var received strings.Builder
+	buf := make([]byte, 64<<10)
+	for !strings.Contains(received.String(), "close.retries") {
+		if err := statsd.SetReadDeadline(time.Now().Add(5 * time.Second)); err != nil {
+			t.Fatal(err)
+		}
+		n, _, err := statsd.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("reading statsd metrics: %v; received %q", err, received.String())
+		}
+		received.Write(buf[:n])
+		received.WriteByte('\n')
+	}
+	for _, metric := range []string{
+		"mrl.api.post_api_2_0_dbfs_create.calls:1|c",
+		"mrl.api.post_api_2_0_dbfs_close.retries:1|c",
+	} {
+		if !strings.Contains(received.String(), metric) {
+			t.Errorf("statsd received %q, want %s", received.String(), metric)
+		}
+	}
+}
+
+func TestDatabricksDbfsFileResource_workspaceBlock(t *testing.T) {
+	m := newMockDbfs(t)
+	other := newMockDbfs(t)
+	p := newTestProvider(t, map[string]interface{}{
+		"databricks": map[string]interface{}{
+			"host":  m.server.URL,
+			"token": mockDatabricksToken,
+			"workspace": []map[string]interface{}{
+				{"host": other.server.URL + "/", "token": mockDatabricksToken},
+			},
+		},
+	})
+	typeName := databricksDbfsFileTypeName
+	const dbfsPath = "/FileStore/test/app.jar"
+	config := p.config(typeName, map[string]interface{}{
+		"local_path": writeLocalFile(t, "app.jar", "content"),
+		"dbfs_path":  dbfsPath,
+		"workspace": map[string]interface{}{
+			"host": other.server.URL,
+		},
+	})
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	state := p.apply(typeName, null, config)
+	if got := stateAttr(t, state, "workspace"); len(got.Type().(tftypes.Object).AttributeTypes) != 1 {
+		t.Errorf("workspace block in state is %s, want its host only", got)
+	}
+	if data, _ := other.file(dbfsPath); string(data) != "content" {
+		t.Errorf("uploaded %q to the workspace of the block, want %q", data, "content")
+	}
+	if _, ok := m.file(dbfsPath); ok {
+		t.Error("file uploaded to the workspace of the provider")
+	}
+
+	// A state written before identities existed has none stored with it.
+	delete(p.identities, identityKey(typeName, state))
+	other.remove(dbfsPath)
+	if state = p.refresh(typeName, state); !state.IsNull() {
+		t.Errorf("state after the file was deleted from the workspace of the block is %s, want null", state)
+	}
+
+	config = p.config(typeName, map[string]interface{}{
+		"local_path":    writeLocalFile(t, "app.jar", "content"),
+		"workspace_url": other.server.URL,
+		"workspace": map[string]interface{}{
+			"host": other.server.URL,
+		},
+	})
+	if _, diags := p.plan(typeName, null, config); !strings.Contains(errorDiagnostics(diags), "Conflicting workspace") {
+		t.Errorf("got diagnostics %q, want a conflict of workspace_url and workspace.host", errorDiagnostics(diags))
+	}
+}
@@ -0,0 +1,577 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksDbfsDirectoryResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksDbfsDirectoryResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksDbfsDirectoryResource{}
+	_ resource.ResourceWithValidateConfig   = &DatabricksDbfsDirectoryResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksDbfsDirectoryResource{}
+)
+
+// NewDatabricksDbfsDirectoryResource is a helper function to simplify the provider implementation.
+func NewDatabricksDbfsDirectoryResource() resource.Resource {
+	return &DatabricksDbfsDirectoryResource{}
+}
+
+// DatabricksDbfsDirectoryResource is the resource implementation.
+type DatabricksDbfsDirectoryResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksDbfsDirectoryResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	AdbId        URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue                       `tfsdk:"workspace_url"`
+	Token        types.String                   `tfsdk:"token"`
+	LocalDir     types.String                   `tfsdk:"local_dir"`
+	DbfsPrefix   DbfsPathValue                  `tfsdk:"dbfs_prefix"`
+	Parallelism  types.Int64                    `tfsdk:"parallelism"`
+	Files        types.Map                      `tfsdk:"files"`
+	Workspace    *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// dbfsDirectoryFileModel is a single uploaded file, keyed by its path relative
+// to the local directory.
+type dbfsDirectoryFileModel struct {
+	ContentMd5 types.String `tfsdk:"content_md5"`
+	FileSize   types.Int64  `tfsdk:"file_size"`
+}
+
+// dbfsDirectoryFileType is the element type of the files attribute.
+var dbfsDirectoryFileType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"content_md5": types.StringType,
+	"file_size":   types.Int64Type,
+}}
+
+// files returns the known files of the model, keyed by relative path.
+func (m *databricksDbfsDirectoryResourceModel) files(ctx context.Context) (map[string]dbfsDirectoryFileModel, diag.Diagnostics) {
+	files := map[string]dbfsDirectoryFileModel{}
+	if m.Files.IsNull() || m.Files.IsUnknown() {
+		return files, nil
+	}
+	diags := m.Files.ElementsAs(ctx, &files, false)
+	return files, diags
+}
+
+// setFiles records files in the model.
+func (m *databricksDbfsDirectoryResourceModel) setFiles(ctx context.Context, files map[string]dbfsDirectoryFileModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Files, diags = types.MapValueFrom(ctx, dbfsDirectoryFileType, files)
+	return diags
+}
+
+// dbfsPath returns the DBFS path a file of the directory is uploaded to.
+func (m *databricksDbfsDirectoryResourceModel) dbfsPath(rel string) string {
+	return joinDbfsPath(m.DbfsPrefix.ValueNormalized(), rel)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksDbfsDirectoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksDbfsDirectoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs_directory"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksDbfsDirectoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Mirrors a local directory to a DBFS prefix, with bounded parallelism. Only files whose content changed are uploaded again, and files deleted locally are deleted from DBFS.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "DBFS prefix the files are uploaded to",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"local_dir": schema.StringAttribute{
+				Required:    true,
+				Description: "Local directory to upload. Every regular file below it is uploaded, keeping its path relative to the directory",
+			},
+			"dbfs_prefix": schema.StringAttribute{
+				CustomType: DbfsPathType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(DbfsPathType{}),
+				},
+				Validators:  []validator.String{DbfsPathNormalized()},
+				Description: "Absolute, normalized DBFS directory the files are uploaded to. Changing it deletes the files from the old prefix and uploads them to the new one",
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultDbfsFilesParallelism),
+				Description: "Maximum number of concurrent uploads and deletes. Uploads also count toward max_parallel_uploads of the provider. Defaults to 8",
+			},
+			"files": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Uploaded files, keyed by path relative to local_dir with / separators",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"content_md5": schema.StringAttribute{
+							Computed:    true,
+							Description: "md5 hash of the local file, computed at plan time",
+						},
+						"file_size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Size of the uploaded file",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksDbfsDirectoryResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(path.Root("workspace_url"), path.Root("adb_id")),
+	}
+}
+
+// ValidateConfig checks the parallelism.
+func (r *DatabricksDbfsDirectoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var parallelism types.Int64
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("parallelism"), &parallelism)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !parallelism.IsNull() && !parallelism.IsUnknown() && parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("parallelism"), "Invalid parallelism", "parallelism must be at least 1.")
+	}
+}
+
+// hashLocalDir returns the hex md5 of every regular file below dir, keyed by
+// its slash-separated path relative to dir. Symbolic links to files are
+// followed; links to directories are skipped.
+func hashLocalDir(dir string) (map[string]string, error) {
+	hashes := map[string]string{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		sum, err := fileMD5(p)
+		if err != nil {
+			return err
+		}
+		hashes[filepath.ToSlash(rel)] = sum
+		return nil
+	})
+	return hashes, err
+}
+
+// ModifyPlan hashes the local directory so that only changed files are
+// uploaded, and warns about the files the apply deletes.
+func (r *DatabricksDbfsDirectoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state databricksDbfsDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.LocalDir.IsUnknown() {
+		plan.Files = types.MapUnknown(dbfsDirectoryFileType)
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	hashes, err := hashLocalDir(plan.LocalDir.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("local_dir"),
+			"Error reading local directory",
+			"Could not hash the files below "+plan.LocalDir.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Files uploaded to another prefix are not kept, so only the state of
+	// the same prefix is carried over.
+	prior := map[string]dbfsDirectoryFileModel{}
+	samePrefix, _ := state.DbfsPrefix.StringSemanticEquals(ctx, plan.DbfsPrefix)
+	if !req.State.Raw.IsNull() && samePrefix {
+		var diags diag.Diagnostics
+		prior, diags = state.files(ctx)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		var deleted []string
+		for rel := range prior {
+			if _, ok := hashes[rel]; !ok {
+				deleted = append(deleted, plan.dbfsPath(rel))
+			}
+		}
+		if len(deleted) > 0 {
+			sort.Strings(deleted)
+			resp.Diagnostics.AddAttributeWarning(
+				path.Root("local_dir"),
+				"DBFS files will be deleted",
+				fmt.Sprintf("%d files are no longer below %s and will be deleted:\n%s", len(deleted), plan.LocalDir.ValueString(), strings.Join(deleted, "\n")),
+			)
+		}
+	}
+
+	planned := make(map[string]dbfsDirectoryFileModel, len(hashes))
+	for rel, sum := range hashes {
+		file := dbfsDirectoryFileModel{ContentMd5: types.StringValue(sum), FileSize: types.Int64Unknown()}
+		if before, ok := prior[rel]; ok && before.ContentMd5.ValueString() == sum {
+			file.FileSize = before.FileSize
+		}
+		planned[rel] = file
+	}
+	resp.Diagnostics.Append(plan.setFiles(ctx, planned)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// sync uploads the files of plan that differ from prior and deletes the files
+// of prior missing from plan. Files that were processed successfully are
+// recorded in plan even when others fail, so a partial apply is not repeated.
+func (r *DatabricksDbfsDirectoryResource) sync(ctx context.Context, plan, prior *databricksDbfsDirectoryResourceModel, action string) error {
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+
+	files, diags := plan.files(ctx)
+	priorFiles, priorDiags := prior.files(ctx)
+	if diags.HasError() || priorDiags.HasError() {
+		return errors.New("could not read the tracked files")
+	}
+
+	var uploads, deletes []string
+	for rel, file := range files {
+		if before, ok := priorFiles[rel]; ok && before.ContentMd5.Equal(file.ContentMd5) && !before.FileSize.IsNull() {
+			file.FileSize = before.FileSize
+			files[rel] = file
+			continue
+		}
+		uploads = append(uploads, rel)
+	}
+	for rel := range priorFiles {
+		if _, ok := files[rel]; !ok {
+			deletes = append(deletes, rel)
+		}
+	}
+	sort.Strings(uploads)
+	sort.Strings(deletes)
+
+	var mu sync.Mutex
+	failed := map[string]bool{}
+	uploadErr := databricks.ForEach(uploads, plan.Parallelism.ValueInt64(), func(rel string) error {
+		want := files[rel].ContentMd5.ValueString()
+		err := func() error {
+			src, err := os.Open(filepath.Join(plan.LocalDir.ValueString(), filepath.FromSlash(rel)))
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+
+			sum, err := client.DbfsPut(ctx, plan.dbfsPath(rel), src)
+			if err != nil {
+				return err
+			}
+			if sum != want {
+				return fmt.Errorf("uploaded content has md5 %v but the planned md5 is %v; the local file changed during apply", sum, want)
+			}
+			return nil
+		}()
+		r.audit.Record(ctx, "mrl_databricks_dbfs_directory", action, plan.dbfsPath(rel), err)
+
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			failed[rel] = true
+		}
+		return err
+	})
+
+	deleteErr := databricks.ForEach(deletes, plan.Parallelism.ValueInt64(), func(rel string) error {
+		err := client.DbfsDelete(ctx, prior.dbfsPath(rel), false)
+		if databricks.IsNotFound(err) {
+			err = nil
+		}
+		r.audit.Record(ctx, "mrl_databricks_dbfs_directory", auditActionDelete, prior.dbfsPath(rel), err)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			failed[rel] = true
+		}
+		return err
+	})
+
+	// Record the outcome per file: uploaded files get their remote size,
+	// failed uploads keep their prior state and failed deletes stay tracked.
+	for _, rel := range uploads {
+		if failed[rel] {
+			if before, ok := priorFiles[rel]; ok {
+				files[rel] = before
+			} else {
+				delete(files, rel)
+			}
+			continue
+		}
+		file := files[rel]
+		info, err := client.DbfsGetStatus(ctx, plan.dbfsPath(rel))
+		if err != nil {
+			uploadErr = errors.Join(uploadErr, fmt.Errorf("%s: %w", plan.dbfsPath(rel), err))
+			file.FileSize = types.Int64Null()
+		} else {
+			file.FileSize = types.Int64Value(info.FileSize)
+		}
+		files[rel] = file
+	}
+	for _, rel := range deletes {
+		if failed[rel] {
+			files[rel] = priorFiles[rel]
+		}
+	}
+
+	if diags := plan.setFiles(ctx, files); diags.HasError() {
+		return errors.New("could not record the uploaded files")
+	}
+	plan.Id = types.StringValue(plan.DbfsPrefix.ValueNormalized())
+	return errors.Join(uploadErr, deleteErr)
+}
+
+// Create a new resource.
+func (r *DatabricksDbfsDirectoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_directory.Create")
+	defer span.End()
+
+	var plan databricksDbfsDirectoryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	prior := databricksDbfsDirectoryResourceModel{Files: types.MapNull(dbfsDirectoryFileType)}
+	if err := r.sync(withAuditRequestID(ctx), &plan, &prior, auditActionCreate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading DBFS directory",
+			"Could not upload every file of "+plan.LocalDir.ValueString()+":\n"+err.Error(),
+		)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksDbfsDirectoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_directory.Read")
+	defer span.End()
+
+	var state databricksDbfsDirectoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+
+	files, diags := state.files(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	paths := make([]string, 0, len(files))
+	for rel := range files {
+		paths = append(paths, rel)
+	}
+
+	var mu sync.Mutex
+	err = databricks.ForEach(paths, state.Parallelism.ValueInt64(), func(rel string) error {
+		info, err := client.DbfsGetStatus(ctx, state.dbfsPath(rel))
+
+		mu.Lock()
+		defer mu.Unlock()
+		file := files[rel]
+		switch {
+		case databricks.IsNotFound(err):
+			// Dropping the file from state makes the next plan upload it.
+			delete(files, rel)
+			return nil
+		case err != nil:
+			return err
+		case !file.FileSize.Equal(types.Int64Value(info.FileSize)):
+			// The file was rewritten outside Terraform.
+			file.ContentMd5 = types.StringNull()
+		}
+		file.FileSize = types.Int64Value(info.FileSize)
+		files[rel] = file
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DBFS directory",
+			"Could not read the status of every file:\n"+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(state.setFiles(ctx, files)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksDbfsDirectoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_directory.Update")
+	defer span.End()
+
+	var plan, state databricksDbfsDirectoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.sync(withAuditRequestID(ctx), &plan, &state, auditActionUpdate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading DBFS directory",
+			"Could not synchronize every file of "+plan.LocalDir.ValueString()+":\n"+err.Error(),
+		)
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the uploaded files and removes the Terraform state on
+// success. Empty directories are kept.
+func (r *DatabricksDbfsDirectoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_directory.Delete")
+	defer span.End()
+
+	var state databricksDbfsDirectoryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan := state
+	plan.Files = types.MapValueMust(dbfsDirectoryFileType, map[string]attr.Value{})
+	if err := r.sync(withAuditRequestID(ctx), &plan, &state, auditActionDelete); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DBFS directory",
+			"Could not delete every file:\n"+err.Error(),
+		)
+		// Keep tracking the files that could not be deleted.
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+	}
+}
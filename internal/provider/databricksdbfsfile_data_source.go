@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksDbfsFileDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksDbfsFileDataSource{}
+)
+
+// NewDatabricksDbfsFileDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksDbfsFileDataSource() datasource.DataSource {
+	return &DatabricksDbfsFileDataSource{}
+}
+
+// DatabricksDbfsFileDataSource is the data source implementation.
+type DatabricksDbfsFileDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksDbfsFileDataSourceModel maps the data source schema data.
+type databricksDbfsFileDataSourceModel struct {
+	Id            types.String  `tfsdk:"id"`
+	AdbId         types.String  `tfsdk:"adb_id"`
+	WorkspaceUrl  types.String  `tfsdk:"workspace_url"`
+	Token         types.String  `tfsdk:"token"`
+	Path          DbfsPathValue `tfsdk:"path"`
+	Content       types.String  `tfsdk:"content"`
+	ContentBase64 types.String  `tfsdk:"content_base64"`
+	FileSize      types.Int64   `tfsdk:"file_size"`
+	LastModified  RFC3339Value  `tfsdk:"modification_time"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksDbfsFileDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksDbfsFileDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs_file"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksDbfsFileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the content of a single DBFS file, e.g. to feed a configuration file hosted in DBFS into other resources.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "DBFS path of the file",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"path": schema.StringAttribute{
+				CustomType:  DbfsPathType{},
+				Required:    true,
+				Description: "Absolute DBFS path of the file",
+			},
+			"content": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content of the file as text. Null unless the file is valid UTF-8 and at most 1 MiB",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:    true,
+				Description: "Base64 encoded content of the file",
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the file",
+			},
+			"modification_time": schema.StringAttribute{
+				CustomType:  RFC3339Type{},
+				Computed:    true,
+				Description: "Last modified time of the file, in RFC3339 format",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksDbfsFileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_dbfs_file.Read")
+	defer span.End()
+
+	var state databricksDbfsFileDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	dbfsPath := state.Path.ValueNormalized()
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.DbfsGetStatus(ctx, dbfsPath)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DBFS file",
+			"Could not read the status of "+dbfsPath+": "+err.Error(),
+		)
+		return
+	}
+	if info.IsDir {
+		resp.Diagnostics.AddError(
+			"Error reading DBFS file",
+			dbfsPath+" is a directory.",
+		)
+		return
+	}
+
+	// The read API returns at most dbfsReadChunkSize bytes per call.
+	var content bytes.Buffer
+	for int64(content.Len()) < info.FileSize {
+		data, err := client.DbfsRead(ctx, dbfsPath, int64(content.Len()), dbfsReadChunkSize)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading DBFS file",
+				"Could not read "+dbfsPath+": "+err.Error(),
+			)
+			return
+		}
+		if len(data) == 0 {
+			break
+		}
+		content.Write(data)
+	}
+
+	state.Id = types.StringValue(info.Path)
+	state.ContentBase64 = types.StringValue(base64.StdEncoding.EncodeToString(content.Bytes()))
+	state.Content = types.StringNull()
+	if content.Len() <= dbfsReadChunkSize && utf8.Valid(content.Bytes()) {
+		state.Content = types.StringValue(content.String())
+	}
+	state.FileSize = types.Int64Value(int64(content.Len()))
+	state.LastModified = NewRFC3339TimeValue(time.UnixMilli(info.ModificationTime))
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,189 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &DatabricksDbfsFileListResource{}
+	_ list.ListResourceWithConfigure = &DatabricksDbfsFileListResource{}
+)
+
+// NewDatabricksDbfsFileListResource is a helper function to simplify the provider implementation.
+func NewDatabricksDbfsFileListResource() list.ListResource {
+	return &DatabricksDbfsFileListResource{}
+}
+
+// DatabricksDbfsFileListResource lists DBFS files as mrl_databricks_dbfs_file
+// instances, for bulk import of files uploaded outside Terraform.
+type DatabricksDbfsFileListResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksDbfsFileListConfigModel maps the list configuration.
+type databricksDbfsFileListConfigModel struct {
+	AdbId        types.String  `tfsdk:"adb_id"`
+	WorkspaceUrl types.String  `tfsdk:"workspace_url"`
+	Path         DbfsPathValue `tfsdk:"path"`
+	Recursive    types.Bool    `tfsdk:"recursive"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (l *DatabricksDbfsFileListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.httpClient = providerData.HTTPClient
+	l.workspace = providerData.Databricks
+}
+
+// Metadata returns the type name of the listed resource.
+func (l *DatabricksDbfsFileListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs_file"
+}
+
+// ListResourceConfigSchema defines the schema of list blocks.
+func (l *DatabricksDbfsFileListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the files of a DBFS directory. Authenticates with the provider databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of the provider Azure service principal.",
+		Attributes: map[string]schema.Attribute{
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"path": schema.StringAttribute{
+				CustomType:  DbfsPathType{},
+				Required:    true,
+				Description: "DBFS directory to list, e.g. /FileStore/jars/init-libs",
+			},
+			"recursive": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to list the files of subdirectories too. Defaults to false",
+			},
+		},
+	}
+}
+
+// List streams the files under the configured directory.
+func (l *DatabricksDbfsFileListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, span := tracing.Start(ctx, "list.mrl_databricks_dbfs_file.List")
+	defer span.End()
+
+	var config databricksDbfsFileListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	adbID, token, err := l.workspace.resolve(workspaceURL(config.WorkspaceUrl, config.AdbId), types.StringNull())
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	client := l.workspace.newClient(l.httpClient, adbID, token)
+	files, err := listDbfsFiles(ctx, client, config.Path.ValueNormalized(), config.Recursive.ValueBool())
+	if err != nil {
+		diags.AddError(
+			"Error listing DBFS files",
+			"Could not list "+config.Path.ValueString()+": "+err.Error(),
+		)
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i, file := range files {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = file.Path
+			result.Diagnostics.Append(setDbfsIdentity(ctx, result.Identity, adbID, file.Path)...)
+			if req.IncludeResource {
+				result.Diagnostics.Append(result.Resource.Set(ctx, databricksDbfsResourceModel{
+					Id:                types.StringValue(file.Path),
+					WorkspaceUrl:      NewURLValue(adbID),
+					Token:             types.StringNull(),
+					LocalPath:         types.StringNull(),
+					DbfsPath:          NewDbfsPathValue(file.Path),
+					DbfsPathTemplate:  types.StringNull(),
+					FileSize:          types.Int64Value(file.FileSize),
+					LastModified:      NewRFC3339TimeValue(time.UnixMilli(file.ModificationTime)),
+					Md5Hash:           types.StringNull(),
+					ChecksumAlgorithm: types.StringValue(checksumMD5),
+					RemoteChecksum:    types.StringNull(),
+					Drift:             types.StringValue(driftDetectionMetadata),
+					ContentChanged:    types.BoolValue(false),
+					SourceHash:        types.StringNull(),
+					BlockSize:         types.Int64Value(databricks.DbfsBlockSize),
+					Overwrite:         types.BoolValue(false),
+					SkipDestroy:       types.BoolValue(false),
+					RecursiveDelete:   types.BoolValue(false),
+				})...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
+
+// listDbfsFiles returns the files, not directories, under dir, descending
+// into subdirectories when recursive is set.
+func listDbfsFiles(ctx context.Context, client *databricks.Client, dir string, recursive bool) ([]databricks.FileInfo, error) {
+	entries, err := client.DbfsList(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []databricks.FileInfo
+	for _, entry := range entries {
+		if !entry.IsDir {
+			files = append(files, entry)
+			continue
+		}
+		if recursive {
+			sub, err := listDbfsFiles(ctx, client, entry.Path, true)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, sub...)
+		}
+	}
+	return files, nil
+}
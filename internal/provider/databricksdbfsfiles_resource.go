@@ -0,0 +1,648 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksDbfsFilesResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksDbfsFilesResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksDbfsFilesResource{}
+	_ resource.ResourceWithValidateConfig   = &DatabricksDbfsFilesResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksDbfsFilesResource{}
+)
+
+// defaultDbfsFilesParallelism is the number of concurrent uploads when
+// parallelism is not set.
+const defaultDbfsFilesParallelism = 8
+
+// NewDatabricksDbfsFilesResource is a helper function to simplify the provider implementation.
+func NewDatabricksDbfsFilesResource() resource.Resource {
+	return &DatabricksDbfsFilesResource{}
+}
+
+// DatabricksDbfsFilesResource is the resource implementation.
+type DatabricksDbfsFilesResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksDbfsFilesResourceModel struct {
+	Id                  types.String                   `tfsdk:"id"`
+	AdbId               URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl        URLValue                       `tfsdk:"workspace_url"`
+	Token               types.String                   `tfsdk:"token"`
+	Parallelism         types.Int64                    `tfsdk:"parallelism"`
+	AuthoritativePrefix DbfsPathValue                  `tfsdk:"authoritative_prefix"`
+	Orphans             types.List                     `tfsdk:"orphans"`
+	Files               map[string]dbfsFileEntryModel  `tfsdk:"files"`
+	Workspace           *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// dbfsFileEntryModel is a single file of the set, keyed by its DBFS path.
+type dbfsFileEntryModel struct {
+	LocalPath  types.String `tfsdk:"local_path"`
+	Content    types.String `tfsdk:"content"`
+	ContentMd5 types.String `tfsdk:"content_md5"`
+	FileSize   types.Int64  `tfsdk:"file_size"`
+}
+
+// open returns a reader for the source of the entry.
+func (e dbfsFileEntryModel) open() (io.ReadCloser, error) {
+	if !e.LocalPath.IsNull() {
+		return os.Open(e.LocalPath.ValueString())
+	}
+	return io.NopCloser(strings.NewReader(e.Content.ValueString())), nil
+}
+
+// sourceMD5 returns the hex md5 of the source of the entry.
+func (e dbfsFileEntryModel) sourceMD5() (string, error) {
+	if !e.LocalPath.IsNull() {
+		return fileMD5(e.LocalPath.ValueString())
+	}
+	sum := md5.Sum([]byte(e.Content.ValueString()))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksDbfsFilesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksDbfsFilesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs_files"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksDbfsFilesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a set of files to DBFS in a single resource, with bounded parallelism. Only files whose content changed are uploaded again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the workspace the files are uploaded to",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(defaultDbfsFilesParallelism),
+				Description: "Maximum number of concurrent uploads and deletes. Uploads also count toward max_parallel_uploads of the provider. Defaults to 8",
+			},
+			"authoritative_prefix": schema.StringAttribute{
+				CustomType:  DbfsPathType{},
+				Optional:    true,
+				Description: "DBFS directory fully managed by this resource. Files below it that are not declared in files are listed in orphans, reported at plan time and deleted on apply. Empty directories are kept",
+			},
+			"orphans": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Files below authoritative_prefix that are not declared in files, as found by the last refresh. Null when authoritative_prefix is not set",
+			},
+			"files": schema.MapNestedAttribute{
+				Required:    true,
+				Description: "Files to upload, keyed by absolute DBFS path",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"local_path": schema.StringAttribute{
+							Optional:    true,
+							Description: "Local file to upload. Conflicts with content",
+						},
+						"content": schema.StringAttribute{
+							Optional:    true,
+							Description: "Literal content to upload. Conflicts with local_path",
+						},
+						"content_md5": schema.StringAttribute{
+							Computed:    true,
+							Description: "md5 hash of the source, computed at plan time",
+						},
+						"file_size": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Size of the uploaded file",
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksDbfsFilesResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(path.Root("workspace_url"), path.Root("adb_id")),
+	}
+}
+
+// ValidateConfig checks the paths and that every file has exactly one source.
+func (r *DatabricksDbfsFilesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksDbfsFilesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Parallelism.IsNull() && !config.Parallelism.IsUnknown() && config.Parallelism.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("parallelism"), "Invalid parallelism", "parallelism must be at least 1.")
+	}
+
+	for dbfsPath, file := range config.Files {
+		attrPath := path.Root("files").AtMapKey(dbfsPath)
+		if !strings.HasPrefix(dbfsPath, "/") {
+			resp.Diagnostics.AddAttributeError(attrPath, "Invalid DBFS path", fmt.Sprintf("%q is not an absolute DBFS path.", dbfsPath))
+		}
+		if file.LocalPath.IsUnknown() || file.Content.IsUnknown() {
+			continue
+		}
+		if file.LocalPath.IsNull() == file.Content.IsNull() {
+			resp.Diagnostics.AddAttributeError(attrPath, "Invalid file source", "Exactly one of local_path and content must be set.")
+		}
+	}
+}
+
+// ModifyPlan hashes every source so that only changed files are uploaded.
+func (r *DatabricksDbfsFilesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state databricksDbfsFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for dbfsPath, file := range plan.Files {
+		if file.LocalPath.IsUnknown() || file.Content.IsUnknown() {
+			file.ContentMd5 = types.StringUnknown()
+			file.FileSize = types.Int64Unknown()
+			plan.Files[dbfsPath] = file
+			continue
+		}
+
+		sum, err := file.sourceMD5()
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("files").AtMapKey(dbfsPath).AtName("local_path"),
+				"Error reading local file",
+				"Could not hash "+file.LocalPath.ValueString()+": "+err.Error(),
+			)
+			continue
+		}
+		file.ContentMd5 = types.StringValue(sum)
+		file.FileSize = types.Int64Unknown()
+		if prior, ok := state.Files[dbfsPath]; ok && prior.ContentMd5.ValueString() == sum {
+			file.FileSize = prior.FileSize
+		}
+		plan.Files[dbfsPath] = file
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Orphans = types.ListNull(types.StringType)
+	if !plan.AuthoritativePrefix.IsNull() {
+		// Every orphan is deleted on apply, so none is left afterwards.
+		plan.Orphans = types.ListValueMust(types.StringType, nil)
+		resp.Diagnostics.Append(r.warnOrphans(ctx, &plan, &state)...)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// warnOrphans reports the files below the authoritative prefix that the
+// planned apply deletes. The refreshed state lists them; on create, the
+// prefix is listed instead.
+func (r *DatabricksDbfsFilesResource) warnOrphans(ctx context.Context, plan, state *databricksDbfsFilesResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.WorkspaceUrl.IsUnknown() || plan.AdbId.IsUnknown() || plan.AuthoritativePrefix.IsUnknown() {
+		return diags
+	}
+
+	var orphans []string
+	if state.AuthoritativePrefix.Equal(plan.AuthoritativePrefix) && !state.Orphans.IsNull() {
+		diags.Append(state.Orphans.ElementsAs(ctx, &orphans, false)...)
+	} else {
+		client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+		if err != nil {
+			diags.AddError("Missing Databricks workspace", err.Error())
+			return diags
+		}
+		orphans, err = findOrphans(ctx, client, plan)
+		if err != nil {
+			diags.AddAttributeWarning(
+				path.Root("authoritative_prefix"),
+				"Unable to list DBFS orphans",
+				"Could not list "+plan.AuthoritativePrefix.ValueString()+": "+err.Error()+". Files below it that are not declared in files are deleted on apply.",
+			)
+			return diags
+		}
+	}
+	if len(orphans) > 0 {
+		diags.AddAttributeWarning(
+			path.Root("authoritative_prefix"),
+			"DBFS files will be deleted",
+			fmt.Sprintf("%d files below %s are not declared in files and will be deleted:\n%s", len(orphans), plan.AuthoritativePrefix.ValueString(), strings.Join(orphans, "\n")),
+		)
+	}
+	return diags
+}
+
+// findOrphans returns the files below the authoritative prefix of model that
+// are not declared in its files, sorted by path.
+func findOrphans(ctx context.Context, client *databricks.Client, model *databricksDbfsFilesResourceModel) ([]string, error) {
+	remote, err := listDbfsFiles(ctx, client, model.AuthoritativePrefix.ValueNormalized(), true)
+	if databricks.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	declared := make(map[string]bool, len(model.Files))
+	for dbfsPath := range model.Files {
+		declared[joinDbfsPath(dbfsPath)] = true
+	}
+	var orphans []string
+	for _, file := range remote {
+		if !declared[joinDbfsPath(file.Path)] {
+			orphans = append(orphans, file.Path)
+		}
+	}
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+// prune deletes the orphans below the authoritative prefix of plan and
+// records the ones that could not be deleted in plan.
+func (r *DatabricksDbfsFilesResource) prune(ctx context.Context, plan *databricksDbfsFilesResourceModel) error {
+	plan.Orphans = types.ListNull(types.StringType)
+	if plan.AuthoritativePrefix.IsNull() {
+		return nil
+	}
+
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+	orphans, err := findOrphans(ctx, client, plan)
+	if err != nil {
+		plan.Orphans = types.ListValueMust(types.StringType, nil)
+		return err
+	}
+
+	var mu sync.Mutex
+	var remaining []attr.Value
+	err = databricks.ForEach(orphans, plan.Parallelism.ValueInt64(), func(dbfsPath string) error {
+		err := client.DbfsDelete(ctx, dbfsPath, false)
+		if databricks.IsNotFound(err) {
+			err = nil
+		}
+		r.audit.Record(ctx, "mrl_databricks_dbfs_files", auditActionDelete, dbfsPath, err)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			remaining = append(remaining, types.StringValue(dbfsPath))
+		}
+		return err
+	})
+	plan.Orphans = types.ListValueMust(types.StringType, remaining)
+	return err
+}
+
+// sync uploads the files of plan that differ from prior and deletes the files
+// of prior missing from plan. Files that were processed successfully are
+// recorded in plan even when others fail, so a partial apply is not repeated.
+func (r *DatabricksDbfsFilesResource) sync(ctx context.Context, plan, prior *databricksDbfsFilesResourceModel, action string) error {
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+
+	var uploads, deletes []string
+	for dbfsPath, file := range plan.Files {
+		if before, ok := prior.Files[dbfsPath]; ok && before.ContentMd5.Equal(file.ContentMd5) && !before.FileSize.IsNull() {
+			file.FileSize = before.FileSize
+			plan.Files[dbfsPath] = file
+			continue
+		}
+		uploads = append(uploads, dbfsPath)
+	}
+	for dbfsPath := range prior.Files {
+		if _, ok := plan.Files[dbfsPath]; !ok {
+			deletes = append(deletes, dbfsPath)
+		}
+	}
+	sort.Strings(uploads)
+	sort.Strings(deletes)
+
+	var mu sync.Mutex
+	failed := map[string]bool{}
+	uploadErr := databricks.ForEach(uploads, plan.Parallelism.ValueInt64(), func(dbfsPath string) error {
+		file := plan.Files[dbfsPath]
+		err := func() error {
+			src, err := file.open()
+			if err != nil {
+				return err
+			}
+			defer src.Close()
+
+			sum, err := client.DbfsPut(ctx, dbfsPath, src)
+			if err != nil {
+				return err
+			}
+			if sum != file.ContentMd5.ValueString() {
+				return fmt.Errorf("uploaded content has md5 %v but the planned md5 is %v; the source changed during apply", sum, file.ContentMd5.ValueString())
+			}
+			return nil
+		}()
+		r.audit.Record(ctx, "mrl_databricks_dbfs_files", action, dbfsPath, err)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed[dbfsPath] = true
+			return err
+		}
+		return nil
+	})
+
+	deleteErr := databricks.ForEach(deletes, plan.Parallelism.ValueInt64(), func(dbfsPath string) error {
+		err := client.DbfsDelete(ctx, dbfsPath, false)
+		if databricks.IsNotFound(err) {
+			err = nil
+		}
+		r.audit.Record(ctx, "mrl_databricks_dbfs_files", auditActionDelete, dbfsPath, err)
+		if err != nil {
+			mu.Lock()
+			defer mu.Unlock()
+			failed[dbfsPath] = true
+		}
+		return err
+	})
+
+	// Record the outcome per file: uploaded files get their remote size,
+	// failed uploads keep their prior state and failed deletes stay tracked.
+	for _, dbfsPath := range uploads {
+		if failed[dbfsPath] {
+			if before, ok := prior.Files[dbfsPath]; ok {
+				plan.Files[dbfsPath] = before
+			} else {
+				delete(plan.Files, dbfsPath)
+			}
+			continue
+		}
+		file := plan.Files[dbfsPath]
+		info, err := client.DbfsGetStatus(ctx, dbfsPath)
+		if err != nil {
+			uploadErr = errors.Join(uploadErr, fmt.Errorf("%s: %w", dbfsPath, err))
+			file.FileSize = types.Int64Null()
+		} else {
+			file.FileSize = types.Int64Value(info.FileSize)
+		}
+		plan.Files[dbfsPath] = file
+	}
+	for _, dbfsPath := range deletes {
+		if failed[dbfsPath] {
+			plan.Files[dbfsPath] = prior.Files[dbfsPath]
+		}
+	}
+
+	host, _, _ := r.workspace.override(plan.Workspace).resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	plan.Id = types.StringValue(normalizePath(host, true))
+	return errors.Join(uploadErr, deleteErr)
+}
+
+// Create a new resource.
+func (r *DatabricksDbfsFilesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_files.Create")
+	defer span.End()
+
+	var plan databricksDbfsFilesResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	if err := r.sync(ctx, &plan, &databricksDbfsFilesResourceModel{}, auditActionCreate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading DBFS files",
+			"Could not upload every file:\n"+err.Error(),
+		)
+	}
+	if err := r.prune(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DBFS orphans",
+			"Could not delete every file below "+plan.AuthoritativePrefix.ValueString()+" that is not declared in files:\n"+err.Error(),
+		)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksDbfsFilesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_files.Read")
+	defer span.End()
+
+	var state databricksDbfsFilesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+
+	paths := make([]string, 0, len(state.Files))
+	for dbfsPath := range state.Files {
+		paths = append(paths, dbfsPath)
+	}
+
+	var mu sync.Mutex
+	err = databricks.ForEach(paths, state.Parallelism.ValueInt64(), func(dbfsPath string) error {
+		info, err := client.DbfsGetStatus(ctx, dbfsPath)
+
+		mu.Lock()
+		defer mu.Unlock()
+		file := state.Files[dbfsPath]
+		switch {
+		case databricks.IsNotFound(err):
+			// Dropping the file from state makes the next plan upload it.
+			delete(state.Files, dbfsPath)
+			return nil
+		case err != nil:
+			return err
+		case !file.FileSize.Equal(types.Int64Value(info.FileSize)):
+			// The file was rewritten outside Terraform.
+			file.ContentMd5 = types.StringNull()
+		}
+		file.FileSize = types.Int64Value(info.FileSize)
+		state.Files[dbfsPath] = file
+		return nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DBFS files",
+			"Could not read the status of every file:\n"+err.Error(),
+		)
+		return
+	}
+
+	state.Orphans = types.ListNull(types.StringType)
+	if !state.AuthoritativePrefix.IsNull() {
+		orphans, err := findOrphans(ctx, client, &state)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading DBFS files",
+				"Could not list "+state.AuthoritativePrefix.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		orphanValues := make([]attr.Value, 0, len(orphans))
+		for _, orphan := range orphans {
+			orphanValues = append(orphanValues, types.StringValue(orphan))
+		}
+		state.Orphans = types.ListValueMust(types.StringType, orphanValues)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksDbfsFilesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_files.Update")
+	defer span.End()
+
+	var plan, state databricksDbfsFilesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	if err := r.sync(ctx, &plan, &state, auditActionUpdate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error uploading DBFS files",
+			"Could not synchronize every file:\n"+err.Error(),
+		)
+	}
+	if err := r.prune(ctx, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DBFS orphans",
+			"Could not delete every file below "+plan.AuthoritativePrefix.ValueString()+" that is not declared in files:\n"+err.Error(),
+		)
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksDbfsFilesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_dbfs_files.Delete")
+	defer span.End()
+
+	var state databricksDbfsFilesResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan := state
+	plan.Files = map[string]dbfsFileEntryModel{}
+	if err := r.sync(withAuditRequestID(ctx), &plan, &state, auditActionDelete); err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting DBFS files",
+			"Could not delete every file:\n"+err.Error(),
+		)
+		// Keep tracking the files that could not be deleted.
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+	}
+}
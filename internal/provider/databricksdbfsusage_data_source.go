@@ -0,0 +1,229 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksDbfsUsageDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksDbfsUsageDataSource{}
+)
+
+// NewDatabricksDbfsUsageDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksDbfsUsageDataSource() datasource.DataSource {
+	return &DatabricksDbfsUsageDataSource{}
+}
+
+// DatabricksDbfsUsageDataSource is the data source implementation.
+type DatabricksDbfsUsageDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksDbfsUsageDataSourceModel maps the data source schema data.
+type databricksDbfsUsageDataSourceModel struct {
+	Id           types.String  `tfsdk:"id"`
+	AdbId        types.String  `tfsdk:"adb_id"`
+	WorkspaceUrl types.String  `tfsdk:"workspace_url"`
+	Token        types.String  `tfsdk:"token"`
+	Path         DbfsPathValue `tfsdk:"path"`
+	TotalBytes   types.Int64   `tfsdk:"total_bytes"`
+	FileCount    types.Int64   `tfsdk:"file_count"`
+	Directories  types.List    `tfsdk:"directories"`
+}
+
+// dbfsUsageAttrTypes are the attribute types of a directories element.
+var dbfsUsageAttrTypes = map[string]attr.Type{
+	"path":        DbfsPathType{},
+	"total_bytes": types.Int64Type,
+	"file_count":  types.Int64Type,
+}
+
+// dbfsUsage is the size of a DBFS tree.
+type dbfsUsage struct {
+	bytes int64
+	files int64
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksDbfsUsageDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksDbfsUsageDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_dbfs_usage"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksDbfsUsageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Walks a DBFS directory recursively and reports its size, in total and per top-level directory. Large trees take one API call per directory.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "DBFS path that was walked",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"path": schema.StringAttribute{
+				CustomType:  DbfsPathType{},
+				Required:    true,
+				Description: "DBFS directory to walk, e.g. /FileStore",
+			},
+			"total_bytes": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Total size of the files below path",
+			},
+			"file_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of files below path",
+			},
+			"directories": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Size of every directory directly below path, sorted by path",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							CustomType:  DbfsPathType{},
+							Computed:    true,
+							Description: "DBFS path of the directory",
+						},
+						"total_bytes": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Total size of the files below the directory",
+						},
+						"file_count": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of files below the directory",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// walkDbfs returns the size of the DBFS tree below dir.
+func walkDbfs(ctx context.Context, client *databricks.Client, dir string) (dbfsUsage, error) {
+	var usage dbfsUsage
+	entries, err := client.DbfsList(ctx, dir)
+	if err != nil {
+		return usage, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir {
+			usage.bytes += entry.FileSize
+			usage.files++
+			continue
+		}
+		sub, err := walkDbfs(ctx, client, entry.Path)
+		if err != nil {
+			return usage, err
+		}
+		usage.bytes += sub.bytes
+		usage.files += sub.files
+	}
+	return usage, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksDbfsUsageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_dbfs_usage.Read")
+	defer span.End()
+
+	var state databricksDbfsUsageDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	entries, err := client.DbfsList(ctx, state.Path.ValueNormalized())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading DBFS usage",
+			"Could not list "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	var total dbfsUsage
+	var directories []attr.Value
+	for _, entry := range entries {
+		if !entry.IsDir {
+			total.bytes += entry.FileSize
+			total.files++
+			continue
+		}
+		usage, err := walkDbfs(ctx, client, entry.Path)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading DBFS usage",
+				"Could not walk "+entry.Path+": "+err.Error(),
+			)
+			return
+		}
+		total.bytes += usage.bytes
+		total.files += usage.files
+		directories = append(directories, types.ObjectValueMust(dbfsUsageAttrTypes, map[string]attr.Value{
+			"path":        NewDbfsPathValue(entry.Path),
+			"total_bytes": types.Int64Value(usage.bytes),
+			"file_count":  types.Int64Value(usage.files),
+		}))
+	}
+
+	state.Id = types.StringValue(state.Path.ValueString())
+	state.TotalBytes = types.Int64Value(total.bytes)
+	state.FileCount = types.Int64Value(total.files)
+	state.Directories = types.ListValueMust(types.ObjectType{AttrTypes: dbfsUsageAttrTypes}, directories)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
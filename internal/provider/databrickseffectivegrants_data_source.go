@@ -0,0 +1,186 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksEffectiveGrantsDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksEffectiveGrantsDataSource{}
+)
+
+// NewDatabricksEffectiveGrantsDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksEffectiveGrantsDataSource() datasource.DataSource {
+	return &DatabricksEffectiveGrantsDataSource{}
+}
+
+// DatabricksEffectiveGrantsDataSource is the data source implementation.
+type DatabricksEffectiveGrantsDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksEffectiveGrantsDataSourceModel maps the data source schema data.
+type databricksEffectiveGrantsDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AdbId         types.String `tfsdk:"adb_id"`
+	WorkspaceUrl  types.String `tfsdk:"workspace_url"`
+	Token         types.String `tfsdk:"token"`
+	SecurableType types.String `tfsdk:"securable_type"`
+	FullName      types.String `tfsdk:"full_name"`
+	Principal     types.String `tfsdk:"principal"`
+	Grants        types.List   `tfsdk:"grants"`
+}
+
+// effectiveGrantAttrTypes are the attribute types of a grants element.
+var effectiveGrantAttrTypes = map[string]attr.Type{
+	"principal":           types.StringType,
+	"privilege":           types.StringType,
+	"inherited_from_type": types.StringType,
+	"inherited_from_name": types.StringType,
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksEffectiveGrantsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksEffectiveGrantsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_effective_grants"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksEffectiveGrantsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the effective Unity Catalog privileges on a catalog, schema or table, including those inherited from parent securables, e.g. to assert that required access exists after an apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Securable type and full name, joined by /",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"securable_type": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{StringOneOf("catalog", "schema", "table")},
+				Description: "Type of the securable: catalog, schema or table",
+			},
+			"full_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Full name of the securable, e.g. main.sales.orders",
+			},
+			"principal": schema.StringAttribute{
+				Optional:    true,
+				Description: "User, group or service principal to limit the result to. Defaults to every principal",
+			},
+			"grants": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Effective privileges, one element per principal and privilege",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"principal": schema.StringAttribute{
+							Computed:    true,
+							Description: "Principal holding the privilege",
+						},
+						"privilege": schema.StringAttribute{
+							Computed:    true,
+							Description: "Privilege, e.g. SELECT or USE_SCHEMA",
+						},
+						"inherited_from_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Type of the securable the privilege is inherited from, empty when granted directly",
+						},
+						"inherited_from_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Full name of the securable the privilege is inherited from, empty when granted directly",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksEffectiveGrantsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_effective_grants.Read")
+	defer span.End()
+
+	var state databricksEffectiveGrantsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	assignments, err := client.EffectivePermissions(ctx, state.SecurableType.ValueString(), state.FullName.ValueString(), state.Principal.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading effective grants",
+			"Could not read the effective permissions of "+state.FullName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	var grants []attr.Value
+	for _, assignment := range assignments {
+		for _, privilege := range assignment.Privileges {
+			grants = append(grants, types.ObjectValueMust(effectiveGrantAttrTypes, map[string]attr.Value{
+				"principal":           types.StringValue(assignment.Principal),
+				"privilege":           types.StringValue(privilege.Privilege),
+				"inherited_from_type": types.StringValue(privilege.InheritedFromType),
+				"inherited_from_name": types.StringValue(privilege.InheritedFromName),
+			}))
+		}
+	}
+
+	state.Id = types.StringValue(state.SecurableType.ValueString() + "/" + state.FullName.ValueString())
+	state.Grants = types.ListValueMust(types.ObjectType{AttrTypes: effectiveGrantAttrTypes}, grants)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksGlobalInitScriptResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksGlobalInitScriptResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksGlobalInitScriptResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksGlobalInitScriptResource{}
+)
+
+// NewDatabricksGlobalInitScriptResource is a helper function to simplify the provider implementation.
+func NewDatabricksGlobalInitScriptResource() resource.Resource {
+	return &DatabricksGlobalInitScriptResource{}
+}
+
+// DatabricksGlobalInitScriptResource is the resource implementation.
+type DatabricksGlobalInitScriptResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksGlobalInitScriptResourceModel maps the resource schema data.
+type databricksGlobalInitScriptResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	AdbId        URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue                       `tfsdk:"workspace_url"`
+	Token        types.String                   `tfsdk:"token"`
+	Name         types.String                   `tfsdk:"name"`
+	LocalPath    types.String                   `tfsdk:"local_path"`
+	Enabled      types.Bool                     `tfsdk:"enabled"`
+	Position     types.Int64                    `tfsdk:"position"`
+	ContentMd5   types.String                   `tfsdk:"content_md5"`
+	Workspace    *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksGlobalInitScriptResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksGlobalInitScriptResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_global_init_script"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksGlobalInitScriptResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a global init script, run on every cluster of the workspace when it starts, from a local shell script. Changes made to the script body outside Terraform are detected on refresh and reverted on the next apply.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the script",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the script",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local shell script to upload, of at most 64 KB",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the script runs on cluster start. Defaults to false",
+			},
+			"position": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators:  []validator.Int64{Int64Between(0, 999)},
+				Description: "Position of the script among the global init scripts, which run in ascending order starting at 0. Scripts at or after the position move down by one. Defaults to after the existing scripts",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the local script, computed at plan time. Refresh records the hash of the remote script, so a script changed outside Terraform is uploaded again",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksGlobalInitScriptResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(path.Root("workspace_url"), path.Root("adb_id")),
+	}
+}
+
+// ModifyPlan hashes the local script so that it is only uploaded again when
+// its content changes.
+func (r *DatabricksGlobalInitScriptResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksGlobalInitScriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocalPath.IsUnknown() {
+		return
+	}
+
+	content, err := os.ReadFile(plan.LocalPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("local_path"),
+			"Error reading local file",
+			"Could not read "+plan.LocalPath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if len(content) > databricks.GlobalInitScriptMaxBytes {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("local_path"),
+			"Script too large",
+			fmt.Sprintf("%s has %d bytes; global init scripts are limited to %d bytes.", plan.LocalPath.ValueString(), len(content), databricks.GlobalInitScriptMaxBytes),
+		)
+		return
+	}
+	sum := md5.Sum(content)
+	plan.ContentMd5 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// save creates the script of plan, or updates it when id is not empty, and
+// records its ID and position in plan.
+func (r *DatabricksGlobalInitScriptResource) save(ctx context.Context, plan *databricksGlobalInitScriptResourceModel, id string) error {
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(plan.LocalPath.ValueString())
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(content)
+	if hex.EncodeToString(sum[:]) != plan.ContentMd5.ValueString() {
+		return fmt.Errorf("%v changed during apply; the planned md5 is %v", plan.LocalPath.ValueString(), plan.ContentMd5.ValueString())
+	}
+
+	position := int64(-1)
+	if !plan.Position.IsUnknown() && !plan.Position.IsNull() {
+		position = plan.Position.ValueInt64()
+	}
+	if id == "" {
+		id, err = client.CreateGlobalInitScript(ctx, plan.Name.ValueString(), content, plan.Enabled.ValueBool(), position)
+		if err != nil {
+			return err
+		}
+		// Record the ID before reading back, so that a failed read does
+		// not leave an untracked script behind.
+		plan.Id = types.StringValue(id)
+	} else if err := client.UpdateGlobalInitScript(ctx, id, plan.Name.ValueString(), content, plan.Enabled.ValueBool(), position); err != nil {
+		return err
+	}
+
+	script, err := client.GetGlobalInitScript(ctx, id)
+	if err != nil {
+		return err
+	}
+	plan.Position = types.Int64Value(script.Position)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksGlobalInitScriptResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_global_init_script.Create")
+	defer span.End()
+
+	var plan databricksGlobalInitScriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.save(ctx, &plan, "")
+	r.audit.Record(ctx, "mrl_databricks_global_init_script", auditActionCreate, plan.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating global init script",
+			"Could not create global init script "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		if !plan.Id.IsUnknown() {
+			if plan.Position.IsUnknown() {
+				plan.Position = types.Int64Null()
+			}
+			resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksGlobalInitScriptResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_global_init_script.Read")
+	defer span.End()
+
+	var state databricksGlobalInitScriptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	script, err := client.GetGlobalInitScript(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading global init script",
+			"Could not read global init script "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	sum := md5.Sum(script.Content)
+	state.Name = types.StringValue(script.Name)
+	state.Enabled = types.BoolValue(script.Enabled)
+	state.Position = types.Int64Value(script.Position)
+	state.ContentMd5 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksGlobalInitScriptResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_global_init_script.Update")
+	defer span.End()
+
+	var plan, state databricksGlobalInitScriptResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	if plan.Name.Equal(state.Name) && plan.ContentMd5.Equal(state.ContentMd5) && plan.Enabled.Equal(state.Enabled) && plan.Position.Equal(state.Position) {
+		// Only token or local_path changed.
+		resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.save(ctx, &plan, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_global_init_script", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating global init script",
+			"Could not update global init script "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksGlobalInitScriptResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_global_init_script.Delete")
+	defer span.End()
+
+	var state databricksGlobalInitScriptResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteGlobalInitScript(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_global_init_script", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting global init script",
+			"Could not delete global init script "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksGroupResource{}
+	_ resource.ResourceWithConfigure = &DatabricksGroupResource{}
+)
+
+// entitlementValues are the entitlements a group or service principal can be
+// granted.
+var entitlementValues = []string{
+	databricks.EntitlementAllowClusterCreate,
+	databricks.EntitlementAllowInstancePoolCreate,
+	databricks.EntitlementDatabricksSQLAccess,
+	databricks.EntitlementWorkspaceAccess,
+}
+
+// NewDatabricksGroupResource is a helper function to simplify the provider implementation.
+func NewDatabricksGroupResource() resource.Resource {
+	return &DatabricksGroupResource{}
+}
+
+// DatabricksGroupResource is the resource implementation.
+type DatabricksGroupResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksGroupResourceModel maps the resource schema data.
+type databricksGroupResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue     `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	DisplayName  types.String `tfsdk:"display_name"`
+	Entitlements types.Set    `tfsdk:"entitlements"`
+	Members      types.Set    `tfsdk:"members"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a workspace group through the SCIM API, with its entitlements and, optionally, its members.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "SCIM ID of the group",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"display_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the group",
+			},
+			"entitlements": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.Set{SetValuesOneOf(entitlementValues...)},
+				Description: "Entitlements of the members of the group: allow-cluster-create, allow-instance-pool-create, databricks-sql-access or workspace-access",
+			},
+			"members": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "SCIM IDs of the users, service principals and groups that are members of the group. Without it, membership is left unmanaged",
+			},
+		},
+	}
+}
+
+// setStrings returns the elements of a set of strings, sorted. A null or
+// unknown set has none.
+func setStrings(ctx context.Context, set types.Set) ([]string, diag.Diagnostics) {
+	var values []string
+	if set.IsNull() || set.IsUnknown() {
+		return values, nil
+	}
+	diags := set.ElementsAs(ctx, &values, false)
+	sort.Strings(values)
+	return values, diags
+}
+
+// scimValueSet returns the values of a multi-valued SCIM attribute as a set.
+// An empty attribute stays null when prior is null, so that an unset
+// attribute does not show a diff.
+func scimValueSet(values []databricks.ScimValue, prior types.Set) types.Set {
+	if len(values) == 0 && prior.IsNull() {
+		return types.SetNull(types.StringType)
+	}
+	elements := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elements = append(elements, types.StringValue(v.Value))
+	}
+	return types.SetValueMust(types.StringType, elements)
+}
+
+// scimSetOps returns the SCIM patch operations turning the multi-valued
+// attribute at path from prior into planned.
+func scimSetOps(path string, prior, planned []string) []databricks.ScimPatchOp {
+	var ops []databricks.ScimPatchOp
+	var added []string
+	for _, v := range planned {
+		if !slices.Contains(prior, v) {
+			added = append(added, v)
+		}
+	}
+	if len(added) > 0 {
+		ops = append(ops, databricks.ScimAddValues(path, added))
+	}
+	for _, v := range prior {
+		if !slices.Contains(planned, v) {
+			ops = append(ops, databricks.ScimRemoveValue(path, v))
+		}
+	}
+	return ops
+}
+
+// Create a new resource.
+func (r *DatabricksGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_group.Create")
+	defer span.End()
+
+	var plan databricksGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entitlements, diags := setStrings(ctx, plan.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	members, diags := setStrings(ctx, plan.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	group, err := client.CreateGroup(ctx, databricks.Group{
+		DisplayName:  plan.DisplayName.ValueString(),
+		Entitlements: databricks.ScimValues(entitlements),
+		Members:      databricks.ScimValues(members),
+	})
+	r.audit.Record(ctx, "mrl_databricks_group", auditActionCreate, plan.DisplayName.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating group", "Could not create group "+plan.DisplayName.ValueString()+": "+err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(group.ID)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_group.Read")
+	defer span.End()
+
+	var state databricksGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	group, err := client.GetGroup(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading group", "Could not read group "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	state.DisplayName = types.StringValue(group.DisplayName)
+	state.Entitlements = scimValueSet(group.Entitlements, state.Entitlements)
+	if !state.Members.IsNull() {
+		state.Members = scimValueSet(group.Members, state.Members)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_group.Update")
+	defer span.End()
+
+	var plan, state databricksGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+
+	var ops []databricks.ScimPatchOp
+	if !plan.DisplayName.Equal(state.DisplayName) {
+		ops = append(ops, databricks.ScimReplace("displayName", plan.DisplayName.ValueString()))
+	}
+	priorEntitlements, diags := setStrings(ctx, state.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	entitlements, diags := setStrings(ctx, plan.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	ops = append(ops, scimSetOps("entitlements", priorEntitlements, entitlements)...)
+
+	if !plan.Members.IsNull() {
+		priorMembers, diags := setStrings(ctx, state.Members)
+		resp.Diagnostics.Append(diags...)
+		if state.Members.IsNull() {
+			// Membership becomes managed; start from the current members.
+			group, err := client.GetGroup(ctx, state.Id.ValueString())
+			if err != nil {
+				resp.Diagnostics.AddError("Error reading group", "Could not read the members of group "+state.Id.ValueString()+": "+err.Error())
+				return
+			}
+			for _, member := range group.Members {
+				priorMembers = append(priorMembers, member.Value)
+			}
+		}
+		members, diags := setStrings(ctx, plan.Members)
+		resp.Diagnostics.Append(diags...)
+		ops = append(ops, scimSetOps("members", priorMembers, members)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = client.PatchGroup(ctx, state.Id.ValueString(), ops)
+	r.audit.Record(ctx, "mrl_databricks_group", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating group", "Could not update group "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	plan.Id = state.Id
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_group.Delete")
+	defer span.End()
+
+	var state databricksGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteGroup(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_group", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting group", "Could not delete group "+state.Id.ValueString()+": "+err.Error())
+	}
+}
@@ -0,0 +1,467 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksInstancePoolResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksInstancePoolResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksInstancePoolResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksInstancePoolResource{}
+)
+
+// NewDatabricksInstancePoolResource is a helper function to simplify the provider implementation.
+func NewDatabricksInstancePoolResource() resource.Resource {
+	return &DatabricksInstancePoolResource{}
+}
+
+// DatabricksInstancePoolResource is the resource implementation.
+type DatabricksInstancePoolResource struct {
+	httpClient  *http.Client
+	workspace   databricksWorkspace
+	audit       *auditLogger
+	defaultTags map[string]string
+}
+
+type databricksInstancePoolResourceModel struct {
+	Id                                 types.String                `tfsdk:"id"`
+	AdbId                              URLValue                    `tfsdk:"adb_id"`
+	WorkspaceUrl                       URLValue                    `tfsdk:"workspace_url"`
+	Token                              types.String                `tfsdk:"token"`
+	InstancePoolName                   types.String                `tfsdk:"instance_pool_name"`
+	NodeTypeId                         types.String                `tfsdk:"node_type_id"`
+	MinIdleInstances                   types.Int64                 `tfsdk:"min_idle_instances"`
+	MaxCapacity                        types.Int64                 `tfsdk:"max_capacity"`
+	IdleInstanceAutoterminationMinutes types.Int64                 `tfsdk:"idle_instance_autotermination_minutes"`
+	PreloadedSparkVersions             types.List                  `tfsdk:"preloaded_spark_versions"`
+	AzureAttributes                    *instancePoolAzureAttrModel `tfsdk:"azure_attributes"`
+	CustomTags                         types.Map                   `tfsdk:"custom_tags"`
+	CustomTagsAll                      types.Map                   `tfsdk:"custom_tags_all"`
+	State                              types.String                `tfsdk:"state"`
+}
+
+// instancePoolAzureAttrModel maps the Azure availability of the instances.
+type instancePoolAzureAttrModel struct {
+	Availability    types.String  `tfsdk:"availability"`
+	SpotBidMaxPrice types.Float64 `tfsdk:"spot_bid_max_price"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksInstancePoolResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksInstancePoolResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_instance_pool"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksInstancePoolResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Databricks instance pool, a set of idle, ready-to-use instances that mrl_databricks_cluster resources set in instance_pool_id start and scale from.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the instance pool",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"instance_pool_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the instance pool",
+			},
+			"node_type_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Node type of the instances, such as Standard_DS3_v2",
+			},
+			"min_idle_instances": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Validators:  []validator.Int64{Int64Between(0, 10000)},
+				Description: "Number of idle instances the pool keeps ready. Defaults to 0",
+			},
+			"max_capacity": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(1, 100000)},
+				Description: "Maximum number of instances, idle and in use, of the pool. Unlimited when unset",
+			},
+			"idle_instance_autotermination_minutes": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(60),
+				Validators:  []validator.Int64{Int64Between(0, 10000)},
+				Description: "Minutes after which idle instances beyond min_idle_instances are terminated. Defaults to 60",
+			},
+			"preloaded_spark_versions": schema.ListAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				Description: "Databricks Runtime version key preloaded on idle instances, such as 15.4.x-scala2.12, so that clusters start faster. At most one",
+			},
+			"azure_attributes": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"availability": schema.StringAttribute{
+						Required:    true,
+						Validators:  []validator.String{StringOneOf(databricks.AvailabilitySpotAzure, databricks.AvailabilityOnDemandAzure)},
+						Description: "Availability of the instances: SPOT_AZURE or ON_DEMAND_AZURE",
+					},
+					"spot_bid_max_price": schema.Float64Attribute{
+						Optional:    true,
+						Description: "Highest price paid for a spot instance, in US dollars, or -1 to pay up to the on-demand price. Defaults to -1",
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Description: "Azure availability of the instances. Defaults to on-demand instances",
+			},
+			"custom_tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Tags added to the pool instances and to the cloud resources they run on",
+			},
+			"custom_tags_all": mergedTagsAttribute("pool instances", "custom_tags"),
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the instance pool, such as ACTIVE",
+			},
+		},
+	}
+}
+
+// ModifyPlan merges the provider default tags into custom_tags_all. The tags
+// of a pool cannot be edited, so a change of the default tags replaces it.
+func (r *DatabricksInstancePoolResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMergedTags(ctx, r.defaultTags, path.Root("custom_tags"), path.Root("custom_tags_all"), req, resp)
+	if resp.Diagnostics.HasError() || req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior types.Map
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("custom_tags_all"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("custom_tags_all"), &prior)...)
+	if !resp.Diagnostics.HasError() && !planned.Equal(prior) {
+		resp.RequiresReplace.Append(path.Root("custom_tags_all"))
+	}
+}
+
+// ValidateConfig checks that min_idle_instances fits in max_capacity and that
+// at most one Spark version is preloaded.
+func (r *DatabricksInstancePoolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksInstancePoolResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.MaxCapacity.IsNull() && !config.MaxCapacity.IsUnknown() && !config.MinIdleInstances.IsUnknown() &&
+		config.MinIdleInstances.ValueInt64() > config.MaxCapacity.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_idle_instances"),
+			"Invalid instance pool size",
+			"min_idle_instances must not be greater than max_capacity.",
+		)
+	}
+	if !config.PreloadedSparkVersions.IsUnknown() && len(config.PreloadedSparkVersions.Elements()) > 1 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("preloaded_spark_versions"),
+			"Invalid preloaded_spark_versions",
+			"At most one Spark version can be preloaded.",
+		)
+	}
+}
+
+// instancePoolSpec builds the create and edit request from the plan.
+func instancePoolSpec(ctx context.Context, plan *databricksInstancePoolResourceModel) (databricks.InstancePoolSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	spec := databricks.InstancePoolSpec{
+		InstancePoolID:                     plan.Id.ValueString(),
+		InstancePoolName:                   plan.InstancePoolName.ValueString(),
+		NodeTypeID:                         plan.NodeTypeId.ValueString(),
+		MinIdleInstances:                   plan.MinIdleInstances.ValueInt64(),
+		MaxCapacity:                        plan.MaxCapacity.ValueInt64(),
+		IdleInstanceAutoterminationMinutes: plan.IdleInstanceAutoterminationMinutes.ValueInt64(),
+	}
+	if a := plan.AzureAttributes; a != nil {
+		spec.AzureAttributes = &databricks.InstancePoolAzureAttrs{
+			Availability:    a.Availability.ValueString(),
+			SpotBidMaxPrice: -1,
+		}
+		if !a.SpotBidMaxPrice.IsNull() {
+			spec.AzureAttributes.SpotBidMaxPrice = a.SpotBidMaxPrice.ValueFloat64()
+		}
+	}
+	diags.Append(plan.PreloadedSparkVersions.ElementsAs(ctx, &spec.PreloadedSparkVersions, false)...)
+	diags.Append(plan.CustomTagsAll.ElementsAs(ctx, &spec.CustomTags, false)...)
+	return spec, diags
+}
+
+// setInstancePoolInfo copies the pool description into the model. Optional
+// attributes that were not configured stay null, and azure_attributes is only
+// refreshed when configured since the API reports the defaults. Custom tags
+// equal to the provider defaults are only kept in custom_tags_all.
+func setInstancePoolInfo(ctx context.Context, model *databricksInstancePoolResourceModel, info *databricks.InstancePoolInfo, defaultTags map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.Id = types.StringValue(info.InstancePoolID)
+	model.InstancePoolName = types.StringValue(info.InstancePoolName)
+	model.NodeTypeId = types.StringValue(info.NodeTypeID)
+	model.MinIdleInstances = types.Int64Value(info.MinIdleInstances)
+	model.IdleInstanceAutoterminationMinutes = types.Int64Value(info.IdleInstanceAutoterminationMinutes)
+	model.State = types.StringValue(info.State)
+	if !model.MaxCapacity.IsNull() || info.MaxCapacity != 0 {
+		model.MaxCapacity = types.Int64Value(info.MaxCapacity)
+	}
+	if model.AzureAttributes != nil && info.AzureAttributes != nil {
+		model.AzureAttributes.Availability = types.StringValue(info.AzureAttributes.Availability)
+		if !model.AzureAttributes.SpotBidMaxPrice.IsNull() {
+			model.AzureAttributes.SpotBidMaxPrice = types.Float64Value(info.AzureAttributes.SpotBidMaxPrice)
+		}
+	}
+
+	var d diag.Diagnostics
+	if len(info.PreloadedSparkVersions) > 0 || !model.PreloadedSparkVersions.IsNull() {
+		model.PreloadedSparkVersions, d = types.ListValueFrom(ctx, types.StringType, info.PreloadedSparkVersions)
+		diags.Append(d...)
+	}
+	model.CustomTags, model.CustomTagsAll, d = readTags(ctx, defaultTags, model.CustomTags, info.CustomTags)
+	diags.Append(d...)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksInstancePoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_instance_pool.Create")
+	defer span.End()
+
+	var plan databricksInstancePoolResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := instancePoolSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	poolID, err := client.CreateInstancePool(ctx, spec)
+	r.audit.Record(ctx, "mrl_databricks_instance_pool", auditActionCreate, poolID, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating instance pool",
+			"Could not create instance pool "+plan.InstancePoolName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(poolID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	info, err := client.GetInstancePool(ctx, poolID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance pool",
+			"Could not read instance pool "+poolID+" after creating it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setInstancePoolInfo(ctx, &plan, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksInstancePoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_instance_pool.Read")
+	defer span.End()
+
+	var state databricksInstancePoolResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.GetInstancePool(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) || (err == nil && info.State == databricks.InstancePoolDeleted) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance pool",
+			"Could not read instance pool "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setInstancePoolInfo(ctx, &state, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksInstancePoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_instance_pool.Update")
+	defer span.End()
+
+	var plan databricksInstancePoolResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := instancePoolSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.EditInstancePool(ctx, spec)
+	r.audit.Record(ctx, "mrl_databricks_instance_pool", auditActionUpdate, plan.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating instance pool",
+			"Could not edit instance pool "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	info, err := client.GetInstancePool(ctx, plan.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading instance pool",
+			"Could not read instance pool "+plan.Id.ValueString()+" after editing it: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setInstancePoolInfo(ctx, &plan, info, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksInstancePoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_instance_pool.Delete")
+	defer span.End()
+
+	var state databricksInstancePoolResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteInstancePool(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_instance_pool", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting instance pool",
+			"Could not delete instance pool "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksInstancePoolTypeName = "mrl_databricks_instance_pool"
+
+// mockInstancePools serves the instance pools API of a mock workspace:
+// create, get, edit and delete. Deleted pools are reported as DELETED.
+type mockInstancePools struct {
+	pools map[string]databricks.InstancePoolInfo
+	next  int
+}
+
+// newMockInstancePools adds the instance pools API to m.
+func newMockInstancePools(m *mockDbfs) *mockInstancePools {
+	pools := &mockInstancePools{pools: map[string]databricks.InstancePoolInfo{}}
+	m.route("/api/2.0/instance-pools/", pools.serveHTTP)
+	return pools
+}
+
+func (m *mockInstancePools) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var spec databricks.InstancePoolSpec
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	if r.Method == http.MethodGet {
+		spec.InstancePoolID = r.URL.Query().Get("instance_pool_id")
+	}
+
+	if r.URL.Path == "/api/2.0/instance-pools/create" {
+		m.next++
+		spec.InstancePoolID = "pool-" + strconv.Itoa(m.next)
+		m.pools[spec.InstancePoolID] = databricks.InstancePoolInfo{InstancePoolSpec: spec, State: databricks.InstancePoolActive}
+		writeMockJSON(w, map[string]interface{}{"instance_pool_id": spec.InstancePoolID})
+		return
+	}
+
+	current, ok := m.pools[spec.InstancePoolID]
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Instance pool "+spec.InstancePoolID+" does not exist")
+		return
+	}
+	switch r.URL.Path {
+	case "/api/2.0/instance-pools/get":
+		writeMockJSON(w, current)
+	case "/api/2.0/instance-pools/edit":
+		spec.CustomTags = current.CustomTags
+		m.pools[spec.InstancePoolID] = databricks.InstancePoolInfo{InstancePoolSpec: spec, State: current.State}
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/instance-pools/delete":
+		current.State = databricks.InstancePoolDeleted
+		m.pools[spec.InstancePoolID] = current
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+func TestDatabricksInstancePoolResource_defaultTags(t *testing.T) {
+	m := newMockDbfs(t)
+	pools := newMockInstancePools(m)
+	providerConfig := func(team string) map[string]interface{} {
+		return map[string]interface{}{
+			"default_tags": map[string]string{"team": team, "env": "prod"},
+			"databricks": map[string]interface{}{
+				"host":  m.server.URL,
+				"token": mockDatabricksToken,
+			},
+		}
+	}
+	p := newTestProvider(t, providerConfig("data"))
+	typeName := databricksInstancePoolTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	attrs := map[string]interface{}{
+		"instance_pool_name": "etl",
+		"node_type_id":       "Standard_DS3_v2",
+		"custom_tags":        map[string]string{"env": "dev"},
+	}
+
+	state := p.apply(typeName, null, p.config(typeName, attrs))
+	id := stringAttr(t, state, "id")
+	if got := pools.pools[id].CustomTags; len(got) != 2 || got["team"] != "data" || got["env"] != "dev" {
+		t.Errorf("created with custom_tags %v, want the defaults merged with the resource tags", got)
+	}
+	if got := mapAttr(t, state, "custom_tags"); len(got) != 1 || got["env"] != "dev" {
+		t.Errorf("custom_tags is %v, want only the configured env", got)
+	}
+	if refreshed := p.refresh(typeName, state); !refreshed.Equal(state) {
+		t.Errorf("refresh changed the state to %s, want %s", refreshed, state)
+	}
+	if planned, diags := p.plan(typeName, state, p.config(typeName, attrs)); errorDiagnostics(diags) != "" || !planned.Equal(state) {
+		t.Errorf("planned %s with %s, want no change", planned, errorDiagnostics(diags))
+	}
+
+	// The tags of a pool cannot be edited, so new default tags replace it.
+	p = newTestProvider(t, providerConfig("platform"))
+	planned, replace, diags := p.planReplace(typeName, state, p.config(typeName, attrs))
+	if errorDiagnostics(diags) != "" {
+		t.Fatalf("plan: %s", errorDiagnostics(diags))
+	}
+	if len(replace) != 1 || replace[0].String() != tftypes.NewAttributePath().WithAttributeName("custom_tags_all").String() {
+		t.Errorf("plan replaces the pool for %v, want custom_tags_all", replace)
+	}
+	if got := mapAttr(t, planned, "custom_tags_all"); got["team"] != "platform" {
+		t.Errorf("planned custom_tags_all is %v, want team platform", got)
+	}
+}
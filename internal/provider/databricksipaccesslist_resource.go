@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &DatabricksIPAccessListResource{}
+	_ resource.ResourceWithConfigure  = &DatabricksIPAccessListResource{}
+	_ resource.ResourceWithModifyPlan = &DatabricksIPAccessListResource{}
+)
+
+// NewDatabricksIPAccessListResource is a helper function to simplify the provider implementation.
+func NewDatabricksIPAccessListResource() resource.Resource {
+	return &DatabricksIPAccessListResource{}
+}
+
+// DatabricksIPAccessListResource is the resource implementation.
+type DatabricksIPAccessListResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksIPAccessListResourceModel maps the resource schema data.
+type databricksIPAccessListResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl    URLValue     `tfsdk:"workspace_url"`
+	Token           types.String `tfsdk:"token"`
+	Label           types.String `tfsdk:"label"`
+	ListType        types.String `tfsdk:"list_type"`
+	IPAddresses     types.Set    `tfsdk:"ip_addresses"`
+	Enabled         types.Bool   `tfsdk:"enabled"`
+	CallerIPAddress types.String `tfsdk:"caller_ip_address"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksIPAccessListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksIPAccessListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_ip_access_list"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksIPAccessListResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an IP access list allowing or blocking IPv4 addresses and CIDR ranges from the workspace. Lists only take effect once enableIpAccessLists is set, such as with mrl_databricks_workspace_conf. Set caller_ip_address to have plans check that the list does not lock Terraform out.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the IP access list",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token of a workspace admin. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"label": schema.StringAttribute{
+				Required:    true,
+				Description: "Label of the list",
+			},
+			"list_type": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{StringOneOf(databricks.IPAccessListAllow, databricks.IPAccessListBlock)},
+				Description: "ALLOW to allow the addresses, or BLOCK to block them even when an ALLOW list contains them",
+			},
+			"ip_addresses": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Validators:  []validator.Set{IPAddresses()},
+				Description: "IPv4 addresses and CIDR ranges of the list, such as 203.0.113.7 or 198.51.100.0/24",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the list is enforced. Defaults to true",
+			},
+			"caller_ip_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "Public IPv4 address Terraform reaches the workspace from. When set, plans fail if an enabled BLOCK list contains it, and warn if an enabled ALLOW list does not",
+			},
+		},
+	}
+}
+
+// ModifyPlan reports lists that would lock out caller_ip_address.
+func (r *DatabricksIPAccessListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksIPAccessListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.CallerIPAddress.IsNull() || plan.CallerIPAddress.IsUnknown() ||
+		plan.IPAddresses.IsUnknown() || plan.ListType.IsUnknown() || !plan.Enabled.ValueBool() {
+		return
+	}
+
+	caller := net.ParseIP(plan.CallerIPAddress.ValueString())
+	if caller == nil || caller.To4() == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("caller_ip_address"),
+			"Invalid caller_ip_address",
+			"caller_ip_address must be an IPv4 address such as 203.0.113.7.",
+		)
+		return
+	}
+
+	var addresses []string
+	resp.Diagnostics.Append(plan.IPAddresses.ElementsAs(ctx, &addresses, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	contained := false
+	for _, address := range addresses {
+		if network := parseIPv4Range(address); network != nil && network.Contains(caller) {
+			contained = true
+			break
+		}
+	}
+
+	switch {
+	case plan.ListType.ValueString() == databricks.IPAccessListBlock && contained:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ip_addresses"),
+			"IP access list would lock out Terraform",
+			fmt.Sprintf("The BLOCK list contains caller_ip_address %s, so Terraform could no longer reach the workspace. Remove the address from ip_addresses or disable the list.", caller),
+		)
+	case plan.ListType.ValueString() == databricks.IPAccessListAllow && !contained:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("ip_addresses"),
+			"IP access list does not allow Terraform",
+			fmt.Sprintf("The ALLOW list does not contain caller_ip_address %s. Unless another ALLOW list contains it, Terraform can no longer reach the workspace.", caller),
+		)
+	}
+}
+
+// ipAccessList returns the list of model.
+func ipAccessList(ctx context.Context, model *databricksIPAccessListResourceModel) (databricks.IPAccessList, diag.Diagnostics) {
+	list := databricks.IPAccessList{
+		ListID:   model.Id.ValueString(),
+		Label:    model.Label.ValueString(),
+		ListType: model.ListType.ValueString(),
+		Enabled:  model.Enabled.ValueBool(),
+	}
+	diags := model.IPAddresses.ElementsAs(ctx, &list.IPAddresses, false)
+	return list, diags
+}
+
+// Create a new resource.
+func (r *DatabricksIPAccessListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_ip_access_list.Create")
+	defer span.End()
+
+	var plan databricksIPAccessListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	list, diags := ipAccessList(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	created, err := client.CreateIPAccessList(ctx, list)
+	target := list.Label
+	if created != nil {
+		target = created.ListID
+	}
+	r.audit.Record(ctx, "mrl_databricks_ip_access_list", auditActionCreate, target, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating IP access list", "Could not create IP access list "+list.Label+": "+err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(created.ListID)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	// Lists are created enabled.
+	if !list.Enabled {
+		list.ListID = created.ListID
+		if err := client.ReplaceIPAccessList(ctx, list); err != nil {
+			resp.Diagnostics.AddError("Error creating IP access list", "Could not disable IP access list "+created.ListID+": "+err.Error())
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksIPAccessListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_ip_access_list.Read")
+	defer span.End()
+
+	var state databricksIPAccessListResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	list, err := client.GetIPAccessList(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading IP access list", "Could not read IP access list "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	addresses, diags := types.SetValueFrom(ctx, types.StringType, list.IPAddresses)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Label = types.StringValue(list.Label)
+	state.ListType = types.StringValue(list.ListType)
+	state.IPAddresses = addresses
+	state.Enabled = types.BoolValue(list.Enabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the list in place.
+func (r *DatabricksIPAccessListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_ip_access_list.Update")
+	defer span.End()
+
+	var plan databricksIPAccessListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	list, diags := ipAccessList(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.ReplaceIPAccessList(ctx, list)
+	r.audit.Record(ctx, "mrl_databricks_ip_access_list", auditActionUpdate, list.ListID, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating IP access list", "Could not update IP access list "+list.ListID+": "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksIPAccessListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_ip_access_list.Delete")
+	defer span.End()
+
+	var state databricksIPAccessListResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteIPAccessList(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_ip_access_list", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting IP access list", "Could not delete IP access list "+state.Id.ValueString()+": "+err.Error())
+	}
+}
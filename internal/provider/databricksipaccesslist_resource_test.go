@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksIPAccessListTypeName = "mrl_databricks_ip_access_list"
+
+// mockIPAccessLists serves the IP access lists API of a mock workspace:
+// create, get, replace and delete.
+type mockIPAccessLists struct {
+	lists map[string]databricks.IPAccessList
+	next  int
+}
+
+// newMockIPAccessLists adds the IP access lists API to m.
+func newMockIPAccessLists(m *mockDbfs) *mockIPAccessLists {
+	lists := &mockIPAccessLists{lists: map[string]databricks.IPAccessList{}}
+	m.route("/api/2.0/ip-access-lists", lists.serveHTTP)
+	return lists
+}
+
+func (m *mockIPAccessLists) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var list databricks.IPAccessList
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+		list.AddressCount = int64(len(list.IPAddresses))
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/api/2.0/ip-access-lists" {
+		m.next++
+		list.ListID = "list-" + strconv.Itoa(m.next)
+		list.Enabled = true
+		m.lists[list.ListID] = list
+		writeMockJSON(w, map[string]interface{}{"ip_access_list": list})
+		return
+	}
+
+	id := strings.TrimPrefix(r.URL.Path, "/api/2.0/ip-access-lists/")
+	if _, ok := m.lists[id]; !ok {
+		writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Can't find an IP access list with id: "+id+".")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeMockJSON(w, map[string]interface{}{"ip_access_list": m.lists[id]})
+	case http.MethodPut:
+		list.ListID = id
+		m.lists[id] = list
+		writeMockJSON(w, map[string]interface{}{})
+	case http.MethodDelete:
+		delete(m.lists, id)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+// ipAddressesValue returns addresses as the value of ip_addresses.
+func ipAddressesValue(addresses ...string) tftypes.Value {
+	elems := make([]tftypes.Value, len(addresses))
+	for i, address := range addresses {
+		elems[i] = tftypes.NewValue(tftypes.String, address)
+	}
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+}
+
+func TestDatabricksIPAccessListResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	lists := newMockIPAccessLists(m)
+	var id string
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksIPAccessListTypeName,
+		config: map[string]interface{}{
+			"label":             "office",
+			"list_type":         databricks.IPAccessListAllow,
+			"ip_addresses":      ipAddressesValue("203.0.113.0/24"),
+			"caller_ip_address": "203.0.113.7",
+		},
+		created: func(t *testing.T, state tftypes.Value) {
+			id = stringAttr(t, state, "id")
+			if got := lists.lists[id]; !got.Enabled || got.ListType != databricks.IPAccessListAllow {
+				t.Errorf("list is %+v, want an enabled ALLOW list", got)
+			}
+		},
+		updates: []lifecycleUpdate{{
+			config: map[string]interface{}{
+				"label":             "office",
+				"list_type":         databricks.IPAccessListAllow,
+				"ip_addresses":      ipAddressesValue("203.0.113.0/24", "198.51.100.7"),
+				"enabled":           false,
+				"caller_ip_address": "203.0.113.7",
+			},
+			check: func(t *testing.T, _ tftypes.Value) {
+				if got := lists.lists[id]; got.Enabled || len(got.IPAddresses) != 2 {
+					t.Errorf("list is %+v after update, want a disabled list of 2 addresses", got)
+				}
+			},
+		}},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := lists.lists[id]; ok {
+				t.Error("list still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksIPAccessListResource_lockout(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksIPAccessListTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	config := func(listType string, enabled bool, addresses ...string) tftypes.Value {
+		return p.config(typeName, map[string]interface{}{
+			"label":             "lockout",
+			"list_type":         listType,
+			"ip_addresses":      ipAddressesValue(addresses...),
+			"enabled":           enabled,
+			"caller_ip_address": "203.0.113.7",
+		})
+	}
+
+	_, diags := p.plan(typeName, null, config(databricks.IPAccessListBlock, true, "198.51.100.7", "203.0.113.0/24"))
+	if !strings.Contains(errorDiagnostics(diags), "would lock out Terraform") {
+		t.Errorf("got diagnostics %q, want the lockout of caller_ip_address", errorDiagnostics(diags))
+	}
+
+	_, diags = p.plan(typeName, null, config(databricks.IPAccessListBlock, false, "203.0.113.7"))
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Errorf("plan of a disabled BLOCK list: %s", msg)
+	}
+	_, diags = p.plan(typeName, null, config(databricks.IPAccessListAllow, true, "198.51.100.0/24"))
+	if msg := errorDiagnostics(diags); msg != "" {
+		t.Errorf("plan of an ALLOW list without caller_ip_address: %s", msg)
+	}
+	if !strings.Contains(warningDiagnostics(diags), "does not allow Terraform") {
+		t.Errorf("got warnings %q, want one about the ALLOW list not containing caller_ip_address", warningDiagnostics(diags))
+	}
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/list/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ list.ListResource              = &DatabricksJobListResource{}
+	_ list.ListResourceWithConfigure = &DatabricksJobListResource{}
+)
+
+// NewDatabricksJobListResource is a helper function to simplify the provider implementation.
+func NewDatabricksJobListResource() list.ListResource {
+	return &DatabricksJobListResource{}
+}
+
+// DatabricksJobListResource lists the jobs of a workspace as
+// mrl_databricks_job instances, for bulk import of jobs created outside
+// Terraform.
+type DatabricksJobListResource struct {
+	httpClient  *http.Client
+	workspace   databricksWorkspace
+	defaultTags map[string]string
+}
+
+// databricksJobListConfigModel maps the list configuration.
+type databricksJobListConfigModel struct {
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+}
+
+// Configure adds the provider configured client to the list resource.
+func (l *DatabricksJobListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected List Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	l.httpClient = providerData.HTTPClient
+	l.workspace = providerData.Databricks
+	l.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the type name of the listed resource.
+func (l *DatabricksJobListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_job"
+}
+
+// ListResourceConfigSchema defines the schema of list blocks.
+func (l *DatabricksJobListResource) ListResourceConfigSchema(_ context.Context, _ list.ListResourceSchemaRequest, resp *list.ListResourceSchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the jobs of a workspace. Authenticates with the provider databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of the provider Azure service principal.",
+		Attributes: map[string]schema.Attribute{
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+		},
+	}
+}
+
+// List streams the jobs of the workspace.
+func (l *DatabricksJobListResource) List(ctx context.Context, req list.ListRequest, stream *list.ListResultsStream) {
+	ctx, span := tracing.Start(ctx, "list.mrl_databricks_job.List")
+	defer span.End()
+
+	var config databricksJobListConfigModel
+	diags := req.Config.Get(ctx, &config)
+	if diags.HasError() {
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	adbID, token, err := l.workspace.resolve(workspaceURL(config.WorkspaceUrl, config.AdbId), types.StringNull())
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+	client := l.workspace.newClient(l.httpClient, adbID, token)
+	jobs, err := client.ListJobs(ctx)
+	if err != nil {
+		diags.AddError("Error listing jobs", "Could not list the jobs: "+err.Error())
+		stream.Results = list.ListResultsStreamDiagnostics(diags)
+		return
+	}
+
+	stream.Results = func(push func(list.ListResult) bool) {
+		for i := range jobs {
+			if req.Limit > 0 && int64(i) >= req.Limit {
+				return
+			}
+			job := &jobs[i]
+			id := strconv.FormatInt(job.JobID, 10)
+
+			result := req.NewListResult(ctx)
+			result.DisplayName = job.Settings.Name
+			result.Diagnostics.Append(setJobIdentity(ctx, result.Identity, adbID, id)...)
+			if req.IncludeResource {
+				model := databricksJobResourceModel{
+					Id:           types.StringValue(id),
+					WorkspaceUrl: NewURLValue(adbID),
+					Token:        types.StringNull(),
+				}
+				result.Diagnostics.Append(setJobSettings(ctx, &model, &job.Settings, l.defaultTags)...)
+				result.Diagnostics.Append(result.Resource.Set(ctx, model)...)
+			}
+
+			if !push(result) {
+				return
+			}
+		}
+	}
+}
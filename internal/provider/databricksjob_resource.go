@@ -0,0 +1,741 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// databricksJobTaskKey is the key of the single task of a job.
+const databricksJobTaskKey = "main"
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksJobResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksJobResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksJobResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksJobResource{}
+	_ resource.ResourceWithImportState    = &DatabricksJobResource{}
+	_ resource.ResourceWithIdentity       = &DatabricksJobResource{}
+)
+
+// NewDatabricksJobResource is a helper function to simplify the provider implementation.
+func NewDatabricksJobResource() resource.Resource {
+	return &DatabricksJobResource{}
+}
+
+// DatabricksJobResource is the resource implementation.
+type DatabricksJobResource struct {
+	httpClient  *http.Client
+	workspace   databricksWorkspace
+	audit       *auditLogger
+	defaultTags map[string]string
+}
+
+type databricksJobResourceModel struct {
+	Id                types.String          `tfsdk:"id"`
+	AdbId             URLValue              `tfsdk:"adb_id"`
+	WorkspaceUrl      URLValue              `tfsdk:"workspace_url"`
+	Token             types.String          `tfsdk:"token"`
+	Name              types.String          `tfsdk:"name"`
+	ExistingClusterId types.String          `tfsdk:"existing_cluster_id"`
+	NewCluster        *jobNewClusterModel   `tfsdk:"new_cluster"`
+	NotebookTask      *jobNotebookTaskModel `tfsdk:"notebook_task"`
+	SparkJarTask      *jobSparkJarTaskModel `tfsdk:"spark_jar_task"`
+	Schedule          *jobScheduleModel     `tfsdk:"schedule"`
+	MaxConcurrentRuns types.Int64           `tfsdk:"max_concurrent_runs"`
+}
+
+// databricksJobResourceIdentityModel identifies a job across workspaces.
+type databricksJobResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	JobId        types.String `tfsdk:"job_id"`
+}
+
+// jobNewClusterModel maps the cluster created for each run.
+type jobNewClusterModel struct {
+	SparkVersion  types.String           `tfsdk:"spark_version"`
+	NodeTypeId    types.String           `tfsdk:"node_type_id"`
+	NumWorkers    types.Int64            `tfsdk:"num_workers"`
+	Autoscale     *clusterAutoscaleModel `tfsdk:"autoscale"`
+	SparkConf     types.Map              `tfsdk:"spark_conf"`
+	CustomTags    types.Map              `tfsdk:"custom_tags"`
+	CustomTagsAll types.Map              `tfsdk:"custom_tags_all"`
+	InitScripts   types.List             `tfsdk:"init_scripts"`
+}
+
+// jobNotebookTaskModel maps a notebook task.
+type jobNotebookTaskModel struct {
+	NotebookPath   types.String `tfsdk:"notebook_path"`
+	BaseParameters types.Map    `tfsdk:"base_parameters"`
+}
+
+// jobSparkJarTaskModel maps a JAR task and the JARs it needs.
+type jobSparkJarTaskModel struct {
+	MainClassName types.String `tfsdk:"main_class_name"`
+	Parameters    types.List   `tfsdk:"parameters"`
+	JarPaths      types.List   `tfsdk:"jar_paths"`
+}
+
+// jobScheduleModel maps the cron schedule.
+type jobScheduleModel struct {
+	QuartzCronExpression types.String `tfsdk:"quartz_cron_expression"`
+	TimezoneId           types.String `tfsdk:"timezone_id"`
+	PauseStatus          types.String `tfsdk:"pause_status"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksJobResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksJobResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_job"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksJobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Databricks job that runs a notebook or the main class of a JAR, on an existing cluster or on a new cluster for each run, optionally on a schedule.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the job",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the job",
+			},
+			"existing_cluster_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the cluster the job runs on, such as the id of a mrl_databricks_cluster. Conflicts with new_cluster",
+			},
+			"new_cluster": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"spark_version": schema.StringAttribute{
+						Required:    true,
+						Description: "Databricks Runtime version key, such as 15.4.x-scala2.12",
+					},
+					"node_type_id": schema.StringAttribute{
+						Required:    true,
+						Description: "Node type of the driver and workers, such as Standard_DS3_v2",
+					},
+					"num_workers": schema.Int64Attribute{
+						Optional:    true,
+						Validators:  []validator.Int64{Int64Between(0, 100000)},
+						Description: "Fixed number of workers. Conflicts with autoscale",
+					},
+					"autoscale": schema.SingleNestedAttribute{
+						Optional: true,
+						Attributes: map[string]schema.Attribute{
+							"min_workers": schema.Int64Attribute{
+								Required:    true,
+								Validators:  []validator.Int64{Int64Between(0, 100000)},
+								Description: "Minimum number of workers",
+							},
+							"max_workers": schema.Int64Attribute{
+								Required:    true,
+								Validators:  []validator.Int64{Int64Between(1, 100000)},
+								Description: "Maximum number of workers",
+							},
+						},
+						Description: "Bounds the number of workers the cluster scales between. Conflicts with num_workers",
+					},
+					"spark_conf": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Spark configuration key-value pairs",
+					},
+					"custom_tags": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Tags added to the cluster and to the cloud resources it runs on",
+					},
+					"custom_tags_all": mergedTagsAttribute("cluster", "custom_tags"),
+					"init_scripts": schema.ListAttribute{
+						Optional:    true,
+						ElementType: DbfsPathType{},
+						Description: "DBFS paths of the scripts run on every node when the cluster starts, in order",
+					},
+				},
+				Description: "Cluster created for each run and terminated after it. Conflicts with existing_cluster_id",
+			},
+			"notebook_task": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"notebook_path": schema.StringAttribute{
+						Required:    true,
+						Description: "Absolute workspace path of the notebook",
+					},
+					"base_parameters": schema.MapAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Values of the notebook widgets",
+					},
+				},
+				Description: "Runs a notebook. Conflicts with spark_jar_task",
+			},
+			"spark_jar_task": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"main_class_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Full name of the class with the main method",
+					},
+					"parameters": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Arguments passed to the main method",
+					},
+					"jar_paths": schema.ListAttribute{
+						Required:    true,
+						ElementType: DbfsPathType{},
+						Description: "DBFS paths of the JARs installed on the cluster, such as the dbfs_path of a mrl_databricks_dbfs_file",
+					},
+				},
+				Description: "Runs the main class of a JAR. Conflicts with notebook_task",
+			},
+			"schedule": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"quartz_cron_expression": schema.StringAttribute{
+						Required:    true,
+						Description: "Quartz cron expression of the run times, such as 0 0 2 * * ?. provider::mrl::cron_to_quartz converts a Unix cron expression",
+					},
+					"timezone_id": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("UTC"),
+						Description: "Java time zone ID the expression is evaluated in. Defaults to UTC",
+					},
+					"pause_status": schema.StringAttribute{
+						Optional:    true,
+						Computed:    true,
+						Default:     stringdefault.StaticString("UNPAUSED"),
+						Validators:  []validator.String{StringOneOf("PAUSED", "UNPAUSED")},
+						Description: "PAUSED or UNPAUSED. Defaults to UNPAUSED",
+					},
+				},
+				Description: "Runs the job on a cron schedule",
+			},
+			"max_concurrent_runs": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators:  []validator.Int64{Int64Between(1, 1000)},
+				Description: "Maximum number of runs of the job at the same time. Defaults to 1",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a job: the workspace and the job ID.
+func (r *DatabricksJobResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"job_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the job",
+			},
+		},
+	}
+}
+
+// setJobIdentity records the identity of the job jobID in the workspace
+// adbID. Terraform versions without identity support pass a nil identity.
+func setJobIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, jobID string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksJobResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		JobId:        types.StringValue(jobID),
+	})
+}
+
+// ImportState imports a job by workspace_url|job_id or by identity. Read fills in
+// its settings.
+func (r *DatabricksJobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksJobResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "job_id")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.JobId = types.StringValue(parts[1])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if _, err := jobID(identity.JobId); err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", "Job ID "+identity.JobId.ValueString()+" is not a number.")
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.JobId.ValueString())...)
+}
+
+// ModifyPlan merges the provider default tags into the custom_tags_all of
+// new_cluster.
+func (r *DatabricksJobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	cluster := path.Root("new_cluster")
+	modifyPlanMergedTags(ctx, r.defaultTags, cluster.AtName("custom_tags"), cluster.AtName("custom_tags_all"), req, resp)
+}
+
+// ValidateConfig checks that the job has exactly one cluster and one task.
+func (r *DatabricksJobResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksJobResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.ExistingClusterId.IsNull() == (config.NewCluster == nil) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("new_cluster"),
+			"Invalid job cluster",
+			"Exactly one of existing_cluster_id and new_cluster must be set.",
+		)
+	}
+	if (config.NotebookTask == nil) == (config.SparkJarTask == nil) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("notebook_task"),
+			"Invalid job task",
+			"Exactly one of notebook_task and spark_jar_task must be set.",
+		)
+	}
+	if c := config.NewCluster; c != nil && !c.NumWorkers.IsNull() && c.Autoscale != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("new_cluster").AtName("autoscale"),
+			"Conflicting cluster size",
+			"Only one of num_workers and autoscale can be set.",
+		)
+	}
+}
+
+// jobSettings builds the create and reset request from the plan.
+func jobSettings(ctx context.Context, plan *databricksJobResourceModel) (databricks.JobSettings, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	task := databricks.JobTask{
+		TaskKey:           databricksJobTaskKey,
+		ExistingClusterID: plan.ExistingClusterId.ValueString(),
+	}
+
+	if c := plan.NewCluster; c != nil {
+		task.NewCluster = &databricks.ClusterSpec{
+			SparkVersion: c.SparkVersion.ValueString(),
+			NodeTypeID:   c.NodeTypeId.ValueString(),
+			NumWorkers:   c.NumWorkers.ValueInt64(),
+		}
+		if c.Autoscale != nil {
+			task.NewCluster.Autoscale = &databricks.Autoscale{
+				MinWorkers: c.Autoscale.MinWorkers.ValueInt64(),
+				MaxWorkers: c.Autoscale.MaxWorkers.ValueInt64(),
+			}
+		}
+		diags.Append(c.SparkConf.ElementsAs(ctx, &task.NewCluster.SparkConf, false)...)
+		diags.Append(c.CustomTagsAll.ElementsAs(ctx, &task.NewCluster.CustomTags, false)...)
+		var scripts []DbfsPathValue
+		diags.Append(c.InitScripts.ElementsAs(ctx, &scripts, false)...)
+		for _, script := range scripts {
+			task.NewCluster.InitScripts = append(task.NewCluster.InitScripts, databricks.InitScript{
+				Dbfs: &databricks.InitScriptDestination{Destination: "dbfs:" + script.ValueNormalized()},
+			})
+		}
+	}
+
+	if t := plan.NotebookTask; t != nil {
+		task.NotebookTask = &databricks.NotebookTask{NotebookPath: t.NotebookPath.ValueString()}
+		diags.Append(t.BaseParameters.ElementsAs(ctx, &task.NotebookTask.BaseParameters, false)...)
+	}
+	if t := plan.SparkJarTask; t != nil {
+		task.SparkJarTask = &databricks.SparkJarTask{MainClassName: t.MainClassName.ValueString()}
+		diags.Append(t.Parameters.ElementsAs(ctx, &task.SparkJarTask.Parameters, false)...)
+		var jars []DbfsPathValue
+		diags.Append(t.JarPaths.ElementsAs(ctx, &jars, false)...)
+		for _, jar := range jars {
+			task.Libraries = append(task.Libraries, databricks.Library{Jar: "dbfs:" + jar.ValueNormalized()})
+		}
+	}
+
+	settings := databricks.JobSettings{
+		Name:              plan.Name.ValueString(),
+		Tasks:             []databricks.JobTask{task},
+		MaxConcurrentRuns: plan.MaxConcurrentRuns.ValueInt64(),
+	}
+	if s := plan.Schedule; s != nil {
+		settings.Schedule = &databricks.CronSchedule{
+			QuartzCronExpression: s.QuartzCronExpression.ValueString(),
+			TimezoneID:           s.TimezoneId.ValueString(),
+			PauseStatus:          s.PauseStatus.ValueString(),
+		}
+	}
+	return settings, diags
+}
+
+// setJobSettings copies the job settings into the model. Empty maps and lists
+// stay null when they were not configured, and new cluster custom tags equal
+// to the provider defaults are only kept in custom_tags_all.
+func setJobSettings(ctx context.Context, model *databricksJobResourceModel, settings *databricks.JobSettings, defaultTags map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	model.Name = types.StringValue(settings.Name)
+	model.MaxConcurrentRuns = types.Int64Value(settings.MaxConcurrentRuns)
+
+	model.Schedule = nil
+	if s := settings.Schedule; s != nil {
+		model.Schedule = &jobScheduleModel{
+			QuartzCronExpression: types.StringValue(s.QuartzCronExpression),
+			TimezoneId:           types.StringValue(s.TimezoneID),
+			PauseStatus:          types.StringValue(s.PauseStatus),
+		}
+	}
+
+	var task *databricks.JobTask
+	for i := range settings.Tasks {
+		if settings.Tasks[i].TaskKey == databricksJobTaskKey {
+			task = &settings.Tasks[i]
+		}
+	}
+	if task == nil {
+		// The task was replaced outside Terraform; plan to set it again.
+		model.ExistingClusterId = types.StringNull()
+		model.NewCluster = nil
+		model.NotebookTask = nil
+		model.SparkJarTask = nil
+		return diags
+	}
+
+	var d diag.Diagnostics
+	model.ExistingClusterId = types.StringNull()
+	if task.ExistingClusterID != "" {
+		model.ExistingClusterId = types.StringValue(task.ExistingClusterID)
+	}
+
+	if c := task.NewCluster; c == nil {
+		model.NewCluster = nil
+	} else {
+		prior := model.NewCluster
+		if prior == nil {
+			prior = &jobNewClusterModel{
+				NumWorkers:  types.Int64Null(),
+				SparkConf:   types.MapNull(types.StringType),
+				CustomTags:  types.MapNull(types.StringType),
+				InitScripts: types.ListNull(DbfsPathType{}),
+			}
+		}
+		cluster := &jobNewClusterModel{
+			SparkVersion: types.StringValue(c.SparkVersion),
+			NodeTypeId:   types.StringValue(c.NodeTypeID),
+			NumWorkers:   types.Int64Null(),
+		}
+		if c.Autoscale != nil {
+			cluster.Autoscale = &clusterAutoscaleModel{
+				MinWorkers: types.Int64Value(c.Autoscale.MinWorkers),
+				MaxWorkers: types.Int64Value(c.Autoscale.MaxWorkers),
+			}
+		} else if !prior.NumWorkers.IsNull() || c.NumWorkers != 0 {
+			cluster.NumWorkers = types.Int64Value(c.NumWorkers)
+		}
+		cluster.SparkConf, d = stringMapValue(ctx, prior.SparkConf, c.SparkConf)
+		diags.Append(d...)
+		cluster.CustomTags, cluster.CustomTagsAll, d = readTags(ctx, defaultTags, prior.CustomTags, c.CustomTags)
+		diags.Append(d...)
+		var scripts []string
+		for _, script := range c.InitScripts {
+			if script.Dbfs != nil {
+				scripts = append(scripts, script.Dbfs.Destination)
+			}
+		}
+		cluster.InitScripts, d = dbfsPathListValue(ctx, prior.InitScripts, scripts)
+		diags.Append(d...)
+		model.NewCluster = cluster
+	}
+
+	if t := task.NotebookTask; t == nil {
+		model.NotebookTask = nil
+	} else {
+		prior := types.MapNull(types.StringType)
+		if model.NotebookTask != nil {
+			prior = model.NotebookTask.BaseParameters
+		}
+		notebook := &jobNotebookTaskModel{NotebookPath: types.StringValue(t.NotebookPath)}
+		notebook.BaseParameters, d = stringMapValue(ctx, prior, t.BaseParameters)
+		diags.Append(d...)
+		model.NotebookTask = notebook
+	}
+
+	if t := task.SparkJarTask; t == nil {
+		model.SparkJarTask = nil
+	} else {
+		prior := &jobSparkJarTaskModel{
+			Parameters: types.ListNull(types.StringType),
+			JarPaths:   types.ListNull(DbfsPathType{}),
+		}
+		if model.SparkJarTask != nil {
+			prior = model.SparkJarTask
+		}
+		jar := &jobSparkJarTaskModel{
+			MainClassName: types.StringValue(t.MainClassName),
+			Parameters:    prior.Parameters,
+		}
+		if len(t.Parameters) > 0 || !prior.Parameters.IsNull() {
+			jar.Parameters, d = types.ListValueFrom(ctx, types.StringType, t.Parameters)
+			diags.Append(d...)
+		}
+		var jars []string
+		for _, library := range task.Libraries {
+			if library.Jar != "" {
+				jars = append(jars, library.Jar)
+			}
+		}
+		jar.JarPaths, d = dbfsPathListValue(ctx, prior.JarPaths, jars)
+		diags.Append(d...)
+		model.SparkJarTask = jar
+	}
+	return diags
+}
+
+// jobID parses the ID of the job in the state.
+func jobID(id types.String) (int64, error) {
+	return strconv.ParseInt(id.ValueString(), 10, 64)
+}
+
+// Create a new resource.
+func (r *DatabricksJobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_job.Create")
+	defer span.End()
+
+	var plan databricksJobResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := jobSettings(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	id, err := client.CreateJob(ctx, settings)
+	r.audit.Record(ctx, "mrl_databricks_job", auditActionCreate, strconv.FormatInt(id, 10), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating job",
+			"Could not create job "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.Id = types.StringValue(strconv.FormatInt(id, 10))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setJobIdentity(ctx, resp.Identity, host, plan.Id.ValueString())...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksJobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_job.Read")
+	defer span.End()
+
+	var state databricksJobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := jobID(state.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid job ID", "Could not parse job ID "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the job is gone.
+	resp.Diagnostics.Append(setJobIdentity(ctx, resp.Identity, host, state.Id.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	settings, err := client.GetJobSettings(ctx, id)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading job",
+			"Could not read job "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setJobSettings(ctx, &state, settings, r.defaultTags)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksJobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_job.Update")
+	defer span.End()
+
+	var plan databricksJobResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, diags := jobSettings(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := jobID(plan.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid job ID", "Could not parse job ID "+plan.Id.ValueString()+": "+err.Error())
+		return
+	}
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	err = client.ResetJob(ctx, id, settings)
+	r.audit.Record(ctx, "mrl_databricks_job", auditActionUpdate, plan.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating job",
+			"Could not reset job "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	resp.Diagnostics.Append(setJobIdentity(ctx, resp.Identity, host, plan.Id.ValueString())...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksJobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_job.Delete")
+	defer span.End()
+
+	var state databricksJobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := jobID(state.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid job ID", "Could not parse job ID "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteJob(ctx, id)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_job", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting job",
+			"Could not delete job "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksJobTypeName = "mrl_databricks_job"
+
+// mockJobs serves the jobs API of a mock workspace: create, get, list, reset
+// and delete.
+type mockJobs struct {
+	settings map[int64]map[string]interface{}
+	next     int64
+}
+
+// newMockJobs adds the jobs API to m.
+func newMockJobs(m *mockDbfs) *mockJobs {
+	jobs := &mockJobs{settings: map[int64]map[string]interface{}{}}
+	m.route("/api/2.1/jobs/", jobs.serveHTTP)
+	return jobs
+}
+
+func (m *mockJobs) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		JobID       int64                  `json:"job_id"`
+		NewSettings map[string]interface{} `json:"new_settings"`
+	}
+	var settings map[string]interface{}
+	if r.Method == http.MethodPost {
+		var raw json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+		_ = json.Unmarshal(raw, &body)
+		_ = json.Unmarshal(raw, &settings)
+	}
+	if r.Method == http.MethodGet {
+		body.JobID, _ = strconv.ParseInt(r.URL.Query().Get("job_id"), 10, 64)
+	}
+
+	switch r.URL.Path {
+	case "/api/2.1/jobs/create":
+		m.next++
+		m.settings[m.next] = settings
+		writeMockJSON(w, map[string]interface{}{"job_id": m.next})
+		return
+	case "/api/2.1/jobs/list":
+		ids := make([]int64, 0, len(m.settings))
+		for id := range m.settings {
+			ids = append(ids, id)
+		}
+		sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+		jobs := make([]map[string]interface{}, 0, len(ids))
+		for _, id := range ids {
+			jobs = append(jobs, map[string]interface{}{"job_id": id, "settings": m.settings[id]})
+		}
+		writeMockJSON(w, map[string]interface{}{"jobs": jobs})
+		return
+	}
+
+	current, ok := m.settings[body.JobID]
+	if !ok {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Job "+strconv.FormatInt(body.JobID, 10)+" does not exist.")
+		return
+	}
+	switch r.URL.Path {
+	case "/api/2.1/jobs/get":
+		writeMockJSON(w, map[string]interface{}{"job_id": body.JobID, "settings": current})
+	case "/api/2.1/jobs/reset":
+		m.settings[body.JobID] = body.NewSettings
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.1/jobs/delete":
+		delete(m.settings, body.JobID)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+func TestDatabricksJobResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	jobs := newMockJobs(m)
+	jarPaths := tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+		tftypes.NewValue(tftypes.String, "/FileStore/jars/etl.jar"),
+	})
+	config := func(maxConcurrentRuns interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                "nightly-etl",
+			"existing_cluster_id": "0923-164208-meows279",
+			"spark_jar_task": map[string]interface{}{
+				"main_class_name": "com.example.Etl",
+				"jar_paths":       jarPaths,
+			},
+			"max_concurrent_runs": maxConcurrentRuns,
+		}
+	}
+	var id string
+	var jobID int64
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksJobTypeName,
+		config:   config(nil),
+		created: func(t *testing.T, state tftypes.Value) {
+			id = stringAttr(t, state, "id")
+			jobID, _ = strconv.ParseInt(id, 10, 64)
+			settings, ok := jobs.settings[jobID]
+			if !ok {
+				t.Fatalf("job %s not created", id)
+			}
+			if got := mustJSON(t, settings["tasks"]); !strings.Contains(got, `"jar":"dbfs:/FileStore/jars/etl.jar"`) {
+				t.Errorf("created with tasks %s, want the JAR dbfs:/FileStore/jars/etl.jar", got)
+			}
+		},
+		identity: true,
+		updates: []lifecycleUpdate{{
+			config: config(2),
+			check: func(t *testing.T, _ tftypes.Value) {
+				if got := jobs.settings[jobID]["max_concurrent_runs"]; got != float64(2) {
+					t.Errorf("max_concurrent_runs is %v after update, want 2", got)
+				}
+			},
+		}},
+		importID:     func(tftypes.Value) string { return m.server.URL + "|" + id },
+		importVerify: []string{"name", "existing_cluster_id", "max_concurrent_runs"},
+		listed:       "nightly-etl",
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := jobs.settings[jobID]; ok {
+				t.Error("job still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksJobResource_defaultTags(t *testing.T) {
+	m := newMockDbfs(t)
+	jobs := newMockJobs(m)
+	p := newTestProvider(t, map[string]interface{}{
+		"default_tags": map[string]string{"team": "data", "env": "prod"},
+		"databricks": map[string]interface{}{
+			"host":  m.server.URL,
+			"token": mockDatabricksToken,
+		},
+	})
+	typeName := databricksJobTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	attrs := map[string]interface{}{
+		"name": "nightly-etl",
+		"new_cluster": map[string]interface{}{
+			"spark_version": "15.4.x-scala2.12",
+			"node_type_id":  "Standard_DS3_v2",
+			"num_workers":   2,
+			"custom_tags":   map[string]string{"env": "dev"},
+		},
+		"notebook_task": map[string]interface{}{
+			"notebook_path": "/Shared/etl",
+		},
+	}
+
+	state := p.apply(typeName, null, p.config(typeName, attrs))
+	jobID, _ := strconv.ParseInt(stringAttr(t, state, "id"), 10, 64)
+	if got := mustJSON(t, jobs.settings[jobID]["tasks"]); !strings.Contains(got, `"custom_tags":{"env":"dev","team":"data"}`) {
+		t.Errorf("created with tasks %s, want the defaults merged with the new_cluster tags", got)
+	}
+	cluster := stateAttr(t, state, "new_cluster")
+	if got := mapAttr(t, cluster, "custom_tags"); len(got) != 1 || got["env"] != "dev" {
+		t.Errorf("custom_tags is %v, want only the configured env", got)
+	}
+	if got := mapAttr(t, cluster, "custom_tags_all"); len(got) != 2 || got["team"] != "data" || got["env"] != "dev" {
+		t.Errorf("custom_tags_all is %v, want team data and env dev", got)
+	}
+	if refreshed := p.refresh(typeName, state); !refreshed.Equal(state) {
+		t.Errorf("refresh changed the state to %s, want %s", refreshed, state)
+	}
+	if planned, diags := p.plan(typeName, state, p.config(typeName, attrs)); errorDiagnostics(diags) != "" || !planned.Equal(state) {
+		t.Errorf("planned %s with %s, want no change", planned, errorDiagnostics(diags))
+	}
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksJobRunOutputDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksJobRunOutputDataSource{}
+)
+
+// NewDatabricksJobRunOutputDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksJobRunOutputDataSource() datasource.DataSource {
+	return &DatabricksJobRunOutputDataSource{}
+}
+
+// DatabricksJobRunOutputDataSource is the data source implementation.
+type DatabricksJobRunOutputDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksJobRunOutputDataSourceModel maps the data source schema data.
+type databricksJobRunOutputDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	AdbId             types.String `tfsdk:"adb_id"`
+	WorkspaceUrl      types.String `tfsdk:"workspace_url"`
+	Token             types.String `tfsdk:"token"`
+	RunId             types.Int64  `tfsdk:"run_id"`
+	JobId             types.Int64  `tfsdk:"job_id"`
+	LifeCycleState    types.String `tfsdk:"life_cycle_state"`
+	ResultState       types.String `tfsdk:"result_state"`
+	StateMessage      types.String `tfsdk:"state_message"`
+	NotebookResult    types.String `tfsdk:"notebook_result"`
+	NotebookTruncated types.Bool   `tfsdk:"notebook_result_truncated"`
+	Logs              types.String `tfsdk:"logs"`
+	Error             types.String `tfsdk:"error"`
+	ErrorTrace        types.String `tfsdk:"error_trace"`
+	RunPageUrl        types.String `tfsdk:"run_page_url"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksJobRunOutputDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksJobRunOutputDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_job_run_output"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksJobRunOutputDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the state and output of a job task run, such as the exit value of a bootstrap notebook.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the run",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"run_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "ID of the task run. Runs of multi-task jobs have no output; use the run ID of the task",
+			},
+			"job_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "ID of the job the run belongs to",
+			},
+			"life_cycle_state": schema.StringAttribute{
+				Computed:    true,
+				Description: "Life cycle state of the run, e.g. RUNNING or TERMINATED",
+			},
+			"result_state": schema.StringAttribute{
+				Computed:    true,
+				Description: "Result of the run, e.g. SUCCESS or FAILED, empty while it runs",
+			},
+			"state_message": schema.StringAttribute{
+				Computed:    true,
+				Description: "Message describing the state of the run",
+			},
+			"notebook_result": schema.StringAttribute{
+				Computed:    true,
+				Description: "Value the notebook passed to dbutils.notebook.exit(), empty for other task types",
+			},
+			"notebook_result_truncated": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether notebook_result was cut at the 5 MB output limit",
+			},
+			"logs": schema.StringAttribute{
+				Computed:    true,
+				Description: "Standard output of the task, for task types that capture it",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "Error message of a failed run",
+			},
+			"error_trace": schema.StringAttribute{
+				Computed:    true,
+				Description: "Stack trace of a failed run",
+			},
+			"run_page_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the run in the workspace UI",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksJobRunOutputDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_job_run_output.Read")
+	defer span.End()
+
+	var state databricksJobRunOutputDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	runID := strconv.FormatInt(state.RunId.ValueInt64(), 10)
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	output, err := client.GetRunOutput(ctx, state.RunId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading job run output",
+			"Could not read the output of run "+runID+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(runID)
+	state.JobId = types.Int64Value(output.Metadata.JobID)
+	state.LifeCycleState = types.StringValue(output.Metadata.State.LifeCycleState)
+	state.ResultState = types.StringValue(output.Metadata.State.ResultState)
+	state.StateMessage = types.StringValue(output.Metadata.State.StateMessage)
+	state.NotebookResult = types.StringValue("")
+	state.NotebookTruncated = types.BoolValue(false)
+	if output.NotebookOutput != nil {
+		state.NotebookResult = types.StringValue(output.NotebookOutput.Result)
+		state.NotebookTruncated = types.BoolValue(output.NotebookOutput.Truncated)
+	}
+	state.Logs = types.StringValue(output.Logs)
+	state.Error = types.StringValue(output.Error)
+	state.ErrorTrace = types.StringValue(output.ErrorTrace)
+	state.RunPageUrl = types.StringValue(output.Metadata.RunPageURL)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,553 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksLibraryResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksLibraryResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksLibraryResource{}
+	_ resource.ResourceWithImportState    = &DatabricksLibraryResource{}
+	_ resource.ResourceWithIdentity       = &DatabricksLibraryResource{}
+)
+
+// NewDatabricksLibraryResource is a helper function to simplify the provider implementation.
+func NewDatabricksLibraryResource() resource.Resource {
+	return &DatabricksLibraryResource{}
+}
+
+// DatabricksLibraryResource is the resource implementation.
+type DatabricksLibraryResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksLibraryResourceModel struct {
+	Id           types.String         `tfsdk:"id"`
+	AdbId        URLValue             `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue             `tfsdk:"workspace_url"`
+	Token        types.String         `tfsdk:"token"`
+	ClusterId    types.String         `tfsdk:"cluster_id"`
+	Jar          types.String         `tfsdk:"jar"`
+	Whl          types.String         `tfsdk:"whl"`
+	Pypi         *libraryPackageModel `tfsdk:"pypi"`
+	Maven        *libraryMavenModel   `tfsdk:"maven"`
+	Cran         *libraryPackageModel `tfsdk:"cran"`
+	Status       types.String         `tfsdk:"status"`
+	Timeouts     *timeoutsModel       `tfsdk:"timeouts"`
+}
+
+// databricksLibraryResourceIdentityModel identifies a library of a cluster
+// across workspaces.
+type databricksLibraryResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	ClusterId    types.String `tfsdk:"cluster_id"`
+	Library      types.String `tfsdk:"library"`
+}
+
+// libraryPackageModel maps a PyPI or CRAN package.
+type libraryPackageModel struct {
+	Package types.String `tfsdk:"package"`
+	Repo    types.String `tfsdk:"repo"`
+}
+
+// libraryMavenModel maps a Maven library.
+type libraryMavenModel struct {
+	Coordinates types.String `tfsdk:"coordinates"`
+	Repo        types.String `tfsdk:"repo"`
+	Exclusions  types.List   `tfsdk:"exclusions"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksLibraryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksLibraryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_library"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksLibraryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	packageAttributes := func(kind string) map[string]schema.Attribute {
+		return map[string]schema.Attribute{
+			"package": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the " + kind + " package, optionally with a version",
+			},
+			"repo": schema.StringAttribute{
+				Optional:    true,
+				Description: "Repository to install the package from. Defaults to the public " + kind + " repository",
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Installs a library on a Databricks cluster, such as a jar uploaded with mrl_databricks_dbfs_file, and waits until it is installed. Libraries of a terminated cluster are installed when it next starts. Removed libraries are uninstalled when the cluster restarts.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the cluster and the library, such as 0123-456789-abcdefgh/jar:dbfs:/FileStore/jars/app.jar",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"cluster_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the cluster, such as the id of a mrl_databricks_cluster",
+			},
+			"jar": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "URI of a jar, such as dbfs:/FileStore/jars/app.jar or /Volumes/main/default/libs/app.jar",
+			},
+			"whl": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "URI of a Python wheel, such as dbfs:/FileStore/wheels/app-1.0-py3-none-any.whl",
+			},
+			"pypi": schema.SingleNestedAttribute{
+				Optional:   true,
+				Attributes: packageAttributes("PyPI"),
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Description: "Python package installed from PyPI, such as requests==2.32.3",
+			},
+			"maven": schema.SingleNestedAttribute{
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"coordinates": schema.StringAttribute{
+						Required:    true,
+						Description: "Maven coordinates of the library, such as org.jsoup:jsoup:1.17.2",
+					},
+					"repo": schema.StringAttribute{
+						Optional:    true,
+						Description: "Maven repository to resolve the library from. Defaults to Maven Central",
+					},
+					"exclusions": schema.ListAttribute{
+						Optional:    true,
+						ElementType: types.StringType,
+						Description: "Dependencies not to install, as groupId:artifactId",
+					},
+				},
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Description: "JVM library resolved from a Maven repository",
+			},
+			"cran": schema.SingleNestedAttribute{
+				Optional:   true,
+				Attributes: packageAttributes("CRAN"),
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Description: "R package installed from CRAN",
+			},
+			"status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Install state of the library, such as INSTALLED, or PENDING while the cluster is terminated",
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a library: the workspace, the
+// cluster and the library.
+func (r *DatabricksLibraryResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"cluster_id": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "ID of the cluster",
+			},
+			"library": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Kind and location of the library, such as jar:dbfs:/FileStore/jars/app.jar or pypi:requests==2.32.3",
+			},
+		},
+	}
+}
+
+// setLibraryIdentity records the identity of library on the cluster
+// clusterID in the workspace adbID. Terraform versions without identity
+// support pass a nil identity.
+func setLibraryIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, clusterID, library string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksLibraryResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		ClusterId:    types.StringValue(clusterID),
+		Library:      types.StringValue(library),
+	})
+}
+
+// ImportState imports a library by workspace_url|cluster_id|library or by
+// identity, the library being given by kind and location as in its id. Read
+// fills in the library attributes from the cluster.
+func (r *DatabricksLibraryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksLibraryResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "cluster_id", "library")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.ClusterId = types.StringValue(parts[1])
+		identity.Library = types.StringValue(parts[2])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("cluster_id"), identity.ClusterId.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.ClusterId.ValueString()+"/"+identity.Library.ValueString())...)
+}
+
+// ValidateConfig checks that exactly one kind of library is set.
+func (r *DatabricksLibraryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksLibraryResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	set := 0
+	for _, isSet := range []bool{!config.Jar.IsNull(), !config.Whl.IsNull(), config.Pypi != nil, config.Maven != nil, config.Cran != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set != 1 {
+		resp.Diagnostics.AddError(
+			"Invalid library",
+			"Exactly one of jar, whl, pypi, maven and cran must be set.",
+		)
+	}
+}
+
+// library builds the library of the install and uninstall requests.
+func (m *databricksLibraryResourceModel) library(ctx context.Context) (databricks.Library, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	library := databricks.Library{
+		Jar: m.Jar.ValueString(),
+		Whl: m.Whl.ValueString(),
+	}
+	if m.Pypi != nil {
+		library.Pypi = &databricks.PypiLibrary{Package: m.Pypi.Package.ValueString(), Repo: m.Pypi.Repo.ValueString()}
+	}
+	if m.Maven != nil {
+		library.Maven = &databricks.MavenLibrary{Coordinates: m.Maven.Coordinates.ValueString(), Repo: m.Maven.Repo.ValueString()}
+		diags.Append(m.Maven.Exclusions.ElementsAs(ctx, &library.Maven.Exclusions, false)...)
+	}
+	if m.Cran != nil {
+		library.Cran = &databricks.CranLibrary{Package: m.Cran.Package.ValueString(), Repo: m.Cran.Repo.ValueString()}
+	}
+	return library, diags
+}
+
+// setLibrary sets the library attributes from library, as the cluster
+// reports it.
+func (m *databricksLibraryResourceModel) setLibrary(ctx context.Context, library databricks.Library) diag.Diagnostics {
+	var diags diag.Diagnostics
+	optional := func(s string) types.String {
+		if s == "" {
+			return types.StringNull()
+		}
+		return types.StringValue(s)
+	}
+	m.Jar = optional(library.Jar)
+	m.Whl = optional(library.Whl)
+	m.Pypi, m.Maven, m.Cran = nil, nil, nil
+	if l := library.Pypi; l != nil {
+		m.Pypi = &libraryPackageModel{Package: types.StringValue(l.Package), Repo: optional(l.Repo)}
+	}
+	if l := library.Maven; l != nil {
+		m.Maven = &libraryMavenModel{Coordinates: types.StringValue(l.Coordinates), Repo: optional(l.Repo), Exclusions: types.ListNull(types.StringType)}
+		if len(l.Exclusions) > 0 {
+			var d diag.Diagnostics
+			m.Maven.Exclusions, d = types.ListValueFrom(ctx, types.StringType, l.Exclusions)
+			diags.Append(d...)
+		}
+	}
+	if l := library.Cran; l != nil {
+		m.Cran = &libraryPackageModel{Package: types.StringValue(l.Package), Repo: optional(l.Repo)}
+	}
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksLibraryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_library.Create")
+	defer span.End()
+
+	var plan databricksLibraryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	library, diags := plan.library(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.create(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	clusterID := plan.ClusterId.ValueString()
+	err = client.InstallLibraries(ctx, clusterID, []databricks.Library{library})
+	r.audit.Record(ctx, "mrl_databricks_library", auditActionCreate, clusterID+"/"+library.String(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error installing library",
+			"Could not install "+library.String()+" on cluster "+clusterID+": "+err.Error(),
+		)
+		return
+	}
+
+	// Save the library first so one that fails to install is uninstalled on
+	// destroy rather than left behind.
+	plan.Id = types.StringValue(clusterID + "/" + library.String())
+	plan.Status = types.StringValue(databricks.LibraryPending)
+	resp.Diagnostics.Append(setLibraryIdentity(ctx, resp.Identity, host, clusterID, library.String())...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+
+	// A terminated cluster keeps the library pending until it starts.
+	cluster, err := client.GetClusterInfo(ctx, clusterID)
+	if err == nil && cluster.State != databricks.ClusterTerminated {
+		var status *databricks.LibraryStatus
+		status, err = client.WaitLibrary(ctx, clusterID, library)
+		if status != nil {
+			plan.Status = types.StringValue(status.Status)
+		}
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error installing library",
+			"Library "+library.String()+" was not installed on cluster "+clusterID+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksLibraryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_library.Read")
+	defer span.End()
+
+	var state databricksLibraryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	library, diags := state.library(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An imported library is only known by the kind and location in its id.
+	clusterID := state.ClusterId.ValueString()
+	key := library.String()
+	if key == "" {
+		key = strings.TrimPrefix(state.Id.ValueString(), clusterID+"/")
+	}
+
+	ctx, cancel := state.Timeouts.read(ctx)
+	defer cancel()
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the library is gone.
+	resp.Diagnostics.Append(setLibraryIdentity(ctx, resp.Identity, host, clusterID, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	statuses, err := client.ClusterLibraryStatuses(ctx, clusterID)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading library",
+			"Could not read the libraries of cluster "+clusterID+": "+err.Error(),
+		)
+		return
+	}
+	var status *databricks.LibraryStatus
+	for i := range statuses {
+		if statuses[i].Library.String() == key {
+			status = &statuses[i]
+		}
+	}
+	// A library uninstalled outside Terraform is gone, or leaves with the
+	// next restart.
+	if status == nil || status.Status == databricks.LibraryUninstallOnRestart {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if library.String() == "" {
+		resp.Diagnostics.Append(state.setLibrary(ctx, status.Library)...)
+	}
+
+	state.Id = types.StringValue(clusterID + "/" + key)
+	state.Status = types.StringValue(status.Status)
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on
+// success. Every library attribute requires replacement, so only token and
+// timeouts change in place.
+func (r *DatabricksLibraryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_library.Update")
+	defer span.End()
+
+	var plan, state databricksLibraryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Status = state.Status
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksLibraryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_library.Delete")
+	defer span.End()
+
+	var state databricksLibraryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	library, diags := state.library(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.delete(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	clusterID := state.ClusterId.ValueString()
+	err = client.UninstallLibraries(ctx, clusterID, []databricks.Library{library})
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_library", auditActionDelete, clusterID+"/"+library.String(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error uninstalling library",
+			"Could not uninstall "+library.String()+" from cluster "+clusterID+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksLibraryTypeName = "mrl_databricks_library"
+
+// mockLibraries serves the libraries API of a mock workspace: install,
+// uninstall and cluster-status. Libraries install right away, and
+// uninstalled ones wait for a restart that never comes.
+type mockLibraries struct {
+	clusters *mockClusters
+	statuses map[string][]databricks.LibraryStatus
+}
+
+// newMockLibraries adds the libraries API to m, for the clusters of
+// clusters.
+func newMockLibraries(m *mockDbfs, clusters *mockClusters) *mockLibraries {
+	libraries := &mockLibraries{clusters: clusters, statuses: map[string][]databricks.LibraryStatus{}}
+	m.route("/api/2.0/libraries/", libraries.serveHTTP)
+	return libraries
+}
+
+func (m *mockLibraries) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ClusterID string               `json:"cluster_id"`
+		Libraries []databricks.Library `json:"libraries"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	if r.Method == http.MethodGet {
+		body.ClusterID = r.URL.Query().Get("cluster_id")
+	}
+	if _, ok := m.clusters.clusters[body.ClusterID]; !ok {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster "+body.ClusterID+" does not exist")
+		return
+	}
+
+	switch r.URL.Path {
+	case "/api/2.0/libraries/install":
+		for _, library := range body.Libraries {
+			m.statuses[body.ClusterID] = append(m.statuses[body.ClusterID], databricks.LibraryStatus{Library: library, Status: databricks.LibraryInstalled})
+		}
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/libraries/uninstall":
+		for _, library := range body.Libraries {
+			if status := m.status(body.ClusterID, library.String()); status != nil {
+				status.Status = databricks.LibraryUninstallOnRestart
+			}
+		}
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/libraries/cluster-status":
+		writeMockJSON(w, map[string]interface{}{"cluster_id": body.ClusterID, "library_statuses": m.statuses[body.ClusterID]})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+// status returns the status of library on the cluster clusterID, nil when it
+// was never installed.
+func (m *mockLibraries) status(clusterID, library string) *databricks.LibraryStatus {
+	for i, status := range m.statuses[clusterID] {
+		if status.Library.String() == library {
+			return &m.statuses[clusterID][i]
+		}
+	}
+	return nil
+}
+
+func TestDatabricksLibraryResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	clusters := newMockClusters(m)
+	libraries := newMockLibraries(m, clusters)
+	clusters.add("0923-164208-meows279", "UI", map[string]interface{}{"cluster_name": "etl"})
+	pypi := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"package": tftypes.String, "repo": tftypes.String}}
+	var status *databricks.LibraryStatus
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksLibraryTypeName,
+		config: map[string]interface{}{
+			"cluster_id": "0923-164208-meows279",
+			"pypi": tftypes.NewValue(pypi, map[string]tftypes.Value{
+				"package": tftypes.NewValue(tftypes.String, "requests==2.32.3"),
+				"repo":    tftypes.NewValue(tftypes.String, "https://pypi.example.com/simple"),
+			}),
+		},
+		created: func(t *testing.T, state tftypes.Value) {
+			status = libraries.status("0923-164208-meows279", "pypi:requests==2.32.3")
+			if status == nil {
+				t.Fatal("library not installed")
+			}
+			if got := status.Library.Pypi.Repo; got != "https://pypi.example.com/simple" {
+				t.Errorf("installed from %q, want https://pypi.example.com/simple", got)
+			}
+			if got := stringAttr(t, state, "status"); got != databricks.LibraryInstalled {
+				t.Errorf("status is %q, want %s", got, databricks.LibraryInstalled)
+			}
+		},
+		identity:     true,
+		importID:     func(tftypes.Value) string { return m.server.URL + "|0923-164208-meows279|pypi:requests==2.32.3" },
+		importVerify: []string{"id", "cluster_id", "status", "pypi"},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if got := status.Status; got != databricks.LibraryUninstallOnRestart {
+				t.Errorf("status is %s after destroy, want %s", got, databricks.LibraryUninstallOnRestart)
+			}
+		},
+	})
+}
@@ -0,0 +1,336 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksMetastoreDataAccessResource{}
+	_ resource.ResourceWithConfigure = &DatabricksMetastoreDataAccessResource{}
+)
+
+// NewDatabricksMetastoreDataAccessResource is a helper function to simplify the provider implementation.
+func NewDatabricksMetastoreDataAccessResource() resource.Resource {
+	return &DatabricksMetastoreDataAccessResource{}
+}
+
+// DatabricksMetastoreDataAccessResource is the resource implementation.
+type DatabricksMetastoreDataAccessResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksMetastoreDataAccessResourceModel struct {
+	Id                     types.String `tfsdk:"id"`
+	AdbId                  URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl           URLValue     `tfsdk:"workspace_url"`
+	Token                  types.String `tfsdk:"token"`
+	MetastoreId            types.String `tfsdk:"metastore_id"`
+	Name                   types.String `tfsdk:"name"`
+	AccessConnectorId      types.String `tfsdk:"access_connector_id"`
+	UserAssignedIdentityId types.String `tfsdk:"user_assigned_identity_id"`
+	Comment                types.String `tfsdk:"comment"`
+	IsDefault              types.Bool   `tfsdk:"is_default"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksMetastoreDataAccessResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksMetastoreDataAccessResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_metastore_data_access"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksMetastoreDataAccessResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages the root storage data access configuration of a Unity Catalog metastore: a storage credential backed by an access connector, set as the default credential of the metastore.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the storage credential",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token of a metastore admin. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"metastore_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ID of the metastore",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the storage credential",
+			},
+			"access_connector_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the access connector, e.g. from mrl_databricks_access_connector",
+			},
+			"user_assigned_identity_id": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the user-assigned identity of the access connector. Omit for system-assigned connectors",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the storage credential",
+			},
+			"is_default": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the credential is the root credential of the metastore. When the metastore is pointed elsewhere outside Terraform, the next apply restores it. Defaults to true",
+			},
+		},
+	}
+}
+
+// readDataAccess fills the computed attributes of model from the metastore.
+func readDataAccess(ctx context.Context, client *databricks.Client, model *databricksMetastoreDataAccessResourceModel) error {
+	credential, err := client.GetStorageCredential(ctx, model.Name.ValueString())
+	if err != nil {
+		return err
+	}
+	metastore, err := client.GetMetastore(ctx, model.MetastoreId.ValueString())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(credential.ID)
+	if credential.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(credential.Comment)
+	}
+	if identity := credential.AzureManagedIdentity; identity != nil {
+		model.AccessConnectorId = types.StringValue(identity.AccessConnectorID)
+		model.UserAssignedIdentityId = optionalString(identity.ManagedIdentityID)
+	}
+	model.IsDefault = types.BoolValue(metastore.StorageRootCredentialID == credential.ID)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksMetastoreDataAccessResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_metastore_data_access.Create")
+	defer span.End()
+
+	var plan databricksMetastoreDataAccessResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	credential, err := client.CreateStorageCredential(ctx, databricks.StorageCredential{
+		Name:    plan.Name.ValueString(),
+		Comment: plan.Comment.ValueString(),
+		AzureManagedIdentity: &databricks.AzureManagedIdentity{
+			AccessConnectorID: plan.AccessConnectorId.ValueString(),
+			ManagedIdentityID: plan.UserAssignedIdentityId.ValueString(),
+		},
+	})
+	if err == nil && plan.IsDefault.ValueBool() {
+		err = client.SetMetastoreRootCredential(ctx, plan.MetastoreId.ValueString(), credential.ID)
+		if err != nil {
+			// Do not leave an unmanaged credential behind.
+			_ = client.DeleteStorageCredential(ctx, plan.Name.ValueString(), true)
+		}
+	}
+	r.audit.Record(ctx, "mrl_databricks_metastore_data_access", auditActionCreate, plan.MetastoreId.ValueString()+"/"+plan.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating metastore data access",
+			"Could not configure the root credential of metastore "+plan.MetastoreId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readDataAccess(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading metastore data access",
+			"Could not read storage credential "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksMetastoreDataAccessResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_metastore_data_access.Read")
+	defer span.End()
+
+	var state databricksMetastoreDataAccessResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readDataAccess(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading metastore data access",
+			"Could not read storage credential "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksMetastoreDataAccessResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_metastore_data_access.Update")
+	defer span.End()
+
+	var plan, state databricksMetastoreDataAccessResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if !plan.Comment.Equal(state.Comment) {
+		err = client.UpdateStorageCredentialComment(ctx, plan.Name.ValueString(), plan.Comment.ValueString())
+	}
+	if err == nil && plan.IsDefault.ValueBool() && !state.IsDefault.ValueBool() {
+		err = client.SetMetastoreRootCredential(ctx, plan.MetastoreId.ValueString(), state.Id.ValueString())
+	}
+	r.audit.Record(ctx, "mrl_databricks_metastore_data_access", auditActionUpdate, plan.MetastoreId.ValueString()+"/"+plan.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating metastore data access",
+			"Could not update storage credential "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readDataAccess(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading metastore data access",
+			"Could not read storage credential "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksMetastoreDataAccessResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_metastore_data_access.Delete")
+	defer span.End()
+
+	var state databricksMetastoreDataAccessResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// The metastore may depend on the credential, so removal is forced.
+	err = client.DeleteStorageCredential(ctx, state.Name.ValueString(), true)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_metastore_data_access", auditActionDelete, state.MetastoreId.ValueString()+"/"+state.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting metastore data access",
+			"Could not delete storage credential "+state.Name.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksModelAliasResource{}
+	_ resource.ResourceWithConfigure = &DatabricksModelAliasResource{}
+)
+
+// NewDatabricksModelAliasResource is a helper function to simplify the provider implementation.
+func NewDatabricksModelAliasResource() resource.Resource {
+	return &DatabricksModelAliasResource{}
+}
+
+// DatabricksModelAliasResource is the resource implementation.
+type DatabricksModelAliasResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksModelAliasResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue     `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	ModelName    types.String `tfsdk:"model_name"`
+	Alias        types.String `tfsdk:"alias"`
+	Version      types.Int64  `tfsdk:"version"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksModelAliasResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksModelAliasResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_model_alias"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksModelAliasResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Points an alias of a Unity Catalog registered model, such as champion, at a model version, so promotions go through review.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Model name and alias, joined by @",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"model_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Full name of the registered model: catalog.schema.model",
+			},
+			"alias": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the alias",
+			},
+			"version": schema.Int64Attribute{
+				Required:    true,
+				Description: "Model version the alias points to",
+			},
+		},
+	}
+}
+
+// setAlias points the alias at the planned version.
+func (r *DatabricksModelAliasResource) setAlias(ctx context.Context, plan *databricksModelAliasResourceModel, action string) error {
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+	id := plan.ModelName.ValueString() + "@" + plan.Alias.ValueString()
+
+	err = client.SetRegisteredModelAlias(ctx, plan.ModelName.ValueString(), plan.Alias.ValueString(), plan.Version.ValueInt64())
+	r.audit.Record(ctx, "mrl_databricks_model_alias", action, id, err)
+	if err != nil {
+		return err
+	}
+	plan.Id = types.StringValue(id)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksModelAliasResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_model_alias.Create")
+	defer span.End()
+
+	var plan databricksModelAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setAlias(withAuditRequestID(ctx), &plan, auditActionCreate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating model alias",
+			"Could not set alias "+plan.Alias.ValueString()+" of model "+plan.ModelName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksModelAliasResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_model_alias.Read")
+	defer span.End()
+
+	var state databricksModelAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	version, err := client.GetRegisteredModelAlias(ctx, state.ModelName.ValueString(), state.Alias.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading model alias",
+			"Could not read alias "+state.Alias.ValueString()+" of model "+state.ModelName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	state.Version = types.Int64Value(version.Version)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksModelAliasResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_model_alias.Update")
+	defer span.End()
+
+	var plan databricksModelAliasResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setAlias(withAuditRequestID(ctx), &plan, auditActionUpdate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating model alias",
+			"Could not set alias "+plan.Alias.ValueString()+" of model "+plan.ModelName.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksModelAliasResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_model_alias.Delete")
+	defer span.End()
+
+	var state databricksModelAliasResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteRegisteredModelAlias(ctx, state.ModelName.ValueString(), state.Alias.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_model_alias", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting model alias",
+			"Could not delete alias "+state.Alias.ValueString()+" of model "+state.ModelName.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,527 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksMountResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksMountResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksMountResource{}
+)
+
+// mountClusterMinCores is the minimum number of cores of the default node
+// type of temporary mount clusters.
+const mountClusterMinCores = 4
+
+// databricksMountTimeouts are the default timeouts of mounts. Unmounting may
+// start a cluster like mounting does, so it gets the create timeout.
+var databricksMountTimeouts = timeoutDefaults{
+	Create: defaultCreateTimeout,
+	Read:   defaultReadTimeout,
+	Update: defaultUpdateTimeout,
+	Delete: defaultCreateTimeout,
+}
+
+// NewDatabricksMountResource is a helper function to simplify the provider implementation.
+func NewDatabricksMountResource() resource.Resource {
+	return &DatabricksMountResource{}
+}
+
+// DatabricksMountResource is the resource implementation.
+type DatabricksMountResource struct {
+	httpClient   *http.Client
+	workspace    databricksWorkspace
+	audit        *auditLogger
+	clientID     string
+	tenantID     string
+	clientSecret string
+	environment  azure.Environment
+}
+
+// databricksMountResourceModel maps the resource schema data.
+type databricksMountResourceModel struct {
+	Id                 types.String   `tfsdk:"id"`
+	AdbId              URLValue       `tfsdk:"adb_id"`
+	WorkspaceUrl       URLValue       `tfsdk:"workspace_url"`
+	Token              types.String   `tfsdk:"token"`
+	Name               types.String   `tfsdk:"name"`
+	StorageAccountName types.String   `tfsdk:"storage_account_name"`
+	ContainerName      types.String   `tfsdk:"container_name"`
+	Directory          PathValue      `tfsdk:"directory"`
+	ClientId           types.String   `tfsdk:"client_id"`
+	TenantId           types.String   `tfsdk:"tenant_id"`
+	ClientSecretScope  types.String   `tfsdk:"client_secret_scope"`
+	ClientSecretKey    types.String   `tfsdk:"client_secret_key"`
+	ExtraConfigs       types.Map      `tfsdk:"extra_configs"`
+	ClusterId          types.String   `tfsdk:"cluster_id"`
+	SparkVersion       types.String   `tfsdk:"spark_version"`
+	NodeTypeId         types.String   `tfsdk:"node_type_id"`
+	Source             types.String   `tfsdk:"source"`
+	Timeouts           *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksMountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+	r.clientID = providerData.ClientID
+	r.tenantID = providerData.TenantID
+	r.clientSecret = providerData.ClientSecret
+	r.environment = providerData.Environment
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksMountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_mount"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksMountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	remount := func() []planmodifier.String {
+		return []planmodifier.String{stringplanmodifier.RequiresReplace()}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Mounts an ADLS Gen2 or Blob storage container to DBFS under /mnt, accessed with the credentials of a Microsoft Entra ID service principal, by default that of the provider. Mounting and unmounting run dbutils on cluster_id, or on a temporary single node cluster that is deleted afterwards. Changes of the storage or credentials unmount and mount again. Unmounting defaults to the create timeout of 20m, since it may start a cluster too.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "DBFS path of the mount point, such as /mnt/raw",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Azure Databricks workspace. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Azure Databricks workspace. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: remount(),
+				Validators:    []validator.String{MountName()},
+				Description:   "Name of the mount point under /mnt",
+			},
+			"storage_account_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: remount(),
+				Description:   "Name of the storage account. Accounts with and without hierarchical namespace are accessed with the ABFS driver",
+			},
+			"container_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: remount(),
+				Description:   "Name of the container or ADLS Gen2 filesystem",
+			},
+			"directory": schema.StringAttribute{
+				CustomType: PathType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(PathType{}),
+				},
+				Description: "Directory of the container to mount, such as /landing. Defaults to the root of the container",
+			},
+			"client_id": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: remount(),
+				Validators:    []validator.String{UUID()},
+				Description:   "Client ID of the service principal the storage is accessed with. Defaults to clientid of the provider",
+			},
+			"tenant_id": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: remount(),
+				Validators:    []validator.String{UUID()},
+				Description:   "Tenant of the service principal. Defaults to tenantid of the provider",
+			},
+			"client_secret_scope": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: remount(),
+				Description:   "Secret scope holding the client secret of the service principal, which the cluster reads so that the secret is not sent with the mount command. Without it, clientsecret of the provider is used",
+			},
+			"client_secret_key": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: remount(),
+				Description:   "Key of the client secret in client_secret_scope",
+			},
+			"extra_configs": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Additional Hadoop configurations of the mount. They override the OAuth configurations the resource sets",
+			},
+			"cluster_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ID of the cluster that mounts and unmounts the storage, started when it is terminated. The cluster must be able to run Python with dbutils. Without it, a temporary single node cluster is created for each mount and unmount",
+			},
+			"spark_version": schema.StringAttribute{
+				Optional:    true,
+				Description: "Runtime version of the temporary cluster. Defaults to the latest LTS version",
+			},
+			"node_type_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Node type of the temporary cluster. Defaults to the smallest node type with at least 4 cores and no GPU",
+			},
+			"source": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URI of the mounted storage, such as abfss://raw@account.dfs.core.windows.net/landing",
+			},
+			"timeouts": databricksMountTimeouts.attribute(),
+		},
+	}
+}
+
+// ValidateConfig checks that the secret scope and key are set together.
+func (r *DatabricksMountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksMountResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.ClientSecretScope.IsNull() != config.ClientSecretKey.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("client_secret_key"),
+			"Incomplete client secret",
+			"client_secret_scope and client_secret_key must be set together.",
+		)
+	}
+}
+
+// mount returns the DBFS mount of model, with the OAuth configurations of
+// its service principal.
+func (r *DatabricksMountResource) mount(ctx context.Context, model *databricksMountResourceModel) (databricks.Mount, error) {
+	clientID := model.ClientId.ValueString()
+	if model.ClientId.IsNull() {
+		clientID = r.clientID
+	}
+	tenantID := model.TenantId.ValueString()
+	if model.TenantId.IsNull() {
+		tenantID = r.tenantID
+	}
+	if clientID == "" || tenantID == "" {
+		return databricks.Mount{}, errors.New("no service principal: set client_id and tenant_id, or clientid and tenantid of the provider")
+	}
+
+	m := databricks.Mount{
+		MountPoint: databricks.MountPrefix + model.Name.ValueString(),
+		Source:     mountSource(model, r.environment),
+		ExtraConfigs: map[string]string{
+			"fs.azure.account.auth.type":              "OAuth",
+			"fs.azure.account.oauth.provider.type":    "org.apache.hadoop.fs.azurebfs.oauth2.ClientCredsTokenProvider",
+			"fs.azure.account.oauth2.client.id":       clientID,
+			"fs.azure.account.oauth2.client.endpoint": strings.TrimRight(r.environment.Cloud.ActiveDirectoryAuthorityHost, "/") + "/" + tenantID + "/oauth2/token",
+		},
+	}
+
+	const secretConfig = "fs.azure.account.oauth2.client.secret"
+	switch {
+	case !model.ClientSecretScope.IsNull():
+		m.SecretConfigs = map[string]databricks.SecretRef{
+			secretConfig: {Scope: model.ClientSecretScope.ValueString(), Key: model.ClientSecretKey.ValueString()},
+		}
+	case r.clientSecret != "":
+		m.ExtraConfigs[secretConfig] = r.clientSecret
+	default:
+		return databricks.Mount{}, errors.New("no client secret: set client_secret_scope and client_secret_key, or clientsecret of the provider")
+	}
+
+	var extra map[string]string
+	if diags := model.ExtraConfigs.ElementsAs(ctx, &extra, false); diags.HasError() {
+		return databricks.Mount{}, errors.New("extra_configs must be a map of strings")
+	}
+	for k, v := range extra {
+		m.ExtraConfigs[k] = v
+		delete(m.SecretConfigs, k)
+	}
+	return m, nil
+}
+
+// mountSource returns the ABFS URI of the storage of model.
+func mountSource(model *databricksMountResourceModel, env azure.Environment) string {
+	source := fmt.Sprintf("abfss://%s@%s.dfs.%s", model.ContainerName.ValueString(), model.StorageAccountName.ValueString(), env.StorageSuffix)
+	if directory := strings.Trim(model.Directory.ValueString(), "/"); directory != "" {
+		source += "/" + directory
+	}
+	return source
+}
+
+// withCluster calls fn with the ID of a running cluster: cluster_id of
+// model, started when needed, or a temporary single node cluster that is
+// deleted once fn returns.
+func (r *DatabricksMountResource) withCluster(ctx context.Context, client *databricks.Client, model *databricksMountResourceModel, fn func(clusterID string) error) error {
+	if clusterID := model.ClusterId.ValueString(); clusterID != "" {
+		info, err := client.GetClusterInfo(ctx, clusterID)
+		if err != nil {
+			return err
+		}
+		if info.State == databricks.ClusterTerminated {
+			if err := client.StartCluster(ctx, clusterID); err != nil {
+				return fmt.Errorf("starting cluster %s: %w", clusterID, err)
+			}
+		}
+		if _, err := client.WaitCluster(ctx, clusterID, databricksClusterTimeout); err != nil {
+			return err
+		}
+		return fn(clusterID)
+	}
+
+	spec, err := mountClusterSpec(ctx, client, model)
+	if err != nil {
+		return err
+	}
+	clusterID, err := client.CreateCluster(ctx, spec)
+	if err != nil {
+		return fmt.Errorf("creating temporary cluster: %w", err)
+	}
+	defer func() {
+		_ = client.PermanentDeleteCluster(context.WithoutCancel(ctx), clusterID)
+	}()
+	if _, err := client.WaitCluster(ctx, clusterID, databricksClusterTimeout); err != nil {
+		return err
+	}
+	return fn(clusterID)
+}
+
+// mountClusterSpec returns the spec of a temporary single node cluster
+// running the mount commands of model.
+func mountClusterSpec(ctx context.Context, client *databricks.Client, model *databricksMountResourceModel) (databricks.ClusterSpec, error) {
+	spec := databricks.ClusterSpec{
+		ClusterName:            "terraform-mount-" + model.Name.ValueString(),
+		SparkVersion:           model.SparkVersion.ValueString(),
+		NodeTypeID:             model.NodeTypeId.ValueString(),
+		NumWorkers:             0,
+		AutoterminationMinutes: 10,
+		SparkConf: map[string]string{
+			"spark.databricks.cluster.profile": "singleNode",
+			"spark.master":                     "local[*]",
+		},
+		CustomTags: map[string]string{"ResourceClass": "SingleNode"},
+	}
+
+	if spec.SparkVersion == "" {
+		versions, err := client.SparkVersions(ctx)
+		if err != nil {
+			return spec, fmt.Errorf("listing runtime versions: %w", err)
+		}
+		bestMajor, bestMinor := -1, -1
+		for _, version := range versions {
+			major, minor, ok := sparkVersionNumber(version.Key)
+			if !ok || !strings.Contains(version.Name, "LTS") {
+				continue
+			}
+			if strings.Contains(version.Key, "-ml-") || strings.Contains(version.Key, "-gpu-") || strings.Contains(version.Key, "photon") || strings.Contains(version.Key, "aarch64") {
+				continue
+			}
+			if major > bestMajor || major == bestMajor && minor > bestMinor {
+				bestMajor, bestMinor = major, minor
+				spec.SparkVersion = version.Key
+			}
+		}
+		if spec.SparkVersion == "" {
+			return spec, errors.New("the workspace has no LTS runtime version: set spark_version")
+		}
+	}
+
+	if spec.NodeTypeID == "" {
+		nodeTypes, err := client.NodeTypes(ctx)
+		if err != nil {
+			return spec, fmt.Errorf("listing node types: %w", err)
+		}
+		var matches []databricks.NodeType
+		for _, nodeType := range nodeTypes {
+			if !nodeType.IsDeprecated && !nodeType.IsHidden && nodeType.NumGPUs == 0 && nodeType.NumCores >= mountClusterMinCores {
+				matches = append(matches, nodeType)
+			}
+		}
+		if len(matches) == 0 {
+			return spec, errors.New("the workspace has no node type with 4 cores: set node_type_id")
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			if matches[i].NumCores != matches[j].NumCores {
+				return matches[i].NumCores < matches[j].NumCores
+			}
+			if matches[i].MemoryMB != matches[j].MemoryMB {
+				return matches[i].MemoryMB < matches[j].MemoryMB
+			}
+			return matches[i].NodeTypeID < matches[j].NodeTypeID
+		})
+		spec.NodeTypeID = matches[0].NodeTypeID
+	}
+	return spec, nil
+}
+
+// Create mounts the storage.
+func (r *DatabricksMountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_mount.Create")
+	defer span.End()
+
+	var plan databricksMountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.createOr(ctx, databricksMountTimeouts)
+	defer cancel()
+
+	m, err := r.mount(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid mount", err.Error())
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = r.withCluster(ctx, client, &plan, func(clusterID string) error {
+		return client.CreateMount(ctx, clusterID, m)
+	})
+	r.audit.Record(ctx, "mrl_databricks_mount", auditActionCreate, m.MountPoint, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating mount", "Could not mount "+m.Source+" to "+m.MountPoint+": "+err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(m.MountPoint)
+	plan.Source = types.StringValue(m.Source)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. Mount points
+// that DBFS no longer reports are removed from state, so that the next apply
+// mounts them again.
+func (r *DatabricksMountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_mount.Read")
+	defer span.End()
+
+	var state databricksMountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.readOr(ctx, databricksMountTimeouts)
+	defer cancel()
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	_, err = client.DbfsGetStatus(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading mount", "Could not read mount point "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update records changes of the cluster used to mount and unmount, which do
+// not remount the storage.
+func (r *DatabricksMountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := tracing.Start(ctx, "mrl_databricks_mount.Update")
+	defer span.End()
+
+	var plan databricksMountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete unmounts the storage and removes the Terraform state on success.
+func (r *DatabricksMountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_mount.Delete")
+	defer span.End()
+
+	var state databricksMountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.deleteOr(ctx, databricksMountTimeouts)
+	defer cancel()
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = r.withCluster(ctx, client, &state, func(clusterID string) error {
+		return client.DeleteMount(ctx, clusterID, state.Id.ValueString())
+	})
+	r.audit.Record(ctx, "mrl_databricks_mount", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting mount", "Could not unmount "+state.Id.ValueString()+": "+err.Error())
+	}
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksMountTypeName = "mrl_databricks_mount"
+
+// mockMountPointPattern and mockMountSourcePattern match the mount point and
+// the source of the dbutils commands that mount and unmount storage.
+var (
+	mockMountPointPattern  = regexp.MustCompile(`mount_point ?= ?("[^"]*")`)
+	mockMountSourcePattern = regexp.MustCompile(`source=("[^"]*")`)
+)
+
+// mockCommands serves the command execution API of a mock workspace. It
+// runs the dbutils mount and unmount commands against the DBFS of the
+// workspace, where a mount point is a directory with a single file, and
+// finishes every command right away.
+type mockCommands struct {
+	dbfs *mockDbfs
+	// mounts are the sources of the mount points.
+	mounts map[string]string
+	// commands are the commands run, by cluster.
+	commands map[string][]string
+	statuses map[string]databricks.CommandStatus
+	next     int
+}
+
+// newMockCommands adds the command execution API to m.
+func newMockCommands(m *mockDbfs) *mockCommands {
+	commands := &mockCommands{
+		dbfs:     m,
+		mounts:   map[string]string{},
+		commands: map[string][]string{},
+		statuses: map[string]databricks.CommandStatus{},
+	}
+	m.route("/api/1.2/", commands.serveHTTP)
+	return commands
+}
+
+func (m *mockCommands) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ClusterID string `json:"clusterId"`
+		Command   string `json:"command"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+
+	switch r.URL.Path {
+	case "/api/1.2/contexts/create":
+		m.next++
+		writeMockJSON(w, map[string]interface{}{"id": "context-" + strconv.Itoa(m.next)})
+	case "/api/1.2/contexts/destroy":
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/1.2/commands/execute":
+		m.next++
+		id := "command-" + strconv.Itoa(m.next)
+		m.commands[body.ClusterID] = append(m.commands[body.ClusterID], body.Command)
+		m.statuses[id] = m.run(id, body.Command)
+		writeMockJSON(w, map[string]interface{}{"id": id})
+	case "/api/1.2/commands/status":
+		status, ok := m.statuses[r.URL.Query().Get("commandId")]
+		if !ok {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Command "+r.URL.Query().Get("commandId")+" does not exist")
+			return
+		}
+		writeMockJSON(w, status)
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+// run runs a mount or unmount command, as dbutils would, and returns its
+// status. It is called with the lock of the DBFS held.
+func (m *mockCommands) run(id, command string) databricks.CommandStatus {
+	status := databricks.CommandStatus{ID: id, Status: databricks.CommandFinished, Results: &databricks.CommandResults{ResultType: "text"}}
+	match := mockMountPointPattern.FindStringSubmatch(command)
+	var mountPoint string
+	if match == nil || json.Unmarshal([]byte(match[1]), &mountPoint) != nil {
+		status.Results = &databricks.CommandResults{ResultType: "error", Summary: "NameError", Cause: "unexpected command"}
+		return status
+	}
+	marker := mountPoint + "/_mounted"
+
+	if !strings.Contains(command, "dbutils.fs.mount(") {
+		delete(m.mounts, mountPoint)
+		delete(m.dbfs.files, marker)
+		return status
+	}
+	if _, ok := m.mounts[mountPoint]; ok {
+		status.Results = &databricks.CommandResults{ResultType: "error", Summary: "ExecutionError", Cause: "Directory already mounted: " + mountPoint}
+		return status
+	}
+	var source string
+	if match := mockMountSourcePattern.FindStringSubmatch(command); match != nil {
+		_ = json.Unmarshal([]byte(match[1]), &source)
+	}
+	m.mounts[mountPoint] = source
+	m.dbfs.files[marker] = nil
+	return status
+}
+
+func TestDatabricksMountResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	clusters := newMockClusters(m)
+	clusters.add("shared", "UI", map[string]interface{}{"cluster_name": "shared", "spark_version": "15.4.x-scala2.12"})
+	clusters.add("other", "UI", map[string]interface{}{"cluster_name": "other", "spark_version": "15.4.x-scala2.12"})
+	commands := newMockCommands(m)
+	const source = "abfss://raw@account.dfs.core.windows.net/landing"
+	config := func(clusterID string) map[string]interface{} {
+		return map[string]interface{}{
+			"name":                 "raw",
+			"storage_account_name": "account",
+			"container_name":       "raw",
+			"directory":            "/landing/",
+			"client_id":            "00000000-0000-0000-0000-000000000001",
+			"tenant_id":            "00000000-0000-0000-0000-000000000002",
+			"client_secret_scope":  "kv",
+			"client_secret_key":    "sp-secret",
+			"cluster_id":           clusterID,
+		}
+	}
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksMountTypeName,
+		config:   config("shared"),
+		created: func(t *testing.T, state tftypes.Value) {
+			if got := commands.mounts["/mnt/raw"]; got != source {
+				t.Errorf("mounted %q, want %q", got, source)
+			}
+			if got := stringAttr(t, state, "id"); got != "/mnt/raw" {
+				t.Errorf("id is %q, want /mnt/raw", got)
+			}
+			if got := stringAttr(t, state, "source"); got != source {
+				t.Errorf("source is %q, want %q", got, source)
+			}
+			if ran := commands.commands["shared"]; len(ran) != 1 || !strings.Contains(ran[0], `dbutils.secrets.get(scope="kv", key="sp-secret")`) {
+				t.Errorf("ran %q on cluster_id, want a mount reading the client secret from the scope", ran)
+			}
+		},
+		updates: []lifecycleUpdate{{
+			// Another cluster does not remount the storage.
+			config: config("other"),
+			check: func(t *testing.T, state tftypes.Value) {
+				if len(commands.commands["other"]) != 0 || len(commands.commands["shared"]) != 1 {
+					t.Errorf("ran %v after changing cluster_id, want no command", commands.commands)
+				}
+			},
+		}},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := commands.mounts["/mnt/raw"]; ok {
+				t.Error("storage still mounted after destroy")
+			}
+			if len(commands.commands["other"]) != 1 {
+				t.Errorf("ran %v, want the unmount on the new cluster_id", commands.commands)
+			}
+		},
+	})
+}
+
+func TestDatabricksMountResource_temporaryCluster(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	clusters := newMockClusters(m)
+	commands := newMockCommands(m)
+	typeName := databricksMountTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	state := p.apply(typeName, null, p.config(typeName, map[string]interface{}{
+		"name":                 "raw",
+		"storage_account_name": "account",
+		"container_name":       "raw",
+		"client_id":            "00000000-0000-0000-0000-000000000001",
+		"tenant_id":            "00000000-0000-0000-0000-000000000002",
+		"client_secret_scope":  "kv",
+		"client_secret_key":    "sp-secret",
+		"spark_version":        "15.4.x-scala2.12",
+		"node_type_id":         "Standard_DS3_v2",
+	}))
+	if got := commands.mounts["/mnt/raw"]; got != "abfss://raw@account.dfs.core.windows.net" {
+		t.Errorf("mounted %q, want the root of the container", got)
+	}
+	if len(commands.commands) != 1 {
+		t.Errorf("ran commands on %d clusters, want 1", len(commands.commands))
+	}
+	if len(clusters.clusters) != 0 {
+		t.Errorf("%d clusters left after mounting, want the temporary cluster deleted", len(clusters.clusters))
+	}
+
+	// A mount point removed outside Terraform is removed from state, so that
+	// the next apply mounts it again.
+	delete(commands.mounts, "/mnt/raw")
+	m.remove("/mnt/raw/_mounted")
+	if refreshed := p.refresh(typeName, state); !refreshed.IsNull() {
+		t.Errorf("state of the unmounted storage is %s, want null", refreshed)
+	}
+}
+
+func TestDatabricksMountResource_incompleteSecret(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksMountTypeName
+
+	diags := p.validate(typeName, p.config(typeName, map[string]interface{}{
+		"name":                 "raw",
+		"storage_account_name": "account",
+		"container_name":       "raw",
+		"client_secret_scope":  "kv",
+	}))
+	if !strings.Contains(errorDiagnostics(diags), "must be set together") {
+		t.Errorf("got diagnostics %q, want the incomplete client secret", errorDiagnostics(diags))
+	}
+}
@@ -0,0 +1,235 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksNodeTypesDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksNodeTypesDataSource{}
+)
+
+// NewDatabricksNodeTypesDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksNodeTypesDataSource() datasource.DataSource {
+	return &DatabricksNodeTypesDataSource{}
+}
+
+// DatabricksNodeTypesDataSource is the data source implementation.
+type DatabricksNodeTypesDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksNodeTypesDataSourceModel maps the data source schema data.
+type databricksNodeTypesDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	MinCores     types.Int64  `tfsdk:"min_cores"`
+	MinMemoryGb  types.Int64  `tfsdk:"min_memory_gb"`
+	MinGpus      types.Int64  `tfsdk:"min_gpus"`
+	Category     types.String `tfsdk:"category"`
+	Photon       types.Bool   `tfsdk:"photon"`
+	Smallest     types.String `tfsdk:"smallest"`
+	NodeTypes    types.List   `tfsdk:"node_types"`
+}
+
+// nodeTypeAttrTypes are the attribute types of a node_types element.
+var nodeTypeAttrTypes = map[string]attr.Type{
+	"node_type_id": types.StringType,
+	"num_cores":    types.Float64Type,
+	"memory_mb":    types.Int64Type,
+	"num_gpus":     types.Int64Type,
+	"category":     types.StringType,
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksNodeTypesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksNodeTypesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_node_types"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksNodeTypesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the node types of a workspace matching the given filters, smallest first, so clusters can pick a node type by size instead of by name. Deprecated and hidden node types are left out.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the smallest matching node type",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"min_cores": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of CPU cores",
+			},
+			"min_memory_gb": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum memory in GB",
+			},
+			"min_gpus": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Minimum number of GPUs",
+			},
+			"category": schema.StringAttribute{
+				Optional:    true,
+				Description: "Category of the node type, such as General Purpose, Memory Optimized or Compute Optimized, ignoring case",
+			},
+			"photon": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return node types that can run Photon on both the driver and the workers. Defaults to false",
+			},
+			"smallest": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the smallest matching node type, to use as node_type_id of a cluster. Null when no node type matches",
+			},
+			"node_types": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching node types, by number of cores, then memory",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"node_type_id": schema.StringAttribute{
+							Computed:    true,
+							Description: "ID of the node type, such as Standard_DS3_v2",
+						},
+						"num_cores": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Number of CPU cores",
+						},
+						"memory_mb": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Memory in MB",
+						},
+						"num_gpus": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of GPUs",
+						},
+						"category": schema.StringAttribute{
+							Computed:    true,
+							Description: "Category of the node type",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksNodeTypesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_node_types.Read")
+	defer span.End()
+
+	var state databricksNodeTypesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	nodeTypes, err := client.NodeTypes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing node types", "Could not list the node types of the workspace: "+err.Error())
+		return
+	}
+
+	var matches []databricks.NodeType
+	for _, nodeType := range nodeTypes {
+		switch {
+		case nodeType.IsDeprecated || nodeType.IsHidden:
+		case nodeType.NumCores < float64(state.MinCores.ValueInt64()):
+		case nodeType.MemoryMB < state.MinMemoryGb.ValueInt64()*1024:
+		case nodeType.NumGPUs < state.MinGpus.ValueInt64():
+		case !state.Category.IsNull() && !strings.EqualFold(nodeType.Category, state.Category.ValueString()):
+		case state.Photon.ValueBool() && !(nodeType.PhotonWorkerCapable && nodeType.PhotonDriverCapable):
+		default:
+			matches = append(matches, nodeType)
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].NumCores != matches[j].NumCores {
+			return matches[i].NumCores < matches[j].NumCores
+		}
+		if matches[i].MemoryMB != matches[j].MemoryMB {
+			return matches[i].MemoryMB < matches[j].MemoryMB
+		}
+		if matches[i].NumGPUs != matches[j].NumGPUs {
+			return matches[i].NumGPUs < matches[j].NumGPUs
+		}
+		return matches[i].NodeTypeID < matches[j].NodeTypeID
+	})
+
+	values := make([]attr.Value, 0, len(matches))
+	for _, nodeType := range matches {
+		values = append(values, types.ObjectValueMust(nodeTypeAttrTypes, map[string]attr.Value{
+			"node_type_id": types.StringValue(nodeType.NodeTypeID),
+			"num_cores":    types.Float64Value(nodeType.NumCores),
+			"memory_mb":    types.Int64Value(nodeType.MemoryMB),
+			"num_gpus":     types.Int64Value(nodeType.NumGPUs),
+			"category":     types.StringValue(nodeType.Category),
+		}))
+	}
+
+	state.Smallest = types.StringNull()
+	state.Id = types.StringValue("")
+	if len(matches) > 0 {
+		state.Smallest = types.StringValue(matches[0].NodeTypeID)
+		state.Id = state.Smallest
+	}
+	state.NodeTypes = types.ListValueMust(types.ObjectType{AttrTypes: nodeTypeAttrTypes}, values)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
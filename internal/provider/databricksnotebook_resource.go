@@ -0,0 +1,436 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksNotebookResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksNotebookResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksNotebookResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksNotebookResource{}
+)
+
+// notebookMaxBytes is the largest file the workspace import API accepts.
+const notebookMaxBytes = 10 << 20
+
+// NewDatabricksNotebookResource is a helper function to simplify the provider implementation.
+func NewDatabricksNotebookResource() resource.Resource {
+	return &DatabricksNotebookResource{}
+}
+
+// DatabricksNotebookResource is the resource implementation.
+type DatabricksNotebookResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksNotebookResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	AdbId        URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue                       `tfsdk:"workspace_url"`
+	Path         types.String                   `tfsdk:"path"`
+	LocalPath    types.String                   `tfsdk:"local_path"`
+	Language     types.String                   `tfsdk:"language"`
+	Format       types.String                   `tfsdk:"format"`
+	Overwrite    types.Bool                     `tfsdk:"overwrite"`
+	ContentMd5   types.String                   `tfsdk:"content_md5"`
+	ObjectId     types.Int64                    `tfsdk:"object_id"`
+	ModifiedAt   types.Int64                    `tfsdk:"modified_at"`
+	Url          types.String                   `tfsdk:"url"`
+	Workspace    *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksNotebookResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksNotebookResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_notebook"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksNotebookResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports a local .py, .scala, .sql, .r or .ipynb file into the workspace as a notebook. The language and format are detected from the file, and the notebook is imported again when the file changes or the notebook is changed outside Terraform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Workspace path of the notebook",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"path": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Absolute workspace path of the notebook, such as /Shared/etl/ingest. Missing parent directories are created",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local notebook file of at most 10 MB: Python, Scala, SQL or R source, or a Jupyter notebook",
+			},
+			"language": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					StringOneOf("PYTHON", "SCALA", "SQL", "R"),
+				},
+				Description: "Language of the notebook: PYTHON, SCALA, SQL or R. Defaults to the language detected from the extension of local_path, its first line or, for a Jupyter notebook, its kernel",
+			},
+			"format": schema.StringAttribute{
+				Computed:    true,
+				Description: "Import format detected from local_path: JUPYTER for .ipynb files and SOURCE otherwise",
+			},
+			"overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Replace an object already present at path on create. Without it, creating the resource fails when path exists. Defaults to false",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the local file, computed at plan time. A change imports the notebook again",
+			},
+			"object_id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the notebook",
+			},
+			"modified_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Modification time of the notebook after the import, in milliseconds since the epoch, used to detect changes made outside Terraform",
+			},
+			"url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the notebook in the workspace UI",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks that local_path is a file the import API accepts.
+func (r *DatabricksNotebookResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		LocalFile(tfpath.Root("local_path"), notebookMaxBytes),
+		WorkspaceBlock(tfpath.Root("workspace_url"), tfpath.Root("adb_id")),
+	}
+}
+
+// ModifyPlan hashes the local file and detects its language and format, so
+// that the import only runs again when the content or language changes.
+func (r *DatabricksNotebookResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksNotebookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocalPath.IsUnknown() {
+		return
+	}
+	localPath := plan.LocalPath.ValueString()
+
+	sum, err := fileMD5(localPath)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("local_path"),
+			"Error reading local file",
+			"Could not hash "+localPath+": "+err.Error(),
+		)
+		return
+	}
+	plan.ContentMd5 = types.StringValue(sum)
+
+	var config databricksNotebookResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	language, format, err := detectNotebookLanguage(localPath)
+	switch {
+	case err == nil:
+		plan.Format = types.StringValue(format)
+		if config.Language.IsNull() {
+			plan.Language = types.StringValue(language)
+		}
+	case !config.Language.IsNull():
+		// A source file without a known extension or header, whose
+		// language is configured.
+		plan.Format = types.StringValue(notebookFormatSource)
+	default:
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("language"),
+			"Unknown notebook language",
+			err.Error()+". Set language.",
+		)
+		return
+	}
+
+	if !req.State.Raw.IsNull() {
+		var state databricksNotebookResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.ContentMd5.Equal(plan.ContentMd5) || !state.Language.Equal(plan.Language) {
+			plan.ObjectId = types.Int64Unknown()
+			plan.ModifiedAt = types.Int64Unknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// importNotebook imports the local file to path. With replace set, an object
+// already at path is replaced; otherwise the import fails if path exists.
+func (r *DatabricksNotebookResource) importNotebook(ctx context.Context, plan *databricksNotebookResourceModel, replace bool) error {
+	workspace := r.workspace.override(plan.Workspace)
+	host, token, err := workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		return err
+	}
+	client := workspace.newClient(r.httpClient, host, token)
+	target := plan.Path.ValueString()
+
+	content, err := os.ReadFile(plan.LocalPath.ValueString())
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(content)
+	if hex.EncodeToString(sum[:]) != plan.ContentMd5.ValueString() {
+		return fmt.Errorf("%v changed during apply; the planned md5 is %v", plan.LocalPath.ValueString(), plan.ContentMd5.ValueString())
+	}
+
+	if _, err := client.WorkspaceGetStatus(ctx, target); err == nil && !replace {
+		return fmt.Errorf("%v already exists; set overwrite to replace it", target)
+	} else if err != nil && !databricks.IsNotFound(err) {
+		return err
+	}
+
+	// Jupyter notebooks carry their language in their metadata.
+	language := plan.Language.ValueString()
+	if plan.Format.ValueString() == notebookFormatJupyter {
+		language = ""
+	}
+	if err := client.WorkspaceMkdirs(ctx, path.Dir(target)); err != nil {
+		return err
+	}
+	if err := client.WorkspaceImport(ctx, target, plan.Format.ValueString(), language, content, replace); err != nil {
+		return err
+	}
+
+	info, err := client.WorkspaceGetStatus(ctx, target)
+	if err != nil {
+		return err
+	}
+	plan.Id = types.StringValue(target)
+	plan.ObjectId = types.Int64Value(info.ObjectID)
+	plan.ModifiedAt = types.Int64Value(info.ModifiedAt)
+	plan.Url = types.StringValue(databricks.WorkspaceURL(host) + "/#workspace" + target)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksNotebookResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_notebook.Create")
+	defer span.End()
+
+	var plan databricksNotebookResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importNotebook(ctx, &plan, plan.Overwrite.ValueBool())
+	r.audit.Record(ctx, "mrl_databricks_notebook", auditActionCreate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing notebook",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksNotebookResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_notebook.Read")
+	defer span.End()
+
+	var state databricksNotebookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.WorkspaceGetStatus(ctx, state.Path.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading notebook",
+			"Could not get the status of "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if info.ObjectType != "NOTEBOOK" || info.ModifiedAt != state.ModifiedAt.ValueInt64() || info.ObjectID != state.ObjectId.ValueInt64() {
+		// The notebook was changed or replaced outside Terraform; clearing
+		// the hash makes the next plan import it again.
+		state.ContentMd5 = types.StringNull()
+	}
+	if info.Language != "" {
+		state.Language = types.StringValue(info.Language)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksNotebookResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_notebook.Update")
+	defer span.End()
+
+	var plan, state databricksNotebookResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ContentMd5.Equal(state.ContentMd5) && plan.Language.Equal(state.Language) {
+		// Only overwrite changed.
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importNotebook(ctx, &plan, true)
+	r.audit.Record(ctx, "mrl_databricks_notebook", auditActionUpdate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing notebook",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksNotebookResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_notebook.Delete")
+	defer span.End()
+
+	var state databricksNotebookResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.WorkspaceDelete(ctx, state.Path.ValueString(), false)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_notebook", auditActionDelete, state.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting notebook",
+			"Could not delete "+state.Path.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,293 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksPermissionAssignmentResource{}
+	_ resource.ResourceWithConfigure = &DatabricksPermissionAssignmentResource{}
+)
+
+// NewDatabricksPermissionAssignmentResource is a helper function to simplify the provider implementation.
+func NewDatabricksPermissionAssignmentResource() resource.Resource {
+	return &DatabricksPermissionAssignmentResource{}
+}
+
+// DatabricksPermissionAssignmentResource is the resource implementation.
+type DatabricksPermissionAssignmentResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksPermissionAssignmentResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue     `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	PrincipalId  types.Int64  `tfsdk:"principal_id"`
+	Permission   types.String `tfsdk:"permission"`
+	DisplayName  types.String `tfsdk:"display_name"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksPermissionAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksPermissionAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_permission_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksPermissionAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assigns an account user, group or service principal to an identity federated workspace.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the account principal",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token of a workspace admin. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"principal_id": schema.Int64Attribute{
+				Required: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Description: "ID of the account user, group or service principal",
+			},
+			"permission": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{StringOneOf(databricks.WorkspacePermissionUser, databricks.WorkspacePermissionAdmin)},
+				Description: "Permission of the principal on the workspace: USER or ADMIN",
+			},
+			"display_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Display name of the principal",
+			},
+		},
+	}
+}
+
+// readAssignment fills the computed attributes of model from the workspace.
+func readAssignment(ctx context.Context, client *databricks.Client, model *databricksPermissionAssignmentResourceModel) error {
+	assignment, err := client.GetPermissionAssignment(ctx, model.PrincipalId.ValueInt64())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(strconv.FormatInt(assignment.Principal.PrincipalID, 10))
+	model.DisplayName = types.StringValue(assignment.Principal.DisplayName)
+	model.Permission = types.StringValue(databricks.WorkspacePermissionUser)
+	for _, permission := range assignment.Permissions {
+		if permission == databricks.WorkspacePermissionAdmin {
+			model.Permission = types.StringValue(databricks.WorkspacePermissionAdmin)
+		}
+	}
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksPermissionAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permission_assignment.Create")
+	defer span.End()
+
+	var plan databricksPermissionAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	principal := strconv.FormatInt(plan.PrincipalId.ValueInt64(), 10)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.PutPermissionAssignment(ctx, plan.PrincipalId.ValueInt64(), []string{plan.Permission.ValueString()})
+	r.audit.Record(ctx, "mrl_databricks_permission_assignment", auditActionCreate, principal, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating permission assignment",
+			"Could not assign principal "+principal+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readAssignment(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permission assignment",
+			"Could not read the assignment of principal "+principal+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksPermissionAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permission_assignment.Read")
+	defer span.End()
+
+	var state databricksPermissionAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readAssignment(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading permission assignment",
+			"Could not read the assignment of principal "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksPermissionAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permission_assignment.Update")
+	defer span.End()
+
+	var plan databricksPermissionAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	principal := strconv.FormatInt(plan.PrincipalId.ValueInt64(), 10)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.PutPermissionAssignment(ctx, plan.PrincipalId.ValueInt64(), []string{plan.Permission.ValueString()})
+	r.audit.Record(ctx, "mrl_databricks_permission_assignment", auditActionUpdate, principal, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating permission assignment",
+			"Could not assign principal "+principal+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readAssignment(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permission assignment",
+			"Could not read the assignment of principal "+principal+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksPermissionAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permission_assignment.Delete")
+	defer span.End()
+
+	var state databricksPermissionAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeletePermissionAssignment(ctx, state.PrincipalId.ValueInt64())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_permission_assignment", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting permission assignment",
+			"Could not remove principal "+state.Id.ValueString()+" from the workspace: "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,445 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksPermissionsResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksPermissionsResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksPermissionsResource{}
+)
+
+// NewDatabricksPermissionsResource is a helper function to simplify the provider implementation.
+func NewDatabricksPermissionsResource() resource.Resource {
+	return &DatabricksPermissionsResource{}
+}
+
+// DatabricksPermissionsResource is the resource implementation.
+type DatabricksPermissionsResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksPermissionsResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AdbId         URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl  URLValue     `tfsdk:"workspace_url"`
+	Token         types.String `tfsdk:"token"`
+	ObjectType    types.String `tfsdk:"object_type"`
+	ObjectId      types.String `tfsdk:"object_id"`
+	AccessControl types.Set    `tfsdk:"access_control"`
+}
+
+// databricksAccessControlModel grants a permission level to one principal.
+type databricksAccessControlModel struct {
+	UserName             types.String `tfsdk:"user_name"`
+	GroupName            types.String `tfsdk:"group_name"`
+	ServicePrincipalName types.String `tfsdk:"service_principal_name"`
+	PermissionLevel      types.String `tfsdk:"permission_level"`
+}
+
+// databricksAccessControlAttrTypes are the attribute types of an
+// access_control element.
+var databricksAccessControlAttrTypes = map[string]attr.Type{
+	"user_name":              types.StringType,
+	"group_name":             types.StringType,
+	"service_principal_name": types.StringType,
+	"permission_level":       types.StringType,
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksPermissionsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksPermissionsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_permissions"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksPermissionsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the access control list of a Databricks object, such as a cluster, job or directory. The list replaces every permission set directly on the object; inherited permissions are left alone. The owner of an object is kept unless access_control sets one. Destroying the resource removes the permissions it manages.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Object type and object ID, separated by a slash",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"object_type": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{StringOneOf(
+					databricks.PermissionObjectClusters,
+					databricks.PermissionObjectJobs,
+					databricks.PermissionObjectNotebooks,
+					databricks.PermissionObjectDirectories,
+					databricks.PermissionObjectPools,
+					databricks.PermissionObjectRepos,
+					databricks.PermissionObjectWarehouses,
+				)},
+				Description: "Type of the object: clusters, jobs, notebooks, directories, instance-pools, repos or warehouses",
+			},
+			"object_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ID of the object. Notebooks and directories are identified by their numeric object ID, not by their path",
+			},
+			"access_control": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "Permissions granted on the object",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"user_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "User name of the user granted the permission",
+						},
+						"group_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Name of the group granted the permission",
+						},
+						"service_principal_name": schema.StringAttribute{
+							Optional:    true,
+							Description: "Application ID of the service principal granted the permission",
+						},
+						"permission_level": schema.StringAttribute{
+							Required:    true,
+							Description: "Permission level, such as CAN_MANAGE, CAN_RESTART, CAN_ATTACH_TO, CAN_MANAGE_RUN, CAN_VIEW, CAN_USE, CAN_EDIT, CAN_RUN, CAN_READ or IS_OWNER, depending on the object type",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that each access control entry names exactly one
+// principal.
+func (r *DatabricksPermissionsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksPermissionsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.AccessControl.IsUnknown() {
+		return
+	}
+
+	var entries []databricksAccessControlModel
+	resp.Diagnostics.Append(config.AccessControl.ElementsAs(ctx, &entries, false)...)
+	for _, entry := range entries {
+		principals := 0
+		unknown := false
+		for _, name := range []types.String{entry.UserName, entry.GroupName, entry.ServicePrincipalName} {
+			if name.IsUnknown() {
+				unknown = true
+			} else if !name.IsNull() {
+				principals++
+			}
+		}
+		if unknown {
+			continue
+		}
+		if principals != 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("access_control"),
+				"Invalid access control",
+				"Each access_control entry must set exactly one of user_name, group_name and service_principal_name.",
+			)
+			return
+		}
+	}
+}
+
+// accessControlList converts the access_control set into the API form.
+func accessControlList(ctx context.Context, set types.Set) ([]databricks.AccessControl, diag.Diagnostics) {
+	var entries []databricksAccessControlModel
+	diags := set.ElementsAs(ctx, &entries, false)
+	acl := make([]databricks.AccessControl, 0, len(entries))
+	for _, entry := range entries {
+		acl = append(acl, databricks.AccessControl{
+			UserName:             entry.UserName.ValueString(),
+			GroupName:            entry.GroupName.ValueString(),
+			ServicePrincipalName: entry.ServicePrincipalName.ValueString(),
+			PermissionLevel:      entry.PermissionLevel.ValueString(),
+		})
+	}
+	return acl, diags
+}
+
+// hasOwner reports whether acl names the owner of the object.
+func hasOwner(acl []databricks.AccessControl) bool {
+	for _, ac := range acl {
+		if ac.PermissionLevel == databricks.PermissionOwner {
+			return true
+		}
+	}
+	return false
+}
+
+// owners returns the entries of acl that name the owner of the object.
+func owners(acl []databricks.AccessControl) []databricks.AccessControl {
+	var result []databricks.AccessControl
+	for _, ac := range acl {
+		if ac.PermissionLevel == databricks.PermissionOwner {
+			result = append(result, ac)
+		}
+	}
+	return result
+}
+
+// setPermissions replaces the permissions of the object with acl, keeping its
+// current owner when acl does not name one.
+func setPermissions(ctx context.Context, client *databricks.Client, objectType, objectID string, acl []databricks.AccessControl) error {
+	if !hasOwner(acl) {
+		current, err := client.GetPermissions(ctx, objectType, objectID)
+		if err != nil {
+			return err
+		}
+		acl = append(acl, owners(current)...)
+	}
+	return client.SetPermissions(ctx, objectType, objectID, acl)
+}
+
+// readPermissions refreshes access_control from the object. The owner is
+// left out unless access_control already names it, so an unmanaged owner
+// does not show as drift.
+func readPermissions(ctx context.Context, client *databricks.Client, model *databricksPermissionsResourceModel) (diag.Diagnostics, error) {
+	acl, err := client.GetPermissions(ctx, model.ObjectType.ValueString(), model.ObjectId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	prior, diags := accessControlList(ctx, model.AccessControl)
+	keepOwner := hasOwner(prior)
+	entries := make([]databricksAccessControlModel, 0, len(acl))
+	for _, ac := range acl {
+		if ac.PermissionLevel == databricks.PermissionOwner && !keepOwner {
+			continue
+		}
+		entries = append(entries, databricksAccessControlModel{
+			UserName:             optionalString(ac.UserName),
+			GroupName:            optionalString(ac.GroupName),
+			ServicePrincipalName: optionalString(ac.ServicePrincipalName),
+			PermissionLevel:      types.StringValue(ac.PermissionLevel),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return accessControlKey(entries[i]) < accessControlKey(entries[j])
+	})
+
+	set, d := types.SetValueFrom(ctx, types.ObjectType{AttrTypes: databricksAccessControlAttrTypes}, entries)
+	diags.Append(d...)
+	model.AccessControl = set
+	model.Id = types.StringValue(model.ObjectType.ValueString() + "/" + model.ObjectId.ValueString())
+	return diags, nil
+}
+
+// accessControlKey orders access control entries for a stable state.
+func accessControlKey(entry databricksAccessControlModel) string {
+	return strings.Join([]string{entry.UserName.ValueString(), entry.GroupName.ValueString(), entry.ServicePrincipalName.ValueString(), entry.PermissionLevel.ValueString()}, "\x00")
+}
+
+// apply replaces the permissions of the object with the plan. The plan is
+// saved as is; permissions the workspace adds on its own show as drift on the
+// next refresh.
+func (r *DatabricksPermissionsResource) apply(ctx context.Context, plan *databricksPermissionsResourceModel, action string) diag.Diagnostics {
+	acl, diags := accessControlList(ctx, plan.AccessControl)
+	if diags.HasError() {
+		return diags
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return diags
+	}
+	id := plan.ObjectType.ValueString() + "/" + plan.ObjectId.ValueString()
+	err = setPermissions(ctx, client, plan.ObjectType.ValueString(), plan.ObjectId.ValueString(), acl)
+	r.audit.Record(ctx, "mrl_databricks_permissions", action, id, err)
+	if err != nil {
+		diags.AddError(
+			"Error setting permissions",
+			"Could not set permissions of "+id+": "+err.Error(),
+		)
+		return diags
+	}
+
+	plan.Id = types.StringValue(id)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksPermissionsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permissions.Create")
+	defer span.End()
+
+	var plan databricksPermissionsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksPermissionsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permissions.Read")
+	defer span.End()
+
+	var state databricksPermissionsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	diags, err = readPermissions(ctx, client, &state)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading permissions",
+			"Could not read permissions of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(diags...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksPermissionsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permissions.Update")
+	defer span.End()
+
+	var plan databricksPermissionsResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksPermissionsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_permissions.Delete")
+	defer span.End()
+
+	var state databricksPermissionsResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Only the owner is kept, so the object is left with its inherited
+	// permissions.
+	err = setPermissions(ctx, client, state.ObjectType.ValueString(), state.ObjectId.ValueString(), nil)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_permissions", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting permissions",
+			"Could not remove permissions of "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksRepoResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksRepoResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksRepoResource{}
+)
+
+// NewDatabricksRepoResource is a helper function to simplify the provider implementation.
+func NewDatabricksRepoResource() resource.Resource {
+	return &DatabricksRepoResource{}
+}
+
+// DatabricksRepoResource is the resource implementation.
+type DatabricksRepoResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksRepoResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue     `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	Url          types.String `tfsdk:"url"`
+	GitProvider  types.String `tfsdk:"git_provider"`
+	Path         types.String `tfsdk:"path"`
+	Branch       types.String `tfsdk:"branch"`
+	Tag          types.String `tfsdk:"tag"`
+	HeadCommitId types.String `tfsdk:"head_commit_id"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksRepoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksRepoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_repo"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksRepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Clones a Git repository into a Databricks Git folder and keeps the configured branch or tag checked out. Changing branch or tag pulls and checks it out in place. The workspace needs Git credentials for private repositories.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the repo",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"url": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "HTTPS URL of the Git repository, such as https://github.com/example/pipelines.git",
+			},
+			"git_provider": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{StringOneOf(
+					databricks.GitProviderGitHub,
+					databricks.GitProviderGitHubEnterprise,
+					databricks.GitProviderBitbucketCloud,
+					databricks.GitProviderBitbucketServer,
+					databricks.GitProviderGitLab,
+					databricks.GitProviderGitLabEnterpriseEdition,
+					databricks.GitProviderAzureDevOpsServices,
+					databricks.GitProviderAWSCodeCommit,
+				)},
+				Description: "Git provider hosting url: gitHub, gitHubEnterprise, bitbucketCloud, bitbucketServer, gitLab, gitLabEnterpriseEdition, azureDevOpsServices or awsCodeCommit. Inferred from url when unset",
+			},
+			"path": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Workspace path of the Git folder, such as /Repos/deploy@example.com/pipelines. Defaults to a folder named after the repository in the home folder of the caller",
+			},
+			"branch": schema.StringAttribute{
+				Optional:    true,
+				Description: "Branch to check out. Each apply that changes it pulls the latest commit of the branch. Conflicts with tag. Defaults to the default branch of the repository",
+			},
+			"tag": schema.StringAttribute{
+				Optional:    true,
+				Description: "Tag to check out, leaving the Git folder in detached HEAD state. Conflicts with branch. Unsetting both branch and tag keeps the current checkout",
+			},
+			"head_commit_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Commit checked out in the Git folder",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that branch and tag are not both set.
+func (r *DatabricksRepoResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksRepoResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Branch.IsNull() && !config.Tag.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tag"),
+			"Conflicting repo checkout",
+			"Only one of branch and tag can be set.",
+		)
+	}
+}
+
+// repoID parses the ID of the repo in the state.
+func repoID(id types.String) (int64, error) {
+	return strconv.ParseInt(id.ValueString(), 10, 64)
+}
+
+// setRepo copies the repo into the model. url keeps its configured spelling,
+// and the checked out branch is only recorded when one is configured, so
+// repos on their default branch or on a tag do not show a diff.
+func setRepo(model *databricksRepoResourceModel, repo *databricks.Repo) {
+	model.Id = types.StringValue(strconv.FormatInt(repo.ID, 10))
+	model.GitProvider = types.StringValue(repo.Provider)
+	model.Path = types.StringValue(repo.Path)
+	model.HeadCommitId = types.StringValue(repo.HeadCommitID)
+	if !model.Branch.IsNull() {
+		model.Branch = types.StringValue(repo.Branch)
+	}
+}
+
+// Create a new resource.
+func (r *DatabricksRepoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_repo.Create")
+	defer span.End()
+
+	var plan databricksRepoResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	repo, err := client.CreateRepo(ctx, plan.Url.ValueString(), plan.GitProvider.ValueString(), plan.Path.ValueString())
+	var target string
+	if repo != nil {
+		target = repo.Path
+	}
+	r.audit.Record(ctx, "mrl_databricks_repo", auditActionCreate, target, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating repo",
+			"Could not clone "+plan.Url.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID first so a repo whose checkout fails is not orphaned.
+	plan.Id = types.StringValue(strconv.FormatInt(repo.ID, 10))
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	if !plan.Branch.IsNull() || !plan.Tag.IsNull() {
+		if err := client.CheckoutRepo(ctx, repo.ID, plan.Branch.ValueString(), plan.Tag.ValueString()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating repo",
+				"Could not check out "+plan.Branch.ValueString()+plan.Tag.ValueString()+" in "+repo.Path+": "+err.Error(),
+			)
+			return
+		}
+		if repo, err = client.GetRepo(ctx, repo.ID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading repo",
+				"Could not read repo "+plan.Id.ValueString()+" after creating it: "+err.Error(),
+			)
+			return
+		}
+	}
+	setRepo(&plan, repo)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksRepoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_repo.Read")
+	defer span.End()
+
+	var state databricksRepoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := repoID(state.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid repo ID", "Could not parse repo ID "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	repo, err := client.GetRepo(ctx, id)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading repo",
+			"Could not read repo "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	setRepo(&state, repo)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update checks out the configured branch or tag, pulling the latest commits.
+func (r *DatabricksRepoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_repo.Update")
+	defer span.End()
+
+	var plan, state databricksRepoResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := repoID(plan.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid repo ID", "Could not parse repo ID "+plan.Id.ValueString()+": "+err.Error())
+		return
+	}
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+
+	// Unsetting both keeps the current checkout.
+	changed := !plan.Branch.Equal(state.Branch) || !plan.Tag.Equal(state.Tag)
+	if changed && (!plan.Branch.IsNull() || !plan.Tag.IsNull()) {
+		ref := plan.Branch.ValueString() + plan.Tag.ValueString()
+		err = client.CheckoutRepo(ctx, id, plan.Branch.ValueString(), plan.Tag.ValueString())
+		r.audit.Record(ctx, "mrl_databricks_repo", auditActionUpdate, plan.Path.ValueString(), err)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating repo",
+				"Could not check out "+ref+" in "+plan.Path.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	repo, err := client.GetRepo(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading repo",
+			"Could not read repo "+plan.Id.ValueString()+" after updating it: "+err.Error(),
+		)
+		return
+	}
+	setRepo(&plan, repo)
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksRepoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_repo.Delete")
+	defer span.End()
+
+	var state databricksRepoResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := repoID(state.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid repo ID", "Could not parse repo ID "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteRepo(ctx, id)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_repo", auditActionDelete, state.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting repo",
+			"Could not delete repo "+state.Path.ValueString()+": "+err.Error(),
+		)
+	}
+}
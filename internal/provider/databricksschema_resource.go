@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksSchemaResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksSchemaResource{}
+	_ resource.ResourceWithImportState = &DatabricksSchemaResource{}
+)
+
+// NewDatabricksSchemaResource is a helper function to simplify the provider implementation.
+func NewDatabricksSchemaResource() resource.Resource {
+	return &DatabricksSchemaResource{}
+}
+
+// DatabricksSchemaResource is the resource implementation.
+type DatabricksSchemaResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksSchemaResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl    URLValue     `tfsdk:"workspace_url"`
+	CatalogName     types.String `tfsdk:"catalog_name"`
+	Name            types.String `tfsdk:"name"`
+	Comment         types.String `tfsdk:"comment"`
+	Owner           types.String `tfsdk:"owner"`
+	StorageRoot     types.String `tfsdk:"storage_root"`
+	StorageLocation types.String `tfsdk:"storage_location"`
+	ForceDestroy    types.Bool   `tfsdk:"force_destroy"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksSchemaResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksSchemaResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_schema"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksSchemaResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a Unity Catalog schema within a catalog.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Full name of the schema, catalog.schema",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"catalog_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the catalog, e.g. from mrl_databricks_catalog",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the schema",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the schema",
+			},
+			"owner": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "User, group or service principal owning the schema. Defaults to the identity the provider authenticates with",
+			},
+			"storage_root": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Storage URL of the managed tables and volumes of the schema. It must be covered by an external location. Defaults to the storage root of the catalog",
+			},
+			"storage_location": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Storage URL Unity Catalog resolved for the managed data of the schema",
+			},
+			"force_destroy": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether destroying the schema also deletes its tables and volumes. Otherwise only an empty schema can be destroyed. Defaults to false",
+			},
+		},
+	}
+}
+
+// readSchema fills the computed attributes of model from the metastore.
+func readSchema(ctx context.Context, client *databricks.Client, model *databricksSchemaResourceModel) error {
+	s, err := client.GetSchema(ctx, model.Id.ValueString())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(s.FullName)
+	// Unity Catalog lowercases names; keep the configured spelling.
+	if model.CatalogName.IsNull() {
+		model.CatalogName = types.StringValue(s.CatalogName)
+	}
+	if model.Name.IsNull() {
+		model.Name = types.StringValue(s.Name)
+	}
+	if s.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(s.Comment)
+	}
+	model.Owner = types.StringValue(s.Owner)
+	model.StorageRoot = optionalString(s.StorageRoot)
+	model.StorageLocation = types.StringValue(s.StorageLocation)
+	return nil
+}
+
+// schemaUpdate returns the update setting the comment and owner of plan. An
+// unknown owner is left to the metastore.
+func schemaUpdate(plan databricksSchemaResourceModel) databricks.SecurableUpdate {
+	update := databricks.SecurableUpdate{Comment: plan.Comment.ValueString()}
+	if !plan.Owner.IsUnknown() {
+		update.Owner = plan.Owner.ValueString()
+	}
+	return update
+}
+
+// Create a new resource.
+func (r *DatabricksSchemaResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_schema.Create")
+	defer span.End()
+
+	var plan databricksSchemaResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	fullName := plan.CatalogName.ValueString() + "." + plan.Name.ValueString()
+	created, err := client.CreateSchema(ctx, databricks.SchemaInfo{
+		Name:        plan.Name.ValueString(),
+		CatalogName: plan.CatalogName.ValueString(),
+		Comment:     plan.Comment.ValueString(),
+		StorageRoot: plan.StorageRoot.ValueString(),
+	})
+	if err == nil && created.FullName != "" {
+		fullName = created.FullName
+	}
+	// The owner can only be set once the schema exists.
+	if err == nil && !plan.Owner.IsUnknown() {
+		err = client.UpdateSchema(ctx, fullName, schemaUpdate(plan))
+		if err != nil {
+			// Do not leave an unmanaged schema behind.
+			_ = client.DeleteSchema(ctx, fullName, false)
+		}
+	}
+	r.audit.Record(ctx, "mrl_databricks_schema", auditActionCreate, fullName, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating schema",
+			"Could not create schema "+fullName+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fullName)
+	if err := readSchema(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading schema",
+			"Could not read schema "+fullName+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksSchemaResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_schema.Read")
+	defer span.End()
+
+	var state databricksSchemaResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readSchema(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading schema",
+			"Could not read schema "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if state.ForceDestroy.IsNull() {
+		state.ForceDestroy = types.BoolValue(false)
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_schema.Update")
+	defer span.End()
+
+	var plan, state databricksSchemaResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if !plan.Comment.Equal(state.Comment) || !plan.Owner.Equal(state.Owner) {
+		err = client.UpdateSchema(ctx, state.Id.ValueString(), schemaUpdate(plan))
+	}
+	r.audit.Record(ctx, "mrl_databricks_schema", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating schema",
+			"Could not update schema "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readSchema(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading schema",
+			"Could not read schema "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_schema.Delete")
+	defer span.End()
+
+	var state databricksSchemaResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteSchema(ctx, state.Id.ValueString(), state.ForceDestroy.ValueBool())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_schema", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting schema",
+			"Could not delete schema "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a schema by an ID of the form workspace_url|catalog_name|name.
+func (r *DatabricksSchemaResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "workspace_url", "catalog_name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1]+"."+parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_destroy"), false)...)
+}
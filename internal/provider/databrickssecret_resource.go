@@ -0,0 +1,359 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksSecretResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksSecretResource{}
+	_ resource.ResourceWithImportState = &DatabricksSecretResource{}
+	_ resource.ResourceWithIdentity    = &DatabricksSecretResource{}
+)
+
+// NewDatabricksSecretResource is a helper function to simplify the provider implementation.
+func NewDatabricksSecretResource() resource.Resource {
+	return &DatabricksSecretResource{}
+}
+
+// DatabricksSecretResource is the resource implementation.
+type DatabricksSecretResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksSecretResourceModel struct {
+	Id                   types.String `tfsdk:"id"`
+	AdbId                URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl         URLValue     `tfsdk:"workspace_url"`
+	Token                types.String `tfsdk:"token"`
+	Scope                types.String `tfsdk:"scope"`
+	Key                  types.String `tfsdk:"key"`
+	StringValueWo        types.String `tfsdk:"string_value_wo"`
+	StringValueWoVersion types.Int64  `tfsdk:"string_value_wo_version"`
+}
+
+// databricksSecretResourceIdentityModel identifies a secret across
+// workspaces.
+type databricksSecretResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Scope        types.String `tfsdk:"scope"`
+	Key          types.String `tfsdk:"key"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksSecretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_secret"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksSecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Writes a secret to a Databricks-backed secret scope. The value is write-only and never stored in the Terraform plan or state; requires Terraform 1.11 or later.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Scope and key, joined by /",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"scope": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the secret scope, such as the name of a mrl_databricks_secret_scope",
+			},
+			"key": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Key of the secret",
+			},
+			"string_value_wo": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				WriteOnly:   true,
+				Description: "Value of the secret. Write-only, so Terraform cannot detect changes to it; change string_value_wo_version to write it again",
+			},
+			"string_value_wo_version": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Version of string_value_wo. Changing it writes the value again",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a secret: the workspace, the scope
+// and the key.
+func (r *DatabricksSecretResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"scope": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Name of the secret scope",
+			},
+			"key": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Key of the secret",
+			},
+		},
+	}
+}
+
+// setSecretIdentity records the identity of the secret key of scope in the
+// workspace adbID. Terraform versions without identity support pass a nil
+// identity.
+func setSecretIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, scope, key string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksSecretResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		Scope:        types.StringValue(scope),
+		Key:          types.StringValue(key),
+	})
+}
+
+// ImportState imports a secret by workspace_url|scope|key or by identity. The value
+// is write-only, so it is never read back; setting string_value_wo_version
+// afterwards writes the configured value.
+func (r *DatabricksSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksSecretResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "scope", "key")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.Scope = types.StringValue(parts[1])
+		identity.Key = types.StringValue(parts[2])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Scope.ValueString()+"/"+identity.Key.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), identity.Scope.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("key"), identity.Key.ValueString())...)
+}
+
+// put writes the secret with the value in config and records its identity.
+func (r *DatabricksSecretResource) put(ctx context.Context, config tfsdk.Config, plan *databricksSecretResourceModel, action string, identity *tfsdk.ResourceIdentity) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var value types.String
+	diags.Append(config.GetAttribute(ctx, path.Root("string_value_wo"), &value)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	id := plan.Scope.ValueString() + "/" + plan.Key.ValueString()
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return diags
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	err = client.PutSecret(ctx, plan.Scope.ValueString(), plan.Key.ValueString(), value.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_secret", action, id, err)
+	if err != nil {
+		diags.AddError("Error writing secret", "Could not write secret "+plan.Key.ValueString()+" to scope "+plan.Scope.ValueString()+": "+err.Error())
+		return diags
+	}
+	plan.Id = types.StringValue(id)
+	diags.Append(setSecretIdentity(ctx, identity, host, plan.Scope.ValueString(), plan.Key.ValueString())...)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret.Create")
+	defer span.End()
+
+	var plan databricksSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(withAuditRequestID(ctx), req.Config, &plan, auditActionCreate, resp.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret.Read")
+	defer span.End()
+
+	var state databricksSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the secret is gone.
+	resp.Diagnostics.Append(setSecretIdentity(ctx, resp.Identity, host, state.Scope.ValueString(), state.Key.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	secrets, err := client.ListSecrets(ctx, state.Scope.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading secret",
+			"Could not list secrets of scope "+state.Scope.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// The API never returns the value, so only the existence of the key is
+	// checked.
+	for _, secret := range secrets {
+		if secret.Key == state.Key.ValueString() {
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// Update writes the value again, as string_value_wo_version or the token
+// changed.
+func (r *DatabricksSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret.Update")
+	defer span.End()
+
+	var plan databricksSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(withAuditRequestID(ctx), req.Config, &plan, auditActionUpdate, resp.Identity)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret.Delete")
+	defer span.End()
+
+	var state databricksSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteSecret(ctx, state.Scope.ValueString(), state.Key.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_secret", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting secret",
+			"Could not delete secret "+state.Key.ValueString()+" from scope "+state.Scope.ValueString()+": "+err.Error(),
+		)
+	}
+}
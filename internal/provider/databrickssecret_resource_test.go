@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const (
+	databricksSecretScopeTypeName = "mrl_databricks_secret_scope"
+	databricksSecretTypeName      = "mrl_databricks_secret"
+)
+
+// mockSecrets serves the secrets API of a mock workspace: scopes and the
+// values of the secrets in them, which are never returned.
+type mockSecrets struct {
+	scopes map[string]map[string]string
+}
+
+// newMockSecrets adds the secrets API to m.
+func newMockSecrets(m *mockDbfs) *mockSecrets {
+	secrets := &mockSecrets{scopes: map[string]map[string]string{}}
+	m.route("/api/2.0/secrets/", secrets.serveHTTP)
+	return secrets
+}
+
+func (m *mockSecrets) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Scope       string `json:"scope"`
+		Key         string `json:"key"`
+		StringValue string `json:"string_value"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	if r.Method == http.MethodGet {
+		body.Scope = r.URL.Query().Get("scope")
+	}
+
+	switch r.URL.Path {
+	case "/api/2.0/secrets/scopes/create":
+		if _, ok := m.scopes[body.Scope]; ok {
+			writeMockError(w, http.StatusBadRequest, "RESOURCE_ALREADY_EXISTS", "Scope "+body.Scope+" already exists!")
+			return
+		}
+		m.scopes[body.Scope] = map[string]string{}
+		writeMockJSON(w, map[string]interface{}{})
+		return
+	case "/api/2.0/secrets/scopes/list":
+		names := make([]string, 0, len(m.scopes))
+		for name := range m.scopes {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		scopes := make([]map[string]interface{}, 0, len(names))
+		for _, name := range names {
+			scopes = append(scopes, map[string]interface{}{"name": name, "backend_type": "DATABRICKS"})
+		}
+		writeMockJSON(w, map[string]interface{}{"scopes": scopes})
+		return
+	}
+
+	secrets, ok := m.scopes[body.Scope]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Scope "+body.Scope+" does not exist!")
+		return
+	}
+	switch r.URL.Path {
+	case "/api/2.0/secrets/scopes/delete":
+		delete(m.scopes, body.Scope)
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/secrets/put":
+		secrets[body.Key] = body.StringValue
+		writeMockJSON(w, map[string]interface{}{})
+	case "/api/2.0/secrets/list":
+		keys := make([]map[string]interface{}, 0, len(secrets))
+		for key := range secrets {
+			keys = append(keys, map[string]interface{}{"key": key, "last_updated_timestamp": 1700000000000})
+		}
+		writeMockJSON(w, map[string]interface{}{"secrets": keys})
+	case "/api/2.0/secrets/delete":
+		if _, ok := secrets[body.Key]; !ok {
+			writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Secret "+body.Key+" does not exist!")
+			return
+		}
+		delete(secrets, body.Key)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+func TestDatabricksSecretScopeResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	secrets := newMockSecrets(m)
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksSecretScopeTypeName,
+		config:   map[string]interface{}{"name": "etl"},
+		created: func(t *testing.T, state tftypes.Value) {
+			if _, ok := secrets.scopes["etl"]; !ok {
+				t.Fatal("scope etl not created")
+			}
+			if got := stringAttr(t, state, "backend_type"); got != "DATABRICKS" {
+				t.Errorf("backend_type is %q, want DATABRICKS", got)
+			}
+		},
+		identity:     true,
+		importID:     func(tftypes.Value) string { return m.server.URL + "|etl" },
+		importVerify: []string{"id", "name", "backend_type"},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := secrets.scopes["etl"]; ok {
+				t.Error("scope still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksSecretResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	secrets := newMockSecrets(m)
+	secrets.scopes["etl"] = map[string]string{}
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksSecretTypeName,
+		config: map[string]interface{}{
+			"scope":           "etl",
+			"key":             "password",
+			"string_value_wo": "s3cr3t",
+		},
+		created: func(t *testing.T, state tftypes.Value) {
+			if got := secrets.scopes["etl"]["password"]; got != "s3cr3t" {
+				t.Errorf("secret is %q, want s3cr3t", got)
+			}
+			if got := stringAttr(t, state, "id"); got != "etl/password" {
+				t.Errorf("id is %q, want etl/password", got)
+			}
+			if !stateAttr(t, state, "string_value_wo").IsNull() {
+				t.Error("string_value_wo is in state")
+			}
+		},
+		identity: true,
+		updates: []lifecycleUpdate{{
+			config: map[string]interface{}{
+				"scope":                   "etl",
+				"key":                     "password",
+				"string_value_wo":         "n3w",
+				"string_value_wo_version": 2,
+			},
+			check: func(t *testing.T, _ tftypes.Value) {
+				if got := secrets.scopes["etl"]["password"]; got != "n3w" {
+					t.Errorf("secret is %q after update, want n3w", got)
+				}
+			},
+		}},
+		importID:     func(tftypes.Value) string { return m.server.URL + "|etl|password" },
+		importVerify: []string{"id", "scope", "key"},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := secrets.scopes["etl"]["password"]; ok {
+				t.Error("secret still exists after destroy")
+			}
+		},
+	})
+}
@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksSecretScopeResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksSecretScopeResource{}
+	_ resource.ResourceWithImportState = &DatabricksSecretScopeResource{}
+	_ resource.ResourceWithIdentity    = &DatabricksSecretScopeResource{}
+)
+
+// NewDatabricksSecretScopeResource is a helper function to simplify the provider implementation.
+func NewDatabricksSecretScopeResource() resource.Resource {
+	return &DatabricksSecretScopeResource{}
+}
+
+// DatabricksSecretScopeResource is the resource implementation.
+type DatabricksSecretScopeResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksSecretScopeResourceModel struct {
+	Id                     types.String           `tfsdk:"id"`
+	AdbId                  URLValue               `tfsdk:"adb_id"`
+	WorkspaceUrl           URLValue               `tfsdk:"workspace_url"`
+	Token                  types.String           `tfsdk:"token"`
+	Name                   types.String           `tfsdk:"name"`
+	InitialManagePrincipal types.String           `tfsdk:"initial_manage_principal"`
+	KeyvaultMetadata       *keyvaultMetadataModel `tfsdk:"keyvault_metadata"`
+	BackendType            types.String           `tfsdk:"backend_type"`
+}
+
+// databricksSecretScopeResourceIdentityModel identifies a secret scope across
+// workspaces.
+type databricksSecretScopeResourceIdentityModel struct {
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Name         types.String `tfsdk:"name"`
+}
+
+// keyvaultMetadataModel maps the Key Vault behind a scope.
+type keyvaultMetadataModel struct {
+	ResourceId types.String `tfsdk:"resource_id"`
+	DnsName    types.String `tfsdk:"dns_name"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksSecretScopeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksSecretScopeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_secret_scope"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksSecretScopeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates a Databricks secret scope, stored by Databricks or backed by an Azure Key Vault. Deleting the scope deletes its secrets.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Name of the scope",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the scope",
+			},
+			"initial_manage_principal": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Principal granted MANAGE permission on the scope; only users is accepted on workspaces without the Premium plan. Defaults to the creator",
+			},
+			"keyvault_metadata": schema.SingleNestedAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Attributes: map[string]schema.Attribute{
+					"resource_id": schema.StringAttribute{
+						Required:    true,
+						Description: "Azure resource ID of the key vault",
+					},
+					"dns_name": schema.StringAttribute{
+						Required:    true,
+						Description: "URL of the key vault, e.g. https://myvault.vault.azure.net/",
+					},
+				},
+				Description: "Azure Key Vault the scope reads its secrets from. Creating such a scope needs a Microsoft Entra ID token rather than a personal access token",
+			},
+			"backend_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "DATABRICKS or AZURE_KEYVAULT",
+			},
+		},
+	}
+}
+
+// IdentitySchema defines the identity of a secret scope: the workspace and
+// the scope name.
+func (r *DatabricksSecretScopeResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"workspace_url": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "URL of the Databricks workspace",
+			},
+			"name": identityschema.StringAttribute{
+				RequiredForImport: true,
+				Description:       "Name of the scope",
+			},
+		},
+	}
+}
+
+// setSecretScopeIdentity records the identity of the scope name in the
+// workspace adbID. Terraform versions without identity support pass a nil
+// identity.
+func setSecretScopeIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, adbID, name string) diag.Diagnostics {
+	if identity == nil {
+		return nil
+	}
+	return identity.Set(ctx, databricksSecretScopeResourceIdentityModel{
+		WorkspaceUrl: types.StringValue(databricks.WorkspaceURL(adbID)),
+		Name:         types.StringValue(name),
+	})
+}
+
+// ImportState imports a secret scope by workspace_url|name or by identity. Read
+// fills in its backend. initial_manage_principal cannot be read back and
+// stays null.
+func (r *DatabricksSecretScopeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var identity databricksSecretScopeResourceIdentityModel
+	if req.ID != "" {
+		parts, err := parseImportID(req.ID, "workspace_url", "name")
+		if err != nil {
+			resp.Diagnostics.AddError("Invalid import ID", err.Error())
+			return
+		}
+		identity.WorkspaceUrl = types.StringValue(parts[0])
+		identity.Name = types.StringValue(parts[1])
+	} else {
+		resp.Diagnostics.Append(req.Identity.Get(ctx, &identity)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), identity.WorkspaceUrl.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), identity.Name.ValueString())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), identity.Name.ValueString())...)
+}
+
+// Create a new resource.
+func (r *DatabricksSecretScopeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret_scope.Create")
+	defer span.End()
+
+	var plan databricksSecretScopeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var kv *databricks.KeyvaultMetadata
+	plan.BackendType = types.StringValue(databricks.ScopeBackendDatabricks)
+	if plan.KeyvaultMetadata != nil {
+		kv = &databricks.KeyvaultMetadata{
+			ResourceID: plan.KeyvaultMetadata.ResourceId.ValueString(),
+			DNSName:    plan.KeyvaultMetadata.DnsName.ValueString(),
+		}
+		plan.BackendType = types.StringValue(databricks.ScopeBackendAzureKeyVault)
+	}
+
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	err = client.CreateSecretScope(ctx, plan.Name.ValueString(), plan.InitialManagePrincipal.ValueString(), kv)
+	r.audit.Record(ctx, "mrl_databricks_secret_scope", auditActionCreate, plan.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating secret scope",
+			"Could not create secret scope "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.Id = plan.Name
+	resp.Diagnostics.Append(setSecretScopeIdentity(ctx, resp.Identity, host, plan.Name.ValueString())...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksSecretScopeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret_scope.Read")
+	defer span.End()
+
+	var state databricksSecretScopeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := r.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// Terraform requires an identity even when the scope is gone.
+	resp.Diagnostics.Append(setSecretScopeIdentity(ctx, resp.Identity, host, state.Name.ValueString())...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	scopes, err := client.ListSecretScopes(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading secret scope",
+			"Could not list secret scopes: "+err.Error(),
+		)
+		return
+	}
+
+	var scope *databricks.SecretScope
+	for i := range scopes {
+		if scopes[i].Name == state.Name.ValueString() {
+			scope = &scopes[i]
+		}
+	}
+	if scope == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.BackendType = types.StringValue(scope.BackendType)
+	if kv := scope.KeyvaultMetadata; kv != nil {
+		state.KeyvaultMetadata = &keyvaultMetadataModel{
+			ResourceId: types.StringValue(kv.ResourceID),
+			DnsName:    types.StringValue(kv.DNSName),
+		}
+	} else {
+		state.KeyvaultMetadata = nil
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update only changes the token; every other attribute replaces the scope.
+func (r *DatabricksSecretScopeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan databricksSecretScopeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksSecretScopeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_secret_scope.Delete")
+	defer span.End()
+
+	var state databricksSecretScopeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteSecretScope(ctx, state.Name.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_secret_scope", auditActionDelete, state.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting secret scope",
+			"Could not delete secret scope "+state.Name.ValueString()+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,298 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksServicePrincipalResource{}
+	_ resource.ResourceWithConfigure = &DatabricksServicePrincipalResource{}
+)
+
+// NewDatabricksServicePrincipalResource is a helper function to simplify the provider implementation.
+func NewDatabricksServicePrincipalResource() resource.Resource {
+	return &DatabricksServicePrincipalResource{}
+}
+
+// DatabricksServicePrincipalResource is the resource implementation.
+type DatabricksServicePrincipalResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksServicePrincipalResourceModel maps the resource schema data.
+type databricksServicePrincipalResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AdbId         URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl  URLValue     `tfsdk:"workspace_url"`
+	Token         types.String `tfsdk:"token"`
+	ApplicationId types.String `tfsdk:"application_id"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Active        types.Bool   `tfsdk:"active"`
+	Entitlements  types.Set    `tfsdk:"entitlements"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksServicePrincipalResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksServicePrincipalResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_service_principal"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksServicePrincipalResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a workspace service principal through the SCIM API, with its entitlements. Add it to groups with the members of mrl_databricks_group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "SCIM ID of the service principal",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"application_id": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.String{UUID()},
+				Description: "Application ID of the service principal. Required on Azure, where it is the client ID of the Microsoft Entra ID application; generated by Databricks elsewhere",
+			},
+			"display_name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Name of the service principal. Defaults to the name Databricks derives from the application",
+			},
+			"active": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the service principal can authenticate. Defaults to true",
+			},
+			"entitlements": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators:  []validator.Set{SetValuesOneOf(entitlementValues...)},
+				Description: "Entitlements of the service principal: allow-cluster-create, allow-instance-pool-create, databricks-sql-access or workspace-access",
+			},
+		},
+	}
+}
+
+// Create a new resource.
+func (r *DatabricksServicePrincipalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_service_principal.Create")
+	defer span.End()
+
+	var plan databricksServicePrincipalResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	entitlements, diags := setStrings(ctx, plan.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	target := plan.DisplayName.ValueString()
+	if target == "" {
+		target = plan.ApplicationId.ValueString()
+	}
+	sp, err := client.CreateServicePrincipal(ctx, databricks.ServicePrincipal{
+		ApplicationID: plan.ApplicationId.ValueString(),
+		DisplayName:   plan.DisplayName.ValueString(),
+		Active:        plan.Active.ValueBool(),
+		Entitlements:  databricks.ScimValues(entitlements),
+	})
+	r.audit.Record(ctx, "mrl_databricks_service_principal", auditActionCreate, target, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating service principal", "Could not create service principal "+target+": "+err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(sp.ID)
+	plan.ApplicationId = types.StringValue(sp.ApplicationID)
+	plan.DisplayName = types.StringValue(sp.DisplayName)
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksServicePrincipalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_service_principal.Read")
+	defer span.End()
+
+	var state databricksServicePrincipalResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	sp, err := client.GetServicePrincipal(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading service principal", "Could not read service principal "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	state.ApplicationId = types.StringValue(sp.ApplicationID)
+	state.DisplayName = types.StringValue(sp.DisplayName)
+	state.Active = types.BoolValue(sp.Active)
+	state.Entitlements = scimValueSet(sp.Entitlements, state.Entitlements)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksServicePrincipalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_service_principal.Update")
+	defer span.End()
+
+	var plan, state databricksServicePrincipalResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var ops []databricks.ScimPatchOp
+	if !plan.DisplayName.IsUnknown() && !plan.DisplayName.Equal(state.DisplayName) {
+		ops = append(ops, databricks.ScimReplace("displayName", plan.DisplayName.ValueString()))
+	}
+	if !plan.Active.Equal(state.Active) {
+		ops = append(ops, databricks.ScimReplace("active", plan.Active.ValueBool()))
+	}
+	priorEntitlements, diags := setStrings(ctx, state.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	entitlements, diags := setStrings(ctx, plan.Entitlements)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ops = append(ops, scimSetOps("entitlements", priorEntitlements, entitlements)...)
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.PatchServicePrincipal(ctx, state.Id.ValueString(), ops)
+	r.audit.Record(ctx, "mrl_databricks_service_principal", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error updating service principal", "Could not update service principal "+state.Id.ValueString()+": "+err.Error())
+		return
+	}
+
+	plan.Id = state.Id
+	plan.ApplicationId = state.ApplicationId
+	if plan.DisplayName.IsUnknown() {
+		plan.DisplayName = state.DisplayName
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksServicePrincipalResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_service_principal.Delete")
+	defer span.End()
+
+	var state databricksServicePrincipalResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteServicePrincipal(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_service_principal", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting service principal", "Could not delete service principal "+state.Id.ValueString()+": "+err.Error())
+	}
+}
@@ -0,0 +1,247 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksSparkVersionsDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksSparkVersionsDataSource{}
+)
+
+// NewDatabricksSparkVersionsDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksSparkVersionsDataSource() datasource.DataSource {
+	return &DatabricksSparkVersionsDataSource{}
+}
+
+// DatabricksSparkVersionsDataSource is the data source implementation.
+type DatabricksSparkVersionsDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksSparkVersionsDataSourceModel maps the data source schema data.
+type databricksSparkVersionsDataSourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           types.String `tfsdk:"adb_id"`
+	WorkspaceUrl    types.String `tfsdk:"workspace_url"`
+	Token           types.String `tfsdk:"token"`
+	LongTermSupport types.Bool   `tfsdk:"long_term_support"`
+	Ml              types.Bool   `tfsdk:"ml"`
+	Gpu             types.Bool   `tfsdk:"gpu"`
+	Photon          types.Bool   `tfsdk:"photon"`
+	Beta            types.Bool   `tfsdk:"beta"`
+	Latest          types.String `tfsdk:"latest"`
+	Versions        types.List   `tfsdk:"versions"`
+}
+
+// sparkVersionAttrTypes are the attribute types of a versions element.
+var sparkVersionAttrTypes = map[string]attr.Type{
+	"key":  types.StringType,
+	"name": types.StringType,
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksSparkVersionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksSparkVersionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_spark_versions"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksSparkVersionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the Databricks runtime versions of a workspace matching the given filters, newest first, so clusters can follow the latest runtime instead of a hardcoded version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Key of the latest matching version",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"long_term_support": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only return long term support versions. Defaults to false",
+			},
+			"ml": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Return Databricks Runtime for Machine Learning versions instead of standard ones. Defaults to false",
+			},
+			"gpu": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Return GPU versions instead of CPU ones. Only ML runtimes have GPU versions. Defaults to false",
+			},
+			"photon": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Return the separate Photon versions of older runtimes instead of standard ones. Recent runtimes enable Photon on the cluster instead. Defaults to false",
+			},
+			"beta": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Also return beta versions. Defaults to false",
+			},
+			"latest": schema.StringAttribute{
+				Computed:    true,
+				Description: "Key of the latest matching version, to use as spark_version of a cluster. Null when no version matches",
+			},
+			"versions": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Matching versions, newest first",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"key": schema.StringAttribute{
+							Computed:    true,
+							Description: "Key of the version, such as 15.4.x-scala2.12",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Display name of the version",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// sparkVersionNumber parses the major and minor version from a runtime key
+// such as 15.4.x-scala2.12. Keys of custom or legacy images give false.
+func sparkVersionNumber(key string) (int, int, bool) {
+	parts := strings.SplitN(key, ".", 3)
+	if len(parts) < 3 {
+		return 0, 0, false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	return major, minor, true
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksSparkVersionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_spark_versions.Read")
+	defer span.End()
+
+	var state databricksSparkVersionsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	versions, err := client.SparkVersions(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error listing Spark versions", "Could not list the runtime versions of the workspace: "+err.Error())
+		return
+	}
+
+	var matches []databricks.SparkVersion
+	for _, version := range versions {
+		if _, _, ok := sparkVersionNumber(version.Key); !ok {
+			continue
+		}
+		if strings.Contains(version.Key, "aarch64") {
+			continue
+		}
+		if state.LongTermSupport.ValueBool() && !strings.Contains(version.Name, "LTS") {
+			continue
+		}
+		if strings.Contains(version.Key, "-ml-") != state.Ml.ValueBool() {
+			continue
+		}
+		if strings.Contains(version.Key, "-gpu-") != state.Gpu.ValueBool() {
+			continue
+		}
+		if strings.Contains(version.Key, "photon") != state.Photon.ValueBool() {
+			continue
+		}
+		if strings.Contains(strings.ToLower(version.Name), "beta") && !state.Beta.ValueBool() {
+			continue
+		}
+		matches = append(matches, version)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		imajor, iminor, _ := sparkVersionNumber(matches[i].Key)
+		jmajor, jminor, _ := sparkVersionNumber(matches[j].Key)
+		if imajor != jmajor {
+			return imajor > jmajor
+		}
+		if iminor != jminor {
+			return iminor > jminor
+		}
+		return matches[i].Key > matches[j].Key
+	})
+
+	values := make([]attr.Value, 0, len(matches))
+	for _, version := range matches {
+		values = append(values, types.ObjectValueMust(sparkVersionAttrTypes, map[string]attr.Value{
+			"key":  types.StringValue(version.Key),
+			"name": types.StringValue(version.Name),
+		}))
+	}
+
+	state.Latest = types.StringNull()
+	state.Id = types.StringValue("")
+	if len(matches) > 0 {
+		state.Latest = types.StringValue(matches[0].Key)
+		state.Id = state.Latest
+	}
+	state.Versions = types.ListValueMust(types.ObjectType{AttrTypes: sparkVersionAttrTypes}, values)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,266 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksSqlQueryDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksSqlQueryDataSource{}
+)
+
+// readOnlyStatement matches the statements the data source is allowed to run.
+var readOnlyStatement = regexp.MustCompile(`(?is)^\s*(SELECT|WITH|SHOW|DESCRIBE|DESC|EXPLAIN|VALUES)\b`)
+
+// defaultSqlQueryRowLimit caps the rows read when row_limit is not set.
+const defaultSqlQueryRowLimit = 1000
+
+// NewDatabricksSqlQueryDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksSqlQueryDataSource() datasource.DataSource {
+	return &DatabricksSqlQueryDataSource{}
+}
+
+// DatabricksSqlQueryDataSource is the data source implementation.
+type DatabricksSqlQueryDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksSqlQueryDataSourceModel maps the data source schema data.
+type databricksSqlQueryDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	WarehouseId  types.String `tfsdk:"warehouse_id"`
+	Statement    types.String `tfsdk:"statement"`
+	Catalog      types.String `tfsdk:"catalog"`
+	Schema       types.String `tfsdk:"schema"`
+	Parameters   types.Map    `tfsdk:"parameters"`
+	RowLimit     types.Int64  `tfsdk:"row_limit"`
+	Columns      types.List   `tfsdk:"columns"`
+	Rows         types.List   `tfsdk:"rows"`
+	Truncated    types.Bool   `tfsdk:"truncated"`
+}
+
+// sqlColumnAttrTypes are the attribute types of a columns element.
+var sqlColumnAttrTypes = map[string]attr.Type{
+	"name": types.StringType,
+	"type": types.StringType,
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksSqlQueryDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksSqlQueryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_sql_query"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksSqlQueryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Runs a read-only SQL statement on a SQL warehouse and exposes the result, for example to look up IDs kept in a configuration table.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the statement execution",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"warehouse_id": schema.StringAttribute{
+				Required:    true,
+				Description: "ID of the SQL warehouse running the statement",
+			},
+			"statement": schema.StringAttribute{
+				Required:    true,
+				Description: "Read-only statement to run: SELECT, WITH, SHOW, DESCRIBE, EXPLAIN or VALUES",
+			},
+			"catalog": schema.StringAttribute{
+				Optional:    true,
+				Description: "Default catalog of the statement",
+			},
+			"schema": schema.StringAttribute{
+				Optional:    true,
+				Description: "Default schema of the statement",
+			},
+			"parameters": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Values of the :name parameter markers of the statement",
+			},
+			"row_limit": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of rows to read. Defaults to 1000",
+			},
+			"columns": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Columns of the result, in order",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the column",
+						},
+						"type": schema.StringAttribute{
+							Computed:    true,
+							Description: "SQL type name of the column",
+						},
+					},
+				},
+			},
+			"rows": schema.ListAttribute{
+				ElementType: types.MapType{ElemType: types.StringType},
+				Computed:    true,
+				Description: "Rows of the result as maps of column name to value rendered as string. NULL values are null",
+			},
+			"truncated": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the result was cut at row_limit",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksSqlQueryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_sql_query.Read")
+	defer span.End()
+
+	var state databricksSqlQueryDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !readOnlyStatement.MatchString(state.Statement.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("statement"),
+			"Statement is not read-only",
+			"The data source only runs SELECT, WITH, SHOW, DESCRIBE, EXPLAIN and VALUES statements. Use mrl_databricks_sql_statement for statements with side effects.",
+		)
+		return
+	}
+
+	var parameters map[string]string
+	resp.Diagnostics.Append(state.Parameters.ElementsAs(ctx, &parameters, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rowLimit := int64(defaultSqlQueryRowLimit)
+	if !state.RowLimit.IsNull() {
+		rowLimit = state.RowLimit.ValueInt64()
+	}
+
+	stmt := databricks.StatementRequest{
+		WarehouseID: state.WarehouseId.ValueString(),
+		Statement:   state.Statement.ValueString(),
+		Catalog:     state.Catalog.ValueString(),
+		Schema:      state.Schema.ValueString(),
+		RowLimit:    rowLimit,
+	}
+	for name, value := range parameters {
+		stmt.Parameters = append(stmt.Parameters, databricks.StatementParameter{Name: name, Value: value})
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	result, err := client.ExecuteStatement(ctx, stmt)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running SQL query",
+			"Statement failed on warehouse "+state.WarehouseId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	rows, err := client.StatementRows(ctx, result)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error running SQL query",
+			"Could not fetch the result of statement "+result.StatementID+": "+err.Error(),
+		)
+		return
+	}
+
+	var columns []databricks.StatementColumn
+	if result.Manifest != nil {
+		columns = result.Manifest.Schema.Columns
+	}
+
+	columnValues := make([]attr.Value, 0, len(columns))
+	for _, column := range columns {
+		columnValues = append(columnValues, types.ObjectValueMust(sqlColumnAttrTypes, map[string]attr.Value{
+			"name": types.StringValue(column.Name),
+			"type": types.StringValue(column.TypeName),
+		}))
+	}
+
+	rowValues := make([]attr.Value, 0, len(rows))
+	for _, row := range rows {
+		values := make(map[string]attr.Value, len(columns))
+		for i, column := range columns {
+			value := types.StringNull()
+			if i < len(row) && row[i] != nil {
+				value = types.StringValue(*row[i])
+			}
+			values[column.Name] = value
+		}
+		rowValues = append(rowValues, types.MapValueMust(types.StringType, values))
+	}
+
+	state.Id = types.StringValue(result.StatementID)
+	state.Columns = types.ListValueMust(types.ObjectType{AttrTypes: sqlColumnAttrTypes}, columnValues)
+	state.Rows = types.ListValueMust(types.MapType{ElemType: types.StringType}, rowValues)
+	state.Truncated = types.BoolValue(result.Manifest != nil && result.Manifest.Truncated)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksSqlStatementResource{}
+	_ resource.ResourceWithConfigure = &DatabricksSqlStatementResource{}
+)
+
+// NewDatabricksSqlStatementResource is a helper function to simplify the provider implementation.
+func NewDatabricksSqlStatementResource() resource.Resource {
+	return &DatabricksSqlStatementResource{}
+}
+
+// DatabricksSqlStatementResource is the resource implementation.
+type DatabricksSqlStatementResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksSqlStatementResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue     `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	WarehouseId  types.String `tfsdk:"warehouse_id"`
+	Statement    types.String `tfsdk:"statement"`
+	Catalog      types.String `tfsdk:"catalog"`
+	Schema       types.String `tfsdk:"schema"`
+	Parameters   types.Map    `tfsdk:"parameters"`
+	Triggers     types.Map    `tfsdk:"triggers"`
+	OnDestroy    types.String `tfsdk:"on_destroy"`
+	State        types.String `tfsdk:"state"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksSqlStatementResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksSqlStatementResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_sql_statement"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksSqlStatementResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Runs a SQL statement on a SQL warehouse when created, for example GRANTs or bootstrap DDL, and waits for it to finish. The statement runs again whenever it or one of the triggers changes.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the last statement execution",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType:         URLType{},
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType:  URLType{},
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"warehouse_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ID of the SQL warehouse running the statement",
+			},
+			"statement": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "SQL statement to run",
+			},
+			"catalog": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Default catalog of the statement",
+			},
+			"schema": schema.StringAttribute{
+				Optional:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Default schema of the statement",
+			},
+			"parameters": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Values of the :name parameter markers of the statement",
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Arbitrary values that run the statement again when they change",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:    true,
+				Description: "SQL statement run on the same warehouse when the resource is destroyed, such as the matching REVOKE",
+			},
+			"state": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Final state of the last statement execution",
+			},
+		},
+	}
+}
+
+// execute runs statement with the warehouse and defaults of model.
+func (r *DatabricksSqlStatementResource) execute(ctx context.Context, model *databricksSqlStatementResourceModel, statement string) (*databricks.StatementResponse, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	var parameters map[string]string
+	diags.Append(model.Parameters.ElementsAs(ctx, &parameters, false)...)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	req := databricks.StatementRequest{
+		WarehouseID: model.WarehouseId.ValueString(),
+		Statement:   statement,
+		Catalog:     model.Catalog.ValueString(),
+		Schema:      model.Schema.ValueString(),
+	}
+	for name, value := range parameters {
+		req.Parameters = append(req.Parameters, databricks.StatementParameter{Name: name, Value: value})
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(model.WorkspaceUrl, model.AdbId), model.Token)
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return nil, diags
+	}
+	result, err := client.ExecuteStatement(ctx, req)
+	if err != nil {
+		diags.AddError("Error running SQL statement", "Statement failed on warehouse "+model.WarehouseId.ValueString()+": "+err.Error())
+		return nil, diags
+	}
+	return result, diags
+}
+
+// Create a new resource.
+func (r *DatabricksSqlStatementResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_statement.Create")
+	defer span.End()
+
+	var plan databricksSqlStatementResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	result, diags := r.execute(ctx, &plan, plan.Statement.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_sql_statement", auditActionCreate, plan.WarehouseId.ValueString(), diagsError(diags))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = types.StringValue(result.StatementID)
+	plan.State = types.StringValue(result.Status.State)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read keeps the recorded execution, a statement is never re-run on refresh.
+func (r *DatabricksSqlStatementResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state databricksSqlStatementResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update records changes that do not run the statement again, such as
+// on_destroy or the token.
+func (r *DatabricksSqlStatementResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan databricksSqlStatementResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete runs the on_destroy statement, if any, and removes the Terraform state on success.
+func (r *DatabricksSqlStatementResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_statement.Delete")
+	defer span.End()
+
+	var state databricksSqlStatementResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.IsNull() || state.OnDestroy.ValueString() == "" {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	_, diags = r.execute(ctx, &state, state.OnDestroy.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_sql_statement", auditActionDelete, state.WarehouseId.ValueString(), diagsError(diags))
+	resp.Diagnostics.Append(diags...)
+}
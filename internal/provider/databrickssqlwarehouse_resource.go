@@ -0,0 +1,458 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// warehouseClusterSizes are the sizes of the clusters of a SQL warehouse.
+var warehouseClusterSizes = []string{"2X-Small", "X-Small", "Small", "Medium", "Large", "X-Large", "2X-Large", "3X-Large", "4X-Large"}
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksSqlWarehouseResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksSqlWarehouseResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksSqlWarehouseResource{}
+)
+
+// NewDatabricksSqlWarehouseResource is a helper function to simplify the provider implementation.
+func NewDatabricksSqlWarehouseResource() resource.Resource {
+	return &DatabricksSqlWarehouseResource{}
+}
+
+// DatabricksSqlWarehouseResource is the resource implementation.
+type DatabricksSqlWarehouseResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksSqlWarehouseResourceModel struct {
+	Id                      types.String   `tfsdk:"id"`
+	AdbId                   URLValue       `tfsdk:"adb_id"`
+	WorkspaceUrl            URLValue       `tfsdk:"workspace_url"`
+	Token                   types.String   `tfsdk:"token"`
+	Name                    types.String   `tfsdk:"name"`
+	ClusterSize             types.String   `tfsdk:"cluster_size"`
+	AutoStopMins            types.Int64    `tfsdk:"auto_stop_mins"`
+	MinNumClusters          types.Int64    `tfsdk:"min_num_clusters"`
+	MaxNumClusters          types.Int64    `tfsdk:"max_num_clusters"`
+	EnableServerlessCompute types.Bool     `tfsdk:"enable_serverless_compute"`
+	WarehouseType           types.String   `tfsdk:"warehouse_type"`
+	Tags                    types.Map      `tfsdk:"tags"`
+	State                   types.String   `tfsdk:"state"`
+	JdbcUrl                 types.String   `tfsdk:"jdbc_url"`
+	HttpPath                types.String   `tfsdk:"http_path"`
+	Timeouts                *timeoutsModel `tfsdk:"timeouts"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksSqlWarehouseResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksSqlWarehouseResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_sql_warehouse"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksSqlWarehouseResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a Databricks SQL warehouse and waits for it to run after it is created. Its id can be used as the warehouse_id of mrl_databricks_sql_statement.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the SQL warehouse",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the SQL warehouse",
+			},
+			"cluster_size": schema.StringAttribute{
+				Required:    true,
+				Validators:  []validator.String{StringOneOf(warehouseClusterSizes...)},
+				Description: "Size of the clusters of the warehouse: 2X-Small, X-Small, Small, Medium, Large, X-Large, 2X-Large, 3X-Large or 4X-Large",
+			},
+			"auto_stop_mins": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(120),
+				Validators:  []validator.Int64{Int64Between(0, 10000)},
+				Description: "Minutes of inactivity after which the warehouse stops. 0 disables auto stop, which serverless warehouses do not support. Defaults to 120",
+			},
+			"min_num_clusters": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators:  []validator.Int64{Int64Between(1, 40)},
+				Description: "Minimum number of clusters the warehouse scales down to. Defaults to 1",
+			},
+			"max_num_clusters": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators:  []validator.Int64{Int64Between(1, 40)},
+				Description: "Maximum number of clusters the warehouse scales up to under load. Defaults to 1",
+			},
+			"enable_serverless_compute": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Run the warehouse on serverless compute. Requires warehouse_type PRO. Defaults to false",
+			},
+			"warehouse_type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(databricks.WarehouseTypePro),
+				Validators:  []validator.String{StringOneOf(databricks.WarehouseTypePro, databricks.WarehouseTypeClassic)},
+				Description: "Type of the warehouse: PRO or CLASSIC. Defaults to PRO",
+			},
+			"tags": schema.MapAttribute{
+				Optional:    true,
+				ElementType: types.StringType,
+				Description: "Tags added to the warehouse and to the cloud resources it runs on",
+			},
+			"state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the warehouse, such as RUNNING or STOPPED",
+			},
+			"jdbc_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "JDBC URL of the warehouse",
+			},
+			"http_path": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "HTTP path of the warehouse for ODBC and Databricks SQL connectors",
+			},
+			"timeouts": timeoutsAttribute(),
+		},
+	}
+}
+
+// ValidateConfig checks the cluster bounds and that serverless warehouses are
+// PRO warehouses.
+func (r *DatabricksSqlWarehouseResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksSqlWarehouseResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.MinNumClusters.IsNull() && !config.MinNumClusters.IsUnknown() && !config.MaxNumClusters.IsNull() && !config.MaxNumClusters.IsUnknown() &&
+		config.MinNumClusters.ValueInt64() > config.MaxNumClusters.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("min_num_clusters"),
+			"Invalid warehouse size",
+			"min_num_clusters must not be greater than max_num_clusters.",
+		)
+	}
+	if config.EnableServerlessCompute.ValueBool() && config.WarehouseType.ValueString() == databricks.WarehouseTypeClassic {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("warehouse_type"),
+			"Invalid warehouse type",
+			"Serverless warehouses must be PRO warehouses.",
+		)
+	}
+}
+
+// warehouseSpec builds the create and edit request from the plan.
+func warehouseSpec(ctx context.Context, plan *databricksSqlWarehouseResourceModel) (databricks.WarehouseSpec, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	spec := databricks.WarehouseSpec{
+		Name:                    plan.Name.ValueString(),
+		ClusterSize:             plan.ClusterSize.ValueString(),
+		AutoStopMins:            plan.AutoStopMins.ValueInt64(),
+		MinNumClusters:          plan.MinNumClusters.ValueInt64(),
+		MaxNumClusters:          plan.MaxNumClusters.ValueInt64(),
+		EnableServerlessCompute: plan.EnableServerlessCompute.ValueBool(),
+		WarehouseType:           plan.WarehouseType.ValueString(),
+	}
+
+	var tags map[string]string
+	diags.Append(plan.Tags.ElementsAs(ctx, &tags, false)...)
+	if !plan.Tags.IsNull() {
+		spec.Tags = &databricks.WarehouseTags{CustomTags: []databricks.WarehouseTag{}}
+		keys := make([]string, 0, len(tags))
+		for k := range tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			spec.Tags.CustomTags = append(spec.Tags.CustomTags, databricks.WarehouseTag{Key: k, Value: tags[k]})
+		}
+	}
+	return spec, diags
+}
+
+// setWarehouseInfo copies the warehouse description into the model.
+func setWarehouseInfo(ctx context.Context, model *databricksSqlWarehouseResourceModel, info *databricks.WarehouseInfo) diag.Diagnostics {
+	model.Id = types.StringValue(info.ID)
+	model.Name = types.StringValue(info.Name)
+	model.ClusterSize = types.StringValue(info.ClusterSize)
+	model.AutoStopMins = types.Int64Value(info.AutoStopMins)
+	model.MinNumClusters = types.Int64Value(info.MinNumClusters)
+	model.MaxNumClusters = types.Int64Value(info.MaxNumClusters)
+	model.EnableServerlessCompute = types.BoolValue(info.EnableServerlessCompute)
+	if info.WarehouseType != "" {
+		model.WarehouseType = types.StringValue(info.WarehouseType)
+	}
+	model.State = types.StringValue(info.State)
+	model.JdbcUrl = types.StringValue(info.JdbcURL)
+	model.HttpPath = types.StringValue(info.OdbcParams.Path)
+
+	tags := map[string]string{}
+	if info.Tags != nil {
+		for _, tag := range info.Tags.CustomTags {
+			tags[tag.Key] = tag.Value
+		}
+	}
+	var diags diag.Diagnostics
+	model.Tags, diags = stringMapValue(ctx, model.Tags, tags)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksSqlWarehouseResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_warehouse.Create")
+	defer span.End()
+
+	var plan databricksSqlWarehouseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := warehouseSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.create(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	id, err := client.CreateWarehouse(ctx, spec)
+	r.audit.Record(ctx, "mrl_databricks_sql_warehouse", auditActionCreate, id, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SQL warehouse",
+			"Could not create SQL warehouse "+plan.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// Save the ID first so a warehouse that fails to start is not orphaned.
+	plan.Id = types.StringValue(id)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+
+	info, err := client.WaitWarehouse(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SQL warehouse",
+			"SQL warehouse "+id+" did not start: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setWarehouseInfo(ctx, &plan, info)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksSqlWarehouseResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_warehouse.Read")
+	defer span.End()
+
+	var state databricksSqlWarehouseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.read(ctx)
+	defer cancel()
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.GetWarehouse(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) || (err == nil && info.State == databricks.WarehouseDeleted) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading SQL warehouse",
+			"Could not read SQL warehouse "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setWarehouseInfo(ctx, &state, info)...)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksSqlWarehouseResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_warehouse.Update")
+	defer span.End()
+
+	var plan databricksSqlWarehouseResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, diags := warehouseSpec(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.update(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.EditWarehouse(ctx, plan.Id.ValueString(), spec)
+	r.audit.Record(ctx, "mrl_databricks_sql_warehouse", auditActionUpdate, plan.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating SQL warehouse",
+			"Could not edit SQL warehouse "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	// A stopped warehouse stays stopped after an edit; a running one may
+	// restart with the new configuration.
+	info, err := client.GetWarehouse(ctx, plan.Id.ValueString())
+	if err == nil && info.State != databricks.WarehouseStopped {
+		info, err = client.WaitWarehouse(ctx, plan.Id.ValueString())
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating SQL warehouse",
+			"SQL warehouse "+plan.Id.ValueString()+" did not restart: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(setWarehouseInfo(ctx, &plan, info)...)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksSqlWarehouseResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_sql_warehouse.Delete")
+	defer span.End()
+
+	var state databricksSqlWarehouseResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.delete(ctx)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteWarehouse(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_sql_warehouse", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting SQL warehouse",
+			"Could not delete SQL warehouse "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
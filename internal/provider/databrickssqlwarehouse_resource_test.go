@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksSqlWarehouseTypeName = "mrl_databricks_sql_warehouse"
+
+// mockWarehouses serves the SQL warehouses API of a mock workspace: create,
+// get, edit and delete. Warehouses start RUNNING and keep their state across
+// edits; deleted warehouses are reported as DELETED.
+type mockWarehouses struct {
+	warehouses map[string]databricks.WarehouseInfo
+	edits      int
+	next       int
+}
+
+// newMockWarehouses adds the SQL warehouses API to m.
+func newMockWarehouses(m *mockDbfs) *mockWarehouses {
+	warehouses := &mockWarehouses{warehouses: map[string]databricks.WarehouseInfo{}}
+	m.route("/api/2.0/sql/warehouses", warehouses.serveHTTP)
+	return warehouses
+}
+
+func (m *mockWarehouses) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var spec databricks.WarehouseSpec
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+
+	if r.Method == http.MethodPost && r.URL.Path == "/api/2.0/sql/warehouses" {
+		m.next++
+		info := databricks.WarehouseInfo{WarehouseSpec: spec, ID: "wh" + strconv.Itoa(m.next), State: databricks.WarehouseRunning}
+		info.JdbcURL = "jdbc:spark://adb.example.net:443/default;httpPath=/sql/1.0/warehouses/" + info.ID
+		info.OdbcParams.Hostname = "adb.example.net"
+		info.OdbcParams.Path = "/sql/1.0/warehouses/" + info.ID
+		m.warehouses[info.ID] = info
+		writeMockJSON(w, map[string]interface{}{"id": info.ID})
+		return
+	}
+
+	id, edit := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/api/2.0/sql/warehouses/"), "/edit")
+	current, ok := m.warehouses[id]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Unable to find warehouse "+id)
+		return
+	}
+	switch {
+	case r.Method == http.MethodGet && !edit:
+		writeMockJSON(w, current)
+	case r.Method == http.MethodPost && edit:
+		m.edits++
+		current.WarehouseSpec = spec
+		m.warehouses[id] = current
+		writeMockJSON(w, map[string]interface{}{})
+	case r.Method == http.MethodDelete && !edit:
+		current.State = databricks.WarehouseDeleted
+		m.warehouses[id] = current
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func TestDatabricksSqlWarehouseResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	warehouses := newMockWarehouses(m)
+	var id string
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksSqlWarehouseTypeName,
+		config: map[string]interface{}{
+			"name":         "analysts",
+			"cluster_size": "Small",
+			"tags":         map[string]string{"team": "bi", "env": "prod"},
+		},
+		created: func(t *testing.T, state tftypes.Value) {
+			id = stringAttr(t, state, "id")
+			got := warehouses.warehouses[id]
+			if got.AutoStopMins != 120 || got.WarehouseType != databricks.WarehouseTypePro || got.Tags == nil || len(got.Tags.CustomTags) != 2 {
+				t.Errorf("created %+v, want the default auto stop and type and 2 tags", got)
+			} else if got.Tags.CustomTags[0].Key != "env" {
+				t.Errorf("tags are sent as %v, want them sorted by key", got.Tags.CustomTags)
+			}
+			if got := stringAttr(t, state, "state"); got != databricks.WarehouseRunning {
+				t.Errorf("state is %q, want RUNNING", got)
+			}
+			if got := stringAttr(t, state, "http_path"); got != "/sql/1.0/warehouses/"+id {
+				t.Errorf("http_path is %q, want that of the ODBC parameters", got)
+			}
+		},
+		updates: []lifecycleUpdate{{
+			// A stopped warehouse is edited without waiting for it to start.
+			prepare: func(t *testing.T) {
+				info := warehouses.warehouses[id]
+				info.State = databricks.WarehouseStopped
+				warehouses.warehouses[id] = info
+			},
+			config: map[string]interface{}{
+				"name":             "analysts",
+				"cluster_size":     "Medium",
+				"max_num_clusters": 3,
+			},
+			check: func(t *testing.T, state tftypes.Value) {
+				if got := warehouses.warehouses[id]; got.ClusterSize != "Medium" || got.MaxNumClusters != 3 || got.Tags != nil {
+					t.Errorf("warehouse is %+v after update, want size Medium, 3 clusters and no tags", got)
+				}
+				if got := stringAttr(t, state, "state"); got != databricks.WarehouseStopped {
+					t.Errorf("state is %q after update, want STOPPED", got)
+				}
+				if got := stringAttr(t, state, "jdbc_url"); !strings.HasSuffix(got, id) {
+					t.Errorf("jdbc_url is %q after update, want it kept", got)
+				}
+			},
+		}},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if got := warehouses.warehouses[id].State; got != databricks.WarehouseDeleted {
+				t.Errorf("warehouse is %s after destroy, want DELETED", got)
+			}
+		},
+	})
+	if warehouses.edits != 1 {
+		t.Errorf("warehouse edited %d times, want 1", warehouses.edits)
+	}
+}
+
+func TestDatabricksSqlWarehouseResource_validate(t *testing.T) {
+	p, _ := testDbfsProvider(t)
+	typeName := databricksSqlWarehouseTypeName
+
+	diags := p.validate(typeName, p.config(typeName, map[string]interface{}{
+		"name":             "analysts",
+		"cluster_size":     "Small",
+		"min_num_clusters": 3,
+		"max_num_clusters": 2,
+	}))
+	if !strings.Contains(errorDiagnostics(diags), "min_num_clusters must not be greater than max_num_clusters") {
+		t.Errorf("got diagnostics %q, want the cluster bounds error", errorDiagnostics(diags))
+	}
+
+	diags = p.validate(typeName, p.config(typeName, map[string]interface{}{
+		"name":                      "analysts",
+		"cluster_size":              "Small",
+		"enable_serverless_compute": true,
+		"warehouse_type":            databricks.WarehouseTypeClassic,
+	}))
+	if !strings.Contains(errorDiagnostics(diags), "Serverless warehouses must be PRO warehouses") {
+		t.Errorf("got diagnostics %q, want the serverless warehouse type error", errorDiagnostics(diags))
+	}
+}
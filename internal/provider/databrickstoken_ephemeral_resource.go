@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &DatabricksTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &DatabricksTokenEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithClose     = &DatabricksTokenEphemeralResource{}
+)
+
+// defaultEphemeralTokenLifetime is the lifetime of ephemeral tokens that do
+// not set lifetime_seconds.
+const defaultEphemeralTokenLifetime = time.Hour
+
+// NewDatabricksTokenEphemeralResource is a helper function to simplify the provider implementation.
+func NewDatabricksTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &DatabricksTokenEphemeralResource{}
+}
+
+// DatabricksTokenEphemeralResource is the ephemeral resource implementation.
+type DatabricksTokenEphemeralResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+type databricksTokenEphemeralResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           types.String `tfsdk:"adb_id"`
+	WorkspaceUrl    types.String `tfsdk:"workspace_url"`
+	LifetimeSeconds types.Int64  `tfsdk:"lifetime_seconds"`
+	Comment         types.String `tfsdk:"comment"`
+	TokenValue      types.String `tfsdk:"token_value"`
+	ExpiryTime      types.String `tfsdk:"expiry_time"`
+}
+
+// databricksTokenPrivate is the private data of an open ephemeral token,
+// which Close revokes.
+type databricksTokenPrivate struct {
+	Host    string `json:"host"`
+	TokenID string `json:"token_id"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *DatabricksTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *DatabricksTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_token"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *DatabricksTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a short-lived personal access token of the principal the provider authenticates as, revoked at the end of the run. Unlike the mrl_databricks_token resource, the token is never written to plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the token",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"lifetime_seconds": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(1, 730*24*3600)},
+				Description: "Lifetime of the token in seconds, as a safeguard should the run end before the token is revoked. Defaults to 3600",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment shown in the token list of the workspace",
+			},
+			"token_value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the token",
+			},
+			"expiry_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Expiry time of the token, in RFC3339 format",
+			},
+		},
+	}
+}
+
+// Open creates the token.
+func (r *DatabricksTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_token.Open")
+	defer span.End()
+
+	var config databricksTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := r.workspace.resolve(workspaceURL(config.WorkspaceUrl, config.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	lifetime := int64(defaultEphemeralTokenLifetime / time.Second)
+	if !config.LifetimeSeconds.IsNull() {
+		lifetime = config.LifetimeSeconds.ValueInt64()
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+	value, info, err := client.CreateToken(ctx, lifetime, config.Comment.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", "Could not create the access token: "+err.Error())
+		return
+	}
+
+	private, err := json.Marshal(databricksTokenPrivate{Host: host, TokenID: info.TokenID})
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", "Could not record the token to revoke: "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, "token", private)...)
+
+	config.Id = types.StringValue(info.TokenID)
+	config.TokenValue = types.StringValue(value)
+	config.ExpiryTime = types.StringValue(time.UnixMilli(info.ExpiryTime).UTC().Format(time.RFC3339))
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
+
+// Close revokes the token. A token already revoked or expired is no error.
+func (r *DatabricksTokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_token.Close")
+	defer span.End()
+
+	data, diags := req.Private.GetKey(ctx, "token")
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || data == nil {
+		return
+	}
+	var private databricksTokenPrivate
+	if err := json.Unmarshal(data, &private); err != nil {
+		resp.Diagnostics.AddError("Error revoking token", "Could not read the token to revoke: "+err.Error())
+		return
+	}
+
+	client := r.workspace.newClient(r.httpClient, private.Host, r.workspace.token)
+	err := client.RevokeToken(ctx, private.TokenID)
+	if err != nil && !databricks.IsNotFound(err) {
+		resp.Diagnostics.AddError("Error revoking token", "Could not revoke token "+private.TokenID+": "+err.Error())
+	}
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksTokenResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksTokenResource{}
+	_ resource.ResourceWithModifyPlan     = &DatabricksTokenResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksTokenResource{}
+)
+
+// NewDatabricksTokenResource is a helper function to simplify the provider implementation.
+func NewDatabricksTokenResource() resource.Resource {
+	return &DatabricksTokenResource{}
+}
+
+// DatabricksTokenResource is the resource implementation.
+type DatabricksTokenResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksTokenResourceModel maps the resource schema data.
+type databricksTokenResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl    URLValue     `tfsdk:"workspace_url"`
+	Token           types.String `tfsdk:"token"`
+	LifetimeSeconds types.Int64  `tfsdk:"lifetime_seconds"`
+	Comment         types.String `tfsdk:"comment"`
+	RotateAfter     types.String `tfsdk:"rotate_after"`
+	Keepers         types.Map    `tfsdk:"keepers"`
+	TokenValue      types.String `tfsdk:"token_value"`
+	CreationTime    RFC3339Value `tfsdk:"creation_time"`
+	ExpiryTime      RFC3339Value `tfsdk:"expiry_time"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_token"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Creates a personal access token of the principal the provider authenticates as, such as its Microsoft Entra ID service principal. The token is replaced once rotate_after has passed or keepers change; use create_before_destroy so that consumers get the new token before the old one is revoked. token_value is stored in the Terraform state; use the mrl_databricks_token ephemeral resource for tokens needed during the run only.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the token",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token the new token is created with. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"lifetime_seconds": schema.Int64Attribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.Int64{Int64Between(1, 730*24*3600)},
+				Description: "Lifetime of the token in seconds. Without it, the token does not expire unless the workspace enforces a maximum lifetime",
+			},
+			"comment": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Comment shown in the token list of the workspace",
+			},
+			"rotate_after": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{Duration()},
+				Description: "Age after which the next plan replaces the token, as a duration such as `720h`. Must be shorter than lifetime_seconds",
+			},
+			"keepers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+				Description: "Arbitrary values that replace the token when they change",
+			},
+			"token_value": schema.StringAttribute{
+				Computed:  true,
+				Sensitive: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Value of the token",
+			},
+			"creation_time": schema.StringAttribute{
+				CustomType: RFC3339Type{},
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Creation time of the token, in RFC3339 format",
+			},
+			"expiry_time": schema.StringAttribute{
+				CustomType: RFC3339Type{},
+				Computed:   true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Expiry time of the token, in RFC3339 format. Null when the token does not expire",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the token is rotated before it expires.
+func (r *DatabricksTokenResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksTokenResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.RotateAfter.IsNull() || config.RotateAfter.IsUnknown() || config.LifetimeSeconds.IsNull() || config.LifetimeSeconds.IsUnknown() {
+		return
+	}
+
+	rotateAfter, err := time.ParseDuration(config.RotateAfter.ValueString())
+	if err != nil {
+		// Reported by the Duration validator.
+		return
+	}
+	if rotateAfter >= time.Duration(config.LifetimeSeconds.ValueInt64())*time.Second {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotate_after"),
+			"Invalid rotate_after",
+			"rotate_after must be shorter than lifetime_seconds, so that the token is replaced before it expires.",
+		)
+	}
+}
+
+// ModifyPlan replaces the token once it is older than rotate_after. Terraform
+// only replaces a resource for an attribute whose planned value changes, so
+// the creation time of the new token is planned unknown and flagged.
+func (r *DatabricksTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state databricksTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.RotateAfter.IsNull() || plan.RotateAfter.IsUnknown() || state.CreationTime.IsNull() {
+		return
+	}
+
+	rotateAfter, err := time.ParseDuration(plan.RotateAfter.ValueString())
+	if err != nil {
+		return
+	}
+	created, err := time.Parse(time.RFC3339, state.CreationTime.ValueString())
+	if err != nil {
+		return
+	}
+	if time.Since(created) < rotateAfter {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("creation_time"), NewRFC3339Unknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("creation_time"))
+	resp.Diagnostics.AddAttributeWarning(
+		path.Root("rotate_after"),
+		"Token rotation due",
+		fmt.Sprintf("Token %s was created at %s, more than %s ago, and is replaced.", state.Id.ValueString(), state.CreationTime.ValueString(), rotateAfter),
+	)
+}
+
+// setTokenTimes records the creation and expiry time of info in model.
+func setTokenTimes(model *databricksTokenResourceModel, info *databricks.TokenInfo) {
+	model.CreationTime = NewRFC3339TimeValue(time.UnixMilli(info.CreationTime))
+	model.ExpiryTime = NewRFC3339Null()
+	if info.ExpiryTime > 0 {
+		model.ExpiryTime = NewRFC3339TimeValue(time.UnixMilli(info.ExpiryTime))
+	}
+}
+
+// Create a new resource.
+func (r *DatabricksTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_token.Create")
+	defer span.End()
+
+	var plan databricksTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	value, info, err := client.CreateToken(ctx, plan.LifetimeSeconds.ValueInt64(), plan.Comment.ValueString())
+	target := plan.Comment.ValueString()
+	if info != nil {
+		target = info.TokenID
+	}
+	r.audit.Record(ctx, "mrl_databricks_token", auditActionCreate, target, err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error creating token", "Could not create the access token: "+err.Error())
+		return
+	}
+
+	plan.Id = types.StringValue(info.TokenID)
+	plan.TokenValue = types.StringValue(value)
+	setTokenTimes(&plan, info)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. Revoked and
+// expired tokens are removed from state, so that the next apply creates a
+// new one.
+func (r *DatabricksTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_token.Read")
+	defer span.End()
+
+	var state databricksTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	tokens, err := client.ListTokens(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading token", "Could not list the access tokens: "+err.Error())
+		return
+	}
+
+	for i := range tokens {
+		info := &tokens[i]
+		if info.TokenID != state.Id.ValueString() {
+			continue
+		}
+		if info.ExpiryTime > 0 && time.UnixMilli(info.ExpiryTime).Before(time.Now()) {
+			break
+		}
+		setTokenTimes(&state, info)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// Update records changes of rotate_after and token, which do not replace the
+// token.
+func (r *DatabricksTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	_, span := tracing.Start(ctx, "mrl_databricks_token.Update")
+	defer span.End()
+
+	var plan databricksTokenResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete revokes the token and removes the Terraform state on success.
+func (r *DatabricksTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_token.Delete")
+	defer span.End()
+
+	var state databricksTokenResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.RevokeToken(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_token", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error revoking token", "Could not revoke token "+state.Id.ValueString()+": "+err.Error())
+	}
+}
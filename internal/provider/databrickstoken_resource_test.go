@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksTokenTypeName = "mrl_databricks_token"
+
+// mockTokens serves the token API of a mock workspace: create, list and
+// delete.
+type mockTokens struct {
+	tokens map[string]map[string]interface{}
+	next   int
+}
+
+// newMockTokens adds the token API to m.
+func newMockTokens(m *mockDbfs) *mockTokens {
+	tokens := &mockTokens{tokens: map[string]map[string]interface{}{}}
+	m.route("/api/2.0/token/", tokens.serveHTTP)
+	return tokens
+}
+
+func (m *mockTokens) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		LifetimeSeconds int64  `json:"lifetime_seconds"`
+		Comment         string `json:"comment"`
+		TokenID         string `json:"token_id"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+
+	switch r.URL.Path {
+	case "/api/2.0/token/create":
+		m.next++
+		id := "token-" + strconv.Itoa(m.next)
+		created := time.Now()
+		expiry := int64(-1)
+		if body.LifetimeSeconds > 0 {
+			expiry = created.Add(time.Duration(body.LifetimeSeconds) * time.Second).UnixMilli()
+		}
+		info := map[string]interface{}{
+			"token_id":      id,
+			"comment":       body.Comment,
+			"creation_time": created.UnixMilli(),
+			"expiry_time":   expiry,
+		}
+		m.tokens[id] = info
+		writeMockJSON(w, map[string]interface{}{"token_value": "dapi-" + id, "token_info": info})
+	case "/api/2.0/token/list":
+		infos := make([]map[string]interface{}, 0, len(m.tokens))
+		for _, info := range m.tokens {
+			infos = append(infos, info)
+		}
+		writeMockJSON(w, map[string]interface{}{"token_infos": infos})
+	case "/api/2.0/token/delete":
+		if _, ok := m.tokens[body.TokenID]; !ok {
+			writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "token "+body.TokenID+" does not exist")
+			return
+		}
+		delete(m.tokens, body.TokenID)
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+// exists reports whether the token id has not been revoked. It is called
+// between requests only, so needs no lock.
+func (m *mockTokens) exists(id string) bool {
+	_, ok := m.tokens[id]
+	return ok
+}
+
+func TestDatabricksTokenResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	tokens := newMockTokens(m)
+	typeName := databricksTokenTypeName
+
+	for _, attr := range p.schemas[typeName].Block.Attributes {
+		if (attr.Name == "token_value" || attr.Name == "token") && !attr.Sensitive {
+			t.Errorf("%s is not sensitive", attr.Name)
+		}
+	}
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: typeName,
+		config: map[string]interface{}{
+			"comment":          "scheduler",
+			"lifetime_seconds": 3600,
+		},
+		created: func(t *testing.T, state tftypes.Value) {
+			id := stringAttr(t, state, "id")
+			if !tokens.exists(id) {
+				t.Fatalf("token %s not created", id)
+			}
+			if got := stringAttr(t, state, "token_value"); got != "dapi-"+id {
+				t.Errorf("token_value is %q, want %q", got, "dapi-"+id)
+			}
+			if stateAttr(t, state, "expiry_time").IsNull() {
+				t.Error("expiry_time is null, want the expiry of the token")
+			}
+		},
+		destroyed: func(t *testing.T, state tftypes.Value) {
+			if id := stringAttr(t, state, "id"); tokens.exists(id) {
+				t.Error("token still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksTokenResource_rotation(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	tokens := newMockTokens(m)
+	typeName := databricksTokenTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	config := func(rotateAfter string) tftypes.Value {
+		return p.config(typeName, map[string]interface{}{
+			"comment":          "scheduler",
+			"lifetime_seconds": 7 * 24 * 3600,
+			"rotate_after":     rotateAfter,
+		})
+	}
+
+	state := p.apply(typeName, null, config("24h"))
+	id := stringAttr(t, state, "id")
+
+	// A new rotate_after is recorded without replacing the token.
+	_, replace, diags := p.planReplace(typeName, state, config("48h"))
+	if errorDiagnostics(diags) != "" || len(replace) != 0 {
+		t.Errorf("plan replaces the token for %v with %s, want an update", replace, errorDiagnostics(diags))
+	}
+	state = p.apply(typeName, state, config("48h"))
+	if got := stringAttr(t, state, "id"); got != id {
+		t.Errorf("id is %q after the update, want %q", got, id)
+	}
+
+	// Once older than rotate_after, the token is replaced.
+	tokens.tokens[id]["creation_time"] = time.Now().Add(-72 * time.Hour).UnixMilli()
+	state = p.refresh(typeName, state)
+	planned, replace, diags := p.planReplace(typeName, state, config("48h"))
+	if errorDiagnostics(diags) != "" {
+		t.Fatalf("plan: %s", errorDiagnostics(diags))
+	}
+	if len(replace) != 1 || replace[0].String() != tftypes.NewAttributePath().WithAttributeName("creation_time").String() {
+		t.Errorf("plan replaces the token for %v, want creation_time", replace)
+	}
+	if stateAttr(t, planned, "creation_time").IsKnown() {
+		t.Error("planned creation_time is known, want the unknown time of the new token")
+	}
+	if !strings.Contains(warningDiagnostics(diags), "Token rotation due") {
+		t.Errorf("got warnings %q, want the rotation", warningDiagnostics(diags))
+	}
+
+	// An expired token is removed from state, so that the next apply creates
+	// a new one.
+	tokens.tokens[id]["expiry_time"] = time.Now().Add(-time.Hour).UnixMilli()
+	if refreshed := p.refresh(typeName, state); !refreshed.IsNull() {
+		t.Errorf("state of the expired token is %s, want null", refreshed)
+	}
+}
+
+func TestDatabricksTokenEphemeralResource(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	tokens := newMockTokens(m)
+	typeName := databricksTokenTypeName
+
+	for _, attr := range p.ephemeralSchemas[typeName].Block.Attributes {
+		if attr.Name == "token_value" && !attr.Sensitive {
+			t.Error("token_value is not sensitive")
+		}
+	}
+
+	result, private := p.openEphemeral(typeName, map[string]interface{}{"comment": "deploy"})
+	id := stringAttr(t, result, "id")
+	if !tokens.exists(id) {
+		t.Fatalf("token %s not created", id)
+	}
+	if got := stringAttr(t, result, "token_value"); got != "dapi-"+id {
+		t.Errorf("token_value is %q, want %q", got, "dapi-"+id)
+	}
+	expiry, err := time.Parse(time.RFC3339, stringAttr(t, result, "expiry_time"))
+	if err != nil {
+		t.Fatalf("expiry_time: %v", err)
+	}
+	if lifetime := time.Until(expiry); lifetime <= 0 || lifetime > defaultEphemeralTokenLifetime {
+		t.Errorf("token expires in %s, want at most %s", lifetime, defaultEphemeralTokenLifetime)
+	}
+
+	p.closeEphemeral(typeName, private)
+	if tokens.exists(id) {
+		t.Error("token still exists after close")
+	}
+	// A token revoked in the meantime is closed without error.
+	p.closeEphemeral(typeName, private)
+}
@@ -0,0 +1,444 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksUnityVolumeFileResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksUnityVolumeFileResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksUnityVolumeFileResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksUnityVolumeFileResource{}
+)
+
+// NewDatabricksUnityVolumeFileResource is a helper function to simplify the provider implementation.
+func NewDatabricksUnityVolumeFileResource() resource.Resource {
+	return &DatabricksUnityVolumeFileResource{}
+}
+
+// DatabricksUnityVolumeFileResource is the resource implementation.
+type DatabricksUnityVolumeFileResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksUnityVolumeFileResourceModel mirrors databricksDbfsResourceModel,
+// with volume_path in place of dbfs_path.
+type databricksUnityVolumeFileResourceModel struct {
+	AdbId          URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl   URLValue                       `tfsdk:"workspace_url"`
+	Token          types.String                   `tfsdk:"token"`
+	LocalPath      types.String                   `tfsdk:"local_path"`
+	VolumePath     types.String                   `tfsdk:"volume_path"`
+	FileSize       types.Int64                    `tfsdk:"file_size"`
+	LastModified   RFC3339Value                   `tfsdk:"modification_time"`
+	Md5Hash        types.String                   `tfsdk:"content_md5"`
+	Drift          types.String                   `tfsdk:"drift_detection"`
+	ContentChanged types.Bool                     `tfsdk:"content_changed"`
+	SourceHash     types.String                   `tfsdk:"source_hash"`
+	Workspace      *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksUnityVolumeFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksUnityVolumeFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_unity_volume_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksUnityVolumeFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a local file to a Unity Catalog volume with the Files API. The attributes match mrl_databricks_dbfs_file, with volume_path in place of dbfs_path.",
+		Attributes: map[string]schema.Attribute{
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local path from where the file needs to be read",
+			},
+			"volume_path": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.String{VolumeFilePath()},
+				Description: "Path the file is uploaded to, /Volumes/<catalog>/<schema>/<volume>/ followed by the file path in the volume. Missing directories are created",
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the file being managed",
+			},
+			"modification_time": schema.StringAttribute{
+				CustomType:  RFC3339Type{},
+				Computed:    true,
+				Description: "Last modified time of the file being managed, in RFC3339 format",
+			},
+			"content_md5": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "md5 hash of the file. Defaults to the md5 of local_path, computed at plan time",
+			},
+			"content_changed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the planned change uploads new content. The plan also shows a warning with the local and remote hashes and the size delta",
+			},
+			"source_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "SHA-256 of local_path, computed at plan time. A change uploads the file again, even when content_md5 is set",
+			},
+			"drift_detection": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(driftDetectionMetadata),
+				Validators:  []validator.String{StringOneOf(driftDetectionNone, driftDetectionMetadata, driftDetectionContent)},
+				Description: "How refresh detects changes made outside Terraform: none skips the remote check, metadata compares size and modification time, content downloads and hashes the file. Defaults to metadata",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksUnityVolumeFileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(path.Root("workspace_url"), path.Root("adb_id")),
+	}
+}
+
+// ModifyPlan flags plans that upload new content and explains why.
+func (r *DatabricksUnityVolumeFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksUnityVolumeFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	md5Hash, diags := planContentMD5(ctx, req.Config, plan.LocalPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Md5Hash = md5Hash
+
+	sourceHash, diags := planSourceHash(plan.LocalPath)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.SourceHash = sourceHash
+
+	if req.State.Raw.IsNull() {
+		plan.ContentChanged = types.BoolValue(true)
+	} else {
+		var state databricksUnityVolumeFileResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		changed, warning := previewContentChange(plan.LocalPath, plan.Md5Hash, state.Md5Hash, state.FileSize)
+		if warning == nil {
+			if stale, sourceWarning := sourceChanged(plan.LocalPath, plan.SourceHash, state.SourceHash); stale {
+				changed, warning = types.BoolValue(true), sourceWarning
+			}
+		}
+		plan.ContentChanged = changed
+		if warning != nil {
+			resp.Diagnostics.Append(warning)
+		}
+		if changed.IsUnknown() || changed.ValueBool() {
+			plan.FileSize = types.Int64Unknown()
+			plan.LastModified = RFC3339Value{StringValue: types.StringUnknown()}
+		} else {
+			plan.FileSize = state.FileSize
+			plan.LastModified = state.LastModified
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// upload uploads the local file of plan to its volume path and records the
+// resulting metadata in plan.
+func (r *DatabricksUnityVolumeFileResource) upload(ctx context.Context, plan *databricksUnityVolumeFileResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		diags.AddError("Missing Databricks workspace", err.Error())
+		return diags
+	}
+	localPath := plan.LocalPath.ValueString()
+	volumePath := plan.VolumePath.ValueString()
+
+	err = uploadVolumeFile(ctx, client, localPath, volumePath)
+	r.audit.Record(ctx, "mrl_databricks_unity_volume_file", action, volumePath, err)
+	if err != nil {
+		diags.AddError(
+			"Error uploading volume file",
+			"Could not upload "+localPath+" to "+volumePath+": "+err.Error(),
+		)
+		return diags
+	}
+
+	info, err := client.FilesGetMetadata(ctx, volumePath)
+	if err != nil {
+		diags.AddError(
+			"Error reading volume file",
+			"Could not read the metadata of "+volumePath+" after uploading it: "+err.Error(),
+		)
+		return diags
+	}
+
+	plan.FileSize = types.Int64Value(info.Size)
+	plan.LastModified = NewRFC3339TimeValue(info.LastModified)
+	return diags
+}
+
+// uploadVolumeFile streams the local file fp to the volume file at
+// volumePath, overwriting an existing file.
+func uploadVolumeFile(ctx context.Context, client *databricks.Client, fp, volumePath string) error {
+	f, err := os.Open(fp)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return client.FilesUpload(ctx, volumePath, f, info.Size())
+}
+
+// volumeFileContentMD5 downloads a volume file and returns the hex md5 of its
+// content.
+func volumeFileContentMD5(ctx context.Context, client *databricks.Client, volumePath string) (string, error) {
+	body, err := client.FilesDownload(ctx, volumePath)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, body); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Create a new resource.
+func (r *DatabricksUnityVolumeFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_unity_volume_file.Create")
+	defer span.End()
+
+	var plan databricksUnityVolumeFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	resp.Diagnostics.Append(r.upload(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksUnityVolumeFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_unity_volume_file.Read")
+	defer span.End()
+
+	var state databricksUnityVolumeFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ContentChanged = types.BoolValue(false)
+
+	drift := state.Drift.ValueString()
+	if drift == driftDetectionNone {
+		diags = resp.State.Set(ctx, &state)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	volumePath := state.VolumePath.ValueString()
+
+	info, err := client.FilesGetMetadata(ctx, volumePath)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading volume file",
+			"Could not read the metadata of "+volumePath+": "+err.Error(),
+		)
+		return
+	}
+
+	lastModified := NewRFC3339TimeValue(info.LastModified)
+	if drift == driftDetectionMetadata {
+		// The remote file was rewritten outside Terraform; forget the
+		// recorded hash so the next plan uploads the local file again.
+		if !state.FileSize.Equal(types.Int64Value(info.Size)) || !state.LastModified.Equal(lastModified) {
+			state.Md5Hash = types.StringNull()
+		}
+	}
+	if drift == driftDetectionContent {
+		sum, err := volumeFileContentMD5(ctx, client, volumePath)
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading volume file",
+				"Could not hash the content of "+volumePath+": "+err.Error(),
+			)
+			return
+		}
+		state.Md5Hash = types.StringValue(sum)
+	}
+
+	state.FileSize = types.Int64Value(info.Size)
+	state.LastModified = lastModified
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksUnityVolumeFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_unity_volume_file.Update")
+	defer span.End()
+
+	var plan databricksUnityVolumeFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ContentChanged.ValueBool() {
+		ctx = withAuditRequestID(ctx)
+		resp.Diagnostics.Append(r.upload(ctx, &plan, auditActionUpdate)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success. A
+// file that is already gone is not an error.
+func (r *DatabricksUnityVolumeFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_unity_volume_file.Delete")
+	defer span.End()
+
+	var state databricksUnityVolumeFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	volumePath := state.VolumePath.ValueString()
+
+	ctx = withAuditRequestID(ctx)
+	err = client.FilesDelete(ctx, volumePath)
+	r.audit.Record(ctx, "mrl_databricks_unity_volume_file", auditActionDelete, volumePath, err)
+	if err != nil && !databricks.IsNotFound(err) {
+		resp.Diagnostics.AddError(
+			"Error deleting volume file",
+			"Could not delete "+volumePath+": "+err.Error(),
+		)
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksUnityVolumeFileTypeName = "mrl_databricks_unity_volume_file"
+
+// mockVolumeFiles serves the Files API of a mock workspace: upload, get
+// metadata, download and delete of volume files.
+type mockVolumeFiles struct {
+	files    map[string][]byte
+	modified map[string]time.Time
+}
+
+// newMockVolumeFiles adds the Files API to m.
+func newMockVolumeFiles(m *mockDbfs) *mockVolumeFiles {
+	files := &mockVolumeFiles{files: map[string][]byte{}, modified: map[string]time.Time{}}
+	m.route("/api/2.0/fs/files/", files.serveHTTP)
+	return files
+}
+
+// put stores a volume file as if written outside Terraform.
+func (m *mockVolumeFiles) put(p string, data []byte) {
+	m.files[p] = data
+	m.modified[p] = time.Now().Add(time.Minute)
+}
+
+func (m *mockVolumeFiles) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	p, err := url.PathUnescape(strings.TrimPrefix(r.URL.EscapedPath(), "/api/2.0/fs/files"))
+	if err != nil {
+		writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", err.Error())
+		return
+	}
+	if r.Method == http.MethodPut {
+		data, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+		m.files[p] = data
+		m.modified[p] = time.Now()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	data, ok := m.files[p]
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "NOT_FOUND", "The file "+p+" does not exist")
+		return
+	}
+	switch r.Method {
+	case http.MethodHead:
+		w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+		w.Header().Set("Last-Modified", m.modified[p].UTC().Format(http.TimeFormat))
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		_, _ = w.Write(data)
+	case http.MethodDelete:
+		delete(m.files, p)
+		delete(m.modified, p)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func TestDatabricksUnityVolumeFileResource_lifecycle(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	files := newMockVolumeFiles(m)
+	const volumePath = "/Volumes/main/default/libs/app 1.jar"
+	localPath := writeLocalFile(t, "app.jar", "version 1")
+	config := map[string]interface{}{
+		"local_path":  localPath,
+		"volume_path": volumePath,
+	}
+
+	testLifecycle(t, p, lifecycleTest{
+		typeName: databricksUnityVolumeFileTypeName,
+		config:   config,
+		created: func(t *testing.T, state tftypes.Value) {
+			if got := string(files.files[volumePath]); got != "version 1" {
+				t.Fatalf("uploaded %q, want %q", got, "version 1")
+			}
+			if got := int64Attr(t, state, "file_size"); got != 9 {
+				t.Errorf("file_size is %d, want 9", got)
+			}
+			if got := stringAttr(t, state, "content_md5"); got != md5Hex([]byte("version 1")) {
+				t.Errorf("content_md5 is %q, want the md5 of the local file", got)
+			}
+		},
+		refreshResets: []string{"content_changed"},
+		updates: []lifecycleUpdate{{
+			prepare: func(t *testing.T) {
+				if err := os.WriteFile(localPath, []byte("version 2, longer"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			config: config,
+			planned: func(t *testing.T, planned tftypes.Value) {
+				if !boolAttr(t, planned, "content_changed") {
+					t.Error("content_changed is false after a local change")
+				}
+			},
+			check: func(t *testing.T, state tftypes.Value) {
+				if got := string(files.files[volumePath]); got != "version 2, longer" {
+					t.Fatalf("uploaded %q, want %q", got, "version 2, longer")
+				}
+				if got := int64Attr(t, state, "file_size"); got != 17 {
+					t.Errorf("file_size is %d, want 17", got)
+				}
+			},
+		}},
+		destroyed: func(t *testing.T, _ tftypes.Value) {
+			if _, ok := files.files[volumePath]; ok {
+				t.Error("file still exists after destroy")
+			}
+		},
+	})
+}
+
+func TestDatabricksUnityVolumeFileResource_drift(t *testing.T) {
+	for _, tc := range []struct {
+		drift  string
+		remote string
+	}{
+		// metadata notices the new size and modification time.
+		{drift: driftDetectionMetadata, remote: "rewritten remotely"},
+		// content notices a rewrite of the same size.
+		{drift: driftDetectionContent, remote: "version 2"},
+	} {
+		t.Run(tc.drift, func(t *testing.T) {
+			p, m := testDbfsProvider(t)
+			files := newMockVolumeFiles(m)
+			typeName := databricksUnityVolumeFileTypeName
+			null := tftypes.NewValue(p.resourceType(typeName), nil)
+			const volumePath = "/Volumes/main/default/libs/app.jar"
+			config := p.config(typeName, map[string]interface{}{
+				"local_path":      writeLocalFile(t, "app.jar", "version 1"),
+				"volume_path":     volumePath,
+				"drift_detection": tc.drift,
+			})
+
+			state := p.apply(typeName, null, config)
+			files.put(volumePath, []byte(tc.remote))
+			state = p.refresh(typeName, state)
+
+			planned, diags := p.plan(typeName, state, config)
+			if msg := errorDiagnostics(diags); msg != "" {
+				t.Fatalf("plan: %s", msg)
+			}
+			if !boolAttr(t, planned, "content_changed") {
+				t.Error("content_changed is false after a remote change")
+			}
+			if !strings.Contains(warningDiagnostics(diags), "will be uploaded again") {
+				t.Errorf("got warnings %q, want the content change", warningDiagnostics(diags))
+			}
+			p.apply(typeName, state, config)
+			if got := string(files.files[volumePath]); got != "version 1" {
+				t.Errorf("remote file is %q after apply, want the local %q", got, "version 1")
+			}
+		})
+	}
+}
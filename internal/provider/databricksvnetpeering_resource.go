@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksVNetPeeringResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksVNetPeeringResource{}
+	_ resource.ResourceWithImportState = &DatabricksVNetPeeringResource{}
+)
+
+// NewDatabricksVNetPeeringResource is a helper function to simplify the provider implementation.
+func NewDatabricksVNetPeeringResource() resource.Resource {
+	return &DatabricksVNetPeeringResource{}
+}
+
+// DatabricksVNetPeeringResource is the resource implementation.
+type DatabricksVNetPeeringResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type databricksVNetPeeringResourceModel struct {
+	Id                         types.String `tfsdk:"id"`
+	Name                       types.String `tfsdk:"name"`
+	WorkspaceId                types.String `tfsdk:"workspace_id"`
+	RemoteVirtualNetworkId     types.String `tfsdk:"remote_virtual_network_id"`
+	AllowVirtualNetworkAccess  types.Bool   `tfsdk:"allow_virtual_network_access"`
+	AllowForwardedTraffic      types.Bool   `tfsdk:"allow_forwarded_traffic"`
+	AllowGatewayTransit        types.Bool   `tfsdk:"allow_gateway_transit"`
+	UseRemoteGateways          types.Bool   `tfsdk:"use_remote_gateways"`
+	DatabricksVirtualNetworkId types.String `tfsdk:"databricks_virtual_network_id"`
+	PeeringState               types.String `tfsdk:"peering_state"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksVNetPeeringResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksVNetPeeringResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_vnet_peering"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksVNetPeeringResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	useStateForUnknown := []planmodifier.String{
+		stringplanmodifier.UseStateForUnknown(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Peers the virtual network managed by a Databricks workspace with another virtual network. The remote side needs a matching peering back to databricks_virtual_network_id.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "ARM ID of the peering",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the peering",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the Databricks workspace",
+			},
+			"remote_virtual_network_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the virtual network to peer with",
+			},
+			"allow_virtual_network_access": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether VMs in both networks can reach each other. Defaults to true",
+			},
+			"allow_forwarded_traffic": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether traffic forwarded by the remote network is allowed. Defaults to false",
+			},
+			"allow_gateway_transit": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the remote network can use gateways of the managed network. Defaults to false",
+			},
+			"use_remote_gateways": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the managed network uses the gateways of the remote network, for hybrid connectivity. Defaults to false",
+			},
+			"databricks_virtual_network_id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "ARM ID of the virtual network managed by the workspace",
+			},
+			"peering_state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the peering: Initiated, Connected or Disconnected",
+			},
+		},
+	}
+}
+
+// applyVNetPeering copies the properties of the peering to the model.
+func applyVNetPeering(model *databricksVNetPeeringResourceModel, peering *azure.WorkspaceVNetPeering) {
+	props := peering.Properties
+	model.Id = types.StringValue(peering.ID)
+	model.AllowVirtualNetworkAccess = types.BoolValue(props.AllowVirtualNetworkAccess)
+	model.AllowForwardedTraffic = types.BoolValue(props.AllowForwardedTraffic)
+	model.AllowGatewayTransit = types.BoolValue(props.AllowGatewayTransit)
+	model.UseRemoteGateways = types.BoolValue(props.UseRemoteGateways)
+	model.DatabricksVirtualNetworkId = types.StringNull()
+	if props.DatabricksVirtualNetwork != nil {
+		model.DatabricksVirtualNetworkId = types.StringValue(props.DatabricksVirtualNetwork.ID)
+	}
+	model.PeeringState = types.StringValue(props.PeeringState)
+}
+
+// put creates or updates the peering from the plan.
+func (r *DatabricksVNetPeeringResource) put(ctx context.Context, plan *databricksVNetPeeringResourceModel, action string) error {
+	id := azure.WorkspaceVNetPeeringID(plan.WorkspaceId.ValueString(), plan.Name.ValueString())
+
+	ctx = withAuditRequestID(ctx)
+	peering, err := r.azure.PutWorkspaceVNetPeering(ctx, id, azure.WorkspaceVNetPeering{
+		Properties: azure.WorkspaceVNetPeeringProperties{
+			RemoteVirtualNetwork:      azure.SubResource{ID: plan.RemoteVirtualNetworkId.ValueString()},
+			AllowVirtualNetworkAccess: plan.AllowVirtualNetworkAccess.ValueBool(),
+			AllowForwardedTraffic:     plan.AllowForwardedTraffic.ValueBool(),
+			AllowGatewayTransit:       plan.AllowGatewayTransit.ValueBool(),
+			UseRemoteGateways:         plan.UseRemoteGateways.ValueBool(),
+		},
+	})
+	r.audit.Record(ctx, "mrl_databricks_vnet_peering", action, id, err)
+	if err != nil {
+		return err
+	}
+
+	applyVNetPeering(plan, peering)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksVNetPeeringResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_vnet_peering.Create")
+	defer span.End()
+
+	var plan databricksVNetPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, &plan, auditActionCreate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating VNet peering",
+			"Could not peer the workspace network with "+plan.RemoteVirtualNetworkId.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksVNetPeeringResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_vnet_peering.Read")
+	defer span.End()
+
+	var state databricksVNetPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	peering, err := r.azure.GetWorkspaceVNetPeering(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading VNet peering",
+			"Could not read peering "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	applyVNetPeering(&state, peering)
+	state.RemoteVirtualNetworkId = types.StringValue(peering.Properties.RemoteVirtualNetwork.ID)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksVNetPeeringResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_vnet_peering.Update")
+	defer span.End()
+
+	var plan databricksVNetPeeringResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.put(ctx, &plan, auditActionUpdate); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating VNet peering",
+			"Could not update peering "+plan.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksVNetPeeringResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_vnet_peering.Delete")
+	defer span.End()
+
+	var state databricksVNetPeeringResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteWorkspaceVNetPeering(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_vnet_peering", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting VNet peering",
+			"Could not delete peering "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a peering by its ARM ID.
+func (r *DatabricksVNetPeeringResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportARMID(req.ID, "Microsoft.Databricks", "workspaces", "virtualNetworkPeerings")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+	workspaceID := azure.DatabricksWorkspaceID(id.SubscriptionID, id.ResourceGroup, id.Names[0])
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), workspaceID)...)
+}
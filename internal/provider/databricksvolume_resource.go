@@ -0,0 +1,416 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DatabricksVolumeResource{}
+	_ resource.ResourceWithConfigure      = &DatabricksVolumeResource{}
+	_ resource.ResourceWithValidateConfig = &DatabricksVolumeResource{}
+	_ resource.ResourceWithImportState    = &DatabricksVolumeResource{}
+)
+
+// NewDatabricksVolumeResource is a helper function to simplify the provider implementation.
+func NewDatabricksVolumeResource() resource.Resource {
+	return &DatabricksVolumeResource{}
+}
+
+// DatabricksVolumeResource is the resource implementation.
+type DatabricksVolumeResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksVolumeResourceModel struct {
+	Id              types.String `tfsdk:"id"`
+	AdbId           URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl    URLValue     `tfsdk:"workspace_url"`
+	CatalogName     types.String `tfsdk:"catalog_name"`
+	SchemaName      types.String `tfsdk:"schema_name"`
+	Name            types.String `tfsdk:"name"`
+	VolumeType      types.String `tfsdk:"volume_type"`
+	StorageLocation types.String `tfsdk:"storage_location"`
+	Comment         types.String `tfsdk:"comment"`
+	Owner           types.String `tfsdk:"owner"`
+	VolumeId        types.String `tfsdk:"volume_id"`
+	VolumePath      types.String `tfsdk:"volume_path"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksVolumeResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksVolumeResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_volume"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksVolumeResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a Unity Catalog volume within a schema. Files are uploaded to the volume with mrl_databricks_unity_volume_file.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Full name of the volume, catalog.schema.volume",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of a Databricks workspace assigned to the metastore. Defaults to host of the provider databricks block",
+			},
+			"catalog_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the catalog, e.g. from mrl_databricks_catalog",
+			},
+			"schema_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the schema, e.g. from mrl_databricks_schema",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the volume",
+			},
+			"volume_type": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString(databricks.VolumeTypeManaged),
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{StringOneOf(databricks.VolumeTypeManaged, databricks.VolumeTypeExternal)},
+				Description:   "MANAGED to store the files under the storage root of the schema, or EXTERNAL to expose storage_location. Defaults to MANAGED",
+			},
+			"storage_location": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Storage URL of the files of the volume. Required for, and only allowed with, EXTERNAL volumes, and must be covered by an external location. Computed for MANAGED volumes",
+			},
+			"comment": schema.StringAttribute{
+				Optional:    true,
+				Description: "Comment of the volume",
+			},
+			"owner": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "User, group or service principal owning the volume. Defaults to the identity the provider authenticates with",
+			},
+			"volume_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Unique ID of the volume",
+			},
+			"volume_path": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Path of the volume in the workspace file system, /Volumes/<catalog>/<schema>/<volume>, as used by mrl_databricks_unity_volume_file",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that a storage location is given exactly for external
+// volumes.
+func (r *DatabricksVolumeResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksVolumeResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.VolumeType.IsUnknown() || config.StorageLocation.IsUnknown() {
+		return
+	}
+	external := config.VolumeType.ValueString() == databricks.VolumeTypeExternal
+	if external == config.StorageLocation.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("storage_location"),
+			"Invalid storage location",
+			"storage_location must be set when, and only when, volume_type is EXTERNAL.",
+		)
+	}
+}
+
+// readVolume fills the computed attributes of model from the metastore.
+func readVolume(ctx context.Context, client *databricks.Client, model *databricksVolumeResourceModel) error {
+	volume, err := client.GetVolume(ctx, model.Id.ValueString())
+	if err != nil {
+		return err
+	}
+
+	model.Id = types.StringValue(volume.FullName)
+	// Unity Catalog lowercases names; keep the configured spelling.
+	if model.CatalogName.IsNull() {
+		model.CatalogName = types.StringValue(volume.CatalogName)
+	}
+	if model.SchemaName.IsNull() {
+		model.SchemaName = types.StringValue(volume.SchemaName)
+	}
+	if model.Name.IsNull() {
+		model.Name = types.StringValue(volume.Name)
+	}
+	model.VolumeType = types.StringValue(volume.VolumeType)
+	model.StorageLocation = types.StringValue(volume.StorageLocation)
+	if volume.Comment != "" || !model.Comment.IsNull() {
+		model.Comment = types.StringValue(volume.Comment)
+	}
+	model.Owner = types.StringValue(volume.Owner)
+	model.VolumeId = types.StringValue(volume.VolumeID)
+	model.VolumePath = types.StringValue("/Volumes/" + model.CatalogName.ValueString() + "/" + model.SchemaName.ValueString() + "/" + model.Name.ValueString())
+	return nil
+}
+
+// volumeUpdate returns the update setting the comment and owner of plan. An
+// unknown owner is left to the metastore.
+func volumeUpdate(plan databricksVolumeResourceModel) databricks.SecurableUpdate {
+	update := databricks.SecurableUpdate{Comment: plan.Comment.ValueString()}
+	if !plan.Owner.IsUnknown() {
+		update.Owner = plan.Owner.ValueString()
+	}
+	return update
+}
+
+// Create a new resource.
+func (r *DatabricksVolumeResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_volume.Create")
+	defer span.End()
+
+	var plan databricksVolumeResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	fullName := plan.CatalogName.ValueString() + "." + plan.SchemaName.ValueString() + "." + plan.Name.ValueString()
+	created, err := client.CreateVolume(ctx, databricks.VolumeInfo{
+		Name:            plan.Name.ValueString(),
+		CatalogName:     plan.CatalogName.ValueString(),
+		SchemaName:      plan.SchemaName.ValueString(),
+		VolumeType:      plan.VolumeType.ValueString(),
+		StorageLocation: plan.StorageLocation.ValueString(),
+		Comment:         plan.Comment.ValueString(),
+	})
+	if err == nil && created.FullName != "" {
+		fullName = created.FullName
+	}
+	// The owner can only be set once the volume exists.
+	if err == nil && !plan.Owner.IsUnknown() {
+		err = client.UpdateVolume(ctx, fullName, volumeUpdate(plan))
+		if err != nil {
+			// Do not leave an unmanaged volume behind.
+			_ = client.DeleteVolume(ctx, fullName)
+		}
+	}
+	r.audit.Record(ctx, "mrl_databricks_volume", auditActionCreate, fullName, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating volume",
+			"Could not create volume "+fullName+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(fullName)
+	if err := readVolume(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading volume",
+			"Could not read volume "+fullName+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksVolumeResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_volume.Read")
+	defer span.End()
+
+	var state databricksVolumeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readVolume(ctx, client, &state); err != nil {
+		if databricks.IsNotFound(err) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Error reading volume",
+			"Could not read volume "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksVolumeResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_volume.Update")
+	defer span.End()
+
+	var plan, state databricksVolumeResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if !plan.Comment.Equal(state.Comment) || !plan.Owner.Equal(state.Owner) {
+		err = client.UpdateVolume(ctx, state.Id.ValueString(), volumeUpdate(plan))
+	}
+	r.audit.Record(ctx, "mrl_databricks_volume", auditActionUpdate, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating volume",
+			"Could not update volume "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	if err := readVolume(ctx, client, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading volume",
+			"Could not read volume "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksVolumeResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_volume.Delete")
+	defer span.End()
+
+	var state databricksVolumeResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), types.StringNull())
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.DeleteVolume(ctx, state.Id.ValueString())
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_volume", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting volume",
+			"Could not delete volume "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a volume by an ID of the form
+// workspace_url|catalog_name|schema_name|name.
+func (r *DatabricksVolumeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "workspace_url", "catalog_name", "schema_name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_url"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[1]+"."+parts[2]+"."+parts[3])...)
+}
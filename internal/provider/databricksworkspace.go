@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"terraform-provider-mrl/internal/databricks"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// errNoDatabricksWorkspace is returned when neither a resource nor the
+// provider databricks block names a workspace.
+var errNoDatabricksWorkspace = errors.New("no Databricks workspace: set workspace_url, or host in the databricks block of the provider")
+
+// databricksTokenDescription describes the token attribute of Databricks
+// resources and data sources.
+const databricksTokenDescription = "Access token for the Databricks workspace. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal"
+
+// adbIDDeprecation is the deprecation message of adb_id, the former name of
+// workspace_url.
+const adbIDDeprecation = "adb_id is renamed workspace_url. Set workspace_url instead; adb_id will be removed in a future version."
+
+// databricksTokenDeprecation is the deprecation message of the token
+// attribute of Databricks resources and data sources, whose value Terraform
+// stores in plaintext in the state. Write-only attributes are not an option:
+// refresh and destroy need the token but never see write-only values.
+const databricksTokenDeprecation = "token is stored in plaintext in the Terraform state. Set token in the databricks block of the provider instead, or in its workspace block of the workspace, which are never stored in state and can come from an ephemeral value, or let the provider authenticate with databricks_client_id and databricks_client_secret or Microsoft Entra ID."
+
+// databricksWorkspace is the workspace configured in the provider databricks
+// block. Resources and data sources fall back to it when workspace_url or
+// token is not set.
+type databricksWorkspace struct {
+	host    string
+	token   string
+	uploads databricks.Limiter
+	// consistencyTimeout bounds the wait for DBFS to report an uploaded
+	// file.
+	consistencyTimeout time.Duration
+	// dbfsPathTemplate and dbfsPathVariables render the DBFS path of DBFS
+	// files that set neither dbfs_path nor dbfs_path_prefix_template.
+	dbfsPathTemplate  string
+	dbfsPathVariables map[string]string
+	// credentials are those of the workspace blocks of the provider
+	// databricks block, by workspaceKey of their host.
+	credentials map[string]databricksCredentials
+	// clients are the HTTP clients of the service principals of
+	// credentials.
+	clients *databricksClients
+}
+
+// databricksCredentials are the credentials of a workspace block of the
+// provider databricks block. They are held by the provider only, so that
+// they are never stored in the Terraform state.
+type databricksCredentials struct {
+	token                  string
+	clientID, clientSecret string
+}
+
+// workspaceKey returns the key of the workspace at host in credentials, the
+// same for all the ways of writing its URL.
+func workspaceKey(host string) string {
+	return normalizePath(databricks.WorkspaceURL(host), true)
+}
+
+// stringAttribute is a string attribute of a resource or data source, of
+// type types.String or of a custom type such as URLValue.
+type stringAttribute interface {
+	IsNull() bool
+	ValueString() string
+}
+
+// workspaceURL returns the workspace attribute of a resource or data source:
+// workspace_url, or else adb_id, its deprecated former name.
+func workspaceURL(workspaceURL, adbID stringAttribute) stringAttribute {
+	if workspaceURL.IsNull() {
+		return adbID
+	}
+	return workspaceURL
+}
+
+// resolve returns the workspace host and token to use given the
+// workspace_url and token attributes of a resource or data source.
+func (w databricksWorkspace) resolve(workspaceAttr, token stringAttribute) (string, string, error) {
+	host := workspaceAttr.ValueString()
+	if workspaceAttr.IsNull() {
+		host = w.host
+	}
+	t := token.ValueString()
+	if token.IsNull() {
+		t = w.token
+		if credentials, ok := w.credentials[workspaceKey(host)]; ok {
+			t = credentials.token
+		}
+	}
+
+	if host == "" {
+		return "", "", errNoDatabricksWorkspace
+	}
+	return host, t, nil
+}
+
+// client returns a client for the workspace given the workspace_url and
+// token attributes of a resource or data source.
+func (w databricksWorkspace) client(httpClient *http.Client, workspaceAttr, token stringAttribute) (*databricks.Client, error) {
+	host, t, err := w.resolve(workspaceAttr, token)
+	if err != nil {
+		return nil, err
+	}
+	return w.newClient(httpClient, host, t), nil
+}
+
+// newClient returns a client for the workspace at host, sharing the upload
+// limiter and consistency timeout of the provider.
+func (w databricksWorkspace) newClient(httpClient *http.Client, host, token string) *databricks.Client {
+	return databricks.NewClient(w.http(httpClient, host, token), host, token).
+		WithUploadLimiter(w.uploads).
+		WithConsistencyTimeout(w.consistencyTimeout)
+}
+
+// http returns the HTTP client to send the requests of the workspace at host
+// with: that of the service principal of its workspace block in the
+// provider, unless a token is used, or else httpClient.
+func (w databricksWorkspace) http(httpClient *http.Client, host, token string) *http.Client {
+	if credentials, ok := w.credentials[workspaceKey(host)]; ok && credentials.clientID != "" && token == "" {
+		return w.clients.get(credentials.clientID, credentials.clientSecret)
+	}
+	return httpClient
+}
+
+// override returns the workspace of a resource with the workspace block
+// block, or w when the resource has none. Its credentials are those of the
+// workspace block of the provider databricks block with the same host, and
+// otherwise do not default to the provider token: the resource then
+// authenticates with databricks_client_id and databricks_client_secret of the
+// provider, or else with Microsoft Entra ID.
+func (w databricksWorkspace) override(block *databricksWorkspaceBlockModel) databricksWorkspace {
+	if block == nil {
+		return w
+	}
+	w.host = block.Host.ValueString()
+	w.token = ""
+	return w
+}
+
+// databricksWorkspaceBlockModel maps the workspace block of resources that
+// can target a workspace other than that of the provider.
+type databricksWorkspaceBlockModel struct {
+	Host URLValue `tfsdk:"host"`
+}
+
+// databricksWorkspaceBlock returns the schema of the workspace block.
+func databricksWorkspaceBlock() schema.SingleNestedBlock {
+	return schema.SingleNestedBlock{
+		Description: "Workspace of the resource, instead of that of the provider databricks block, to deploy to several workspaces from one provider instance. The resource authenticates with the credentials of the workspace block of the provider databricks block with the same host, which are never stored in the Terraform state",
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL()},
+				Description: "URL or host name of the workspace, required in the block. Changing it replaces the resource",
+			},
+		},
+	}
+}
+
+// databricksClients caches an HTTP client per service principal of workspace
+// blocks, so that the resources authenticating as the same principal share
+// its OAuth tokens, which the client caches per workspace.
+type databricksClients struct {
+	// newClient returns the HTTP client of a service principal.
+	newClient func(clientID, clientSecret string) *http.Client
+
+	mu      sync.Mutex
+	clients map[databricksClientKey]*http.Client
+}
+
+type databricksClientKey struct {
+	clientID, clientSecret string
+}
+
+// get returns the cached HTTP client of the service principal, creating it
+// on first use.
+func (c *databricksClients) get(clientID, clientSecret string) *http.Client {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := databricksClientKey{clientID, clientSecret}
+	client, ok := c.clients[key]
+	if !ok {
+		if c.clients == nil {
+			c.clients = map[databricksClientKey]*http.Client{}
+		}
+		client = c.newClient(clientID, clientSecret)
+		c.clients[key] = client
+	}
+	return client
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksWorkspaceDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksWorkspaceDataSource{}
+)
+
+// NewDatabricksWorkspaceDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceDataSource() datasource.DataSource {
+	return &DatabricksWorkspaceDataSource{}
+}
+
+// DatabricksWorkspaceDataSource is the data source implementation.
+type DatabricksWorkspaceDataSource struct {
+	azure          *azure.Client
+	subscriptionID string
+}
+
+// databricksWorkspaceDataSourceModel maps the data source schema data.
+type databricksWorkspaceDataSourceModel struct {
+	ResourceId             types.String `tfsdk:"resource_id"`
+	Name                   types.String `tfsdk:"name"`
+	ResourceGroupName      types.String `tfsdk:"resource_group_name"`
+	Location               types.String `tfsdk:"location"`
+	Sku                    types.String `tfsdk:"sku"`
+	WorkspaceUrl           types.String `tfsdk:"workspace_url"`
+	WorkspaceId            types.String `tfsdk:"workspace_id"`
+	ManagedResourceGroupId types.String `tfsdk:"managed_resource_group_id"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksWorkspaceDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.azure = providerData.Azure
+	d.subscriptionID = providerData.SubscriptionID
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksWorkspaceDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksWorkspaceDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Resolves an Azure Databricks workspace through Azure Resource Manager, either from its resource ID or from its name and resource group in the provider subscription.",
+		Attributes: map[string]schema.Attribute{
+			"resource_id": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "ARM resource ID of the workspace. Conflicts with name and resource_group_name",
+			},
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Name of the workspace",
+			},
+			"resource_group_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Resource group of the workspace",
+			},
+			"location": schema.StringAttribute{
+				Computed:    true,
+				Description: "Azure region of the workspace",
+			},
+			"sku": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pricing tier of the workspace",
+			},
+			"workspace_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the workspace, usable as workspace_url of Databricks resources and data sources",
+			},
+			"workspace_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Databricks ID of the workspace",
+			},
+			"managed_resource_group_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "ID of the resource group managed by Databricks",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksWorkspaceDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_workspace.Read")
+	defer span.End()
+
+	var state databricksWorkspaceDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ResourceId.ValueString()
+	switch {
+	case id != "" && (!state.Name.IsNull() || !state.ResourceGroupName.IsNull()):
+		resp.Diagnostics.AddAttributeError(
+			path.Root("resource_id"),
+			"Conflicting workspace reference",
+			"Set either resource_id, or name and resource_group_name, but not both.",
+		)
+		return
+	case id == "" && (state.Name.IsNull() || state.ResourceGroupName.IsNull()):
+		resp.Diagnostics.AddError(
+			"Missing workspace reference",
+			"Set either resource_id, or both name and resource_group_name.",
+		)
+		return
+	case id == "":
+		id = azure.DatabricksWorkspaceID(d.subscriptionID, state.ResourceGroupName.ValueString(), state.Name.ValueString())
+	}
+
+	parsed, err := azure.ParseResourceID(id)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("resource_id"), "Invalid resource_id", err.Error())
+		return
+	}
+
+	workspace, err := d.azure.GetDatabricksWorkspace(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Databricks workspace",
+			"Could not read workspace "+id+": "+err.Error(),
+		)
+		return
+	}
+
+	state.ResourceId = types.StringValue(workspace.ID)
+	state.Name = types.StringValue(workspace.Name)
+	state.ResourceGroupName = types.StringValue(parsed.ResourceGroup)
+	state.Location = types.StringValue(workspace.Location)
+	state.Sku = types.StringValue("")
+	if workspace.Sku != nil {
+		state.Sku = types.StringValue(workspace.Sku.Name)
+	}
+	state.WorkspaceUrl = types.StringValue("https://" + workspace.Properties.WorkspaceURL)
+	state.WorkspaceId = types.StringValue(workspace.Properties.WorkspaceID)
+	state.ManagedResourceGroupId = types.StringValue(workspace.Properties.ManagedResourceGroupID)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
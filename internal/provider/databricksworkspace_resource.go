@@ -0,0 +1,436 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &DatabricksWorkspaceResource{}
+	_ resource.ResourceWithConfigure   = &DatabricksWorkspaceResource{}
+	_ resource.ResourceWithModifyPlan  = &DatabricksWorkspaceResource{}
+	_ resource.ResourceWithImportState = &DatabricksWorkspaceResource{}
+)
+
+// databricksWorkspaceTimeouts are the default timeouts of workspaces.
+// Provisioning or deleting a workspace and its managed resource group takes
+// up to half an hour.
+var databricksWorkspaceTimeouts = timeoutDefaults{
+	Create: 45 * time.Minute,
+	Read:   defaultReadTimeout,
+	Update: 45 * time.Minute,
+	Delete: 45 * time.Minute,
+}
+
+// NewDatabricksWorkspaceResource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceResource() resource.Resource {
+	return &DatabricksWorkspaceResource{}
+}
+
+// DatabricksWorkspaceResource is the resource implementation.
+type DatabricksWorkspaceResource struct {
+	azure          *azure.Client
+	subscriptionID string
+	audit          *auditLogger
+	defaultTags    map[string]string
+}
+
+type databricksWorkspaceResourceModel struct {
+	Id                         types.String                    `tfsdk:"id"`
+	Name                       types.String                    `tfsdk:"name"`
+	ResourceGroupName          types.String                    `tfsdk:"resource_group_name"`
+	Location                   types.String                    `tfsdk:"location"`
+	Sku                        types.String                    `tfsdk:"sku"`
+	ManagedResourceGroupName   types.String                    `tfsdk:"managed_resource_group_name"`
+	PublicNetworkAccessEnabled types.Bool                      `tfsdk:"public_network_access_enabled"`
+	RequiredNsgRules           types.String                    `tfsdk:"required_nsg_rules"`
+	CustomParameters           *workspaceCustomParametersModel `tfsdk:"custom_parameters"`
+	Tags                       types.Map                       `tfsdk:"tags"`
+	TagsAll                    types.Map                       `tfsdk:"tags_all"`
+	WorkspaceUrl               types.String                    `tfsdk:"workspace_url"`
+	WorkspaceId                types.String                    `tfsdk:"workspace_id"`
+	ManagedResourceGroupId     types.String                    `tfsdk:"managed_resource_group_id"`
+	Timeouts                   *timeoutsModel                  `tfsdk:"timeouts"`
+}
+
+// workspaceCustomParametersModel maps the VNet injection parameters.
+type workspaceCustomParametersModel struct {
+	VirtualNetworkId  types.String `tfsdk:"virtual_network_id"`
+	PublicSubnetName  types.String `tfsdk:"public_subnet_name"`
+	PrivateSubnetName types.String `tfsdk:"private_subnet_name"`
+	NoPublicIp        types.Bool   `tfsdk:"no_public_ip"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksWorkspaceResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.subscriptionID = providerData.SubscriptionID
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksWorkspaceResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksWorkspaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Provisions an Azure Databricks workspace through Azure Resource Manager in the provider subscription.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ARM resource ID of the workspace",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the workspace",
+			},
+			"resource_group_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Resource group in which the workspace is created",
+			},
+			"location": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Azure region of the workspace",
+			},
+			"sku": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("premium"),
+				Description: "Pricing tier of the workspace: standard, premium or trial. Defaults to premium",
+			},
+			"managed_resource_group_name": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Name of the resource group managed by Databricks. Defaults to databricks-rg-<name>",
+			},
+			"public_network_access_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether the workspace can be reached over the public network. Defaults to true",
+			},
+			"required_nsg_rules": schema.StringAttribute{
+				Optional:    true,
+				Description: "NSG rules Databricks manages for VNet injected workspaces: AllRules, NoAzureDatabricksRules or NoAzureServiceRules",
+			},
+			"custom_parameters": schema.SingleNestedAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.Object{
+					objectplanmodifier.RequiresReplace(),
+				},
+				Description: "VNet injection settings",
+				Attributes: map[string]schema.Attribute{
+					"virtual_network_id": schema.StringAttribute{
+						Required:    true,
+						Description: "ID of the virtual network the workspace is injected into",
+					},
+					"public_subnet_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Name of the host (public) subnet",
+					},
+					"private_subnet_name": schema.StringAttribute{
+						Required:    true,
+						Description: "Name of the container (private) subnet",
+					},
+					"no_public_ip": schema.BoolAttribute{
+						Optional:    true,
+						Description: "Enable secure cluster connectivity so cluster nodes get no public IP",
+					},
+				},
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags of the workspace",
+			},
+			"tags_all": tagsAllAttribute(),
+			"workspace_url": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the workspace, usable as workspace_url of Databricks resources and data sources",
+			},
+			"workspace_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Databricks ID of the workspace",
+			},
+			"managed_resource_group_id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the resource group managed by Databricks",
+			},
+			"timeouts": databricksWorkspaceTimeouts.attribute(),
+		},
+	}
+}
+
+// ModifyPlan merges the provider default tags into tags_all.
+func (r *DatabricksWorkspaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+}
+
+// workspaceFromModel builds the ARM request body from the plan.
+func (r *DatabricksWorkspaceResource) workspaceFromModel(ctx context.Context, model *databricksWorkspaceResourceModel) (azure.DatabricksWorkspace, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	if model.ManagedResourceGroupName.IsUnknown() || model.ManagedResourceGroupName.IsNull() {
+		model.ManagedResourceGroupName = types.StringValue("databricks-rg-" + model.Name.ValueString())
+	}
+
+	workspace := azure.DatabricksWorkspace{
+		Location: model.Location.ValueString(),
+		Sku:      &azure.Sku{Name: model.Sku.ValueString()},
+		Properties: azure.DatabricksWorkspaceProperties{
+			ManagedResourceGroupID: azure.ResourceGroupID(r.subscriptionID, model.ManagedResourceGroupName.ValueString()),
+			PublicNetworkAccess:    "Disabled",
+			RequiredNsgRules:       model.RequiredNsgRules.ValueString(),
+		},
+	}
+	if model.PublicNetworkAccessEnabled.ValueBool() {
+		workspace.Properties.PublicNetworkAccess = "Enabled"
+	}
+	if params := model.CustomParameters; params != nil {
+		workspace.Properties.Parameters = &azure.DatabricksWorkspaceParameters{
+			CustomVirtualNetworkID:  &azure.StringParameter{Value: params.VirtualNetworkId.ValueString()},
+			CustomPublicSubnetName:  &azure.StringParameter{Value: params.PublicSubnetName.ValueString()},
+			CustomPrivateSubnetName: &azure.StringParameter{Value: params.PrivateSubnetName.ValueString()},
+		}
+		if !params.NoPublicIp.IsNull() {
+			workspace.Properties.Parameters.EnableNoPublicIP = &azure.BoolParameter{Value: params.NoPublicIp.ValueBool()}
+		}
+	}
+	diags.Append(model.TagsAll.ElementsAs(ctx, &workspace.Tags, false)...)
+
+	return workspace, diags
+}
+
+// applyWorkspace copies the computed attributes of the workspace to the model.
+func applyWorkspace(model *databricksWorkspaceResourceModel, workspace *azure.DatabricksWorkspace) {
+	model.Id = types.StringValue(workspace.ID)
+	model.WorkspaceUrl = types.StringValue("https://" + workspace.Properties.WorkspaceURL)
+	model.WorkspaceId = types.StringValue(workspace.Properties.WorkspaceID)
+	model.ManagedResourceGroupId = types.StringValue(workspace.Properties.ManagedResourceGroupID)
+}
+
+// put creates or updates the workspace from the plan.
+func (r *DatabricksWorkspaceResource) put(ctx context.Context, plan *databricksWorkspaceResourceModel, action string) diag.Diagnostics {
+	workspace, diags := r.workspaceFromModel(ctx, plan)
+	if diags.HasError() {
+		return diags
+	}
+
+	id := azure.DatabricksWorkspaceID(r.subscriptionID, plan.ResourceGroupName.ValueString(), plan.Name.ValueString())
+
+	ctx = withAuditRequestID(ctx)
+	result, err := r.azure.PutDatabricksWorkspace(ctx, id, workspace)
+	r.audit.Record(ctx, "mrl_databricks_workspace", action, id, err)
+	if err != nil {
+		diags.AddError("Error provisioning Databricks workspace", "Could not provision workspace "+id+": "+err.Error())
+		return diags
+	}
+
+	applyWorkspace(plan, result)
+	return diags
+}
+
+// Create a new resource.
+func (r *DatabricksWorkspaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace.Create")
+	defer span.End()
+
+	var plan databricksWorkspaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.createOr(ctx, databricksWorkspaceTimeouts)
+	defer cancel()
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksWorkspaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace.Read")
+	defer span.End()
+
+	var state databricksWorkspaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.readOr(ctx, databricksWorkspaceTimeouts)
+	defer cancel()
+	workspace, err := r.azure.GetDatabricksWorkspace(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Databricks workspace",
+			"Could not read workspace "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	applyWorkspace(&state, workspace)
+	if state.Location.IsNull() {
+		// Imported: fill in the arguments the import ID does not carry.
+		state.Location = types.StringValue(workspace.Location)
+		managed := workspace.Properties.ManagedResourceGroupID
+		state.ManagedResourceGroupName = types.StringValue(managed[strings.LastIndex(managed, "/")+1:])
+		state.RequiredNsgRules = optionalString(workspace.Properties.RequiredNsgRules)
+		if params := workspace.Properties.Parameters; params != nil && params.CustomVirtualNetworkID != nil {
+			state.CustomParameters = &workspaceCustomParametersModel{
+				VirtualNetworkId: types.StringValue(params.CustomVirtualNetworkID.Value),
+			}
+			if params.EnableNoPublicIP != nil {
+				state.CustomParameters.NoPublicIp = types.BoolValue(params.EnableNoPublicIP.Value)
+			}
+			if params.CustomPublicSubnetName != nil {
+				state.CustomParameters.PublicSubnetName = types.StringValue(params.CustomPublicSubnetName.Value)
+			}
+			if params.CustomPrivateSubnetName != nil {
+				state.CustomParameters.PrivateSubnetName = types.StringValue(params.CustomPrivateSubnetName.Value)
+			}
+		}
+	}
+	if workspace.Sku != nil {
+		state.Sku = types.StringValue(workspace.Sku.Name)
+	}
+	state.PublicNetworkAccessEnabled = types.BoolValue(workspace.Properties.PublicNetworkAccess != "Disabled")
+	tags, tagsAll, diags := readTags(ctx, r.defaultTags, state.Tags, workspace.Tags)
+	resp.Diagnostics.Append(diags...)
+	state.Tags = tags
+	state.TagsAll = tagsAll
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksWorkspaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace.Update")
+	defer span.End()
+
+	var plan databricksWorkspaceResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := plan.Timeouts.updateOr(ctx, databricksWorkspaceTimeouts)
+	defer cancel()
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksWorkspaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace.Delete")
+	defer span.End()
+
+	var state databricksWorkspaceResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx, cancel := state.Timeouts.deleteOr(ctx, databricksWorkspaceTimeouts)
+	defer cancel()
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteDatabricksWorkspace(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_workspace", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Databricks workspace",
+			"Could not delete workspace "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a workspace by its ARM ID.
+func (r *DatabricksWorkspaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportARMID(req.ID, "Microsoft.Databricks", "workspaces")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_group_name"), id.ResourceGroup)...)
+}
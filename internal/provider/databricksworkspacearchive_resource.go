@@ -0,0 +1,404 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksWorkspaceArchiveResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksWorkspaceArchiveResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksWorkspaceArchiveResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksWorkspaceArchiveResource{}
+)
+
+// NewDatabricksWorkspaceArchiveResource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceArchiveResource() resource.Resource {
+	return &DatabricksWorkspaceArchiveResource{}
+}
+
+// DatabricksWorkspaceArchiveResource is the resource implementation.
+type DatabricksWorkspaceArchiveResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksWorkspaceArchiveResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	AdbId        URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue                       `tfsdk:"workspace_url"`
+	Token        types.String                   `tfsdk:"token"`
+	Path         types.String                   `tfsdk:"path"`
+	LocalPath    types.String                   `tfsdk:"local_path"`
+	Format       types.String                   `tfsdk:"format"`
+	Overwrite    types.Bool                     `tfsdk:"overwrite"`
+	ContentMd5   types.String                   `tfsdk:"content_md5"`
+	RemoteMd5    types.String                   `tfsdk:"remote_md5"`
+	Workspace    *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksWorkspaceArchiveResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksWorkspaceArchiveResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_archive"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksWorkspaceArchiveResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports a DBC or zipped SOURCE archive into a workspace path, e.g. one produced by the mrl_databricks_workspace_export data source. Changes made to the imported objects outside Terraform are detected and the archive is imported again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Workspace path of the imported archive",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"path": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Workspace path the archive is imported to. Missing parent directories are created",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local archive to import. The workspace import API accepts archives of up to 10 MB",
+			},
+			"format": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(databricks.ExportFormatDBC),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators:  []validator.String{StringOneOf(databricks.ExportFormatDBC, databricks.ExportFormatSource)},
+				Description: "Archive format: DBC or SOURCE. Defaults to DBC",
+			},
+			"overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Replace objects already present at path on create. Without it, creating the resource fails when path exists. Defaults to false",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the local archive, computed at plan time",
+			},
+			"remote_md5": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "md5 hash of the export of path taken after the import, used to detect changes made outside Terraform",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksWorkspaceArchiveResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(tfpath.Root("workspace_url"), tfpath.Root("adb_id")),
+	}
+}
+
+// ModifyPlan hashes the local archive so that the import only runs again when
+// its content changes.
+func (r *DatabricksWorkspaceArchiveResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksWorkspaceArchiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocalPath.IsUnknown() {
+		return
+	}
+
+	sum, err := fileMD5(plan.LocalPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("local_path"),
+			"Error reading local file",
+			"Could not hash "+plan.LocalPath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.ContentMd5 = types.StringValue(sum)
+
+	if !req.State.Raw.IsNull() {
+		var state databricksWorkspaceArchiveResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.ContentMd5.Equal(plan.ContentMd5) {
+			plan.RemoteMd5 = types.StringUnknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// exportMD5 returns the hex md5 of the export of the archive path.
+func exportMD5(ctx context.Context, client *databricks.Client, model *databricksWorkspaceArchiveResourceModel) (string, error) {
+	content, err := client.WorkspaceExport(ctx, model.Path.ValueString(), model.Format.ValueString())
+	if err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return "", err
+	}
+	sum := md5.Sum(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// importArchive imports the local archive to path. With replace set, objects
+// already at path are replaced; otherwise the import fails if path exists.
+func (r *DatabricksWorkspaceArchiveResource) importArchive(ctx context.Context, plan *databricksWorkspaceArchiveResourceModel, replace bool) error {
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+	target := plan.Path.ValueString()
+
+	content, err := os.ReadFile(plan.LocalPath.ValueString())
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(content)
+	if hex.EncodeToString(sum[:]) != plan.ContentMd5.ValueString() {
+		return fmt.Errorf("%v changed during apply; the planned md5 is %v", plan.LocalPath.ValueString(), plan.ContentMd5.ValueString())
+	}
+
+	if _, err := client.WorkspaceGetStatus(ctx, target); err == nil {
+		if !replace {
+			return fmt.Errorf("%v already exists; set overwrite to replace it", target)
+		}
+		// DBC imports cannot overwrite, so the previous objects are removed
+		// first for either format.
+		if err := client.WorkspaceDelete(ctx, target, true); err != nil {
+			return err
+		}
+	} else if !databricks.IsNotFound(err) {
+		return err
+	}
+
+	if err := client.WorkspaceMkdirs(ctx, path.Dir(target)); err != nil {
+		return err
+	}
+	if err := client.WorkspaceImport(ctx, target, plan.Format.ValueString(), "", content, false); err != nil {
+		return err
+	}
+
+	remote, err := exportMD5(ctx, client, plan)
+	if err != nil {
+		return err
+	}
+	plan.Id = types.StringValue(target)
+	plan.RemoteMd5 = types.StringValue(remote)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksWorkspaceArchiveResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_archive.Create")
+	defer span.End()
+
+	var plan databricksWorkspaceArchiveResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importArchive(ctx, &plan, plan.Overwrite.ValueBool())
+	r.audit.Record(ctx, "mrl_databricks_workspace_archive", auditActionCreate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing workspace archive",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksWorkspaceArchiveResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_archive.Read")
+	defer span.End()
+
+	var state databricksWorkspaceArchiveResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	remote, err := exportMD5(ctx, client, &state)
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workspace archive",
+			"Could not export "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if remote != state.RemoteMd5.ValueString() {
+		// The objects were changed outside Terraform; clearing the hash makes
+		// the next plan import the archive again.
+		state.ContentMd5 = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksWorkspaceArchiveResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_archive.Update")
+	defer span.End()
+
+	var plan, state databricksWorkspaceArchiveResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ContentMd5.Equal(state.ContentMd5) {
+		// Only token or overwrite changed.
+		plan.RemoteMd5 = state.RemoteMd5
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importArchive(ctx, &plan, true)
+	r.audit.Record(ctx, "mrl_databricks_workspace_archive", auditActionUpdate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing workspace archive",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksWorkspaceArchiveResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_archive.Delete")
+	defer span.End()
+
+	var state databricksWorkspaceArchiveResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.WorkspaceDelete(ctx, state.Path.ValueString(), true)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_workspace_archive", auditActionDelete, state.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting workspace archive",
+			"Could not delete "+state.Path.ValueString()+": "+err.Error(),
+		)
+	}
+}
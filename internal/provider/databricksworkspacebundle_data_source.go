@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"terraform-provider-mrl/internal/bundle"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksWorkspaceBundleDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksWorkspaceBundleDataSource{}
+)
+
+// NewDatabricksWorkspaceBundleDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceBundleDataSource() datasource.DataSource {
+	return &DatabricksWorkspaceBundleDataSource{}
+}
+
+// DatabricksWorkspaceBundleDataSource is the data source implementation.
+type DatabricksWorkspaceBundleDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksWorkspaceBundleDataSourceModel maps the data source schema data.
+type databricksWorkspaceBundleDataSourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	AdbId              types.String `tfsdk:"adb_id"`
+	WorkspaceUrl       types.String `tfsdk:"workspace_url"`
+	Token              types.String `tfsdk:"token"`
+	OutputPath         types.String `tfsdk:"output_path"`
+	NotebookPaths      types.Set    `tfsdk:"notebook_paths"`
+	JobIds             types.Set    `tfsdk:"job_ids"`
+	ClusterIds         types.Set    `tfsdk:"cluster_ids"`
+	IncludePermissions types.Bool   `tfsdk:"include_permissions"`
+	ContentMd5         types.String `tfsdk:"content_md5"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksWorkspaceBundleDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksWorkspaceBundleDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_bundle"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksWorkspaceBundleDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Snapshots notebooks, jobs, cluster configurations and their permissions into a local bundle, a gzipped tar archive with a JSON manifest, for disaster recovery with the mrl_databricks_workspace_bundle_restore resource. The bundle is written on every read; unchanged assets give an identical file.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Path of the bundle",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"output_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local file the bundle is written to. Missing parent directories are created",
+			},
+			"notebook_paths": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Workspace paths of the notebooks and directories to snapshot, exported as DBC archives",
+			},
+			"job_ids": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Optional:    true,
+				Description: "IDs of the jobs to snapshot",
+			},
+			"cluster_ids": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "IDs of the clusters whose configuration is snapshot",
+			},
+			"include_permissions": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether to snapshot the permissions set directly on the assets. Inherited permissions are left out. Defaults to true",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the bundle, to pass to bundle_md5 of mrl_databricks_workspace_bundle_restore",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksWorkspaceBundleDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_workspace_bundle.Read")
+	defer span.End()
+
+	var state databricksWorkspaceBundleDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var notebookPaths, clusterIDs []string
+	var jobIDs []int64
+	resp.Diagnostics.Append(state.NotebookPaths.ElementsAs(ctx, &notebookPaths, false)...)
+	resp.Diagnostics.Append(state.JobIds.ElementsAs(ctx, &jobIDs, false)...)
+	resp.Diagnostics.Append(state.ClusterIds.ElementsAs(ctx, &clusterIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(notebookPaths)
+	sort.Slice(jobIDs, func(i, j int) bool { return jobIDs[i] < jobIDs[j] })
+	sort.Strings(clusterIDs)
+
+	includePermissions := state.IncludePermissions.IsNull() || state.IncludePermissions.ValueBool()
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	b, err := snapshotWorkspace(ctx, client, notebookPaths, jobIDs, clusterIDs, includePermissions)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error snapshotting workspace",
+			"Could not snapshot the workspace assets: "+err.Error(),
+		)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf, b); err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing workspace bundle",
+			"Could not encode the bundle: "+err.Error(),
+		)
+		return
+	}
+	outputPath := state.OutputPath.ValueString()
+	if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing workspace bundle",
+			"Could not create the directory of "+outputPath+": "+err.Error(),
+		)
+		return
+	}
+	if err := os.WriteFile(outputPath, buf.Bytes(), 0o600); err != nil {
+		resp.Diagnostics.AddError(
+			"Error writing workspace bundle",
+			"Could not write "+outputPath+": "+err.Error(),
+		)
+		return
+	}
+	sum := md5.Sum(buf.Bytes())
+
+	state.Id = types.StringValue(outputPath)
+	state.ContentMd5 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// snapshotWorkspace reads the given assets into a bundle.
+func snapshotWorkspace(ctx context.Context, client *databricks.Client, notebookPaths []string, jobIDs []int64, clusterIDs []string, includePermissions bool) (*bundle.Bundle, error) {
+	b := &bundle.Bundle{Files: map[string][]byte{}}
+	permissions := func(objectType, objectID string) ([]databricks.AccessControl, error) {
+		if !includePermissions {
+			return nil, nil
+		}
+		return client.GetPermissions(ctx, objectType, objectID)
+	}
+
+	for i, notebookPath := range notebookPaths {
+		info, err := client.WorkspaceGetStatus(ctx, notebookPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", notebookPath, err)
+		}
+		content, err := client.WorkspaceExport(ctx, notebookPath, databricks.ExportFormatDBC)
+		if err != nil {
+			return nil, fmt.Errorf("exporting %s: %w", notebookPath, err)
+		}
+		data, err := base64.StdEncoding.DecodeString(content)
+		if err != nil {
+			return nil, fmt.Errorf("decoding the export of %s: %w", notebookPath, err)
+		}
+		acl, err := permissions(notebookPermissionObject(info.ObjectType), strconv.FormatInt(info.ObjectID, 10))
+		if err != nil {
+			return nil, fmt.Errorf("reading the permissions of %s: %w", notebookPath, err)
+		}
+
+		file := fmt.Sprintf("notebooks/%d.dbc", i)
+		b.Files[file] = data
+		b.Manifest.Notebooks = append(b.Manifest.Notebooks, bundle.Notebook{
+			Path:        info.Path,
+			ObjectType:  info.ObjectType,
+			File:        file,
+			Permissions: acl,
+		})
+	}
+
+	for _, jobID := range jobIDs {
+		job, err := client.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, fmt.Errorf("reading job %d: %w", jobID, err)
+		}
+		acl, err := permissions(databricks.PermissionObjectJobs, strconv.FormatInt(jobID, 10))
+		if err != nil {
+			return nil, fmt.Errorf("reading the permissions of job %d: %w", jobID, err)
+		}
+		b.Manifest.Jobs = append(b.Manifest.Jobs, bundle.Job{
+			JobID:       jobID,
+			Settings:    job.Settings,
+			Permissions: acl,
+		})
+	}
+
+	for _, clusterID := range clusterIDs {
+		info, err := client.GetCluster(ctx, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("reading cluster %s: %w", clusterID, err)
+		}
+		spec, err := bundle.ClusterSpec(info)
+		if err != nil {
+			return nil, fmt.Errorf("decoding cluster %s: %w", clusterID, err)
+		}
+		acl, err := permissions(databricks.PermissionObjectClusters, clusterID)
+		if err != nil {
+			return nil, fmt.Errorf("reading the permissions of cluster %s: %w", clusterID, err)
+		}
+		b.Manifest.Clusters = append(b.Manifest.Clusters, bundle.Cluster{
+			ClusterID:   clusterID,
+			Spec:        spec,
+			Permissions: acl,
+		})
+	}
+
+	return b, nil
+}
+
+// notebookPermissionObject returns the permissions API object type of a
+// workspace object type.
+func notebookPermissionObject(objectType string) string {
+	if objectType == "DIRECTORY" {
+		return databricks.PermissionObjectDirectories
+	}
+	return databricks.PermissionObjectNotebooks
+}
@@ -0,0 +1,414 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"terraform-provider-mrl/internal/bundle"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksWorkspaceBundleRestoreResource{}
+	_ resource.ResourceWithConfigure = &DatabricksWorkspaceBundleRestoreResource{}
+)
+
+// NewDatabricksWorkspaceBundleRestoreResource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceBundleRestoreResource() resource.Resource {
+	return &DatabricksWorkspaceBundleRestoreResource{}
+}
+
+// DatabricksWorkspaceBundleRestoreResource is the resource implementation.
+type DatabricksWorkspaceBundleRestoreResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksWorkspaceBundleRestoreResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	AdbId              URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl       URLValue     `tfsdk:"workspace_url"`
+	Token              types.String `tfsdk:"token"`
+	BundlePath         types.String `tfsdk:"bundle_path"`
+	BundleMd5          types.String `tfsdk:"bundle_md5"`
+	RestorePermissions types.Bool   `tfsdk:"restore_permissions"`
+	NotebookPaths      types.List   `tfsdk:"notebook_paths"`
+	JobIds             types.Map    `tfsdk:"job_ids"`
+	ClusterIds         types.Map    `tfsdk:"cluster_ids"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksWorkspaceBundleRestoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksWorkspaceBundleRestoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_bundle_restore"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksWorkspaceBundleRestoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Re-applies a bundle written by the mrl_databricks_workspace_bundle data source to a workspace: clusters are created and terminated, notebooks are imported to their original paths, replacing what is there, and jobs are created on the restored clusters. Destroying the resource deletes the restored assets. Changes made to them outside Terraform are not tracked.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "md5 hash of the restored bundle",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace the bundle is restored to, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace the bundle is restored to, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"bundle_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local bundle to restore",
+			},
+			"bundle_md5": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Expected md5 hash of the bundle, e.g. the content_md5 of mrl_databricks_workspace_bundle. The restore fails when the file does not match, and a new hash restores the bundle again",
+			},
+			"restore_permissions": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+				Description: "Whether to grant the permissions recorded in the bundle. Principals must exist in the target workspace. Ownership is not restored. Defaults to true",
+			},
+			"notebook_paths": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Workspace paths of the restored notebooks and directories",
+			},
+			"job_ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				Description: "IDs of the restored jobs, by the ID of the job in the bundle",
+			},
+			"cluster_ids": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+				Description: "IDs of the restored clusters, by the ID of the cluster in the bundle",
+			},
+		},
+	}
+}
+
+// restoredAssets records the assets created by a restore, so that a failed
+// restore can be cleaned up by destroying the resource.
+type restoredAssets struct {
+	notebookPaths []string
+	jobIDs        map[string]string
+	clusterIDs    map[string]string
+}
+
+// setModel stores the restored assets in model.
+func (a *restoredAssets) setModel(ctx context.Context, model *databricksWorkspaceBundleRestoreResourceModel) diag.Diagnostics {
+	var diags, d diag.Diagnostics
+	model.NotebookPaths, d = types.ListValueFrom(ctx, types.StringType, a.notebookPaths)
+	diags.Append(d...)
+	model.JobIds, d = types.MapValueFrom(ctx, types.StringType, a.jobIDs)
+	diags.Append(d...)
+	model.ClusterIds, d = types.MapValueFrom(ctx, types.StringType, a.clusterIDs)
+	diags.Append(d...)
+	return diags
+}
+
+// readBundle reads the local bundle and checks it against the planned md5.
+func readBundle(model *databricksWorkspaceBundleRestoreResourceModel) (*bundle.Bundle, error) {
+	sum, err := fileMD5(model.BundlePath.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	if sum != model.BundleMd5.ValueString() {
+		return nil, fmt.Errorf("the md5 of %v is %v, not %v", model.BundlePath.ValueString(), sum, model.BundleMd5.ValueString())
+	}
+
+	f, err := os.Open(model.BundlePath.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return bundle.Read(f)
+}
+
+// restore applies b to the workspace, recording the created assets in
+// assets as it goes.
+func restore(ctx context.Context, client *databricks.Client, b *bundle.Bundle, withPermissions bool, assets *restoredAssets) error {
+	grant := func(objectType, objectID string, acl []databricks.AccessControl) error {
+		if !withPermissions {
+			return nil
+		}
+		var grants []databricks.AccessControl
+		for _, entry := range acl {
+			if entry.PermissionLevel != databricks.PermissionOwner {
+				grants = append(grants, entry)
+			}
+		}
+		if len(grants) == 0 {
+			return nil
+		}
+		return client.UpdatePermissions(ctx, objectType, objectID, grants)
+	}
+
+	for _, cluster := range b.Manifest.Clusters {
+		clusterID, err := client.CreateCluster(ctx, cluster.Spec)
+		if err != nil {
+			return fmt.Errorf("creating cluster %s: %w", cluster.ClusterID, err)
+		}
+		assets.clusterIDs[cluster.ClusterID] = clusterID
+		// Clusters start on creation; the restored ones are left stopped
+		// until used.
+		if err := client.TerminateCluster(ctx, clusterID); err != nil {
+			return fmt.Errorf("terminating cluster %s: %w", clusterID, err)
+		}
+		if err := grant(databricks.PermissionObjectClusters, clusterID, cluster.Permissions); err != nil {
+			return fmt.Errorf("granting permissions on cluster %s: %w", clusterID, err)
+		}
+	}
+
+	for _, notebook := range b.Manifest.Notebooks {
+		// DBC imports cannot overwrite, so existing objects are removed first.
+		if err := client.WorkspaceDelete(ctx, notebook.Path, true); err != nil && !databricks.IsNotFound(err) {
+			return fmt.Errorf("deleting %s: %w", notebook.Path, err)
+		}
+		if err := client.WorkspaceMkdirs(ctx, path.Dir(notebook.Path)); err != nil {
+			return fmt.Errorf("creating the parent of %s: %w", notebook.Path, err)
+		}
+		if err := client.WorkspaceImport(ctx, notebook.Path, databricks.ExportFormatDBC, "", b.Files[notebook.File], false); err != nil {
+			return fmt.Errorf("importing %s: %w", notebook.Path, err)
+		}
+		assets.notebookPaths = append(assets.notebookPaths, notebook.Path)
+
+		if withPermissions && len(notebook.Permissions) > 0 {
+			info, err := client.WorkspaceGetStatus(ctx, notebook.Path)
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", notebook.Path, err)
+			}
+			if err := grant(notebookPermissionObject(info.ObjectType), strconv.FormatInt(info.ObjectID, 10), notebook.Permissions); err != nil {
+				return fmt.Errorf("granting permissions on %s: %w", notebook.Path, err)
+			}
+		}
+	}
+
+	for _, job := range b.Manifest.Jobs {
+		settings, err := bundle.RemapClusters(job.Settings, assets.clusterIDs)
+		if err != nil {
+			return fmt.Errorf("decoding the settings of job %d: %w", job.JobID, err)
+		}
+		jobID, err := client.CreateJob(ctx, settings)
+		if err != nil {
+			return fmt.Errorf("creating job %d: %w", job.JobID, err)
+		}
+		assets.jobIDs[strconv.FormatInt(job.JobID, 10)] = strconv.FormatInt(jobID, 10)
+		if err := grant(databricks.PermissionObjectJobs, strconv.FormatInt(jobID, 10), job.Permissions); err != nil {
+			return fmt.Errorf("granting permissions on job %d: %w", jobID, err)
+		}
+	}
+
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksWorkspaceBundleRestoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_bundle_restore.Create")
+	defer span.End()
+
+	var plan databricksWorkspaceBundleRestoreResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	b, err := readBundle(&plan)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("bundle_path"),
+			"Error reading workspace bundle",
+			"Could not read "+plan.BundlePath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	assets := &restoredAssets{notebookPaths: []string{}, jobIDs: map[string]string{}, clusterIDs: map[string]string{}}
+	err = restore(ctx, client, b, plan.RestorePermissions.ValueBool(), assets)
+	r.audit.Record(ctx, "mrl_databricks_workspace_bundle_restore", auditActionCreate, plan.BundlePath.ValueString(), err)
+
+	plan.Id = types.StringValue(plan.BundleMd5.ValueString())
+	resp.Diagnostics.Append(assets.setModel(ctx, &plan)...)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error restoring workspace bundle",
+			"Could not restore "+plan.BundlePath.ValueString()+": "+err.Error()+". The assets restored so far are kept in the state and deleted when the resource is replaced or destroyed.",
+		)
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data. The restored
+// assets are not tracked, so the state is kept as is.
+func (r *DatabricksWorkspaceBundleRestoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	_, span := tracing.Start(ctx, "mrl_databricks_workspace_bundle_restore.Read")
+	defer span.End()
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+// Only token and bundle_path can change in place.
+func (r *DatabricksWorkspaceBundleRestoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_bundle_restore.Update")
+	defer span.End()
+
+	var plan, state databricksWorkspaceBundleRestoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.NotebookPaths = state.NotebookPaths
+	plan.JobIds = state.JobIds
+	plan.ClusterIds = state.ClusterIds
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksWorkspaceBundleRestoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_bundle_restore.Delete")
+	defer span.End()
+
+	var state databricksWorkspaceBundleRestoreResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var notebookPaths []string
+	var jobIDs, clusterIDs map[string]string
+	resp.Diagnostics.Append(state.NotebookPaths.ElementsAs(ctx, &notebookPaths, false)...)
+	resp.Diagnostics.Append(state.JobIds.ElementsAs(ctx, &jobIDs, false)...)
+	resp.Diagnostics.Append(state.ClusterIds.ElementsAs(ctx, &clusterIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = func() error {
+		for _, id := range jobIDs {
+			jobID, err := strconv.ParseInt(id, 10, 64)
+			if err != nil {
+				return err
+			}
+			if err := client.DeleteJob(ctx, jobID); err != nil && !databricks.IsNotFound(err) {
+				return fmt.Errorf("deleting job %d: %w", jobID, err)
+			}
+		}
+		for _, notebookPath := range notebookPaths {
+			if err := client.WorkspaceDelete(ctx, notebookPath, true); err != nil && !databricks.IsNotFound(err) {
+				return fmt.Errorf("deleting %s: %w", notebookPath, err)
+			}
+		}
+		for _, clusterID := range clusterIDs {
+			if err := client.PermanentDeleteCluster(ctx, clusterID); err != nil && !databricks.IsNotFound(err) {
+				return fmt.Errorf("deleting cluster %s: %w", clusterID, err)
+			}
+		}
+		return nil
+	}()
+	r.audit.Record(ctx, "mrl_databricks_workspace_bundle_restore", auditActionDelete, state.BundlePath.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting restored workspace assets",
+			"Could not delete the assets restored from "+state.BundlePath.ValueString()+": "+err.Error(),
+		)
+	}
+}
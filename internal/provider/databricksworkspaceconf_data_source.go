@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksWorkspaceConfDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksWorkspaceConfDataSource{}
+)
+
+// NewDatabricksWorkspaceConfDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceConfDataSource() datasource.DataSource {
+	return &DatabricksWorkspaceConfDataSource{}
+}
+
+// DatabricksWorkspaceConfDataSource is the data source implementation.
+type DatabricksWorkspaceConfDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksWorkspaceConfDataSourceModel maps the data source schema data.
+type databricksWorkspaceConfDataSourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	AdbId        types.String `tfsdk:"adb_id"`
+	WorkspaceUrl types.String `tfsdk:"workspace_url"`
+	Token        types.String `tfsdk:"token"`
+	Keys         types.Set    `tfsdk:"keys"`
+	Values       types.Map    `tfsdk:"values"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksWorkspaceConfDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksWorkspaceConfDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_conf"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksWorkspaceConfDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads workspace configuration values such as enableIpAccessLists or enableTokensConfig, so modules can branch on workspace capabilities.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Sorted keys, joined by commas",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token of a workspace admin. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"keys": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Configuration keys to read",
+			},
+			"values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Values of the keys, e.g. \"true\". Keys that were never set are null",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksWorkspaceConfDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_workspace_conf.Read")
+	defer span.End()
+
+	var state databricksWorkspaceConfDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var keys []string
+	resp.Diagnostics.Append(state.Keys.ElementsAs(ctx, &keys, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sort.Strings(keys)
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	conf, err := client.GetWorkspaceConf(ctx, keys)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workspace configuration",
+			"Could not read keys "+strings.Join(keys, ", ")+": "+err.Error(),
+		)
+		return
+	}
+
+	values := make(map[string]types.String, len(keys))
+	for _, key := range keys {
+		values[key] = types.StringNull()
+		if value, ok := conf[key]; ok {
+			values[key] = types.StringValue(value)
+		}
+	}
+	valuesMap, diags := types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.Id = types.StringValue(strings.Join(keys, ","))
+	state.Values = valuesMap
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &DatabricksWorkspaceConfResource{}
+	_ resource.ResourceWithConfigure = &DatabricksWorkspaceConfResource{}
+)
+
+// NewDatabricksWorkspaceConfResource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceConfResource() resource.Resource {
+	return &DatabricksWorkspaceConfResource{}
+}
+
+// DatabricksWorkspaceConfResource is the resource implementation.
+type DatabricksWorkspaceConfResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+// databricksWorkspaceConfResourceModel maps the resource schema data.
+type databricksWorkspaceConfResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	AdbId          URLValue     `tfsdk:"adb_id"`
+	WorkspaceUrl   URLValue     `tfsdk:"workspace_url"`
+	Token          types.String `tfsdk:"token"`
+	CustomConfig   types.Map    `tfsdk:"custom_config"`
+	PreviousValues types.Map    `tfsdk:"previous_values"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksWorkspaceConfResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksWorkspaceConfResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_conf"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksWorkspaceConfResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages workspace configuration keys such as enableIpAccessLists, enableTokensConfig or maxTokenLifetimeDays. Only the keys in custom_config are changed. Keys removed from custom_config, and all keys on destroy, are set back to the value they had before the resource first set them; keys that had no value are left as they are.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the workspace",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        "Access token of a workspace admin. Defaults to token of the provider databricks block. When neither is set, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token of its Azure service principal",
+			},
+			"custom_config": schema.MapAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Values of the configuration keys to set, such as `{ enableIpAccessLists = \"true\" }`",
+			},
+			"previous_values": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Values the keys of custom_config had before the resource first set them, restored when they are removed. Keys that had no value are null",
+			},
+		},
+	}
+}
+
+// restoreValues returns the keys of previous whose value is known, to be set
+// back.
+func restoreValues(previous map[string]*string, keys []string) map[string]string {
+	values := map[string]string{}
+	for _, key := range keys {
+		if value := previous[key]; value != nil {
+			values[key] = *value
+		}
+	}
+	return values
+}
+
+// sortedKeys returns the keys of m in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// readPreviousValues reads the current values of keys, as previous values
+// of the keys that are not set yet.
+func readPreviousValues(ctx context.Context, client *databricks.Client, keys []string, previous map[string]*string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	current, err := client.GetWorkspaceConf(ctx, keys)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		previous[key] = nil
+		if value, ok := current[key]; ok {
+			previous[key] = &value
+		}
+	}
+	return nil
+}
+
+// previousValuesMap returns previous as the previous_values attribute.
+func previousValuesMap(ctx context.Context, previous map[string]*string) (types.Map, diag.Diagnostics) {
+	values := make(map[string]types.String, len(previous))
+	for key, value := range previous {
+		values[key] = types.StringPointerValue(value)
+	}
+	return types.MapValueFrom(ctx, types.StringType, values)
+}
+
+// Create records the current values of the keys and sets them.
+func (r *DatabricksWorkspaceConfResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_conf.Create")
+	defer span.End()
+
+	var plan databricksWorkspaceConfResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config map[string]string
+	resp.Diagnostics.Append(plan.CustomConfig.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys := sortedKeys(config)
+
+	ctx = withAuditRequestID(ctx)
+	host, token, err := r.workspace.resolve(workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := r.workspace.newClient(r.httpClient, host, token)
+
+	previous := map[string]*string{}
+	if err := readPreviousValues(ctx, client, keys, previous); err != nil {
+		resp.Diagnostics.AddError("Error reading workspace configuration", "Could not read keys "+strings.Join(keys, ", ")+": "+err.Error())
+		return
+	}
+	err = client.SetWorkspaceConf(ctx, config)
+	r.audit.Record(ctx, "mrl_databricks_workspace_conf", auditActionCreate, strings.Join(keys, ","), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error setting workspace configuration", "Could not set keys "+strings.Join(keys, ", ")+": "+err.Error())
+		return
+	}
+
+	var diags diag.Diagnostics
+	plan.Id = types.StringValue(databricks.WorkspaceURL(host))
+	plan.PreviousValues, diags = previousValuesMap(ctx, previous)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data. Keys changed
+// outside Terraform show as drift of custom_config.
+func (r *DatabricksWorkspaceConfResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_conf.Read")
+	defer span.End()
+
+	var state databricksWorkspaceConfResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config map[string]string
+	resp.Diagnostics.Append(state.CustomConfig.ElementsAs(ctx, &config, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	keys := sortedKeys(config)
+
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	current, err := client.GetWorkspaceConf(ctx, keys)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading workspace configuration", "Could not read keys "+strings.Join(keys, ", ")+": "+err.Error())
+		return
+	}
+
+	values := make(map[string]types.String, len(keys))
+	for _, key := range keys {
+		values[key] = types.StringNull()
+		// Boolean flags are reported in lower case whatever case they were
+		// set in.
+		if value, ok := current[key]; ok && strings.EqualFold(value, config[key]) {
+			values[key] = types.StringValue(config[key])
+		} else if ok {
+			values[key] = types.StringValue(value)
+		}
+	}
+	var diags diag.Diagnostics
+	state.CustomConfig, diags = types.MapValueFrom(ctx, types.StringType, values)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update sets the changed keys, restores the previous values of removed
+// keys and records the current values of added keys.
+func (r *DatabricksWorkspaceConfResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_conf.Update")
+	defer span.End()
+
+	var plan, state databricksWorkspaceConfResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var config, prior map[string]string
+	previous := map[string]*string{}
+	resp.Diagnostics.Append(plan.CustomConfig.ElementsAs(ctx, &config, false)...)
+	resp.Diagnostics.Append(state.CustomConfig.ElementsAs(ctx, &prior, false)...)
+	resp.Diagnostics.Append(state.PreviousValues.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if previous == nil {
+		previous = map[string]*string{}
+	}
+
+	var added, removed []string
+	changed := map[string]string{}
+	for _, key := range sortedKeys(config) {
+		if _, ok := previous[key]; !ok {
+			added = append(added, key)
+		}
+		if value, ok := prior[key]; !ok || value != config[key] {
+			changed[key] = config[key]
+		}
+	}
+	for _, key := range sortedKeys(previous) {
+		if _, ok := config[key]; !ok {
+			removed = append(removed, key)
+		}
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	if err := readPreviousValues(ctx, client, added, previous); err != nil {
+		resp.Diagnostics.AddError("Error reading workspace configuration", "Could not read keys "+strings.Join(added, ", ")+": "+err.Error())
+		return
+	}
+	for key, value := range restoreValues(previous, removed) {
+		changed[key] = value
+	}
+
+	if len(changed) > 0 {
+		err = client.SetWorkspaceConf(ctx, changed)
+		r.audit.Record(ctx, "mrl_databricks_workspace_conf", auditActionUpdate, strings.Join(sortedKeys(changed), ","), err)
+		if err != nil {
+			resp.Diagnostics.AddError("Error setting workspace configuration", "Could not set keys "+strings.Join(sortedKeys(changed), ", ")+": "+err.Error())
+			return
+		}
+	}
+	for _, key := range removed {
+		delete(previous, key)
+	}
+
+	var diags diag.Diagnostics
+	plan.PreviousValues, diags = previousValuesMap(ctx, previous)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete sets the keys back to their previous values and removes the
+// Terraform state on success.
+func (r *DatabricksWorkspaceConfResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_conf.Delete")
+	defer span.End()
+
+	var state databricksWorkspaceConfResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	previous := map[string]*string{}
+	resp.Diagnostics.Append(state.PreviousValues.ElementsAs(ctx, &previous, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	values := restoreValues(previous, sortedKeys(previous))
+	if len(values) == 0 {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	err = client.SetWorkspaceConf(ctx, values)
+	r.audit.Record(ctx, "mrl_databricks_workspace_conf", auditActionDelete, strings.Join(sortedKeys(values), ","), err)
+	if err != nil {
+		resp.Diagnostics.AddError("Error restoring workspace configuration", "Could not restore keys "+strings.Join(sortedKeys(values), ", ")+": "+err.Error())
+	}
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const databricksWorkspaceConfTypeName = "mrl_databricks_workspace_conf"
+
+// mockWorkspaceConf serves the workspace configuration API of a mock
+// workspace. Boolean flags are stored in lower case, as the API reports
+// them.
+type mockWorkspaceConf struct {
+	values map[string]string
+}
+
+// newMockWorkspaceConf adds the workspace configuration API to m, with the
+// keys of values set.
+func newMockWorkspaceConf(m *mockDbfs, values map[string]string) *mockWorkspaceConf {
+	conf := &mockWorkspaceConf{values: values}
+	m.route("/api/2.0/workspace-conf", conf.serveHTTP)
+	return conf
+}
+
+func (m *mockWorkspaceConf) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		values := map[string]*string{}
+		for _, key := range strings.Split(r.URL.Query().Get("keys"), ",") {
+			values[key] = nil
+			if value, ok := m.values[key]; ok {
+				values[key] = &value
+			}
+		}
+		writeMockJSON(w, values)
+	case http.MethodPatch:
+		var values map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&values); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+		for key, value := range values {
+			if strings.EqualFold(value, "true") || strings.EqualFold(value, "false") {
+				value = strings.ToLower(value)
+			}
+			m.values[key] = value
+		}
+		writeMockJSON(w, map[string]interface{}{})
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.Method+" "+r.URL.Path)
+	}
+}
+
+func TestDatabricksWorkspaceConfResource_restore(t *testing.T) {
+	p, m := testDbfsProvider(t)
+	conf := newMockWorkspaceConf(m, map[string]string{
+		"maxTokenLifetimeDays": "90",
+		"enableTokensConfig":   "true",
+	})
+	typeName := databricksWorkspaceConfTypeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+	config := func(values map[string]string) tftypes.Value {
+		return p.config(typeName, map[string]interface{}{"custom_config": values})
+	}
+
+	created := config(map[string]string{"enableIpAccessLists": "TRUE", "maxTokenLifetimeDays": "30"})
+	state := p.apply(typeName, null, created)
+	if got := conf.values["maxTokenLifetimeDays"]; got != "30" {
+		t.Errorf("maxTokenLifetimeDays is %q, want 30", got)
+	}
+	previous := stateAttr(t, state, "previous_values")
+	want := tftypes.NewValue(previous.Type(), map[string]tftypes.Value{
+		"enableIpAccessLists":  tftypes.NewValue(tftypes.String, nil),
+		"maxTokenLifetimeDays": tftypes.NewValue(tftypes.String, "90"),
+	})
+	if !previous.Equal(want) {
+		t.Errorf("previous_values is %s, want %s", previous, want)
+	}
+	// enableIpAccessLists is reported as true, the same flag as TRUE.
+	if refreshed := p.refresh(typeName, state); !refreshed.Equal(state) {
+		t.Errorf("refresh changed the state to %s, want %s", refreshed, state)
+	}
+	if planned, diags := p.plan(typeName, state, created); errorDiagnostics(diags) != "" || !planned.Equal(state) {
+		t.Errorf("planned %s with %s, want no change", planned, errorDiagnostics(diags))
+	}
+
+	// Removing a key restores its previous value.
+	state = p.apply(typeName, state, config(map[string]string{"enableIpAccessLists": "TRUE", "enableTokensConfig": "false"}))
+	if got := conf.values["maxTokenLifetimeDays"]; got != "90" {
+		t.Errorf("maxTokenLifetimeDays is %q after its removal, want the previous 90", got)
+	}
+	if got := conf.values["enableTokensConfig"]; got != "false" {
+		t.Errorf("enableTokensConfig is %q, want false", got)
+	}
+	if got := mapAttr(t, state, "previous_values"); len(got) != 2 || got["enableTokensConfig"] != "true" {
+		t.Errorf("previous_values is %v, want enableIpAccessLists and enableTokensConfig true", got)
+	}
+
+	// Destroy restores the keys that had a value and leaves the others.
+	if state = p.apply(typeName, state, null); !state.IsNull() {
+		t.Errorf("state after destroy is %s, want null", state)
+	}
+	if got := conf.values["enableTokensConfig"]; got != "true" {
+		t.Errorf("enableTokensConfig is %q after destroy, want the previous true", got)
+	}
+	if got := conf.values["enableIpAccessLists"]; got != "true" {
+		t.Errorf("enableIpAccessLists is %q after destroy, want it left as true", got)
+	}
+	if got := conf.values["maxTokenLifetimeDays"]; got != "90" {
+		t.Errorf("maxTokenLifetimeDays is %q after destroy, want 90", got)
+	}
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksWorkspaceExportDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksWorkspaceExportDataSource{}
+)
+
+// NewDatabricksWorkspaceExportDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceExportDataSource() datasource.DataSource {
+	return &DatabricksWorkspaceExportDataSource{}
+}
+
+// DatabricksWorkspaceExportDataSource is the data source implementation.
+type DatabricksWorkspaceExportDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksWorkspaceExportDataSourceModel maps the data source schema data.
+type databricksWorkspaceExportDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AdbId         types.String `tfsdk:"adb_id"`
+	WorkspaceUrl  types.String `tfsdk:"workspace_url"`
+	Token         types.String `tfsdk:"token"`
+	Path          types.String `tfsdk:"path"`
+	Format        types.String `tfsdk:"format"`
+	ObjectType    types.String `tfsdk:"object_type"`
+	ContentBase64 types.String `tfsdk:"content_base64"`
+	ContentMd5    types.String `tfsdk:"content_md5"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksWorkspaceExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksWorkspaceExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksWorkspaceExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Exports a workspace notebook or directory as a base64 encoded archive, e.g. to back it up or to promote it to another workspace.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Workspace path of the exported object",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"path": schema.StringAttribute{
+				Required:    true,
+				Description: "Workspace path of the notebook or directory to export",
+			},
+			"format": schema.StringAttribute{
+				Optional:    true,
+				Description: "Archive format: DBC or SOURCE. Directories exported as SOURCE are zipped. Defaults to DBC",
+				Validators:  []validator.String{StringOneOf(databricks.ExportFormatDBC, databricks.ExportFormatSource)},
+			},
+			"object_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the exported object, e.g. DIRECTORY or NOTEBOOK",
+			},
+			"content_base64": schema.StringAttribute{
+				Computed:    true,
+				Description: "Base64 encoded archive",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the decoded archive",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksWorkspaceExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_workspace_export.Read")
+	defer span.End()
+
+	var state databricksWorkspaceExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	format := databricks.ExportFormatDBC
+	if !state.Format.IsNull() {
+		format = state.Format.ValueString()
+	}
+
+	client, err := d.workspace.client(d.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.WorkspaceGetStatus(ctx, state.Path.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting workspace object",
+			"Could not read "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	content, err := client.WorkspaceExport(ctx, state.Path.ValueString(), format)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting workspace object",
+			"Could not export "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error exporting workspace object",
+			"Could not decode the export of "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	sum := md5.Sum(data)
+
+	state.Id = types.StringValue(info.Path)
+	state.ObjectType = types.StringValue(info.ObjectType)
+	state.ContentBase64 = types.StringValue(content)
+	state.ContentMd5 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
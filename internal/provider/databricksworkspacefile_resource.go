@@ -0,0 +1,445 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+
+	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                     = &DatabricksWorkspaceFileResource{}
+	_ resource.ResourceWithConfigure        = &DatabricksWorkspaceFileResource{}
+	_ resource.ResourceWithModifyPlan       = &DatabricksWorkspaceFileResource{}
+	_ resource.ResourceWithValidateConfig   = &DatabricksWorkspaceFileResource{}
+	_ resource.ResourceWithConfigValidators = &DatabricksWorkspaceFileResource{}
+)
+
+// NewDatabricksWorkspaceFileResource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceFileResource() resource.Resource {
+	return &DatabricksWorkspaceFileResource{}
+}
+
+// DatabricksWorkspaceFileResource is the resource implementation.
+type DatabricksWorkspaceFileResource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+	audit      *auditLogger
+}
+
+type databricksWorkspaceFileResourceModel struct {
+	Id           types.String                   `tfsdk:"id"`
+	AdbId        URLValue                       `tfsdk:"adb_id"`
+	WorkspaceUrl URLValue                       `tfsdk:"workspace_url"`
+	Token        types.String                   `tfsdk:"token"`
+	Path         types.String                   `tfsdk:"path"`
+	LocalPath    types.String                   `tfsdk:"local_path"`
+	Format       types.String                   `tfsdk:"format"`
+	Language     types.String                   `tfsdk:"language"`
+	Overwrite    types.Bool                     `tfsdk:"overwrite"`
+	ContentMd5   types.String                   `tfsdk:"content_md5"`
+	ObjectType   types.String                   `tfsdk:"object_type"`
+	ObjectId     types.Int64                    `tfsdk:"object_id"`
+	ModifiedAt   types.Int64                    `tfsdk:"modified_at"`
+	Workspace    *databricksWorkspaceBlockModel `tfsdk:"workspace"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DatabricksWorkspaceFileResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.httpClient = providerData.HTTPClient
+	r.workspace = providerData.Databricks
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DatabricksWorkspaceFileResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_file"
+}
+
+// Schema defines the schema for the resource.
+func (r *DatabricksWorkspaceFileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Imports a local notebook or file into the workspace tree. Changes made to it outside Terraform are detected from its modification time and the file is imported again.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Workspace path of the imported object",
+			},
+			"adb_id": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Optional:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"path": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Absolute workspace path the notebook or file is imported to. Missing parent directories are created",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local notebook or file to import. The workspace import API accepts files of up to 10 MB",
+			},
+			"format": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(databricks.ExportFormatAuto),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{StringOneOf(
+					databricks.ExportFormatSource,
+					databricks.ExportFormatDBC,
+					databricks.ExportFormatJupyter,
+					databricks.ExportFormatAuto,
+				)},
+				Description: "Import format: SOURCE, DBC, JUPYTER or AUTO, which imports a notebook or a plain file depending on the content and extension. Defaults to AUTO",
+			},
+			"language": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{StringOneOf("PYTHON", "SCALA", "SQL", "R")},
+				Description: "Language of the notebook: PYTHON, SCALA, SQL or R. Required with the SOURCE format",
+			},
+			"overwrite": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Replace an object already present at path on create. Without it, creating the resource fails when path exists. Defaults to false",
+			},
+			"content_md5": schema.StringAttribute{
+				Computed:    true,
+				Description: "md5 hash of the local file, computed at plan time",
+			},
+			"object_type": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "Type of the imported object: NOTEBOOK, FILE or, for a DBC archive, DIRECTORY",
+			},
+			"object_id": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "ID of the imported object",
+			},
+			"modified_at": schema.Int64Attribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Description: "Modification time of the object after the import, in milliseconds since the epoch, used to detect changes made outside Terraform",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"workspace": databricksWorkspaceBlock(),
+		},
+	}
+}
+
+// ConfigValidators checks the workspace block.
+func (r *DatabricksWorkspaceFileResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		WorkspaceBlock(tfpath.Root("workspace_url"), tfpath.Root("adb_id")),
+	}
+}
+
+// ValidateConfig checks that a SOURCE import names its language.
+func (r *DatabricksWorkspaceFileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config databricksWorkspaceFileResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Format.ValueString() == databricks.ExportFormatSource && config.Language.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("language"),
+			"Missing language",
+			"language must be set when format is SOURCE.",
+		)
+	}
+}
+
+// ModifyPlan hashes the local file so that the import only runs again when
+// its content or language changes.
+func (r *DatabricksWorkspaceFileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan databricksWorkspaceFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.LocalPath.IsUnknown() {
+		return
+	}
+
+	sum, err := fileMD5(plan.LocalPath.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			tfpath.Root("local_path"),
+			"Error reading local file",
+			"Could not hash "+plan.LocalPath.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	plan.ContentMd5 = types.StringValue(sum)
+
+	if !req.State.Raw.IsNull() {
+		var state databricksWorkspaceFileResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !state.ContentMd5.Equal(plan.ContentMd5) || !state.Language.Equal(plan.Language) {
+			plan.ObjectType = types.StringUnknown()
+			plan.ObjectId = types.Int64Unknown()
+			plan.ModifiedAt = types.Int64Unknown()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// importFile imports the local file to path. With replace set, an object
+// already at path is replaced; otherwise the import fails if path exists.
+func (r *DatabricksWorkspaceFileResource) importFile(ctx context.Context, plan *databricksWorkspaceFileResourceModel, replace bool) error {
+	client, err := r.workspace.override(plan.Workspace).client(r.httpClient, workspaceURL(plan.WorkspaceUrl, plan.AdbId), plan.Token)
+	if err != nil {
+		return err
+	}
+	target := plan.Path.ValueString()
+	format := plan.Format.ValueString()
+
+	content, err := os.ReadFile(plan.LocalPath.ValueString())
+	if err != nil {
+		return err
+	}
+	sum := md5.Sum(content)
+	if hex.EncodeToString(sum[:]) != plan.ContentMd5.ValueString() {
+		return fmt.Errorf("%v changed during apply; the planned md5 is %v", plan.LocalPath.ValueString(), plan.ContentMd5.ValueString())
+	}
+
+	overwrite := false
+	if _, err := client.WorkspaceGetStatus(ctx, target); err == nil {
+		if !replace {
+			return fmt.Errorf("%v already exists; set overwrite to replace it", target)
+		}
+		if format == databricks.ExportFormatDBC {
+			// DBC imports cannot overwrite.
+			if err := client.WorkspaceDelete(ctx, target, true); err != nil {
+				return err
+			}
+		} else {
+			overwrite = true
+		}
+	} else if !databricks.IsNotFound(err) {
+		return err
+	}
+
+	if err := client.WorkspaceMkdirs(ctx, path.Dir(target)); err != nil {
+		return err
+	}
+	if err := client.WorkspaceImport(ctx, target, format, plan.Language.ValueString(), content, overwrite); err != nil {
+		return err
+	}
+
+	info, err := client.WorkspaceGetStatus(ctx, target)
+	if err != nil {
+		return err
+	}
+	plan.Id = types.StringValue(target)
+	plan.ObjectType = types.StringValue(info.ObjectType)
+	plan.ObjectId = types.Int64Value(info.ObjectID)
+	plan.ModifiedAt = types.Int64Value(info.ModifiedAt)
+	return nil
+}
+
+// Create a new resource.
+func (r *DatabricksWorkspaceFileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_file.Create")
+	defer span.End()
+
+	var plan databricksWorkspaceFileResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importFile(ctx, &plan, plan.Overwrite.ValueBool())
+	r.audit.Record(ctx, "mrl_databricks_workspace_file", auditActionCreate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing workspace file",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DatabricksWorkspaceFileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_file.Read")
+	defer span.End()
+
+	var state databricksWorkspaceFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	info, err := client.WorkspaceGetStatus(ctx, state.Path.ValueString())
+	if databricks.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading workspace file",
+			"Could not get the status of "+state.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+	if info.ModifiedAt != state.ModifiedAt.ValueInt64() || info.ObjectID != state.ObjectId.ValueInt64() {
+		// The object was changed outside Terraform; clearing the hash makes
+		// the next plan import the file again.
+		state.ContentMd5 = types.StringNull()
+	}
+	state.ObjectType = types.StringValue(info.ObjectType)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DatabricksWorkspaceFileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_file.Update")
+	defer span.End()
+
+	var plan, state databricksWorkspaceFileResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ContentMd5.Equal(state.ContentMd5) && plan.Language.Equal(state.Language) {
+		// Only token or overwrite changed.
+		diags := resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.importFile(ctx, &plan, true)
+	r.audit.Record(ctx, "mrl_databricks_workspace_file", auditActionUpdate, plan.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing workspace file",
+			"Could not import "+plan.LocalPath.ValueString()+" to "+plan.Path.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DatabricksWorkspaceFileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_workspace_file.Delete")
+	defer span.End()
+
+	var state databricksWorkspaceFileResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	client, err := r.workspace.override(state.Workspace).client(r.httpClient, workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	// A DBC archive may hold a directory of notebooks.
+	err = client.WorkspaceDelete(ctx, state.Path.ValueString(), state.Format.ValueString() == databricks.ExportFormatDBC)
+	if databricks.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_databricks_workspace_file", auditActionDelete, state.Path.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting workspace file",
+			"Could not delete "+state.Path.ValueString()+": "+err.Error(),
+		)
+	}
+}
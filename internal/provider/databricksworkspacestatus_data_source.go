@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &DatabricksWorkspaceStatusDataSource{}
+	_ datasource.DataSourceWithConfigure = &DatabricksWorkspaceStatusDataSource{}
+)
+
+// NewDatabricksWorkspaceStatusDataSource is a helper function to simplify the provider implementation.
+func NewDatabricksWorkspaceStatusDataSource() datasource.DataSource {
+	return &DatabricksWorkspaceStatusDataSource{}
+}
+
+// DatabricksWorkspaceStatusDataSource is the data source implementation.
+type DatabricksWorkspaceStatusDataSource struct {
+	httpClient *http.Client
+	workspace  databricksWorkspace
+}
+
+// databricksWorkspaceStatusDataSourceModel maps the data source schema data.
+type databricksWorkspaceStatusDataSourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	AdbId         types.String `tfsdk:"adb_id"`
+	WorkspaceUrl  types.String `tfsdk:"workspace_url"`
+	Token         types.String `tfsdk:"token"`
+	Reachable     types.Bool   `tfsdk:"reachable"`
+	Authenticated types.Bool   `tfsdk:"authenticated"`
+	LatencyMs     types.Int64  `tfsdk:"latency_ms"`
+	UserId        types.String `tfsdk:"user_id"`
+	UserName      types.String `tfsdk:"user_name"`
+	DisplayName   types.String `tfsdk:"display_name"`
+	Error         types.String `tfsdk:"error"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *DatabricksWorkspaceStatusDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.httpClient = providerData.HTTPClient
+	d.workspace = providerData.Databricks
+}
+
+// Metadata returns the data source type name.
+func (d *DatabricksWorkspaceStatusDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_workspace_status"
+}
+
+// Schema defines the schema for the data source.
+func (d *DatabricksWorkspaceStatusDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks that the workspace API is reachable and the credentials are valid, and reports the authenticated principal. Failures are reported in the attributes rather than as errors, for use in preconditions before expensive applies.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "URL of the workspace",
+			},
+			"adb_id": schema.StringAttribute{
+				Optional:           true,
+				Validators:         []validator.String{WorkspaceURL()},
+				DeprecationMessage: adbIDDeprecation,
+				Description:        "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"workspace_url": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{WorkspaceURL(), ConflictsWith("adb_id")},
+				Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP. Defaults to host of the provider databricks block",
+			},
+			"token": schema.StringAttribute{
+				Optional:           true,
+				Sensitive:          true,
+				DeprecationMessage: databricksTokenDeprecation,
+				Description:        databricksTokenDescription,
+			},
+			"reachable": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the workspace API answered",
+			},
+			"authenticated": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the workspace accepted the credentials",
+			},
+			"latency_ms": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Round-trip time of the check in milliseconds",
+			},
+			"user_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "SCIM ID of the authenticated user or service principal",
+			},
+			"user_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "User name of the authenticated principal, the application ID for a service principal",
+			},
+			"display_name": schema.StringAttribute{
+				Computed:    true,
+				Description: "Display name of the authenticated principal",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "Why the check failed, null when authenticated is true",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *DatabricksWorkspaceStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_databricks_workspace_status.Read")
+	defer span.End()
+
+	var state databricksWorkspaceStatusDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	host, token, err := d.workspace.resolve(workspaceURL(state.WorkspaceUrl, state.AdbId), state.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing Databricks workspace", err.Error())
+		return
+	}
+	client := databricks.NewClient(d.httpClient, host, token)
+	start := time.Now()
+	user, err := client.CurrentUser(ctx)
+	latency := time.Since(start)
+
+	var apiErr *databricks.APIError
+	state.Id = types.StringValue(databricks.WorkspaceURL(host))
+	state.Reachable = types.BoolValue(err == nil || errors.As(err, &apiErr))
+	state.Authenticated = types.BoolValue(err == nil)
+	state.LatencyMs = types.Int64Value(latency.Milliseconds())
+	state.UserId = types.StringNull()
+	state.UserName = types.StringNull()
+	state.DisplayName = types.StringNull()
+	state.Error = types.StringNull()
+	if err != nil {
+		state.Error = types.StringValue(err.Error())
+	} else {
+		state.UserId = types.StringValue(user.ID)
+		state.UserName = types.StringValue(user.UserName)
+		state.DisplayName = optionalString(user.DisplayName)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
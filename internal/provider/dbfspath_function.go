@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &DbfsPathFunction{}
+)
+
+// NewDbfsPathFunction is a helper function to simplify the provider implementation.
+func NewDbfsPathFunction() function.Function {
+	return &DbfsPathFunction{}
+}
+
+// DbfsPathFunction is the function implementation.
+type DbfsPathFunction struct{}
+
+// dbfsDestinationPath returns the normalized DBFS path the local file fp is
+// uploaded to under the directory prefix, or under the default directory of
+// mrl_databricks_dbfs when prefix is empty.
+func dbfsDestinationPath(fp, prefix string) string {
+	if prefix == "" {
+		return joinDbfsPath(dbfsLibPath(fp))
+	}
+	return joinDbfsPath(prefix, filepath.Base(fp))
+}
+
+// Metadata returns the function name.
+func (f *DbfsPathFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dbfs_path"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *DbfsPathFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compute the DBFS destination path of a local file",
+		Description: "Returns the normalized DBFS path a local file is uploaded to: the file name under the directory prefix, or under /FileStore/jars/init-libs, the default directory of mrl_databricks_dbfs, when prefix is null or empty.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "local_file",
+				Description: "Path of the local file",
+			},
+			function.StringParameter{
+				Name:           "prefix",
+				AllowNullValue: true,
+				Description:    "Absolute DBFS directory, with or without the dbfs: scheme",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run computes the path.
+func (f *DbfsPathFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var localFile string
+	var prefix *string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &localFile, &prefix))
+	if resp.Error != nil {
+		return
+	}
+
+	base := filepath.Base(localFile)
+	if localFile == "" || strings.HasSuffix(localFile, "/") || base == "." || base == "/" {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Invalid local file: %q does not name a file", localFile))
+		return
+	}
+	dir := ""
+	if prefix != nil {
+		dir = *prefix
+	}
+	if dir != "" && !strings.HasPrefix(strings.TrimPrefix(dir, "dbfs:"), "/") {
+		resp.Error = function.NewArgumentFuncError(1, fmt.Sprintf("Invalid DBFS path: %q is not an absolute DBFS path", dir))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, dbfsDestinationPath(localFile, dir)))
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &DbfsPathJoinFunction{}
+)
+
+// NewDbfsPathJoinFunction is a helper function to simplify the provider implementation.
+func NewDbfsPathJoinFunction() function.Function {
+	return &DbfsPathJoinFunction{}
+}
+
+// DbfsPathJoinFunction is the function implementation.
+type DbfsPathJoinFunction struct{}
+
+// joinDbfsPath joins path segments into a normalized, absolute DBFS path:
+// duplicate slashes are collapsed, "." and ".." elements are resolved, a
+// leading "dbfs:" scheme is dropped and the result always starts with "/".
+func joinDbfsPath(parts ...string) string {
+	if len(parts) > 0 {
+		parts[0] = strings.TrimPrefix(parts[0], "dbfs:")
+	}
+	return path.Clean("/" + strings.Join(parts, "/"))
+}
+
+// Metadata returns the function name.
+func (f *DbfsPathJoinFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "dbfs_path_join"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *DbfsPathJoinFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Join and normalize DBFS path segments",
+		Description: "Joins the given segments with \"/\", collapses duplicate slashes, resolves \".\" and \"..\" elements and makes the result absolute, so every resource refers to a DBFS location with the same spelling.",
+		VariadicParameter: function.StringParameter{
+			Name:        "parts",
+			Description: "Path segments to join",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run joins the segments.
+func (f *DbfsPathJoinFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var parts []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &parts))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, joinDbfsPath(parts...)))
+}
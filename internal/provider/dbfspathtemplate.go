@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// dbfsPathPlaceholder matches the {name} placeholders of a DBFS path
+// template.
+var dbfsPathPlaceholder = regexp.MustCompile(`\{([A-Za-z0-9_]*)\}`)
+
+// renderDbfsPathTemplate returns the DBFS path a local file with content
+// hash md5 is uploaded to under template. {filename} is the base name of
+// localPath and {md5} is md5; other placeholders, such as {env}, take their
+// values from variables. When template has no {filename} placeholder, it is a
+// directory prefix and the file name is appended to it.
+func renderDbfsPathTemplate(template string, variables map[string]string, localPath, md5 string) (string, error) {
+	filename := filepath.Base(localPath)
+	var missing []string
+	rendered := dbfsPathPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := strings.Trim(placeholder, "{}")
+		switch name {
+		case "filename":
+			return filename
+		case "md5":
+			return md5
+		}
+		value, ok := variables[name]
+		if !ok {
+			missing = append(missing, placeholder)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("%s in DBFS path template %q has no value; set it in dbfs_path_variables of the provider databricks block", strings.Join(missing, ", "), template)
+	}
+	if strings.ContainsAny(rendered, "{}") {
+		return "", fmt.Errorf("DBFS path template %q has an unterminated placeholder", template)
+	}
+	if !strings.Contains(template, "{filename}") {
+		rendered += "/" + filename
+	}
+	return joinDbfsPath(rendered), nil
+}
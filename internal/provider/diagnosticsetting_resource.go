@@ -0,0 +1,337 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &DiagnosticSettingResource{}
+	_ resource.ResourceWithConfigure      = &DiagnosticSettingResource{}
+	_ resource.ResourceWithValidateConfig = &DiagnosticSettingResource{}
+	_ resource.ResourceWithImportState    = &DiagnosticSettingResource{}
+)
+
+// NewDiagnosticSettingResource is a helper function to simplify the provider implementation.
+func NewDiagnosticSettingResource() resource.Resource {
+	return &DiagnosticSettingResource{}
+}
+
+// DiagnosticSettingResource is the resource implementation.
+type DiagnosticSettingResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type diagnosticSettingResourceModel struct {
+	Id                          types.String `tfsdk:"id"`
+	Name                        types.String `tfsdk:"name"`
+	WorkspaceId                 types.String `tfsdk:"workspace_id"`
+	LogAnalyticsWorkspaceId     types.String `tfsdk:"log_analytics_workspace_id"`
+	EventhubAuthorizationRuleId types.String `tfsdk:"eventhub_authorization_rule_id"`
+	EventhubName                types.String `tfsdk:"eventhub_name"`
+	StorageAccountId            types.String `tfsdk:"storage_account_id"`
+	LogCategories               types.Set    `tfsdk:"log_categories"`
+	LogCategoryGroups           types.Set    `tfsdk:"log_category_groups"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *DiagnosticSettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *DiagnosticSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_diagnostic_setting"
+}
+
+// Schema defines the schema for the resource.
+func (r *DiagnosticSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Configures Azure diagnostic settings on a Databricks workspace, exporting its audit and service logs to Log Analytics, Event Hub or a storage account.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ARM ID of the diagnostic setting",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the diagnostic setting",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the Databricks workspace",
+			},
+			"log_analytics_workspace_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARM ID of the Log Analytics workspace receiving the logs",
+			},
+			"eventhub_authorization_rule_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARM ID of the Event Hub namespace authorization rule used to send the logs",
+			},
+			"eventhub_name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Event Hub receiving the logs. A hub per category is created when unset",
+			},
+			"storage_account_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "ARM ID of the storage account archiving the logs",
+			},
+			"log_categories": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Log categories to export, such as accounts, clusters, jobs, notebook or unityCatalog",
+			},
+			"log_category_groups": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Log category groups to export: allLogs or audit",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that the setting has a destination and logs to send.
+func (r *DiagnosticSettingResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config diagnosticSettingResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.LogAnalyticsWorkspaceId.IsNull() && config.EventhubAuthorizationRuleId.IsNull() && config.StorageAccountId.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("log_analytics_workspace_id"),
+			"Missing destination",
+			"At least one of log_analytics_workspace_id, eventhub_authorization_rule_id and storage_account_id must be set.",
+		)
+	}
+	if config.LogCategories.IsNull() && config.LogCategoryGroups.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("log_categories"),
+			"Missing log categories",
+			"At least one of log_categories and log_category_groups must be set.",
+		)
+	}
+}
+
+// optionalString maps the empty string the API returns for unset properties
+// to null.
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// put creates or replaces the diagnostic setting from the plan.
+func (r *DiagnosticSettingResource) put(ctx context.Context, plan *diagnosticSettingResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var categories, groups []string
+	diags.Append(plan.LogCategories.ElementsAs(ctx, &categories, false)...)
+	diags.Append(plan.LogCategoryGroups.ElementsAs(ctx, &groups, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	setting := azure.DiagnosticSetting{
+		Properties: azure.DiagnosticSettingProperties{
+			WorkspaceID:                 plan.LogAnalyticsWorkspaceId.ValueString(),
+			EventHubAuthorizationRuleID: plan.EventhubAuthorizationRuleId.ValueString(),
+			EventHubName:                plan.EventhubName.ValueString(),
+			StorageAccountID:            plan.StorageAccountId.ValueString(),
+		},
+	}
+	for _, category := range categories {
+		setting.Properties.Logs = append(setting.Properties.Logs, azure.DiagnosticLog{Category: category, Enabled: true})
+	}
+	for _, group := range groups {
+		setting.Properties.Logs = append(setting.Properties.Logs, azure.DiagnosticLog{CategoryGroup: group, Enabled: true})
+	}
+
+	id := azure.DiagnosticSettingID(plan.WorkspaceId.ValueString(), plan.Name.ValueString())
+
+	ctx = withAuditRequestID(ctx)
+	_, err := r.azure.PutDiagnosticSetting(ctx, id, setting)
+	r.audit.Record(ctx, "mrl_databricks_diagnostic_setting", action, id, err)
+	if err != nil {
+		diags.AddError("Error configuring diagnostic setting", "Could not configure diagnostic setting "+id+": "+err.Error())
+		return diags
+	}
+
+	plan.Id = types.StringValue(id)
+	return diags
+}
+
+// Create a new resource.
+func (r *DiagnosticSettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_diagnostic_setting.Create")
+	defer span.End()
+
+	var plan diagnosticSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *DiagnosticSettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_diagnostic_setting.Read")
+	defer span.End()
+
+	var state diagnosticSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	setting, err := r.azure.GetDiagnosticSetting(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading diagnostic setting",
+			"Could not read diagnostic setting "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	props := setting.Properties
+	state.LogAnalyticsWorkspaceId = optionalString(props.WorkspaceID)
+	state.EventhubAuthorizationRuleId = optionalString(props.EventHubAuthorizationRuleID)
+	state.EventhubName = optionalString(props.EventHubName)
+	state.StorageAccountId = optionalString(props.StorageAccountID)
+
+	var categories, groups []string
+	for _, log := range props.Logs {
+		switch {
+		case !log.Enabled:
+		case log.CategoryGroup != "":
+			groups = append(groups, log.CategoryGroup)
+		case log.Category != "":
+			categories = append(categories, log.Category)
+		}
+	}
+	if !state.LogCategories.IsNull() || len(categories) > 0 {
+		state.LogCategories, diags = types.SetValueFrom(ctx, types.StringType, categories)
+		resp.Diagnostics.Append(diags...)
+	}
+	if !state.LogCategoryGroups.IsNull() || len(groups) > 0 {
+		state.LogCategoryGroups, diags = types.SetValueFrom(ctx, types.StringType, groups)
+		resp.Diagnostics.Append(diags...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *DiagnosticSettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_diagnostic_setting.Update")
+	defer span.End()
+
+	var plan diagnosticSettingResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *DiagnosticSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_diagnostic_setting.Delete")
+	defer span.End()
+
+	var state diagnosticSettingResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteDiagnosticSetting(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_diagnostic_setting", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting diagnostic setting",
+			"Could not delete diagnostic setting "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a diagnostic setting by its ARM ID,
+// {workspace_id}/providers/Microsoft.Insights/diagnosticSettings/{name}.
+func (r *DiagnosticSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	workspaceID, name, err := splitExtensionID(req.ID, "/providers/Microsoft.Insights/diagnosticSettings/")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_id"), workspaceID)...)
+}
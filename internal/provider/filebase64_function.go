@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &FileBase64Function{}
+)
+
+// fileBase64MaxBytes is the largest file file_base64 encodes. Larger files
+// would bloat the plan and the state; they are uploaded with
+// mrl_databricks_dbfs instead.
+const fileBase64MaxBytes = 1 << 20
+
+// NewFileBase64Function is a helper function to simplify the provider implementation.
+func NewFileBase64Function() function.Function {
+	return &FileBase64Function{}
+}
+
+// FileBase64Function is the function implementation.
+type FileBase64Function struct{}
+
+// fileBase64 returns the content of the file at fp base64 encoded. Files
+// larger than maxBytes are rejected before they are read.
+func fileBase64(fp string, maxBytes int64) (string, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if fileInfo.Size() > maxBytes {
+		return "", fmt.Errorf("file is %d bytes, which exceeds the limit of %d bytes", fileInfo.Size(), maxBytes)
+	}
+
+	content, err := io.ReadAll(io.LimitReader(f, maxBytes))
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(content), nil
+}
+
+// Metadata returns the function name.
+func (f *FileBase64Function) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "file_base64"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *FileBase64Function) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Base64 encode a small local file",
+		Description: "Reads the file at the given path and returns its content base64 encoded, e.g. for `content_base64` attributes or content-based triggers. When the path comes from the resource that generates the file, the file is read during apply. Files larger than 1 MiB produce an error; upload them with `mrl_databricks_dbfs` instead.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path of the local file to encode",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run encodes the file.
+func (f *FileBase64Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fp string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fp))
+	if resp.Error != nil {
+		return
+	}
+
+	encoded, err := fileBase64(fp, fileBase64MaxBytes)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to encode file: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
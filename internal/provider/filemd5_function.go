@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &FileMd5Function{}
+)
+
+// NewFileMd5Function is a helper function to simplify the provider implementation.
+func NewFileMd5Function() function.Function {
+	return &FileMd5Function{}
+}
+
+// FileMd5Function is the function implementation.
+type FileMd5Function struct{}
+
+// fileMD5 streams the file at fp and returns its lowercase hex MD5 digest. It is
+// the single implementation shared by functions and resources so that a hash
+// computed in configuration always matches what the provider computes.
+func fileMD5(fp string) (string, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Metadata returns the function name.
+func (f *FileMd5Function) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "file_md5"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *FileMd5Function) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Compute the MD5 hash of a local file",
+		Description: "Streams the file at the given path and returns its MD5 hash as a lowercase hex string, the same value the DBFS resource computes for uploaded files.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path of the local file to hash",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run computes the hash.
+func (f *FileMd5Function) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fp string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fp))
+	if resp.Error != nil {
+		return
+	}
+
+	sum, err := fileMD5(fp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to hash file: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, sum))
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &GzipBase64FileFunction{}
+)
+
+// NewGzipBase64FileFunction is a helper function to simplify the provider implementation.
+func NewGzipBase64FileFunction() function.Function {
+	return &GzipBase64FileFunction{}
+}
+
+// GzipBase64FileFunction is the function implementation.
+type GzipBase64FileFunction struct{}
+
+// gzipBase64File gzips the file at fp and returns the compressed bytes base64
+// encoded. Files larger than maxBytes are rejected before they are read.
+func gzipBase64File(fp string, maxBytes int64) (string, error) {
+	f, err := os.Open(fp)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	fileInfo, err := f.Stat()
+	if err != nil {
+		return "", err
+	}
+	if fileInfo.Size() > maxBytes {
+		return "", fmt.Errorf("file is %d bytes, which exceeds the limit of %d bytes", fileInfo.Size(), maxBytes)
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := io.Copy(zw, io.LimitReader(f, maxBytes)); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Metadata returns the function name.
+func (f *GzipBase64FileFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "gzip_base64_file"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *GzipBase64FileFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Gzip and base64 encode a local file",
+		Description: "Reads the file at the given path, compresses it with gzip and returns the result base64 encoded, ready for inline `content_base64` attributes. Files larger than `max_bytes` produce an error instead of an oversized value.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path of the local file to encode",
+			},
+			function.Int64Parameter{
+				Name:        "max_bytes",
+				Description: "Largest accepted size of the uncompressed file, in bytes",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run encodes the file.
+func (f *GzipBase64FileFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fp string
+	var maxBytes int64
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fp, &maxBytes))
+	if resp.Error != nil {
+		return
+	}
+
+	if maxBytes <= 0 {
+		resp.Error = function.NewArgumentFuncError(1, "max_bytes must be greater than zero")
+		return
+	}
+
+	encoded, err := gzipBase64File(fp, maxBytes)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Unable to encode file: "+err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, encoded))
+}
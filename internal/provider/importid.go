@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+)
+
+// importIDSeparator separates the parts of composite import IDs, such as
+// account|container|name for blobs.
+const importIDSeparator = "|"
+
+// parseImportID splits a composite import ID into one value per named part.
+func parseImportID(id string, parts ...string) ([]string, error) {
+	values := strings.Split(id, importIDSeparator)
+	if len(values) != len(parts) {
+		return nil, fmt.Errorf("expected an import ID of the form %s, got %q", strings.Join(parts, importIDSeparator), id)
+	}
+	for i, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("the %s part of import ID %q is empty", parts[i], id)
+		}
+	}
+	return values, nil
+}
+
+// parseImportARMID parses the ARM ID of a resource of the given provider
+// namespace and resource types, e.g. Microsoft.Databricks and workspaces.
+func parseImportARMID(id, namespace string, types ...string) (azure.ResourceID, error) {
+	parsed, err := azure.ParseResourceID(id)
+	if err != nil {
+		return parsed, err
+	}
+	if !strings.EqualFold(parsed.Provider, namespace) || len(parsed.Types) != len(types) {
+		return parsed, fmt.Errorf("%q is not the ID of a %s/%s resource", id, namespace, strings.Join(types, "/"))
+	}
+	for i, t := range types {
+		if !strings.EqualFold(parsed.Types[i], t) {
+			return parsed, fmt.Errorf("%q is not the ID of a %s/%s resource", id, namespace, strings.Join(types, "/"))
+		}
+	}
+	return parsed, nil
+}
+
+// splitExtensionID splits the ID of an extension resource, such as a role
+// assignment or diagnostic setting, into the ID of the resource it extends and
+// its name. The segment is the provider and type part in between, e.g.
+// /providers/Microsoft.Authorization/roleAssignments/.
+func splitExtensionID(id, segment string) (string, string, error) {
+	i := strings.LastIndex(strings.ToLower(id), strings.ToLower(segment))
+	if i <= 0 || strings.Contains(id[i+len(segment):], "/") || i+len(segment) == len(id) {
+		return "", "", fmt.Errorf("%q is not of the form {scope}%s{name}", id, segment)
+	}
+	return id[:i], id[i+len(segment):], nil
+}
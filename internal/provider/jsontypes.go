@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = NormalizedJSONType{}
+	_ xattr.TypeWithValidate                     = NormalizedJSONType{}
+	_ basetypes.StringValuableWithSemanticEquals = NormalizedJSONValue{}
+)
+
+// NormalizedJSONType is the attribute type of JSON documents. Values that only
+// differ in key order or whitespace are semantically equal, so formatting
+// returned by the API never shows up as a diff. Use it as the CustomType of
+// every JSON valued string attribute.
+type NormalizedJSONType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t NormalizedJSONType) String() string {
+	return "NormalizedJSONType"
+}
+
+// ValueType returns the Value type.
+func (t NormalizedJSONType) ValueType(_ context.Context) attr.Value {
+	return NormalizedJSONValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t NormalizedJSONType) Equal(o attr.Type) bool {
+	other, ok := o.(NormalizedJSONType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t NormalizedJSONType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NormalizedJSONValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t NormalizedJSONType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// Validate rejects values that are not valid JSON.
+func (t NormalizedJSONType) Validate(_ context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var s string
+	if err := in.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid JSON", "Could not read the value: "+err.Error())
+		return diags
+	}
+	if !json.Valid([]byte(s)) {
+		diags.AddAttributeError(p, "Invalid JSON", "The value is not a valid JSON document.")
+	}
+	return diags
+}
+
+// NormalizedJSONValue is a value of NormalizedJSONType.
+type NormalizedJSONValue struct {
+	basetypes.StringValue
+}
+
+// NewNormalizedJSONValue returns a known JSON value.
+func NewNormalizedJSONValue(value string) NormalizedJSONValue {
+	return NormalizedJSONValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewNormalizedJSONNull returns a null JSON value.
+func NewNormalizedJSONNull() NormalizedJSONValue {
+	return NormalizedJSONValue{StringValue: basetypes.NewStringNull()}
+}
+
+// Type returns the type of the value.
+func (v NormalizedJSONValue) Type(_ context.Context) attr.Type {
+	return NormalizedJSONType{}
+}
+
+// Equal returns true if the given value is equivalent, including formatting.
+func (v NormalizedJSONValue) Equal(o attr.Value) bool {
+	other, ok := o.(NormalizedJSONValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the two documents are equal after
+// decoding, regardless of key order and whitespace.
+func (v NormalizedJSONValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(NormalizedJSONValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	return jsonEqual(v.ValueString(), newValue.ValueString()), diags
+}
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &KeyVaultSecretDataSource{}
+	_ datasource.DataSourceWithConfigure = &KeyVaultSecretDataSource{}
+)
+
+// NewKeyVaultSecretDataSource is a helper function to simplify the provider implementation.
+func NewKeyVaultSecretDataSource() datasource.DataSource {
+	return &KeyVaultSecretDataSource{}
+}
+
+// KeyVaultSecretDataSource is the data source implementation.
+type KeyVaultSecretDataSource struct {
+	azure *azure.Client
+}
+
+// keyVaultSecretDataSourceModel maps the data source schema data.
+type keyVaultSecretDataSourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	VaultUrl    types.String `tfsdk:"vault_url"`
+	Name        types.String `tfsdk:"name"`
+	Version     types.String `tfsdk:"version"`
+	Value       types.String `tfsdk:"value"`
+	ContentType types.String `tfsdk:"content_type"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Expires     types.String `tfsdk:"expiration_date"`
+	Tags        types.Map    `tfsdk:"tags"`
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *KeyVaultSecretDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.azure = providerData.Azure
+}
+
+// Metadata returns the data source type name.
+func (d *KeyVaultSecretDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyvault_secret"
+}
+
+// Schema defines the schema for the data source.
+func (d *KeyVaultSecretDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads a secret from Azure Key Vault using the provider credential.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Versioned ID of the secret",
+			},
+			"vault_url": schema.StringAttribute{
+				Required:    true,
+				Description: "URL of the key vault, e.g. https://myvault.vault.azure.net",
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the secret",
+			},
+			"version": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the secret to read. Defaults to the current version",
+			},
+			"value": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Value of the secret",
+			},
+			"content_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content type of the secret",
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the secret is enabled",
+			},
+			"expiration_date": schema.StringAttribute{
+				Computed:    true,
+				Description: "Expiration time of the secret in RFC3339 format, empty when it does not expire",
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Tags of the secret",
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *KeyVaultSecretDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "data.mrl_keyvault_secret.Read")
+	defer span.End()
+
+	var state keyVaultSecretDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := d.azure.GetSecret(ctx, state.VaultUrl.ValueString(), state.Name.ValueString(), state.Version.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Key Vault secret",
+			"Could not read secret "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(secret.ID)
+	state.Version = types.StringValue(secret.Version())
+	state.Value = types.StringValue(secret.Value)
+	state.ContentType = types.StringValue(secret.ContentType)
+	state.Enabled = types.BoolValue(secret.Attributes.Enabled == nil || *secret.Attributes.Enabled)
+	state.Expires = types.StringValue("")
+	if secret.Attributes.Expires != nil {
+		state.Expires = types.StringValue(time.Unix(*secret.Attributes.Expires, 0).UTC().Format(time.RFC3339))
+	}
+
+	tags, diags := types.MapValueFrom(ctx, types.StringType, secret.Tags)
+	resp.Diagnostics.Append(diags...)
+	state.Tags = tags
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
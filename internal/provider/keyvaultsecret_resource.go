@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &KeyVaultSecretResource{}
+	_ resource.ResourceWithConfigure   = &KeyVaultSecretResource{}
+	_ resource.ResourceWithImportState = &KeyVaultSecretResource{}
+)
+
+// NewKeyVaultSecretResource is a helper function to simplify the provider implementation.
+func NewKeyVaultSecretResource() resource.Resource {
+	return &KeyVaultSecretResource{}
+}
+
+// KeyVaultSecretResource is the resource implementation.
+type KeyVaultSecretResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type keyVaultSecretResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	VaultUrl    URLValue     `tfsdk:"vault_url"`
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	ContentType types.String `tfsdk:"content_type"`
+	Expires     types.String `tfsdk:"expiration_date"`
+	Tags        types.Map    `tfsdk:"tags"`
+	Version     types.String `tfsdk:"version"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *KeyVaultSecretResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *KeyVaultSecretResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_keyvault_secret"
+}
+
+// Schema defines the schema for the resource.
+func (r *KeyVaultSecretResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Writes a secret to Azure Key Vault using the provider credential. Every change creates a new secret version.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Versioned ID of the secret",
+			},
+			"vault_url": schema.StringAttribute{
+				CustomType: URLType{},
+				Required:   true,
+				PlanModifiers: []planmodifier.String{
+					RequiresReplaceUnlessEquivalent(URLType{}),
+				},
+				Description: "URL of the key vault, e.g. https://myvault.vault.azure.net",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the secret",
+			},
+			"value": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Value of the secret",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content type of the secret",
+			},
+			"expiration_date": schema.StringAttribute{
+				Optional:    true,
+				Description: "Expiration time of the secret in RFC3339 format",
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags of the secret",
+			},
+			"version": schema.StringAttribute{
+				Computed:    true,
+				Description: "Current version of the secret",
+			},
+		},
+	}
+}
+
+// write stores a new version of the secret from the plan.
+func (r *KeyVaultSecretResource) write(ctx context.Context, plan *keyVaultSecretResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	secret := azure.Secret{
+		Value:       plan.Value.ValueString(),
+		ContentType: plan.ContentType.ValueString(),
+	}
+	diags.Append(plan.Tags.ElementsAs(ctx, &secret.Tags, false)...)
+	if !plan.Expires.IsNull() {
+		expires, err := time.Parse(time.RFC3339, plan.Expires.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("expiration_date"), "Invalid expiration_date", "The value must be an RFC3339 timestamp: "+err.Error())
+		}
+		exp := expires.Unix()
+		secret.Attributes.Expires = &exp
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	ctx = withAuditRequestID(ctx)
+	result, err := r.azure.SetSecret(ctx, plan.VaultUrl.ValueString(), plan.Name.ValueString(), secret)
+	r.audit.Record(ctx, "mrl_keyvault_secret", action, plan.VaultUrl.ValueString()+"/secrets/"+plan.Name.ValueString(), err)
+	if err != nil {
+		diags.AddError("Error writing Key Vault secret", "Could not write secret "+plan.Name.ValueString()+": "+err.Error())
+		return diags
+	}
+
+	plan.Id = types.StringValue(result.ID)
+	plan.Version = types.StringValue(result.Version())
+	return diags
+}
+
+// Create a new resource.
+func (r *KeyVaultSecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_keyvault_secret.Create")
+	defer span.End()
+
+	var plan keyVaultSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *KeyVaultSecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_keyvault_secret.Read")
+	defer span.End()
+
+	var state keyVaultSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	secret, err := r.azure.GetSecret(ctx, state.VaultUrl.ValueString(), state.Name.ValueString(), "")
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading Key Vault secret",
+			"Could not read secret "+state.Name.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Id = types.StringValue(secret.ID)
+	state.Version = types.StringValue(secret.Version())
+	state.Value = types.StringValue(secret.Value)
+	if !state.ContentType.IsNull() || secret.ContentType != "" {
+		state.ContentType = types.StringValue(secret.ContentType)
+	}
+	if secret.Attributes.Expires != nil {
+		// Keep the configured spelling when it denotes the same instant.
+		configured, err := time.Parse(time.RFC3339, state.Expires.ValueString())
+		if err != nil || configured.Unix() != *secret.Attributes.Expires {
+			state.Expires = types.StringValue(time.Unix(*secret.Attributes.Expires, 0).UTC().Format(time.RFC3339))
+		}
+	} else {
+		state.Expires = types.StringNull()
+	}
+	if !state.Tags.IsNull() || len(secret.Tags) > 0 {
+		tags, diags := types.MapValueFrom(ctx, types.StringType, secret.Tags)
+		resp.Diagnostics.Append(diags...)
+		state.Tags = tags
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *KeyVaultSecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_keyvault_secret.Update")
+	defer span.End()
+
+	var plan keyVaultSecretResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.write(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *KeyVaultSecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_keyvault_secret.Delete")
+	defer span.End()
+
+	var state keyVaultSecretResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteSecret(ctx, state.VaultUrl.ValueString(), state.Name.ValueString())
+	if azure.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_keyvault_secret", auditActionDelete, state.VaultUrl.ValueString()+"/secrets/"+state.Name.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting Key Vault secret",
+			"Could not delete secret "+state.Name.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a secret by its ID, e.g.
+// https://myvault.vault.azure.net/secrets/name. A version in the ID is ignored.
+func (r *KeyVaultSecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	u, err := url.Parse(req.ID)
+	var segments []string
+	if err == nil {
+		segments = strings.Split(strings.Trim(u.Path, "/"), "/")
+	}
+	if err != nil || u.Host == "" || len(segments) < 2 || segments[0] != "secrets" {
+		resp.Diagnostics.AddError("Invalid import ID", fmt.Sprintf("expected a secret ID of the form https://{vault}.vault.azure.net/secrets/{name}, got %q", req.ID))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("vault_url"), u.Scheme+"://"+u.Host)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), segments[1])...)
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// lifecycleTest is the lifecycle of a resource run by testLifecycle: create,
+// refresh, a plan without changes, updates, import, list and destroy. Steps
+// whose fields are not set are skipped.
+type lifecycleTest struct {
+	typeName string
+	// config is the configuration the resource is created with.
+	config map[string]interface{}
+	// created checks the state and the mock workspace after create.
+	created func(t *testing.T, state tftypes.Value)
+	// identity is set for resource types with a resource identity.
+	identity bool
+	// refreshResets are the computed attributes describing the last apply,
+	// such as content_changed, that refresh is expected to reset.
+	refreshResets []string
+	// updates are applied in order after create.
+	updates []lifecycleUpdate
+	// importID returns the import ID of the resource in state.
+	importID func(state tftypes.Value) string
+	// importVerify are the attributes import must set as they are in state.
+	importVerify []string
+	// listed is the display name the list resource returns the resource
+	// with, as its only result.
+	listed string
+	// destroyed checks that the resource is gone from the mock workspace.
+	destroyed func(t *testing.T, state tftypes.Value)
+}
+
+// lifecycleUpdate is an update of a lifecycleTest.
+type lifecycleUpdate struct {
+	// prepare runs before the plan, to change something outside the
+	// configuration such as a local file.
+	prepare func(t *testing.T)
+	// config is the new configuration.
+	config map[string]interface{}
+	// planned checks the plan of the update.
+	planned func(t *testing.T, planned tftypes.Value)
+	// check checks the state and the mock workspace after the update.
+	check func(t *testing.T, state tftypes.Value)
+}
+
+// testLifecycle runs the lifecycle lc against the mock workspace of p.
+func testLifecycle(t *testing.T, p *testProvider, lc lifecycleTest) {
+	t.Helper()
+	typeName := lc.typeName
+	null := tftypes.NewValue(p.resourceType(typeName), nil)
+
+	state := p.apply(typeName, null, p.config(typeName, lc.config))
+	if lc.created != nil {
+		lc.created(t, state)
+	}
+	if lc.identity && p.identity(typeName, state) == nil {
+		t.Error("no identity after create")
+	}
+	refreshed := p.refresh(typeName, state)
+	if got, want := withoutAttrs(t, refreshed, lc.refreshResets), withoutAttrs(t, state, lc.refreshResets); !got.Equal(want) {
+		t.Errorf("refresh changed the state to %s, want %s", refreshed, state)
+	}
+	state = refreshed
+	if planned, diags := p.plan(typeName, state, p.config(typeName, lc.config)); errorDiagnostics(diags) != "" || !planned.Equal(state) {
+		t.Errorf("planned %s with %s, want no change", planned, errorDiagnostics(diags))
+	}
+
+	for _, update := range lc.updates {
+		if update.prepare != nil {
+			update.prepare(t)
+		}
+		config := p.config(typeName, update.config)
+		if update.planned != nil {
+			planned, diags := p.plan(typeName, state, config)
+			if msg := errorDiagnostics(diags); msg != "" {
+				t.Fatalf("plan: %s", msg)
+			}
+			update.planned(t, planned)
+		}
+		state = p.apply(typeName, state, config)
+		if update.check != nil {
+			update.check(t, state)
+		}
+	}
+
+	if lc.importID != nil {
+		imported := p.importState(typeName, lc.importID(state))
+		for _, name := range lc.importVerify {
+			if got, want := stateAttr(t, imported, name), stateAttr(t, state, name); !got.Equal(want) {
+				t.Errorf("imported %s is %s, want %s", name, got, want)
+			}
+		}
+	}
+
+	if lc.listed != "" {
+		listed := p.list(typeName, nil)
+		if len(listed) != 1 || listed[lc.listed].IsNull() {
+			t.Errorf("listed %d resources, want only %s", len(listed), lc.listed)
+		} else if got, want := stringAttr(t, listed[lc.listed], "id"), stringAttr(t, state, "id"); got != want {
+			t.Errorf("listed %s with id %q, want %q", lc.listed, got, want)
+		}
+	}
+
+	if destroyed := p.apply(typeName, state, null); !destroyed.IsNull() {
+		t.Errorf("state after destroy is %s, want null", destroyed)
+	}
+	if lc.destroyed != nil {
+		lc.destroyed(t, state)
+	}
+	if refreshed := p.refresh(typeName, state); !refreshed.IsNull() {
+		t.Errorf("state of the destroyed resource is %s, want null", refreshed)
+	}
+}
+
+// withoutAttrs returns value with the attributes in names set to null.
+func withoutAttrs(t *testing.T, value tftypes.Value, names []string) tftypes.Value {
+	t.Helper()
+	if len(names) == 0 || value.IsNull() {
+		return value
+	}
+	var attrs map[string]tftypes.Value
+	if err := value.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	stripped := make(map[string]tftypes.Value, len(attrs))
+	for name, v := range attrs {
+		stripped[name] = v
+	}
+	for _, name := range names {
+		stripped[name] = tftypes.NewValue(stripped[name].Type(), nil)
+	}
+	return tftypes.NewValue(value.Type(), stripped)
+}
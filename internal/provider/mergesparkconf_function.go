@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &MergeSparkConfFunction{}
+)
+
+// NewMergeSparkConfFunction is a helper function to simplify the provider implementation.
+func NewMergeSparkConfFunction() function.Function {
+	return &MergeSparkConfFunction{}
+}
+
+// MergeSparkConfFunction is the function implementation.
+type MergeSparkConfFunction struct{}
+
+// normalizeSparkConfValue renders booleans and numbers the way Databricks
+// returns them, so a configured "True" or "4.0" does not diff against the API
+// value "true" or "4".
+func normalizeSparkConfValue(v string) string {
+	v = strings.TrimSpace(v)
+
+	switch strings.ToLower(v) {
+	case "true", "false":
+		return strings.ToLower(v)
+	}
+
+	// Leave values such as "007" alone, they are rarely meant as numbers.
+	if len(v) > 1 && v[0] == '0' && v[1] != '.' {
+		return v
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	return v
+}
+
+// mergeSparkConf merges the maps in order, later maps overriding keys of
+// earlier ones, and normalizes every value.
+func mergeSparkConf(confs ...map[string]string) map[string]string {
+	merged := map[string]string{}
+	for _, conf := range confs {
+		for k, v := range conf {
+			merged[strings.TrimSpace(k)] = normalizeSparkConfValue(v)
+		}
+	}
+	return merged
+}
+
+// Metadata returns the function name.
+func (f *MergeSparkConfFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "merge_spark_conf"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *MergeSparkConfFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Merge spark_conf maps",
+		Description: "Merges the given spark_conf maps, later maps taking precedence over earlier ones, and normalizes boolean and number values to the strings Databricks returns (`true`, `false`, `4`), avoiding noisy diffs on cluster resources.",
+		VariadicParameter: function.MapParameter{
+			ElementType: types.StringType,
+			Name:        "confs",
+			Description: "spark_conf maps in increasing order of precedence",
+		},
+		Return: function.MapReturn{
+			ElementType: types.StringType,
+		},
+	}
+}
+
+// Run merges the maps.
+func (f *MergeSparkConfFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var confs []map[string]string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &confs))
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, mergeSparkConf(confs...)))
+}
@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockDatabricksToken is the only token the mock workspace accepts.
+const mockDatabricksToken = "test-token"
+
+// mockDbfs is an in-memory Databricks workspace serving the DBFS API:
+// create, add-block, close, put, get-status, list, read and delete. Tests
+// of other resources add the APIs they need with route.
+type mockDbfs struct {
+	server *httptest.Server
+
+	mu       sync.Mutex
+	routes   map[string]http.HandlerFunc
+	files    map[string][]byte
+	modified map[string]int64
+	uploads  map[int64]*mockDbfsUpload
+	handle   int64
+	calls    map[string]int
+	failures map[string]int
+	// corrupt flips a byte of the next file closed, as a faulty
+	// transfer would.
+	corrupt bool
+}
+
+// mockDbfsUpload is a file being written through create and add-block.
+type mockDbfsUpload struct {
+	path string
+	data []byte
+}
+
+// newMockDbfs starts a mock workspace, stopped when the test ends.
+func newMockDbfs(t *testing.T) *mockDbfs {
+	t.Helper()
+
+	m := &mockDbfs{
+		files:    map[string][]byte{},
+		modified: map[string]int64{},
+		uploads:  map[int64]*mockDbfsUpload{},
+		calls:    map[string]int{},
+		failures: map[string]int{},
+		routes:   map[string]http.HandlerFunc{},
+	}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// route serves the API calls whose path starts with prefix, such as
+// /api/2.0/token/, with handler. Handlers run one at a time and count as
+// calls of their path relative to prefix.
+func (m *mockDbfs) route(prefix string, handler http.HandlerFunc) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.routes[prefix] = handler
+}
+
+// put stores a file as if uploaded outside Terraform.
+func (m *mockDbfs) put(p string, data []byte) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[p] = append([]byte(nil), data...)
+	m.modified[p] = time.Now().UnixMilli()
+}
+
+// remove deletes the file at p as if deleted outside Terraform.
+func (m *mockDbfs) remove(p string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.files, p)
+	delete(m.modified, p)
+}
+
+// file returns the content of the file at p and whether it exists.
+func (m *mockDbfs) file(p string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.files[p]
+	return data, ok
+}
+
+// callCount returns how many times the endpoint, such as add-block, was
+// called.
+func (m *mockDbfs) callCount(endpoint string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[endpoint]
+}
+
+// corruptNext makes the next upload store content other than that sent,
+// of the same size.
+func (m *mockDbfs) corruptNext() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.corrupt = true
+}
+
+// failNext makes the next call to the endpoint fail with status.
+func (m *mockDbfs) failNext(endpoint string, status int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.failures[endpoint] = status
+}
+
+func (m *mockDbfs) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer "+mockDatabricksToken {
+		writeMockError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "invalid access token")
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for prefix, handler := range m.routes {
+		if endpoint, ok := strings.CutPrefix(r.URL.Path, prefix); ok {
+			m.calls[endpoint]++
+			handler(w, r)
+			return
+		}
+	}
+	endpoint, ok := strings.CutPrefix(r.URL.Path, "/api/2.0/dbfs/")
+	if !ok {
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+		return
+	}
+	m.calls[endpoint]++
+	if status, ok := m.failures[endpoint]; ok {
+		delete(m.failures, endpoint)
+		writeMockError(w, status, "INTERNAL_ERROR", "injected failure")
+		return
+	}
+
+	var body struct {
+		Path      string `json:"path"`
+		Handle    int64  `json:"handle"`
+		Data      string `json:"data"`
+		Contents  string `json:"contents"`
+		Overwrite bool   `json:"overwrite"`
+		Recursive bool   `json:"recursive"`
+	}
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeMockError(w, http.StatusBadRequest, "MALFORMED_REQUEST", err.Error())
+			return
+		}
+	}
+	query := r.URL.Query()
+
+	switch endpoint {
+	case "create":
+		if _, exists := m.files[body.Path]; exists && !body.Overwrite {
+			writeMockError(w, http.StatusBadRequest, "RESOURCE_ALREADY_EXISTS", body.Path+" already exists")
+			return
+		}
+		m.handle++
+		m.uploads[m.handle] = &mockDbfsUpload{path: body.Path}
+		writeMockJSON(w, map[string]interface{}{"handle": m.handle})
+	case "add-block":
+		upload, ok := m.uploads[body.Handle]
+		if !ok {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "unknown handle")
+			return
+		}
+		data, err := base64.StdEncoding.DecodeString(body.Data)
+		if err != nil {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", err.Error())
+			return
+		}
+		if len(data) > 1<<20 {
+			writeMockError(w, http.StatusBadRequest, "MAX_BLOCK_SIZE_EXCEEDED", "block larger than 1 MB")
+			return
+		}
+		upload.data = append(upload.data, data...)
+		writeMockJSON(w, map[string]interface{}{})
+	case "close":
+		upload, ok := m.uploads[body.Handle]
+		if !ok {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "unknown handle")
+			return
+		}
+		delete(m.uploads, body.Handle)
+		if m.corrupt && len(upload.data) > 0 {
+			m.corrupt = false
+			upload.data[0] ^= 0xff
+		}
+		m.files[upload.path] = upload.data
+		m.modified[upload.path] = time.Now().UnixMilli()
+		writeMockJSON(w, map[string]interface{}{})
+	case "put":
+		data, err := base64.StdEncoding.DecodeString(body.Contents)
+		if err != nil {
+			writeMockError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", err.Error())
+			return
+		}
+		m.files[body.Path] = data
+		m.modified[body.Path] = time.Now().UnixMilli()
+		writeMockJSON(w, map[string]interface{}{})
+	case "get-status":
+		p := query.Get("path")
+		if data, ok := m.files[p]; ok {
+			writeMockJSON(w, m.fileInfo(p, data))
+			return
+		}
+		if m.isDir(p) {
+			writeMockJSON(w, map[string]interface{}{"path": p, "is_dir": true, "file_size": 0})
+			return
+		}
+		writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "No file or directory exists on path "+p+".")
+	case "list":
+		p := query.Get("path")
+		if _, ok := m.files[p]; !ok && !m.isDir(p) {
+			writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "No file or directory exists on path "+p+".")
+			return
+		}
+		writeMockJSON(w, map[string]interface{}{"files": m.list(p)})
+	case "read":
+		data, ok := m.files[query.Get("path")]
+		if !ok {
+			writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "No file exists on path "+query.Get("path")+".")
+			return
+		}
+		offset, _ := strconv.ParseInt(query.Get("offset"), 10, 64)
+		length, _ := strconv.ParseInt(query.Get("length"), 10, 64)
+		if offset > int64(len(data)) {
+			offset = int64(len(data))
+		}
+		end := offset + length
+		if end > int64(len(data)) {
+			end = int64(len(data))
+		}
+		writeMockJSON(w, map[string]interface{}{
+			"bytes_read": end - offset,
+			"data":       base64.StdEncoding.EncodeToString(data[offset:end]),
+		})
+	case "delete":
+		if _, ok := m.files[body.Path]; ok {
+			delete(m.files, body.Path)
+			delete(m.modified, body.Path)
+			writeMockJSON(w, map[string]interface{}{})
+			return
+		}
+		if m.isDir(body.Path) && body.Recursive {
+			for p := range m.files {
+				if strings.HasPrefix(p, body.Path+"/") {
+					delete(m.files, p)
+					delete(m.modified, p)
+				}
+			}
+			writeMockJSON(w, map[string]interface{}{})
+			return
+		}
+		writeMockError(w, http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "No file or directory exists on path "+body.Path+".")
+	default:
+		writeMockError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", "no API found for "+r.URL.Path)
+	}
+}
+
+// fileInfo returns the get-status payload of the file at p.
+func (m *mockDbfs) fileInfo(p string, data []byte) map[string]interface{} {
+	return map[string]interface{}{
+		"path":              p,
+		"is_dir":            false,
+		"file_size":         len(data),
+		"modification_time": m.modified[p],
+	}
+}
+
+// isDir reports whether a file exists below p.
+func (m *mockDbfs) isDir(p string) bool {
+	for f := range m.files {
+		if strings.HasPrefix(f, strings.TrimSuffix(p, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns the entries directly below the directory p, sorted by path.
+func (m *mockDbfs) list(p string) []map[string]interface{} {
+	dir := strings.TrimSuffix(p, "/")
+	entries := map[string]map[string]interface{}{}
+	for f, data := range m.files {
+		rel, ok := strings.CutPrefix(f, dir+"/")
+		if !ok {
+			continue
+		}
+		if first, _, nested := strings.Cut(rel, "/"); nested {
+			sub := path.Join(dir, first)
+			entries[sub] = map[string]interface{}{"path": sub, "is_dir": true, "file_size": 0}
+			continue
+		}
+		entries[f] = m.fileInfo(f, data)
+	}
+
+	paths := make([]string, 0, len(entries))
+	for p := range entries {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	list := make([]map[string]interface{}, 0, len(paths))
+	for _, p := range paths {
+		list = append(list, entries[p])
+	}
+	return list
+}
+
+func writeMockJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeMockError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error_code": code, "message": message})
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ function.Function = &NotebookLanguageFunction{}
+)
+
+// NewNotebookLanguageFunction is a helper function to simplify the provider implementation.
+func NewNotebookLanguageFunction() function.Function {
+	return &NotebookLanguageFunction{}
+}
+
+// NotebookLanguageFunction is the function implementation.
+type NotebookLanguageFunction struct{}
+
+// notebookLanguageModel maps the function result.
+type notebookLanguageModel struct {
+	Language string `tfsdk:"language"`
+	Format   string `tfsdk:"format"`
+}
+
+// Workspace import formats.
+const (
+	notebookFormatSource  = "SOURCE"
+	notebookFormatJupyter = "JUPYTER"
+)
+
+var notebookExtensionLanguages = map[string]string{
+	".py":    "PYTHON",
+	".scala": "SCALA",
+	".sql":   "SQL",
+	".r":     "R",
+}
+
+// detectNotebookLanguage infers the notebook language and import format of a
+// local file from its extension, falling back to a shebang or the Databricks
+// "notebook source" header on the first line. Jupyter notebooks take their
+// language from the kernel metadata.
+func detectNotebookLanguage(fp string) (string, string, error) {
+	ext := strings.ToLower(filepath.Ext(fp))
+
+	if ext == ".ipynb" {
+		language, err := jupyterLanguage(fp)
+		return language, notebookFormatJupyter, err
+	}
+
+	if language, ok := notebookExtensionLanguages[ext]; ok {
+		return language, notebookFormatSource, nil
+	}
+
+	f, err := os.Open(fp)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+
+	firstLine, err := bufio.NewReader(f).ReadString('\n')
+	if err != nil && firstLine == "" {
+		return "", "", fmt.Errorf("unable to read %v: %w", fp, err)
+	}
+	firstLine = strings.ToLower(strings.TrimSpace(firstLine))
+
+	switch {
+	case firstLine == "# databricks notebook source":
+		return "PYTHON", notebookFormatSource, nil
+	case firstLine == "// databricks notebook source":
+		return "SCALA", notebookFormatSource, nil
+	case firstLine == "-- databricks notebook source":
+		return "SQL", notebookFormatSource, nil
+	case strings.HasPrefix(firstLine, "#!"):
+		switch {
+		case strings.Contains(firstLine, "python"):
+			return "PYTHON", notebookFormatSource, nil
+		case strings.Contains(firstLine, "rscript"):
+			return "R", notebookFormatSource, nil
+		case strings.Contains(firstLine, "scala"):
+			return "SCALA", notebookFormatSource, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("unable to infer the notebook language of %v from its extension or first line", fp)
+}
+
+// jupyterLanguage reads the kernel language of a Jupyter notebook, defaulting
+// to PYTHON when the metadata does not name one.
+func jupyterLanguage(fp string) (string, error) {
+	content, err := os.ReadFile(fp)
+	if err != nil {
+		return "", err
+	}
+
+	notebook := struct {
+		Metadata struct {
+			Kernelspec struct {
+				Language string `json:"language"`
+			} `json:"kernelspec"`
+			LanguageInfo struct {
+				Name string `json:"name"`
+			} `json:"language_info"`
+		} `json:"metadata"`
+	}{}
+	if err := json.Unmarshal(content, &notebook); err != nil {
+		return "", fmt.Errorf("unable to parse %v as a Jupyter notebook: %w", fp, err)
+	}
+
+	language := notebook.Metadata.Kernelspec.Language
+	if language == "" {
+		language = notebook.Metadata.LanguageInfo.Name
+	}
+
+	switch strings.ToLower(language) {
+	case "", "python":
+		return "PYTHON", nil
+	case "scala":
+		return "SCALA", nil
+	case "sql":
+		return "SQL", nil
+	case "r":
+		return "R", nil
+	}
+
+	return "", fmt.Errorf("unsupported Jupyter kernel language %q in %v", language, fp)
+}
+
+// Metadata returns the function name.
+func (f *NotebookLanguageFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "notebook_language"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *NotebookLanguageFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:     "Infer the language and format of a notebook file",
+		Description: "Returns an object with the `language` (PYTHON, SCALA, SQL or R) and import `format` (SOURCE or JUPYTER) of a local notebook file, inferred from its extension, shebang or Databricks notebook header.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "path",
+				Description: "Path of the local notebook file",
+			},
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: map[string]attr.Type{
+				"language": types.StringType,
+				"format":   types.StringType,
+			},
+		},
+	}
+}
+
+// Run detects the language.
+func (f *NotebookLanguageFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var fp string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &fp))
+	if resp.Error != nil {
+		return
+	}
+
+	language, format, err := detectNotebookLanguage(fp)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, notebookLanguageModel{
+		Language: language,
+		Format:   format,
+	}))
+}
@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = DbfsPathType{}
+	_ xattr.TypeWithValidate                     = DbfsPathType{}
+	_ basetypes.StringValuableWithSemanticEquals = DbfsPathValue{}
+	_ basetypes.StringTypable                    = URLType{}
+	_ basetypes.StringValuableWithSemanticEquals = URLValue{}
+	_ basetypes.StringTypable                    = PathType{}
+	_ basetypes.StringValuableWithSemanticEquals = PathValue{}
+)
+
+// DbfsPathType is the attribute type of absolute DBFS paths. Values that name
+// the same location, such as "dbfs:/FileStore/jars/" and "/FileStore//jars",
+// are semantically equal. DBFS paths are case sensitive.
+type DbfsPathType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t DbfsPathType) String() string {
+	return "DbfsPathType"
+}
+
+// ValueType returns the Value type.
+func (t DbfsPathType) ValueType(_ context.Context) attr.Value {
+	return DbfsPathValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t DbfsPathType) Equal(o attr.Type) bool {
+	other, ok := o.(DbfsPathType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t DbfsPathType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return DbfsPathValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t DbfsPathType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringFromTerraform(ctx, t, in)
+}
+
+// Validate rejects relative paths.
+func (t DbfsPathType) Validate(_ context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var s string
+	if err := in.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid DBFS path", "Could not read the value: "+err.Error())
+		return diags
+	}
+	if !strings.HasPrefix(strings.TrimPrefix(s, "dbfs:"), "/") {
+		diags.AddAttributeError(p, "Invalid DBFS path", fmt.Sprintf("%q is not an absolute DBFS path.", s))
+	}
+	return diags
+}
+
+// DbfsPathValue is a value of DbfsPathType.
+type DbfsPathValue struct {
+	basetypes.StringValue
+}
+
+// NewDbfsPathValue returns a known DBFS path.
+func NewDbfsPathValue(value string) DbfsPathValue {
+	return DbfsPathValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewDbfsPathNull returns a null DBFS path.
+func NewDbfsPathNull() DbfsPathValue {
+	return DbfsPathValue{StringValue: basetypes.NewStringNull()}
+}
+
+// NewDbfsPathUnknown returns an unknown DBFS path.
+func NewDbfsPathUnknown() DbfsPathValue {
+	return DbfsPathValue{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns the type of the value.
+func (v DbfsPathValue) Type(_ context.Context) attr.Type {
+	return DbfsPathType{}
+}
+
+// Equal returns true if the given value is equivalent, including formatting.
+func (v DbfsPathValue) Equal(o attr.Value) bool {
+	other, ok := o.(DbfsPathValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the two paths name the same location.
+func (v DbfsPathValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(DbfsPathValue)
+	if !ok {
+		return false, semanticEqualsError(v, newValuable)
+	}
+	return v.ValueNormalized() == newValue.ValueNormalized(), nil
+}
+
+// ValueNormalized returns the path with the dbfs: scheme dropped, duplicate
+// slashes collapsed and "." and ".." elements resolved, as sent to the API.
+func (v DbfsPathValue) ValueNormalized() string {
+	return joinDbfsPath(v.ValueString())
+}
+
+// stringFromTerraform converts in to a StringValue and then to a value of t.
+func stringFromTerraform(ctx context.Context, t basetypes.StringTypable, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := basetypes.StringType{}.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// semanticEqualsError reports a value of an unexpected type in a semantic
+// equality check.
+func semanticEqualsError(v, newValuable interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddError(
+		"Semantic Equality Check Error",
+		fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+	)
+	return diags
+}
+
+// URLType is the attribute type of workspace and vault URLs, which may also
+// be given as host names. Values that only differ in trailing slashes and
+// letter case, such as "https://adb-1.azuredatabricks.net/" and
+// "https://ADB-1.azuredatabricks.net", are semantically equal.
+type URLType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t URLType) String() string {
+	return "URLType"
+}
+
+// ValueType returns the Value type.
+func (t URLType) ValueType(_ context.Context) attr.Value {
+	return URLValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t URLType) Equal(o attr.Type) bool {
+	other, ok := o.(URLType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t URLType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return URLValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t URLType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringFromTerraform(ctx, t, in)
+}
+
+// URLValue is a value of URLType.
+type URLValue struct {
+	basetypes.StringValue
+}
+
+// NewURLValue returns a known URL.
+func NewURLValue(value string) URLValue {
+	return URLValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// NewURLNull returns a null URL.
+func NewURLNull() URLValue {
+	return URLValue{StringValue: basetypes.NewStringNull()}
+}
+
+// Type returns the type of the value.
+func (v URLValue) Type(_ context.Context) attr.Type {
+	return URLType{}
+}
+
+// Equal returns true if the given value is equivalent, including formatting.
+func (v URLValue) Equal(o attr.Value) bool {
+	other, ok := o.(URLValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the two URLs only differ in trailing
+// slashes and letter case.
+func (v URLValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(URLValue)
+	if !ok {
+		return false, semanticEqualsError(v, newValuable)
+	}
+	return normalizePath(v.ValueString(), true) == normalizePath(newValue.ValueString(), true), nil
+}
+
+// PathType is the attribute type of case sensitive paths outside DBFS, such
+// as ADLS file paths and mount directories. Values that only differ in
+// trailing slashes are semantically equal.
+type PathType struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t PathType) String() string {
+	return "PathType"
+}
+
+// ValueType returns the Value type.
+func (t PathType) ValueType(_ context.Context) attr.Value {
+	return PathValue{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t PathType) Equal(o attr.Type) bool {
+	other, ok := o.(PathType)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t PathType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return PathValue{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t PathType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	return stringFromTerraform(ctx, t, in)
+}
+
+// PathValue is a value of PathType.
+type PathValue struct {
+	basetypes.StringValue
+}
+
+// NewPathValue returns a known path.
+func NewPathValue(value string) PathValue {
+	return PathValue{StringValue: basetypes.NewStringValue(value)}
+}
+
+// Type returns the type of the value.
+func (v PathValue) Type(_ context.Context) attr.Type {
+	return PathType{}
+}
+
+// Equal returns true if the given value is equivalent, including formatting.
+func (v PathValue) Equal(o attr.Value) bool {
+	other, ok := o.(PathValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the two paths only differ in trailing
+// slashes.
+func (v PathValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	newValue, ok := newValuable.(PathValue)
+	if !ok {
+		return false, semanticEqualsError(v, newValuable)
+	}
+	return normalizePath(v.ValueString(), false) == normalizePath(newValue.ValueString(), false), nil
+}
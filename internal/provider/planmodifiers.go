@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ planmodifier.String = normalizedPathModifier{}
+	_ planmodifier.String = semanticJSONModifier{}
+	_ planmodifier.String = requiresReplaceUnlessEquivalentModifier{}
+)
+
+// normalizePath trims trailing slashes from a path or URL, keeping a lone "/"
+// intact, and optionally folds it to lower case.
+func normalizePath(p string, foldCase bool) string {
+	trimmed := strings.TrimRight(p, "/")
+	if trimmed == "" && strings.HasPrefix(p, "/") {
+		trimmed = "/"
+	}
+	if foldCase {
+		trimmed = strings.ToLower(trimmed)
+	}
+	return trimmed
+}
+
+// NormalizedPath returns a plan modifier that keeps the prior state value when
+// the planned value only differs from it by trailing slashes (and letter case
+// when foldCase is set). DBFS paths are case sensitive, URLs are not. It is
+// meant for computed attributes; attributes set in the configuration take a
+// custom type with semantic equality instead, such as URLType, along with
+// RequiresReplaceUnlessEquivalent.
+func NormalizedPath(foldCase bool) planmodifier.String {
+	return normalizedPathModifier{foldCase: foldCase}
+}
+
+// normalizedPathModifier implements the plan modifier.
+type normalizedPathModifier struct {
+	foldCase bool
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (m normalizedPathModifier) Description(_ context.Context) string {
+	if m.foldCase {
+		return "Differences in trailing slashes and letter case are ignored."
+	}
+	return "Differences in trailing slashes are ignored."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m normalizedPathModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m normalizedPathModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if normalizePath(req.PlanValue.ValueString(), m.foldCase) == normalizePath(req.StateValue.ValueString(), m.foldCase) {
+		resp.PlanValue = req.StateValue
+	}
+}
+
+// RequiresReplaceUnlessEquivalent returns a plan modifier that requires
+// replacing the resource when the planned value changes, unless the values
+// of the custom type t are semantically equal, e.g. two spellings of the same
+// URL. The planned value is left as configured.
+func RequiresReplaceUnlessEquivalent(t basetypes.StringTypable) planmodifier.String {
+	return requiresReplaceUnlessEquivalentModifier{typ: t}
+}
+
+// requiresReplaceUnlessEquivalentModifier implements the plan modifier.
+type requiresReplaceUnlessEquivalentModifier struct {
+	typ basetypes.StringTypable
+}
+
+// Description returns a human-readable description of the plan modifier.
+func (m requiresReplaceUnlessEquivalentModifier) Description(_ context.Context) string {
+	return "If the value of this attribute changes to a value that is not equivalent, Terraform will destroy and recreate the resource."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m requiresReplaceUnlessEquivalentModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m requiresReplaceUnlessEquivalentModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to replace on create and destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+	if req.PlanValue.IsUnknown() || req.PlanValue.Equal(req.StateValue) {
+		return
+	}
+	if req.PlanValue.IsNull() || req.StateValue.IsNull() {
+		resp.RequiresReplace = true
+		return
+	}
+
+	prior, diags := m.typ.ValueFromString(ctx, req.StateValue)
+	resp.Diagnostics.Append(diags...)
+	planned, diags := m.typ.ValueFromString(ctx, req.PlanValue)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	semantic, ok := prior.(basetypes.StringValuableWithSemanticEquals)
+	if !ok {
+		resp.RequiresReplace = true
+		return
+	}
+	equal, diags := semantic.StringSemanticEquals(ctx, planned)
+	resp.Diagnostics.Append(diags...)
+	resp.RequiresReplace = !equal
+}
+
+// jsonEqual reports whether two JSON documents are semantically equal, that is
+// equal after decoding regardless of key order and whitespace.
+func jsonEqual(a, b string) bool {
+	var av, bv interface{}
+	if err := json.Unmarshal([]byte(a), &av); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(b), &bv); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(av, bv)
+}
+
+// SemanticJSON returns a plan modifier that keeps the prior state value when
+// the planned JSON document is semantically equal to it, so formatting and
+// key ordering returned by the API never show up as a diff.
+func SemanticJSON() planmodifier.String {
+	return semanticJSONModifier{}
+}
+
+// semanticJSONModifier implements the plan modifier.
+type semanticJSONModifier struct{}
+
+// Description returns a human-readable description of the plan modifier.
+func (m semanticJSONModifier) Description(_ context.Context) string {
+	return "Formatting and key ordering differences in the JSON document are ignored."
+}
+
+// MarkdownDescription returns a markdown description of the plan modifier.
+func (m semanticJSONModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+// PlanModifyString implements the plan modification logic.
+func (m semanticJSONModifier) PlanModifyString(_ context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() {
+		return
+	}
+	if req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	if jsonEqual(req.PlanValue.ValueString(), req.StateValue.ValueString()) {
+		resp.PlanValue = req.StateValue
+	}
+}
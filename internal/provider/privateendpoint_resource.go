@@ -0,0 +1,358 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &PrivateEndpointResource{}
+	_ resource.ResourceWithConfigure   = &PrivateEndpointResource{}
+	_ resource.ResourceWithModifyPlan  = &PrivateEndpointResource{}
+	_ resource.ResourceWithImportState = &PrivateEndpointResource{}
+)
+
+// NewPrivateEndpointResource is a helper function to simplify the provider implementation.
+func NewPrivateEndpointResource() resource.Resource {
+	return &PrivateEndpointResource{}
+}
+
+// PrivateEndpointResource is the resource implementation.
+type PrivateEndpointResource struct {
+	azure          *azure.Client
+	subscriptionID string
+	audit          *auditLogger
+	defaultTags    map[string]string
+}
+
+type privateEndpointResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	Location          types.String `tfsdk:"location"`
+	SubnetId          types.String `tfsdk:"subnet_id"`
+	WorkspaceId       types.String `tfsdk:"workspace_id"`
+	Subresource       types.String `tfsdk:"subresource"`
+	PrivateDnsZoneIds types.List   `tfsdk:"private_dns_zone_ids"`
+	Tags              types.Map    `tfsdk:"tags"`
+	TagsAll           types.Map    `tfsdk:"tags_all"`
+	PrivateIpAddress  types.String `tfsdk:"private_ip_address"`
+	ConnectionStatus  types.String `tfsdk:"connection_status"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *PrivateEndpointResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.subscriptionID = providerData.SubscriptionID
+	r.audit = providerData.Audit
+	r.defaultTags = providerData.DefaultTags
+}
+
+// Metadata returns the resource type name.
+func (r *PrivateEndpointResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_databricks_private_endpoint"
+}
+
+// Schema defines the schema for the resource.
+func (r *PrivateEndpointResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	useStateForUnknown := []planmodifier.String{
+		stringplanmodifier.UseStateForUnknown(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Creates a private endpoint to an Azure Databricks workspace for front-end (databricks_ui_api) or browser authentication (browser_authentication) private link.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "ARM resource ID of the private endpoint",
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Name of the private endpoint",
+			},
+			"resource_group_name": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Resource group in which the private endpoint is created",
+			},
+			"location": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "Azure region of the private endpoint, the region of the subnet",
+			},
+			"subnet_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the subnet the endpoint gets its private IP from",
+			},
+			"workspace_id": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Description:   "ARM ID of the Databricks workspace",
+			},
+			"subresource": schema.StringAttribute{
+				Required:      true,
+				PlanModifiers: requiresReplace,
+				Validators:    []validator.String{StringOneOf("databricks_ui_api", "browser_authentication")},
+				Description:   "Workspace sub-resource to connect to: databricks_ui_api or browser_authentication",
+			},
+			"private_dns_zone_ids": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Private DNS zones, usually privatelink.azuredatabricks.net, to register the endpoint in",
+			},
+			"tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags of the private endpoint",
+			},
+			"tags_all": tagsAllAttribute(),
+			"private_ip_address": schema.StringAttribute{
+				Computed:      true,
+				PlanModifiers: useStateForUnknown,
+				Description:   "Private IP address of the endpoint",
+			},
+			"connection_status": schema.StringAttribute{
+				Computed:    true,
+				Description: "Approval status of the private link connection",
+			},
+		},
+	}
+}
+
+// ModifyPlan merges the provider default tags into tags_all.
+func (r *PrivateEndpointResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+}
+
+// applyPrivateEndpoint copies the computed attributes of the endpoint to the
+// model.
+func applyPrivateEndpoint(model *privateEndpointResourceModel, endpoint *azure.PrivateEndpoint) {
+	model.Id = types.StringValue(endpoint.ID)
+	model.PrivateIpAddress = types.StringNull()
+	for _, config := range endpoint.Properties.CustomDnsConfigs {
+		if len(config.IPAddresses) > 0 {
+			model.PrivateIpAddress = types.StringValue(config.IPAddresses[0])
+			break
+		}
+	}
+	model.ConnectionStatus = types.StringNull()
+	for _, connection := range endpoint.Properties.PrivateLinkServiceConnections {
+		if state := connection.Properties.PrivateLinkServiceConnectionState; state != nil {
+			model.ConnectionStatus = types.StringValue(state.Status)
+		}
+	}
+}
+
+// put creates or updates the private endpoint and its DNS zone group from the
+// plan.
+func (r *PrivateEndpointResource) put(ctx context.Context, plan *privateEndpointResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	endpoint := azure.PrivateEndpoint{
+		Location: plan.Location.ValueString(),
+		Properties: azure.PrivateEndpointProperties{
+			Subnet: azure.SubResource{ID: plan.SubnetId.ValueString()},
+		},
+	}
+	var connection azure.PrivateLinkServiceConnection
+	connection.Name = plan.Name.ValueString()
+	connection.Properties.PrivateLinkServiceID = plan.WorkspaceId.ValueString()
+	connection.Properties.GroupIDs = []string{plan.Subresource.ValueString()}
+	endpoint.Properties.PrivateLinkServiceConnections = []azure.PrivateLinkServiceConnection{connection}
+
+	var zoneIDs []string
+	diags.Append(plan.TagsAll.ElementsAs(ctx, &endpoint.Tags, false)...)
+	diags.Append(plan.PrivateDnsZoneIds.ElementsAs(ctx, &zoneIDs, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	id := azure.PrivateEndpointID(r.subscriptionID, plan.ResourceGroupName.ValueString(), plan.Name.ValueString())
+
+	ctx = withAuditRequestID(ctx)
+	result, err := r.azure.PutPrivateEndpoint(ctx, id, endpoint)
+	if err == nil && len(zoneIDs) > 0 {
+		err = r.azure.PutPrivateDnsZoneGroup(ctx, id, zoneIDs)
+	}
+	r.audit.Record(ctx, "mrl_databricks_private_endpoint", action, id, err)
+	if err != nil {
+		diags.AddError("Error provisioning private endpoint", "Could not provision private endpoint "+id+": "+err.Error())
+		return diags
+	}
+
+	applyPrivateEndpoint(plan, result)
+	return diags
+}
+
+// Create a new resource.
+func (r *PrivateEndpointResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_private_endpoint.Create")
+	defer span.End()
+
+	var plan privateEndpointResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *PrivateEndpointResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_private_endpoint.Read")
+	defer span.End()
+
+	var state privateEndpointResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoint, err := r.azure.GetPrivateEndpoint(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading private endpoint",
+			"Could not read private endpoint "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	applyPrivateEndpoint(&state, endpoint)
+	if state.Location.IsNull() {
+		// Imported: fill in the arguments the import ID does not carry.
+		state.Location = types.StringValue(endpoint.Location)
+		for _, connection := range endpoint.Properties.PrivateLinkServiceConnections {
+			state.WorkspaceId = types.StringValue(connection.Properties.PrivateLinkServiceID)
+			if len(connection.Properties.GroupIDs) > 0 {
+				state.Subresource = types.StringValue(connection.Properties.GroupIDs[0])
+			}
+		}
+		state.PrivateDnsZoneIds = types.ListValueMust(types.StringType, nil)
+	}
+	state.SubnetId = types.StringValue(endpoint.Properties.Subnet.ID)
+	if !state.PrivateDnsZoneIds.IsNull() {
+		zoneIDs, err := r.azure.GetPrivateDnsZoneIDs(ctx, state.Id.ValueString())
+		if err != nil && !azure.IsNotFound(err) {
+			resp.Diagnostics.AddError(
+				"Error reading private endpoint",
+				"Could not read DNS zone group of "+state.Id.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		zones, diags := types.ListValueFrom(ctx, types.StringType, zoneIDs)
+		resp.Diagnostics.Append(diags...)
+		state.PrivateDnsZoneIds = zones
+	}
+	tags, tagsAll, diags := readTags(ctx, r.defaultTags, state.Tags, endpoint.Tags)
+	resp.Diagnostics.Append(diags...)
+	state.Tags = tags
+	state.TagsAll = tagsAll
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *PrivateEndpointResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_private_endpoint.Update")
+	defer span.End()
+
+	var plan privateEndpointResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.put(ctx, &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *PrivateEndpointResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_databricks_private_endpoint.Delete")
+	defer span.End()
+
+	var state privateEndpointResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeletePrivateEndpoint(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_databricks_private_endpoint", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting private endpoint",
+			"Could not delete private endpoint "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a private endpoint by its ARM ID.
+func (r *PrivateEndpointResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseImportARMID(req.ID, "Microsoft.Network", "privateEndpoints")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_group_name"), id.ResourceGroup)...)
+}
@@ -0,0 +1,705 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProvider drives the provider through the plugin protocol, making the
+// same calls as Terraform does for plan, apply, refresh and import, without
+// needing a Terraform binary. Like Terraform, it fails the test when a plan
+// does not keep the configured values or an apply does not keep the planned
+// ones.
+type testProvider struct {
+	t           *testing.T
+	server      tfprotov6.ProviderServer
+	schemas     map[string]*tfprotov6.Schema
+	dataSchemas map[string]*tfprotov6.Schema
+	// ephemeralSchemas are the schemas of the ephemeral resources.
+	ephemeralSchemas map[string]*tfprotov6.Schema
+	// listSchemas are the schemas of the list blocks of list resources.
+	listSchemas map[string]*tfprotov6.Schema
+	// identities holds the identity Terraform stores along with each state,
+	// by resource type and id, and sends back with the next request.
+	identities map[string]*tfprotov6.ResourceIdentityData
+}
+
+// newTestProvider returns a provider configured with the given provider
+// configuration attributes; the others are null.
+func newTestProvider(t *testing.T, config map[string]interface{}) *testProvider {
+	t.Helper()
+	ctx := context.Background()
+
+	server := providerserver.NewProtocol6(New("test")())()
+	schemaResp, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("GetProviderSchema: %v", err)
+	}
+	p := &testProvider{
+		t:                t,
+		server:           server,
+		schemas:          schemaResp.ResourceSchemas,
+		dataSchemas:      schemaResp.DataSourceSchemas,
+		ephemeralSchemas: schemaResp.EphemeralResourceSchemas,
+		listSchemas:      schemaResp.ListResourceSchemas,
+		identities:       map[string]*tfprotov6.ResourceIdentityData{},
+	}
+	p.checkDiagnostics("GetProviderSchema", schemaResp.Diagnostics)
+
+	providerType := schemaResp.Provider.ValueType()
+	configResp, err := server.ConfigureProvider(ctx, &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.12.0",
+		Config:           p.dynamicValue(providerType, objectValue(providerType, config)),
+	})
+	if err != nil {
+		t.Fatalf("ConfigureProvider: %v", err)
+	}
+	p.checkDiagnostics("ConfigureProvider", configResp.Diagnostics)
+	return p
+}
+
+// objectValue returns an object of type typ with the given attributes, null
+// for the others. Nested objects are given as maps too, and lists of nested
+// objects as slices of maps.
+func objectValue(typ tftypes.Type, attrs map[string]interface{}) tftypes.Value {
+	objectType := typ.(tftypes.Object)
+	values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+	for name, attrType := range objectType.AttributeTypes {
+		switch v := attrs[name].(type) {
+		case nil:
+			values[name] = tftypes.NewValue(attrType, nil)
+		case tftypes.Value:
+			values[name] = v
+		case map[string]interface{}:
+			values[name] = objectValue(attrType, v)
+		case map[string]string:
+			elemType := attrType.(tftypes.Map).ElementType
+			elems := make(map[string]tftypes.Value, len(v))
+			for k, elem := range v {
+				elems[k] = tftypes.NewValue(elemType, elem)
+			}
+			values[name] = tftypes.NewValue(attrType, elems)
+		case []map[string]interface{}:
+			elemType := attrType.(tftypes.List).ElementType
+			elems := make([]tftypes.Value, len(v))
+			for i, elem := range v {
+				elems[i] = objectValue(elemType, elem)
+			}
+			values[name] = tftypes.NewValue(attrType, elems)
+		default:
+			values[name] = tftypes.NewValue(attrType, v)
+		}
+	}
+	return tftypes.NewValue(objectType, values)
+}
+
+func (p *testProvider) dynamicValue(typ tftypes.Type, value tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(typ, value)
+	if err != nil {
+		p.t.Fatalf("encoding %s: %v", value, err)
+	}
+	return &dv
+}
+
+func (p *testProvider) value(typ tftypes.Type, dv *tfprotov6.DynamicValue) tftypes.Value {
+	p.t.Helper()
+	if dv == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	value, err := dv.Unmarshal(typ)
+	if err != nil {
+		p.t.Fatalf("decoding value: %v", err)
+	}
+	return value
+}
+
+// checkDiagnostics fails the test on error diagnostics.
+func (p *testProvider) checkDiagnostics(call string, diags []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	if msg := errorDiagnostics(diags); msg != "" {
+		p.t.Fatalf("%s: %s", call, msg)
+	}
+}
+
+// errorDiagnostics joins the summaries and details of the error diagnostics.
+func errorDiagnostics(diags []*tfprotov6.Diagnostic) string {
+	return joinDiagnostics(diags, tfprotov6.DiagnosticSeverityError)
+}
+
+// warningDiagnostics joins the summaries and details of the warning
+// diagnostics.
+func warningDiagnostics(diags []*tfprotov6.Diagnostic) string {
+	return joinDiagnostics(diags, tfprotov6.DiagnosticSeverityWarning)
+}
+
+func joinDiagnostics(diags []*tfprotov6.Diagnostic, severity tfprotov6.DiagnosticSeverity) string {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity == severity {
+			msgs = append(msgs, d.Summary+": "+d.Detail)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// resourceType returns the object type of the state of a resource type.
+func (p *testProvider) resourceType(typeName string) tftypes.Object {
+	p.t.Helper()
+	s, ok := p.schemas[typeName]
+	if !ok {
+		p.t.Fatalf("no resource type %s", typeName)
+	}
+	return s.ValueType().(tftypes.Object)
+}
+
+// config returns the configuration of a resource with the given attributes.
+func (p *testProvider) config(typeName string, attrs map[string]interface{}) tftypes.Value {
+	return objectValue(p.resourceType(typeName), attrs)
+}
+
+// proposedNewState merges config with prior as Terraform does before
+// planning: computed attributes not set in config keep their prior value.
+func (p *testProvider) proposedNewState(typeName string, prior, config tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	typ := p.resourceType(typeName)
+	if prior.IsNull() || config.IsNull() {
+		return config
+	}
+
+	var priorAttrs, configAttrs map[string]tftypes.Value
+	if err := prior.As(&priorAttrs); err != nil {
+		p.t.Fatal(err)
+	}
+	if err := config.As(&configAttrs); err != nil {
+		p.t.Fatal(err)
+	}
+	// configAttrs shares its values with config, which must not change.
+	proposed := make(map[string]tftypes.Value, len(configAttrs))
+	for name, value := range configAttrs {
+		proposed[name] = value
+	}
+	for _, schemaAttr := range p.schemas[typeName].Block.Attributes {
+		if schemaAttr.Computed && configAttrs[schemaAttr.Name].IsNull() {
+			proposed[schemaAttr.Name] = priorAttrs[schemaAttr.Name]
+		}
+	}
+	return tftypes.NewValue(typ, proposed)
+}
+
+// identityKey returns the key of the identity stored with state, empty when
+// the state has no id.
+func identityKey(typeName string, state tftypes.Value) string {
+	if state.IsNull() {
+		return ""
+	}
+	var attrs map[string]tftypes.Value
+	if err := state.As(&attrs); err != nil {
+		return ""
+	}
+	var id string
+	if v, ok := attrs["id"]; !ok || !v.IsKnown() || v.IsNull() || v.As(&id) != nil {
+		return ""
+	}
+	return typeName + "|" + id
+}
+
+// identity returns the identity stored with state, nil when there is none.
+func (p *testProvider) identity(typeName string, state tftypes.Value) *tfprotov6.ResourceIdentityData {
+	if key := identityKey(typeName, state); key != "" {
+		return p.identities[key]
+	}
+	return nil
+}
+
+// storeIdentity stores identity with state, as Terraform does when it
+// writes the state.
+func (p *testProvider) storeIdentity(typeName string, state tftypes.Value, identity *tfprotov6.ResourceIdentityData) {
+	if key := identityKey(typeName, state); key != "" && identity != nil {
+		p.identities[key] = identity
+	}
+}
+
+// validate validates config and returns the diagnostics. Write-only
+// attributes are allowed, as by Terraform 1.11 and later.
+func (p *testProvider) validate(typeName string, config tftypes.Value) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+	resp, err := p.server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(p.resourceType(typeName), config),
+		ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{
+			WriteOnlyAttributesAllowed: true,
+		},
+	})
+	if err != nil {
+		p.t.Fatalf("ValidateResourceConfig: %v", err)
+	}
+	return resp.Diagnostics
+}
+
+// plan plans the change from prior to config, returning the planned state
+// and the diagnostics. A null config plans the destruction.
+func (p *testProvider) plan(typeName string, prior, config tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	planned, _, diags := p.planReplace(typeName, prior, config)
+	return planned, diags
+}
+
+// planReplace is plan that also returns the attributes whose change requires
+// replacing the resource. As in Terraform, attributes the provider flags but
+// whose planned value is that of the prior state do not replace it.
+func (p *testProvider) planReplace(typeName string, prior, config tftypes.Value) (tftypes.Value, []*tftypes.AttributePath, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	ctx := context.Background()
+	typ := p.resourceType(typeName)
+
+	if !config.IsNull() {
+		if diags := p.validate(typeName, config); errorDiagnostics(diags) != "" {
+			return tftypes.NewValue(typ, nil), nil, diags
+		}
+	}
+
+	resp, err := p.server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       p.dynamicValue(typ, prior),
+		PriorIdentity:    p.identity(typeName, prior),
+		ProposedNewState: p.dynamicValue(typ, p.proposedNewState(typeName, prior, config)),
+		Config:           p.dynamicValue(typ, config),
+	})
+	if err != nil {
+		p.t.Fatalf("PlanResourceChange: %v", err)
+	}
+	planned := p.value(typ, resp.PlannedState)
+	if !config.IsNull() && errorDiagnostics(resp.Diagnostics) == "" {
+		for _, msg := range planErrors(tftypes.NewAttributePath(), p.schemas[typeName].Block.Attributes, p.schemas[typeName].Block.BlockTypes, config, planned) {
+			p.t.Errorf("Provider produced invalid plan for %s: %s", typeName, msg)
+		}
+	}
+	return planned, changedPaths(prior, planned, resp.RequiresReplace), resp.Diagnostics
+}
+
+// changedPaths returns the paths of paths whose value differs between prior
+// and planned, or is unknown in planned. Nothing is replaced on create.
+func changedPaths(prior, planned tftypes.Value, paths []*tftypes.AttributePath) []*tftypes.AttributePath {
+	if prior.IsNull() || planned.IsNull() {
+		return nil
+	}
+	var changed []*tftypes.AttributePath
+	for _, p := range paths {
+		before, _, errBefore := tftypes.WalkAttributePath(prior, p)
+		after, _, errAfter := tftypes.WalkAttributePath(planned, p)
+		beforeValue, okBefore := before.(tftypes.Value)
+		afterValue, okAfter := after.(tftypes.Value)
+		if errBefore != nil || errAfter != nil || !okBefore || !okAfter || !afterValue.IsFullyKnown() || !afterValue.Equal(beforeValue) {
+			changed = append(changed, p)
+		}
+	}
+	return changed
+}
+
+// apply plans and applies the change from prior to config and returns the
+// new state. A null config destroys the resource.
+func (p *testProvider) apply(typeName string, prior, config tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	state, diags := p.applyDiagnostics(typeName, prior, config)
+	p.checkDiagnostics("ApplyResourceChange", diags)
+	return state
+}
+
+// applyDiagnostics is apply for changes that are expected to fail: it
+// returns the new state with the diagnostics of the apply.
+func (p *testProvider) applyDiagnostics(typeName string, prior, config tftypes.Value) (tftypes.Value, []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	typ := p.resourceType(typeName)
+
+	planned, diags := p.plan(typeName, prior, config)
+	p.checkDiagnostics("PlanResourceChange", diags)
+
+	resp, err := p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:        typeName,
+		PriorState:      p.dynamicValue(typ, prior),
+		PlannedState:    p.dynamicValue(typ, planned),
+		PlannedIdentity: p.identity(typeName, prior),
+		Config:          p.dynamicValue(typ, config),
+	})
+	if err != nil {
+		p.t.Fatalf("ApplyResourceChange: %v", err)
+	}
+	state := p.value(typ, resp.NewState)
+	if !config.IsNull() && errorDiagnostics(resp.Diagnostics) == "" {
+		for _, msg := range applyErrors(tftypes.NewAttributePath(), planned, state) {
+			p.t.Errorf("Provider produced inconsistent result after apply of %s: %s", typeName, msg)
+		}
+	}
+	p.storeIdentity(typeName, state, resp.NewIdentity)
+	return state, resp.Diagnostics
+}
+
+// planErrors checks a planned object against its configuration the way
+// Terraform does before accepting a plan: attributes that are not computed,
+// or that are set in the configuration, must be planned as configured, and
+// write-only attributes must be planned null. Computed attributes left out of
+// the configuration may be planned with any value.
+func planErrors(at *tftypes.AttributePath, attrs []*tfprotov6.SchemaAttribute, blocks []*tfprotov6.SchemaNestedBlock, config, planned tftypes.Value) []string {
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+	if !planned.IsKnown() || planned.IsNull() {
+		return []string{at.String() + ": planned " + planned.String() + " for a configured object"}
+	}
+	var configAttrs, plannedAttrs map[string]tftypes.Value
+	if config.As(&configAttrs) != nil || planned.As(&plannedAttrs) != nil {
+		return []string{at.String() + ": not an object"}
+	}
+
+	var errs []string
+	for _, attr := range attrs {
+		attrPath := at.WithAttributeName(attr.Name)
+		c, pl := configAttrs[attr.Name], plannedAttrs[attr.Name]
+		switch {
+		case attr.WriteOnly:
+			if !pl.IsNull() {
+				errs = append(errs, attrPath.String()+": planned "+pl.String()+" for a write-only attribute")
+			}
+		case attr.Computed && c.IsNull():
+		case attr.NestedType != nil && c.IsKnown() && !c.IsNull() && pl.IsKnown() && !pl.IsNull():
+			errs = append(errs, nestedPlanErrors(attrPath, attr.NestedType.Nesting == tfprotov6.SchemaObjectNestingModeSingle,
+				attr.NestedType.Nesting == tfprotov6.SchemaObjectNestingModeSet, attr.NestedType.Attributes, nil, c, pl)...)
+		case !c.Equal(pl):
+			errs = append(errs, attrPath.String()+": planned "+pl.String()+" for a non-computed attribute configured as "+c.String())
+		}
+	}
+	for _, block := range blocks {
+		blockPath := at.WithAttributeName(block.TypeName)
+		c, pl := configAttrs[block.TypeName], plannedAttrs[block.TypeName]
+		single := block.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle || block.Nesting == tfprotov6.SchemaNestedBlockNestingModeGroup
+		errs = append(errs, nestedPlanErrors(blockPath, single, block.Nesting == tfprotov6.SchemaNestedBlockNestingModeSet,
+			block.Block.Attributes, block.Block.BlockTypes, c, pl)...)
+	}
+	return errs
+}
+
+// nestedPlanErrors is planErrors for a nested attribute or block, holding a
+// single object or a collection of them. The elements of sets cannot be
+// matched with those of the configuration, so sets are only checked for
+// size.
+func nestedPlanErrors(at *tftypes.AttributePath, single, set bool, attrs []*tfprotov6.SchemaAttribute, blocks []*tfprotov6.SchemaNestedBlock, config, planned tftypes.Value) []string {
+	if single {
+		return planErrors(at, attrs, blocks, config, planned)
+	}
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+	if !planned.IsKnown() || planned.IsNull() {
+		return []string{at.String() + ": planned " + planned.String() + " for a configured collection"}
+	}
+
+	var errs []string
+	switch {
+	case set:
+		var c, pl []tftypes.Value
+		if config.As(&c) == nil && planned.As(&pl) == nil && len(c) != len(pl) {
+			errs = append(errs, at.String()+": planned "+planned.String()+" for a set configured as "+config.String())
+		}
+	case config.Type().Is(tftypes.Map{}):
+		var c, pl map[string]tftypes.Value
+		if config.As(&c) != nil || planned.As(&pl) != nil || len(c) != len(pl) {
+			return []string{at.String() + ": planned " + planned.String() + " for a map configured as " + config.String()}
+		}
+		for key, elem := range c {
+			errs = append(errs, planErrors(at.WithElementKeyString(key), attrs, blocks, elem, pl[key])...)
+		}
+	default:
+		var c, pl []tftypes.Value
+		if config.As(&c) != nil || planned.As(&pl) != nil || len(c) != len(pl) {
+			return []string{at.String() + ": planned " + planned.String() + " for a list configured as " + config.String()}
+		}
+		for i, elem := range c {
+			errs = append(errs, planErrors(at.WithElementKeyInt(i), attrs, blocks, elem, pl[i])...)
+		}
+	}
+	return errs
+}
+
+// applyErrors checks a new state against its plan the way Terraform does
+// after an apply: the new state must be wholly known, and every value known
+// in the plan must be the same in the new state.
+func applyErrors(at *tftypes.AttributePath, planned, state tftypes.Value) []string {
+	if at.String() == tftypes.NewAttributePath().String() && !state.IsFullyKnown() {
+		return []string{"the new state " + state.String() + " has unknown values"}
+	}
+	if !planned.IsKnown() {
+		return nil
+	}
+	if planned.IsNull() || state.IsNull() {
+		if !planned.Equal(state) {
+			return []string{at.String() + ": was " + planned.String() + ", but now " + state.String()}
+		}
+		return nil
+	}
+
+	var errs []string
+	switch {
+	case planned.Type().Is(tftypes.Object{}):
+		var pl, st map[string]tftypes.Value
+		if planned.As(&pl) != nil || state.As(&st) != nil {
+			return []string{at.String() + ": not an object"}
+		}
+		for name, value := range pl {
+			errs = append(errs, applyErrors(at.WithAttributeName(name), value, st[name])...)
+		}
+	case planned.Type().Is(tftypes.Map{}):
+		var pl, st map[string]tftypes.Value
+		if planned.As(&pl) != nil || state.As(&st) != nil || len(pl) != len(st) {
+			return []string{at.String() + ": was " + planned.String() + ", but now " + state.String()}
+		}
+		for key, value := range pl {
+			errs = append(errs, applyErrors(at.WithElementKeyString(key), value, st[key])...)
+		}
+	case planned.Type().Is(tftypes.List{}) || planned.Type().Is(tftypes.Tuple{}):
+		var pl, st []tftypes.Value
+		if planned.As(&pl) != nil || state.As(&st) != nil || len(pl) != len(st) {
+			return []string{at.String() + ": was " + planned.String() + ", but now " + state.String()}
+		}
+		for i, value := range pl {
+			errs = append(errs, applyErrors(at.WithElementKeyInt(i), value, st[i])...)
+		}
+	case planned.Type().Is(tftypes.Set{}):
+		var pl, st []tftypes.Value
+		if planned.As(&pl) != nil || state.As(&st) != nil || len(pl) != len(st) ||
+			(planned.IsFullyKnown() && !planned.Equal(state)) {
+			return []string{at.String() + ": was " + planned.String() + ", but now " + state.String()}
+		}
+	default:
+		if !planned.Equal(state) {
+			return []string{at.String() + ": was " + planned.String() + ", but now " + state.String()}
+		}
+	}
+	return errs
+}
+
+// refresh reads the resource and returns its refreshed state, null when the
+// resource is gone.
+func (p *testProvider) refresh(typeName string, state tftypes.Value) tftypes.Value {
+	p.t.Helper()
+	typ := p.resourceType(typeName)
+
+	resp, err := p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:        typeName,
+		CurrentState:    p.dynamicValue(typ, state),
+		CurrentIdentity: p.identity(typeName, state),
+	})
+	if err != nil {
+		p.t.Fatalf("ReadResource: %v", err)
+	}
+	p.checkDiagnostics("ReadResource", resp.Diagnostics)
+	newState := p.value(typ, resp.NewState)
+	p.storeIdentity(typeName, newState, resp.NewIdentity)
+	return newState
+}
+
+// importState imports the resource with the import ID and refreshes it, as
+// terraform import does.
+func (p *testProvider) importState(typeName, id string) tftypes.Value {
+	p.t.Helper()
+	typ := p.resourceType(typeName)
+
+	resp, err := p.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: typeName,
+		ID:       id,
+	})
+	if err != nil {
+		p.t.Fatalf("ImportResourceState: %v", err)
+	}
+	p.checkDiagnostics("ImportResourceState", resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		p.t.Fatalf("ImportResourceState returned %d resources, want 1", len(resp.ImportedResources))
+	}
+	imported := resp.ImportedResources[0]
+	state := p.value(typ, imported.State)
+	p.storeIdentity(typeName, state, imported.Identity)
+	return p.refresh(typeName, state)
+}
+
+// readDataSource reads the data source with the given configuration
+// attributes, as Terraform does during plan, and returns its state.
+func (p *testProvider) readDataSource(typeName string, attrs map[string]interface{}) tftypes.Value {
+	p.t.Helper()
+	s, ok := p.dataSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("no data source %s", typeName)
+	}
+	typ := s.ValueType().(tftypes.Object)
+
+	resp, err := p.server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(typ, objectValue(typ, attrs)),
+	})
+	if err != nil {
+		p.t.Fatalf("ReadDataSource: %v", err)
+	}
+	p.checkDiagnostics("ReadDataSource", resp.Diagnostics)
+	return p.value(typ, resp.State)
+}
+
+// list evaluates a list block of the list resource with the given
+// configuration attributes, as terraform query does, and returns the display
+// name and resource state of each result, storing their identities.
+func (p *testProvider) list(typeName string, attrs map[string]interface{}) map[string]tftypes.Value {
+	p.t.Helper()
+	s, ok := p.listSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("no list resource %s", typeName)
+	}
+	configType := s.ValueType().(tftypes.Object)
+	typ := p.resourceType(typeName)
+
+	server, ok := p.server.(tfprotov6.ProviderServerWithListResource)
+	if !ok {
+		p.t.Fatal("the provider server does not serve list resources")
+	}
+	stream, err := server.ListResource(context.Background(), &tfprotov6.ListResourceRequest{
+		TypeName:        typeName,
+		Config:          p.dynamicValue(configType, objectValue(configType, attrs)),
+		IncludeResource: true,
+	})
+	if err != nil {
+		p.t.Fatalf("ListResource: %v", err)
+	}
+	results := map[string]tftypes.Value{}
+	for result := range stream.Results {
+		p.checkDiagnostics("ListResource", result.Diagnostics)
+		state := p.value(typ, result.Resource)
+		p.storeIdentity(typeName, state, result.Identity)
+		results[result.DisplayName] = state
+	}
+	return results
+}
+
+// openEphemeral opens the ephemeral resource with the given configuration
+// attributes and returns its result and the private data that closeEphemeral
+// takes.
+func (p *testProvider) openEphemeral(typeName string, attrs map[string]interface{}) (tftypes.Value, []byte) {
+	p.t.Helper()
+	s, ok := p.ephemeralSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("no ephemeral resource %s", typeName)
+	}
+	typ := s.ValueType().(tftypes.Object)
+
+	resp, err := p.server.OpenEphemeralResource(context.Background(), &tfprotov6.OpenEphemeralResourceRequest{
+		TypeName: typeName,
+		Config:   p.dynamicValue(typ, objectValue(typ, attrs)),
+	})
+	if err != nil {
+		p.t.Fatalf("OpenEphemeralResource: %v", err)
+	}
+	p.checkDiagnostics("OpenEphemeralResource", resp.Diagnostics)
+	return p.value(typ, resp.Result), resp.Private
+}
+
+// closeEphemeral closes the ephemeral resource opened with the private data,
+// as Terraform does at the end of the run.
+func (p *testProvider) closeEphemeral(typeName string, private []byte) {
+	p.t.Helper()
+	resp, err := p.server.CloseEphemeralResource(context.Background(), &tfprotov6.CloseEphemeralResourceRequest{
+		TypeName: typeName,
+		Private:  private,
+	})
+	if err != nil {
+		p.t.Fatalf("CloseEphemeralResource: %v", err)
+	}
+	p.checkDiagnostics("CloseEphemeralResource", resp.Diagnostics)
+}
+
+// upgradeState upgrades the JSON state of the given schema version to the
+// current schema, as Terraform does before reading a state written by an
+// older provider release.
+func (p *testProvider) upgradeState(typeName string, version int64, state string) tftypes.Value {
+	p.t.Helper()
+	typ := p.resourceType(typeName)
+
+	resp, err := p.server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	if err != nil {
+		p.t.Fatalf("UpgradeResourceState: %v", err)
+	}
+	p.checkDiagnostics("UpgradeResourceState", resp.Diagnostics)
+	return p.value(typ, resp.UpgradedState)
+}
+
+// stateAttr returns the top-level attribute name of the object value.
+func stateAttr(t *testing.T, value tftypes.Value, name string) tftypes.Value {
+	t.Helper()
+	var attrs map[string]tftypes.Value
+	if err := value.As(&attrs); err != nil {
+		t.Fatal(err)
+	}
+	v, ok := attrs[name]
+	if !ok {
+		t.Fatalf("no attribute %s", name)
+	}
+	return v
+}
+
+// stringAttr returns the string attribute name of the object value.
+func stringAttr(t *testing.T, value tftypes.Value, name string) string {
+	t.Helper()
+	var s string
+	if err := stateAttr(t, value, name).As(&s); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return s
+}
+
+// int64Attr returns the number attribute name of the object value.
+func int64Attr(t *testing.T, value tftypes.Value, name string) int64 {
+	t.Helper()
+	var n big.Float
+	if err := stateAttr(t, value, name).As(&n); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	i, _ := n.Int64()
+	return i
+}
+
+// boolAttr returns the bool attribute name of the object value.
+func boolAttr(t *testing.T, value tftypes.Value, name string) bool {
+	t.Helper()
+	var b bool
+	if err := stateAttr(t, value, name).As(&b); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return b
+}
+
+// mapAttr returns the string map attribute name of the object value, nil
+// when it is null.
+func mapAttr(t *testing.T, value tftypes.Value, name string) map[string]string {
+	t.Helper()
+	var elems map[string]tftypes.Value
+	if err := stateAttr(t, value, name).As(&elems); err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	if elems == nil {
+		return nil
+	}
+	m := make(map[string]string, len(elems))
+	for k, elem := range elems {
+		var s string
+		if err := elem.As(&s); err != nil {
+			t.Fatalf("%s[%q]: %v", name, k, err)
+		}
+		m[k] = s
+	}
+	return m
+}
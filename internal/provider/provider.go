@@ -2,22 +2,42 @@ package provider
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/databricks"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/list"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &mrlProvider{}
+	_ provider.Provider                       = &mrlProvider{}
+	_ provider.ProviderWithFunctions          = &mrlProvider{}
+	_ provider.ProviderWithEphemeralResources = &mrlProvider{}
+	_ provider.ProviderWithListResources      = &mrlProvider{}
 )
 
+// errNoAzureCredentials is returned by Azure API calls when the provider has
+// no Azure credentials configured.
+var errNoAzureCredentials = errors.New("the provider has no Azure credentials: set subscriptionid and auth_method, or clientid, clientsecret and tenantid")
+
 // New is a helper function to simplify provider server and testing implementation.
 func New(version string) func() provider.Provider {
 	return func() provider.Provider {
@@ -39,6 +59,94 @@ type mrlProviderModel struct {
 	ClientSecret   types.String `tfsdk:"clientsecret"`
 	SubscriptionId types.String `tfsdk:"subscriptionid"`
 	TenantId       types.String `tfsdk:"tenantid"`
+
+	AuthMethod        types.String `tfsdk:"auth_method"`
+	OIDCTokenFilePath types.String `tfsdk:"oidc_token_file_path"`
+	Environment       types.String `tfsdk:"environment"`
+
+	MaxIdleConns        types.Int64  `tfsdk:"max_idle_conns"`
+	MaxIdleConnsPerHost types.Int64  `tfsdk:"max_idle_conns_per_host"`
+	IdleConnTimeout     types.String `tfsdk:"idle_conn_timeout"`
+	TLSHandshakeTimeout types.String `tfsdk:"tls_handshake_timeout"`
+	EnableHTTP2         types.Bool   `tfsdk:"enable_http2"`
+	RequestTimeout      types.String `tfsdk:"request_timeout"`
+	MaxRetries          types.Int64  `tfsdk:"max_retries"`
+	MaxParallelUploads  types.Int64  `tfsdk:"max_parallel_uploads"`
+	ConsistencyTimeout  types.String `tfsdk:"eventual_consistency_timeout"`
+
+	HTTPProxy             types.String `tfsdk:"http_proxy"`
+	HTTPSProxy            types.String `tfsdk:"https_proxy"`
+	CustomCAPem           types.String `tfsdk:"custom_ca_pem"`
+	CACertFile            types.String `tfsdk:"ca_cert_file"`
+	TLSInsecureSkipVerify types.Bool   `tfsdk:"tls_insecure_skip_verify"`
+
+	AuditLogPath types.String `tfsdk:"audit_log_path"`
+
+	APIMetrics              types.Bool   `tfsdk:"api_metrics"`
+	APIMetricsStatsdAddress types.String `tfsdk:"api_metrics_statsd_address"`
+
+	DefaultTags types.Map `tfsdk:"default_tags"`
+
+	DatabricksClientId     types.String `tfsdk:"databricks_client_id"`
+	DatabricksClientSecret types.String `tfsdk:"databricks_client_secret"`
+
+	Databricks *mrlProviderDatabricksModel `tfsdk:"databricks"`
+}
+
+// mrlProviderDatabricksModel maps the databricks block of the provider.
+type mrlProviderDatabricksModel struct {
+	Host              types.String `tfsdk:"host"`
+	Token             types.String `tfsdk:"token"`
+	AzureClientId     types.String `tfsdk:"azure_client_id"`
+	AzureClientSecret types.String `tfsdk:"azure_client_secret"`
+	AzureTenantId     types.String `tfsdk:"azure_tenant_id"`
+
+	DbfsPathPrefixTemplate types.String `tfsdk:"dbfs_path_prefix_template"`
+	DbfsPathVariables      types.Map    `tfsdk:"dbfs_path_variables"`
+
+	Workspaces []mrlProviderDatabricksWorkspaceModel `tfsdk:"workspace"`
+}
+
+// mrlProviderDatabricksWorkspaceModel maps a workspace block of the provider
+// databricks block.
+type mrlProviderDatabricksWorkspaceModel struct {
+	Host         types.String `tfsdk:"host"`
+	Token        types.String `tfsdk:"token"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+// ClientBundle holds the clients built from the provider configuration. It is
+// handed to data sources and resources through their Configure methods, so
+// that they share the credential, HTTP plumbing and logging of the provider
+// instead of building their own.
+type ClientBundle struct {
+	// Credential authenticates to Azure. It is nil when no Azure
+	// credentials are configured.
+	Credential     azcore.TokenCredential
+	SubscriptionID string
+	// ClientID, TenantID and ClientSecret identify the Azure service
+	// principal of the provider, for resources that hand its credentials to
+	// Databricks, such as DBFS mounts. ClientSecret is empty when the
+	// provider authenticates without a client secret.
+	ClientID     string
+	TenantID     string
+	ClientSecret string
+	// Environment is the Azure cloud of the provider.
+	Environment azure.Environment
+	// HTTPClient sends the Databricks and Azure API calls, with the retries,
+	// proxy, tracing and OAuth of the provider.
+	HTTPClient *http.Client
+	Azure      *azure.Client
+	// Audit records the changes made by resources, when audit_log_path is
+	// set.
+	Audit *auditLogger
+	// Databricks is the workspace of the provider databricks block.
+	Databricks databricksWorkspace
+	// DefaultTags are merged into the tags of every taggable Azure resource.
+	DefaultTags map[string]string
+	// DbfsTargets are the DBFS files planned by DBFS file resources.
+	DbfsTargets *dbfsTargets
 }
 
 // Metadata returns the provider type name.
@@ -53,22 +161,193 @@ func (p *mrlProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 		Attributes: map[string]schema.Attribute{
 			"clientid": schema.StringAttribute{
 				Optional:    true,
+				Validators:  []validator.String{UUID()},
 				Description: "Provide the clientid of the spn which has permission to do the necessary resource creation",
 			},
 			"clientsecret": schema.StringAttribute{
 				Optional:    true,
+				Sensitive:   true,
 				Description: "Provide the clientsecret of the spn which has permission to do the necessary resource creation",
 			},
 			"subscriptionid": schema.StringAttribute{
 				Optional:    true,
-				Sensitive:   true,
+				Validators:  []validator.String{UUID()},
 				Description: "Provide the subscriptionid id of the subscription in which the resources needs to be created",
 			},
 			"tenantid": schema.StringAttribute{
 				Optional:    true,
-				Sensitive:   true,
+				Validators:  []validator.String{UUID()},
 				Description: "Provide the tenant id of the tenant in which the resources needs to be created",
 			},
+			"auth_method": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{StringOneOf(authMethodClientSecret, authMethodManagedIdentity, authMethodAzureCLI, authMethodWorkloadIdentity, authMethodDefault)},
+				Description: "How the provider authenticates to Azure: client_secret with clientid, clientsecret and tenantid; managed_identity with the identity of the host, user-assigned when clientid is set; azure_cli with the signed in Azure CLI; workload_identity with a federated OIDC token, such as on Kubernetes or GitHub Actions, of the clientid application in tenantid; default tries the environment, workload identity, managed identity and the Azure CLI in turn. Defaults to client_secret when clientsecret is set, and to default otherwise",
+			},
+			"oidc_token_file_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "File holding the federated OIDC token of the workload_identity auth method. Defaults to the AZURE_FEDERATED_TOKEN_FILE environment variable",
+			},
+			"environment": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{StringOneOf(azure.PublicCloud.Name, azure.USGovernmentCloud.Name, azure.ChinaCloud.Name)},
+				Description: "Azure cloud of the Microsoft Entra ID tenant, the Azure resources and the Azure Databricks workspaces: public, usgovernment or china. It sets the login, Azure Resource Manager, Key Vault and storage endpoints. The azure_cli auth method uses the cloud of the Azure CLI instead. Defaults to public",
+			},
+			"max_idle_conns": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of idle keep-alive connections kept across all hosts. Defaults to 100",
+			},
+			"max_idle_conns_per_host": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of idle keep-alive connections kept per host. Defaults to 10",
+			},
+			"idle_conn_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long an idle connection is kept in the pool, as a duration such as `90s`. Defaults to 90s",
+			},
+			"tls_handshake_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum time to wait for a TLS handshake, as a duration such as `10s`. Defaults to 10s",
+			},
+			"enable_http2": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Negotiate HTTP/2 with the Databricks API when the server supports it. Defaults to false",
+			},
+			"request_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "Maximum time a Databricks or Azure API call may take, retries included, as a duration such as `5m`. Defaults to 5m",
+			},
+			"max_retries": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(0, 20)},
+				Description: "How often an API call that was rate limited (429) or failed with a server error (5xx) is retried, with exponential backoff and jitter. Other server errors than 503 are only retried for idempotent requests. 0 disables retries. Defaults to 5",
+			},
+			"max_parallel_uploads": schema.Int64Attribute{
+				Optional:    true,
+				Validators:  []validator.Int64{Int64Between(1, 64)},
+				Description: "Maximum number of DBFS, volume and workspace file uploads in flight across all resources of the provider. The parallelism of a multi-file resource only bounds its own uploads within this limit. Defaults to 8",
+			},
+			"eventual_consistency_timeout": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long a DBFS upload waits for DBFS to report the uploaded file with its full size, which can lag a second or two behind the upload, as a duration such as `30s`. `0s` disables the wait. Defaults to 30s",
+			},
+			"http_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy for plain HTTP requests, such as `http://proxy.example.com:8080`. Defaults to the HTTP_PROXY and NO_PROXY environment variables",
+			},
+			"https_proxy": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the proxy for HTTPS requests, which include all Databricks and Azure API calls. Defaults to the HTTPS_PROXY and NO_PROXY environment variables",
+			},
+			"custom_ca_pem": schema.StringAttribute{
+				Optional:    true,
+				Description: "PEM encoded certificates of additional certificate authorities to trust, such as that of a TLS intercepting proxy. The system certificate authorities stay trusted",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local file holding PEM encoded certificates of additional certificate authorities to trust. Can be combined with custom_ca_pem",
+			},
+			"tls_insecure_skip_verify": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Skip the verification of server certificates. This allows man-in-the-middle attacks, so only use it for testing; prefer custom_ca_pem or ca_cert_file. Defaults to false",
+			},
+			"audit_log_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Local file to which a JSON line is appended for every create, update and delete performed by the provider",
+			},
+			"api_metrics": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Record the API calls of every operation by endpoint, with their count, retries, errors and durations. They are logged at the end of each operation, visible with TF_LOG=INFO, and added as events to its span when tracing is enabled. Defaults to false",
+			},
+			"api_metrics_statsd_address": schema.StringAttribute{
+				Optional:    true,
+				Description: "host:port of a statsd server to which the API calls recorded by api_metrics are also sent over UDP, as counters and timers named mrl.api.<method>_<endpoint>.calls, .retries, .errors and .duration",
+			},
+			"databricks_client_id": schema.StringAttribute{
+				Optional:    true,
+				Validators:  []validator.String{UUID()},
+				Description: "Client ID of a Databricks service principal. Databricks resources and data sources without a token authenticate as this principal through OAuth machine-to-machine. Without it, they authenticate to Azure Databricks with a Microsoft Entra ID token of the clientid service principal",
+			},
+			"databricks_client_secret": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "OAuth secret of the Databricks service principal set in databricks_client_id",
+			},
+			"default_tags": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Tags applied to every taggable Azure resource and added to the custom tags of Databricks clusters, job clusters and instance pools, such as cost attribution tags. Tags set on a resource override defaults with the same key",
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"databricks": schema.SingleNestedBlock{
+				Description: "Default Databricks workspace of the Databricks resources and data sources that do not set workspace_url or token",
+				Attributes: map[string]schema.Attribute{
+					"host": schema.StringAttribute{
+						Optional:    true,
+						Validators:  []validator.String{WorkspaceURL()},
+						Description: "URL or host name of the Databricks workspace, on Azure, AWS or GCP",
+					},
+					"token": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Access token for the workspace. When unset, the provider authenticates with databricks_client_id and databricks_client_secret, or else with a Microsoft Entra ID token. Unlike the token attribute of resources and data sources, it is never stored in the Terraform state, and it can be set from an ephemeral value",
+					},
+					"azure_client_id": schema.StringAttribute{
+						Optional:    true,
+						Validators:  []validator.String{UUID()},
+						Description: "Client ID of the Microsoft Entra ID service principal whose tokens authenticate to Azure Databricks. Defaults to clientid",
+					},
+					"azure_client_secret": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "Client secret of the service principal set in azure_client_id",
+					},
+					"azure_tenant_id": schema.StringAttribute{
+						Optional:    true,
+						Validators:  []validator.String{UUID()},
+						Description: "Tenant of the service principal set in azure_client_id. Defaults to tenantid",
+					},
+					"dbfs_path_prefix_template": schema.StringAttribute{
+						Optional:    true,
+						Description: "Template of the DBFS path of mrl_databricks_dbfs_file resources that set neither dbfs_path nor dbfs_path_prefix_template, e.g. /FileStore/jars/{env}/{version}. {filename} is the name of the local file and {md5} its md5 hash; other placeholders take their values from dbfs_path_variables. Without {filename}, the template is a directory the file is uploaded to",
+					},
+					"dbfs_path_variables": schema.MapAttribute{
+						ElementType: types.StringType,
+						Optional:    true,
+						Description: "Values of the placeholders of DBFS path templates, e.g. { env = \"prod\", version = \"1.4.0\" } for {env} and {version}",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"workspace": schema.ListNestedBlock{
+						Description: "Credentials of another workspace, used by the resources and data sources whose workspace_url or workspace block names it. Like token, they are never stored in the Terraform state",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"host": schema.StringAttribute{
+									Required:    true,
+									Validators:  []validator.String{WorkspaceURL()},
+									Description: "URL or host name of the workspace",
+								},
+								"token": schema.StringAttribute{
+									Optional:    true,
+									Sensitive:   true,
+									Description: "Access token for the workspace. Conflicts with client_id",
+								},
+								"client_id": schema.StringAttribute{
+									Optional:    true,
+									Validators:  []validator.String{UUID()},
+									Description: "Client ID of a Databricks service principal to authenticate as through OAuth machine-to-machine. Its tokens are cached per workspace and shared by the workspaces with the same client_id and client_secret. Without token and client_id, the workspace is authenticated with databricks_client_id and databricks_client_secret, or else with Microsoft Entra ID",
+								},
+								"client_secret": schema.StringAttribute{
+									Optional:    true,
+									Sensitive:   true,
+									Description: "OAuth secret of the service principal set in client_id",
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -138,71 +417,376 @@ func (p *mrlProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	subscriptionid := config.SubscriptionId.ValueString()
 	tenantid := config.TenantId.ValueString()
 
-	fmt.Println(subscriptionid)
-
 	// // If any of the expected configurations are missing, return
 	// // errors with provider-specific guidance.
 
-	if clientid == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("clientid"),
-			"Missing clientid",
-			"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API host. "+
-				"Set the host value in the configuration or use the HASHICUPS_HOST environment variable. "+
-				"If either is already set, ensure the value is not empty.",
-		)
+	// The Azure credentials are only needed by the Azure resources. Without
+	// any of them the provider works against Databricks workspaces alone, on
+	// any cloud.
+	authMethod := config.AuthMethod.ValueString()
+	if authMethod == "" && clientsecret != "" {
+		authMethod = authMethodClientSecret
+	} else if authMethod == "" && (clientid != "" || subscriptionid != "" || tenantid != "") {
+		authMethod = authMethodDefault
 	}
+	azureConfigured := authMethod != ""
+	if azureConfigured {
+		if clientid == "" && authMethod == authMethodClientSecret {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clientid"),
+				"Missing clientid",
+				"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API host. "+
+					"Set the host value in the configuration or use the HASHICUPS_HOST environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
 
-	if clientsecret == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("clientsecret"),
-			"Missing clientSecret",
-			"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API username. "+
-				"Set the username value in the configuration or use the HASHICUPS_USERNAME environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+		if clientsecret == "" && authMethod == authMethodClientSecret {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("clientsecret"),
+				"Missing clientSecret",
+				"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API username. "+
+					"Set the username value in the configuration or use the HASHICUPS_USERNAME environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if subscriptionid == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("subscriptionid"),
+				"Missing subscriptionid",
+				"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API password. "+
+					"Set the password value in the configuration or use the HASHICUPS_PASSWORD environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+
+		if tenantid == "" && authMethod == authMethodClientSecret {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tenantid"),
+				"Missing tenantid",
+				"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API password. "+
+					"Set the password value in the configuration or use the HASHICUPS_PASSWORD environment variable. "+
+					"If either is already set, ensure the value is not empty.",
+			)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	transportConfig := databricks.TransportConfig{
+		MaxIdleConns:        int(config.MaxIdleConns.ValueInt64()),
+		MaxIdleConnsPerHost: int(config.MaxIdleConnsPerHost.ValueInt64()),
+		EnableHTTP2:         config.EnableHTTP2.ValueBool(),
+	}
+
+	if !config.IdleConnTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.IdleConnTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("idle_conn_timeout"),
+				"Invalid idle_conn_timeout",
+				"The value must be a duration such as \"90s\": "+err.Error(),
+			)
+		}
+		transportConfig.IdleConnTimeout = timeout
+	}
+
+	if !config.TLSHandshakeTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.TLSHandshakeTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls_handshake_timeout"),
+				"Invalid tls_handshake_timeout",
+				"The value must be a duration such as \"10s\": "+err.Error(),
+			)
+		}
+		transportConfig.TLSHandshakeTimeout = timeout
+	}
+
+	if !config.RequestTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request_timeout",
+				"The value must be a duration such as \"5m\": "+err.Error(),
+			)
+		}
+		transportConfig.RequestTimeout = timeout
+	}
+
+	transportConfig.MaxRetries = databricks.DefaultMaxRetries
+	if !config.MaxRetries.IsNull() {
+		transportConfig.MaxRetries = int(config.MaxRetries.ValueInt64())
+	}
+
+	if config.APIMetrics.ValueBool() {
+		metrics, err := tracing.NewMetrics(config.APIMetricsStatsdAddress.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_metrics_statsd_address"),
+				"Invalid api_metrics_statsd_address",
+				"The value must be the host:port of a statsd server: "+err.Error(),
+			)
+		}
+		transportConfig.Metrics = metrics
+	} else if !config.APIMetricsStatsdAddress.IsNull() {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("api_metrics_statsd_address"),
+			"API metrics disabled",
+			"api_metrics_statsd_address is ignored as api_metrics is not set to true.",
 		)
 	}
 
-	if subscriptionid == "" {
-		resp.Diagnostics.AddAttributeError(
-			path.Root("subscriptionid"),
-			"Missing subscriptionid",
-			"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API password. "+
-				"Set the password value in the configuration or use the HASHICUPS_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+	for _, proxy := range []struct {
+		name   string
+		value  types.String
+		target **url.URL
+	}{
+		{"http_proxy", config.HTTPProxy, &transportConfig.HTTPProxy},
+		{"https_proxy", config.HTTPSProxy, &transportConfig.HTTPSProxy},
+	} {
+		if proxy.value.IsNull() {
+			continue
+		}
+		proxyURL, err := url.Parse(proxy.value.ValueString())
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(proxy.name),
+				"Invalid "+proxy.name,
+				"The value must be a URL such as \"http://proxy.example.com:8080\".",
+			)
+			continue
+		}
+		*proxy.target = proxyURL
+	}
+
+	var pems [][]byte
+	if !config.CustomCAPem.IsNull() {
+		pems = append(pems, []byte(config.CustomCAPem.ValueString()))
+	}
+	if !config.CACertFile.IsNull() {
+		pem, err := os.ReadFile(config.CACertFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Invalid ca_cert_file",
+				"Could not read the certificate file: "+err.Error(),
+			)
+		}
+		pems = append(pems, pem)
+	}
+	if len(pems) > 0 {
+		pool, err := databricks.CertPool(pems...)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid custom certificate authority",
+				"custom_ca_pem and ca_cert_file must hold PEM encoded certificates: "+err.Error(),
+			)
+		}
+		transportConfig.RootCAs = pool
+	}
+
+	transportConfig.InsecureSkipVerify = config.TLSInsecureSkipVerify.ValueBool()
+	if transportConfig.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("tls_insecure_skip_verify"),
+			"Server certificates are not verified",
+			"tls_insecure_skip_verify is set, so API calls are open to man-in-the-middle attacks. Trust the certificate authority of the proxy with custom_ca_pem or ca_cert_file instead.",
 		)
 	}
 
-	if tenantid == "" {
+	environment := azure.PublicCloud
+	if !config.Environment.IsNull() {
+		environment, _ = azure.EnvironmentByName(config.Environment.ValueString())
+	}
+
+	// Token requests of the Azure credentials go through the same proxy
+	// and certificate authorities, without the retries of the API client.
+	credentialTransport := &http.Client{Transport: databricks.NewTransport(transportConfig)}
+
+	// Create a new HashiCups client using the configuration values
+	var credential azcore.TokenCredential
+	if azureConfigured {
+		var err error
+		credential, err = newAzureCredential(azureAuthConfig{
+			method:        authMethod,
+			tenantID:      tenantid,
+			clientID:      clientid,
+			clientSecret:  clientsecret,
+			tokenFilePath: config.OIDCTokenFilePath.ValueString(),
+			transport:     credentialTransport,
+			cloud:         environment.Cloud,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Create Credentials",
+				"Could not create the "+authMethod+" Azure credential: "+err.Error(),
+			)
+			return
+		}
+	}
+
+	if config.DatabricksClientId.IsNull() != config.DatabricksClientSecret.IsNull() {
 		resp.Diagnostics.AddAttributeError(
-			path.Root("tenantid"),
-			"Missing tenantid",
-			"The provider cannot create the HashiCups API client as there is a missing or empty value for the HashiCups API password. "+
-				"Set the password value in the configuration or use the HASHICUPS_PASSWORD environment variable. "+
-				"If either is already set, ensure the value is not empty.",
+			path.Root("databricks_client_secret"),
+			"Incomplete Databricks OAuth configuration",
+			"databricks_client_id and databricks_client_secret must be set together.",
 		)
 	}
 
+	var workspace databricksWorkspace
+	var databricksTenantId string
+	if config.Databricks != nil {
+		workspace = databricksWorkspace{
+			host:  config.Databricks.Host.ValueString(),
+			token: config.Databricks.Token.ValueString(),
+		}
+
+		workspace.dbfsPathTemplate = config.Databricks.DbfsPathPrefixTemplate.ValueString()
+		resp.Diagnostics.Append(config.Databricks.DbfsPathVariables.ElementsAs(ctx, &workspace.dbfsPathVariables, false)...)
+
+		workspace.credentials = map[string]databricksCredentials{}
+		for i, block := range config.Databricks.Workspaces {
+			blockPath := path.Root("databricks").AtName("workspace").AtListIndex(i)
+			if !block.Token.IsNull() && !block.ClientId.IsNull() {
+				resp.Diagnostics.AddAttributeError(blockPath.AtName("token"), "Conflicting workspace credentials",
+					"Set either token or client_id and client_secret in the workspace block, not both.")
+			}
+			if block.ClientId.IsNull() != block.ClientSecret.IsNull() {
+				resp.Diagnostics.AddAttributeError(blockPath.AtName("client_id"), "Incomplete workspace OAuth configuration",
+					"client_id and client_secret must be set together in the workspace block.")
+			}
+			key := workspaceKey(block.Host.ValueString())
+			if _, ok := workspace.credentials[key]; ok {
+				resp.Diagnostics.AddAttributeError(blockPath.AtName("host"), "Duplicate workspace",
+					"Another workspace block of the databricks block has the host "+block.Host.ValueString()+".")
+			}
+			workspace.credentials[key] = databricksCredentials{
+				token:        block.Token.ValueString(),
+				clientID:     block.ClientId.ValueString(),
+				clientSecret: block.ClientSecret.ValueString(),
+			}
+		}
+
+		databricksTenantId = config.Databricks.AzureTenantId.ValueString()
+		if databricksTenantId == "" {
+			databricksTenantId = tenantid
+		}
+		if !config.Databricks.AzureClientId.IsNull() {
+			if config.Databricks.AzureClientSecret.IsNull() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("databricks").AtName("azure_client_secret"),
+					"Incomplete Databricks Entra ID configuration",
+					"azure_client_id and azure_client_secret must be set together.",
+				)
+			}
+			if databricksTenantId == "" {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("databricks").AtName("azure_tenant_id"),
+					"Incomplete Databricks Entra ID configuration",
+					"Set azure_tenant_id, or tenantid of the provider, with azure_client_id.",
+				)
+			}
+		}
+	}
+
+	workspace.uploads = databricks.NewLimiter(int(config.MaxParallelUploads.ValueInt64()))
+	workspace.consistencyTimeout = databricks.DefaultConsistencyTimeout
+	if !config.ConsistencyTimeout.IsNull() {
+		timeout, err := time.ParseDuration(config.ConsistencyTimeout.ValueString())
+		if err != nil || timeout < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("eventual_consistency_timeout"),
+				"Invalid eventual_consistency_timeout",
+				"The value must be a duration such as \"30s\", or \"0s\" to not wait.",
+			)
+		}
+		workspace.consistencyTimeout = timeout
+	}
+
+	var defaultTags map[string]string
+	resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// Create a new HashiCups client using the configuration values
-	credential, err := azidentity.NewClientSecretCredential(tenantid, clientid, clientsecret, nil)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"Unable to Create Credentials",
-			"An unexpected error occurred when creating the HashiCups API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
-				"HashiCups Client Error: "+err.Error(),
-		)
-		return
+	azureToken := func(ctx context.Context, scope string) (string, error) {
+		if credential == nil {
+			return "", errNoAzureCredentials
+		}
+		token, err := credential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+		return token.Token, err
 	}
 
-	// Make the HashiCups client available during DataSource and Resource
-	// type Configure methods.
-	resp.DataSourceData = credential
-	resp.ResourceData = credential
+	httpClient := databricks.NewHTTPClient(transportConfig)
+	oauth := &databricks.OAuthTransport{
+		Base:         httpClient.Transport,
+		ClientID:     config.DatabricksClientId.ValueString(),
+		ClientSecret: config.DatabricksClientSecret.ValueString(),
+	}
+	if config.Databricks != nil && !config.Databricks.AzureClientId.IsNull() {
+		databricksCredential, err := azidentity.NewClientSecretCredential(databricksTenantId, config.Databricks.AzureClientId.ValueString(), config.Databricks.AzureClientSecret.ValueString(), &azidentity.ClientSecretCredentialOptions{
+			ClientOptions: azcore.ClientOptions{Transport: credentialTransport, Cloud: environment.Cloud},
+		})
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("databricks").AtName("azure_client_id"),
+				"Unable to Create Databricks Credentials",
+				"Could not create the Microsoft Entra ID credential of the databricks block: "+err.Error(),
+			)
+			return
+		}
+		oauth.AzureToken = func(ctx context.Context) (string, error) {
+			token, err := databricksCredential.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{databricks.AzureDatabricksScope}})
+			return token.Token, err
+		}
+	} else if credential != nil {
+		// Workspaces on Azure accept Microsoft Entra ID tokens of the
+		// provider service principal, so no personal access token is needed.
+		oauth.AzureToken = func(ctx context.Context) (string, error) {
+			return azureToken(ctx, databricks.AzureDatabricksScope)
+		}
+	}
+	httpClient.Transport = tracing.Transport(&auditTransport{base: oauth})
+	workspace.clients = &databricksClients{
+		newClient: func(clientID, clientSecret string) *http.Client {
+			return &http.Client{
+				Transport: tracing.Transport(&auditTransport{base: &databricks.OAuthTransport{
+					Base:         oauth.Base,
+					ClientID:     clientID,
+					ClientSecret: clientSecret,
+				}}),
+				Timeout: httpClient.Timeout,
+			}
+		},
+	}
+
+	providerData := &ClientBundle{
+		Credential:     credential,
+		SubscriptionID: subscriptionid,
+		ClientID:       clientid,
+		TenantID:       tenantid,
+		ClientSecret:   clientsecret,
+		Environment:    environment,
+		HTTPClient:     httpClient,
+		Azure:          azure.NewClient(httpClient, azureToken).WithEnvironment(environment),
+		Audit:          newAuditLogger(config.AuditLogPath.ValueString()),
+		Databricks:     workspace,
+		DefaultTags:    defaultTags,
+		DbfsTargets:    newDbfsTargets(),
+	}
+
+	// Make the credential and shared HTTP client available during DataSource,
+	// Resource, EphemeralResource and ListResource type Configure methods.
+	resp.DataSourceData = providerData
+	resp.ResourceData = providerData
+	resp.EphemeralResourceData = providerData
+	resp.ListResourceData = providerData
 }
 
 // DataSources defines the data sources implemented in the provider.
@@ -210,6 +794,25 @@ func (p *mrlProvider) Configure(ctx context.Context, req provider.ConfigureReque
 func (p *mrlProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewDatabricksDbfs,
+		NewDatabricksDbfsFilesDataSource,
+		NewKeyVaultSecretDataSource,
+		NewDatabricksWorkspaceDataSource,
+		NewDatabricksSqlQueryDataSource,
+		NewDatabricksWorkspaceExportDataSource,
+		NewDatabricksCurrentMetastoreDataSource,
+		NewDatabricksEffectiveGrantsDataSource,
+		NewDatabricksWorkspaceConfDataSource,
+		NewDatabricksDbfsUsageDataSource,
+		NewDatabricksJobRunOutputDataSource,
+		NewDatabricksClusterEventsDataSource,
+		NewDatabricksWorkspaceStatusDataSource,
+		NewDatabricksWorkspaceBundleDataSource,
+		NewDatabricksDbfsFileDataSource,
+		NewDatabricksClusterDataSource,
+		NewDatabricksClustersDataSource,
+		NewDatabricksSparkVersionsDataSource,
+		NewDatabricksNodeTypesDataSource,
+		NewDatabricksCurrentUserDataSource,
 	}
 }
 
@@ -217,5 +820,78 @@ func (p *mrlProvider) DataSources(_ context.Context) []func() datasource.DataSou
 func (p *mrlProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewDatabricksDbfsResource,
+		NewDatabricksDbfsFileResource,
+		NewAdlsFileResource,
+		NewStorageBlobResource,
+		NewKeyVaultSecretResource,
+		NewDatabricksWorkspaceResource,
+		NewRoleAssignmentResource,
+		NewAdlsFilesystemResource,
+		NewDatabricksAccessConnectorResource,
+		NewPrivateEndpointResource,
+		NewDiagnosticSettingResource,
+		NewDatabricksVNetPeeringResource,
+		NewDatabricksDbfsFilesResource,
+		NewDatabricksSqlStatementResource,
+		NewDatabricksWorkspaceArchiveResource,
+		NewDatabricksPermissionAssignmentResource,
+		NewDatabricksMetastoreDataAccessResource,
+		NewDatabricksModelAliasResource,
+		NewDatabricksWorkspaceBundleRestoreResource,
+		NewDatabricksClusterResource,
+		NewDatabricksJobResource,
+		NewDatabricksSecretScopeResource,
+		NewDatabricksSecretResource,
+		NewDatabricksWorkspaceFileResource,
+		NewDatabricksUnityVolumeFileResource,
+		NewDatabricksDbfsDirectoryResource,
+		NewDatabricksLibraryResource,
+		NewDatabricksInstancePoolResource,
+		NewDatabricksRepoResource,
+		NewDatabricksSqlWarehouseResource,
+		NewDatabricksPermissionsResource,
+		NewDatabricksGlobalInitScriptResource,
+		NewDatabricksTokenResource,
+		NewDatabricksGroupResource,
+		NewDatabricksServicePrincipalResource,
+		NewDatabricksMountResource,
+		NewDatabricksWorkspaceConfResource,
+		NewDatabricksIPAccessListResource,
+		NewDatabricksNotebookResource,
+		NewDatabricksCatalogResource,
+		NewDatabricksSchemaResource,
+		NewDatabricksVolumeResource,
+		NewDatabricksClusterPolicyResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider.
+func (p *mrlProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewStorageSasEphemeralResource,
+		NewDatabricksTokenEphemeralResource,
+	}
+}
+
+// ListResources defines the list resources implemented in the provider.
+func (p *mrlProvider) ListResources(_ context.Context) []func() list.ListResource {
+	return []func() list.ListResource{
+		NewDatabricksDbfsFileListResource,
+		NewDatabricksClusterListResource,
+		NewDatabricksJobListResource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in the provider.
+func (p *mrlProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewFileMd5Function,
+		NewFileBase64Function,
+		NewDbfsPathJoinFunction,
+		NewDbfsPathFunction,
+		NewGzipBase64FileFunction,
+		NewCronToQuartzFunction,
+		NewMergeSparkConfFunction,
+		NewNotebookLanguageFunction,
 	}
 }
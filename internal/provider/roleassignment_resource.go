@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &RoleAssignmentResource{}
+	_ resource.ResourceWithConfigure      = &RoleAssignmentResource{}
+	_ resource.ResourceWithValidateConfig = &RoleAssignmentResource{}
+	_ resource.ResourceWithImportState    = &RoleAssignmentResource{}
+)
+
+// NewRoleAssignmentResource is a helper function to simplify the provider implementation.
+func NewRoleAssignmentResource() resource.Resource {
+	return &RoleAssignmentResource{}
+}
+
+// RoleAssignmentResource is the resource implementation.
+type RoleAssignmentResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type roleAssignmentResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Scope              types.String `tfsdk:"scope"`
+	PrincipalId        types.String `tfsdk:"principal_id"`
+	PrincipalType      types.String `tfsdk:"principal_type"`
+	RoleDefinitionId   types.String `tfsdk:"role_definition_id"`
+	RoleDefinitionName types.String `tfsdk:"role_definition_name"`
+	Description        types.String `tfsdk:"description"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *RoleAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *RoleAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *RoleAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replaceOrKeep := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+		stringplanmodifier.UseStateForUnknown(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Grants an Azure RBAC role to a principal at a scope, for example a Databricks access connector on the storage account behind a mount or external location.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "ARM resource ID of the role assignment",
+			},
+			"name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replaceOrKeep,
+				Description:   "GUID name of the role assignment. Generated when not set",
+			},
+			"scope": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "ARM ID of the scope the role is granted on, such as a storage account",
+			},
+			"principal_id": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Object ID of the service principal, managed identity, user or group",
+			},
+			"principal_type": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replaceOrKeep,
+				Description:   "Type of the principal: ServicePrincipal, User, Group or ForeignGroup. Setting it avoids replication delays for new principals",
+			},
+			"role_definition_id": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replaceOrKeep,
+				Description:   "ARM ID of the role definition. Conflicts with role_definition_name",
+			},
+			"role_definition_name": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: replaceOrKeep,
+				Description:   "Name of the role, such as Storage Blob Data Contributor. Conflicts with role_definition_id",
+			},
+			"description": schema.StringAttribute{
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Description of the role assignment",
+			},
+		},
+	}
+}
+
+// ValidateConfig checks that exactly one way of naming the role is used.
+func (r *RoleAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config roleAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.RoleDefinitionId.IsUnknown() || config.RoleDefinitionName.IsUnknown() {
+		return
+	}
+	if config.RoleDefinitionId.IsNull() == config.RoleDefinitionName.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_definition_id"),
+			"Invalid role",
+			"Exactly one of role_definition_id and role_definition_name must be set.",
+		)
+	}
+}
+
+// Create a new resource.
+func (r *RoleAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_role_assignment.Create")
+	defer span.End()
+
+	var plan roleAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope := plan.Scope.ValueString()
+	if plan.RoleDefinitionId.IsUnknown() || plan.RoleDefinitionId.IsNull() {
+		definition, err := r.azure.FindRoleDefinition(ctx, scope, plan.RoleDefinitionName.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error resolving role definition",
+				"Could not resolve role "+plan.RoleDefinitionName.ValueString()+": "+err.Error(),
+			)
+			return
+		}
+		plan.RoleDefinitionId = types.StringValue(definition.ID)
+	}
+
+	name := plan.Name.ValueString()
+	if plan.Name.IsUnknown() || plan.Name.IsNull() {
+		generated, err := azure.NewAssignmentName()
+		if err != nil {
+			resp.Diagnostics.AddError("Error creating role assignment", "Could not generate assignment name: "+err.Error())
+			return
+		}
+		name = generated
+	}
+
+	id := azure.RoleAssignmentID(scope, name)
+	ctx = withAuditRequestID(ctx)
+	assignment, err := r.azure.CreateRoleAssignment(ctx, id, azure.RoleAssignment{
+		Properties: azure.RoleAssignmentProperties{
+			RoleDefinitionID: plan.RoleDefinitionId.ValueString(),
+			PrincipalID:      plan.PrincipalId.ValueString(),
+			PrincipalType:    plan.PrincipalType.ValueString(),
+			Description:      plan.Description.ValueString(),
+		},
+	})
+	r.audit.Record(ctx, "mrl_role_assignment", auditActionCreate, id, err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating role assignment",
+			"Could not assign role on "+scope+": "+err.Error(),
+		)
+		return
+	}
+
+	plan.Id = types.StringValue(assignment.ID)
+	plan.Name = types.StringValue(assignment.Name)
+	plan.PrincipalType = types.StringValue(assignment.Properties.PrincipalType)
+	if plan.RoleDefinitionName.IsUnknown() {
+		plan.RoleDefinitionName = types.StringNull()
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *RoleAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_role_assignment.Read")
+	defer span.End()
+
+	var state roleAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignment, err := r.azure.GetRoleAssignment(ctx, state.Id.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading role assignment",
+			"Could not read role assignment "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.Name = types.StringValue(assignment.Name)
+	state.PrincipalId = types.StringValue(assignment.Properties.PrincipalID)
+	state.PrincipalType = types.StringValue(assignment.Properties.PrincipalType)
+	state.RoleDefinitionId = types.StringValue(assignment.Properties.RoleDefinitionID)
+	if state.RoleDefinitionName.IsNull() {
+		// Imported: resolve the role name so configurations using
+		// role_definition_name match.
+		definition, err := r.azure.GetRoleDefinition(ctx, assignment.Properties.RoleDefinitionID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error reading role assignment",
+				"Could not read role definition "+assignment.Properties.RoleDefinitionID+": "+err.Error(),
+			)
+			return
+		}
+		state.RoleDefinitionName = types.StringValue(definition.Properties.RoleName)
+		if assignment.Properties.Description != "" {
+			state.Description = types.StringValue(assignment.Properties.Description)
+		}
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, every attribute forces a new role assignment.
+func (r *RoleAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan roleAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *RoleAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_role_assignment.Delete")
+	defer span.End()
+
+	var state roleAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteRoleAssignment(ctx, state.Id.ValueString())
+	r.audit.Record(ctx, "mrl_role_assignment", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting role assignment",
+			"Could not delete role assignment "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a role assignment by its ARM ID,
+// {scope}/providers/Microsoft.Authorization/roleAssignments/{name}.
+func (r *RoleAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	scope, name, err := splitExtensionID(req.ID, "/providers/Microsoft.Authorization/roleAssignments/")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("scope"), scope)...)
+}
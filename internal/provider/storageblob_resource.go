@@ -0,0 +1,370 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &StorageBlobResource{}
+	_ resource.ResourceWithConfigure   = &StorageBlobResource{}
+	_ resource.ResourceWithModifyPlan  = &StorageBlobResource{}
+	_ resource.ResourceWithImportState = &StorageBlobResource{}
+)
+
+// NewStorageBlobResource is a helper function to simplify the provider implementation.
+func NewStorageBlobResource() resource.Resource {
+	return &StorageBlobResource{}
+}
+
+// StorageBlobResource is the resource implementation.
+type StorageBlobResource struct {
+	azure *azure.Client
+	audit *auditLogger
+}
+
+type storageBlobResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	StorageAccountName types.String `tfsdk:"storage_account_name"`
+	ContainerName      types.String `tfsdk:"container_name"`
+	Name               types.String `tfsdk:"name"`
+	LocalPath          types.String `tfsdk:"local_path"`
+	ContentType        types.String `tfsdk:"content_type"`
+	Metadata           types.Map    `tfsdk:"metadata"`
+	Md5Hash            types.String `tfsdk:"content_md5"`
+	FileSize           types.Int64  `tfsdk:"file_size"`
+	LastModified       types.String `tfsdk:"modification_time"`
+	ContentChanged     types.Bool   `tfsdk:"content_changed"`
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *StorageBlobResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+	r.audit = providerData.Audit
+}
+
+// Metadata returns the resource type name.
+func (r *StorageBlobResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_blob"
+}
+
+// Schema defines the schema for the resource.
+func (r *StorageBlobResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a local file as a block blob using the provider credential.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Description: "URL of the blob",
+			},
+			"storage_account_name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the storage account",
+			},
+			"container_name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the container holding the blob",
+			},
+			"name": schema.StringAttribute{
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Description: "Name of the blob",
+			},
+			"local_path": schema.StringAttribute{
+				Required:    true,
+				Description: "Local path from where the file needs to be read",
+			},
+			"content_type": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content type of the blob. Defaults to application/octet-stream",
+			},
+			"metadata": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Metadata stored with the blob. Keys are compared case insensitively",
+			},
+			"content_md5": schema.StringAttribute{
+				Required:    true,
+				Description: "md5 hash of the file. A different hash on the remote blob is reported as drift",
+			},
+			"content_changed": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the planned change uploads new content. The plan also shows a warning with the local and remote hashes and the size delta",
+			},
+			"file_size": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Size of the blob",
+			},
+			"modification_time": schema.StringAttribute{
+				Computed:    true,
+				Description: "Last modified time of the blob",
+			},
+		},
+	}
+}
+
+// upload sends the local file and refreshes the computed attributes.
+func (r *StorageBlobResource) upload(ctx context.Context, model *storageBlobResourceModel, action string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	metadata := map[string]string{}
+	diags.Append(model.Metadata.ElementsAs(ctx, &metadata, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	account := model.StorageAccountName.ValueString()
+	container := model.ContainerName.ValueString()
+	blob := model.Name.ValueString()
+	target := r.azure.BlobURL(account, container, blob)
+
+	err := func() error {
+		f, err := os.Open(model.LocalPath.ValueString())
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		sum, err := r.azure.UploadBlockBlob(ctx, account, container, blob, f, model.ContentType.ValueString(), metadata)
+		if err != nil {
+			return err
+		}
+		if !model.Md5Hash.IsUnknown() && sum != model.Md5Hash.ValueString() {
+			return fmt.Errorf("uploaded content has md5 %v but content_md5 is %v; the local file changed during the upload or content_md5 is stale", sum, model.Md5Hash.ValueString())
+		}
+		return nil
+	}()
+	r.audit.Record(ctx, "mrl_storage_blob", action, target, err)
+	if err != nil {
+		diags.AddError("Error uploading blob", "Could not upload "+model.LocalPath.ValueString()+": "+err.Error())
+		return diags
+	}
+
+	props, err := r.azure.GetBlobProperties(ctx, account, container, blob)
+	if err != nil {
+		diags.AddError("Error reading blob", "Could not read properties of "+target+": "+err.Error())
+		return diags
+	}
+
+	model.Id = types.StringValue(target)
+	model.FileSize = types.Int64Value(props.ContentLength)
+	model.LastModified = types.StringValue(props.LastModified.Format(time.RFC3339))
+	return diags
+}
+
+// ModifyPlan flags plans that upload new content and explains why.
+func (r *StorageBlobResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan storageBlobResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		plan.ContentChanged = types.BoolValue(true)
+	} else {
+		var state storageBlobResourceModel
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		changed, warning := previewContentChange(plan.LocalPath, plan.Md5Hash, state.Md5Hash, state.FileSize)
+		plan.ContentChanged = changed
+		if warning != nil {
+			resp.Diagnostics.Append(warning)
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create a new resource.
+func (r *StorageBlobResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_storage_blob.Create")
+	defer span.End()
+
+	var plan storageBlobResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(withAuditRequestID(ctx), &plan, auditActionCreate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *StorageBlobResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_storage_blob.Read")
+	defer span.End()
+
+	var state storageBlobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.ContentChanged = types.BoolValue(false)
+
+	props, err := r.azure.GetBlobProperties(ctx, state.StorageAccountName.ValueString(), state.ContainerName.ValueString(), state.Name.ValueString())
+	if azure.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading blob",
+			"Could not read properties of "+state.Id.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	state.FileSize = types.Int64Value(props.ContentLength)
+	state.LastModified = types.StringValue(props.LastModified.Format(time.RFC3339))
+	if props.ContentMD5 != "" {
+		state.Md5Hash = types.StringValue(props.ContentMD5)
+	}
+	if !state.ContentType.IsNull() {
+		state.ContentType = types.StringValue(props.ContentType)
+	}
+
+	// Keep the configured spelling of the keys unless the metadata really changed.
+	configured := map[string]string{}
+	resp.Diagnostics.Append(state.Metadata.ElementsAs(ctx, &configured, false)...)
+	if !metadataEqualFold(configured, props.Metadata) && (!state.Metadata.IsNull() || len(props.Metadata) > 0) {
+		metadata, diags := types.MapValueFrom(ctx, types.StringType, props.Metadata)
+		resp.Diagnostics.Append(diags...)
+		state.Metadata = metadata
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// metadataEqualFold compares two metadata maps, ignoring the case of keys.
+func metadataEqualFold(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[strings.ToLower(k)] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *StorageBlobResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_storage_blob.Update")
+	defer span.End()
+
+	var plan storageBlobResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upload(withAuditRequestID(ctx), &plan, auditActionUpdate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *StorageBlobResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_storage_blob.Delete")
+	defer span.End()
+
+	var state storageBlobResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ctx = withAuditRequestID(ctx)
+	err := r.azure.DeleteBlob(ctx, state.StorageAccountName.ValueString(), state.ContainerName.ValueString(), state.Name.ValueString())
+	if azure.IsNotFound(err) {
+		err = nil
+	}
+	r.audit.Record(ctx, "mrl_storage_blob", auditActionDelete, state.Id.ValueString(), err)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error deleting blob",
+			"Could not delete "+state.Id.ValueString()+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState imports a blob by an ID of the form account|container|name.
+// The next apply uploads local_path, as the imported state carries none.
+func (r *StorageBlobResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts, err := parseImportID(req.ID, "storage_account_name", "container_name", "name")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import ID", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), r.azure.BlobURL(parts[0], parts[1], parts[2]))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("storage_account_name"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("container_name"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-mrl/internal/azure"
+	"terraform-provider-mrl/internal/tracing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ ephemeral.EphemeralResource              = &StorageSasEphemeralResource{}
+	_ ephemeral.EphemeralResourceWithConfigure = &StorageSasEphemeralResource{}
+)
+
+// sasPermissionOrder is the order the service requires SAS permissions in.
+const sasPermissionOrder = "racwdlmeop"
+
+// maxUserDelegationValidity is the longest lifetime Azure accepts for a user
+// delegation key.
+const maxUserDelegationValidity = 7 * 24 * time.Hour
+
+// NewStorageSasEphemeralResource is a helper function to simplify the provider implementation.
+func NewStorageSasEphemeralResource() ephemeral.EphemeralResource {
+	return &StorageSasEphemeralResource{}
+}
+
+// StorageSasEphemeralResource is the ephemeral resource implementation.
+type StorageSasEphemeralResource struct {
+	azure *azure.Client
+}
+
+type storageSasEphemeralResourceModel struct {
+	StorageAccountName types.String `tfsdk:"storage_account_name"`
+	ContainerName      types.String `tfsdk:"container_name"`
+	Permissions        types.String `tfsdk:"permissions"`
+	Validity           types.String `tfsdk:"validity"`
+	SasToken           types.String `tfsdk:"sas_token"`
+	ExpiresAt          types.String `tfsdk:"expires_at"`
+}
+
+// Configure adds the provider configured client to the ephemeral resource.
+func (r *StorageSasEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*ClientBundle)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			fmt.Sprintf("Expected *ClientBundle, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.azure = providerData.Azure
+}
+
+// Metadata returns the ephemeral resource type name.
+func (r *StorageSasEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_storage_sas"
+}
+
+// Schema defines the schema for the ephemeral resource.
+func (r *StorageSasEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Generates a short-lived user delegation SAS for an ADLS container with the provider credential. The token is never written to plan or state.",
+		Attributes: map[string]schema.Attribute{
+			"storage_account_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the storage account",
+			},
+			"container_name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the container or ADLS filesystem the SAS grants access to",
+			},
+			"permissions": schema.StringAttribute{
+				Optional:    true,
+				Description: "Permissions granted by the SAS, a combination of " + sasPermissionOrder + ". Defaults to rl",
+			},
+			"validity": schema.StringAttribute{
+				Optional:    true,
+				Description: "How long the SAS is valid as a Go duration, at most 168h. Defaults to 1h",
+			},
+			"sas_token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "SAS query string without the leading ?",
+			},
+			"expires_at": schema.StringAttribute{
+				Computed:    true,
+				Description: "RFC 3339 time at which the SAS expires",
+			},
+		},
+	}
+}
+
+// normalizeSasPermissions validates permissions and orders them the way the
+// service expects.
+func normalizeSasPermissions(permissions string) (string, error) {
+	var ordered strings.Builder
+	for _, p := range sasPermissionOrder {
+		if strings.ContainsRune(permissions, p) {
+			ordered.WriteRune(p)
+		}
+	}
+	for _, p := range permissions {
+		if !strings.ContainsRune(sasPermissionOrder, p) {
+			return "", fmt.Errorf("unsupported permission %q, use a combination of %s", p, sasPermissionOrder)
+		}
+	}
+	if ordered.Len() == 0 {
+		return "", fmt.Errorf("at least one permission is required")
+	}
+	return ordered.String(), nil
+}
+
+// Open generates the SAS token.
+func (r *StorageSasEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	ctx, span := tracing.Start(ctx, "mrl_storage_sas.Open")
+	defer span.End()
+
+	var config storageSasEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	permissions := "rl"
+	if !config.Permissions.IsNull() {
+		permissions = config.Permissions.ValueString()
+	}
+	permissions, err := normalizeSasPermissions(permissions)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("permissions"), "Invalid permissions", err.Error())
+		return
+	}
+
+	validity := time.Hour
+	if !config.Validity.IsNull() {
+		validity, err = time.ParseDuration(config.Validity.ValueString())
+		if err != nil || validity <= 0 || validity > maxUserDelegationValidity {
+			resp.Diagnostics.AddAttributeError(path.Root("validity"), "Invalid validity", "validity must be a positive duration of at most 168h.")
+			return
+		}
+	}
+
+	// Start slightly in the past to tolerate clock skew with the service.
+	start := time.Now().UTC().Add(-5 * time.Minute).Truncate(time.Second)
+	expiry := time.Now().UTC().Add(validity).Truncate(time.Second)
+
+	account := config.StorageAccountName.ValueString()
+	key, err := r.azure.GetUserDelegationKey(ctx, account, start, expiry)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error generating SAS token",
+			"Could not obtain a user delegation key for "+account+": "+err.Error(),
+		)
+		return
+	}
+
+	token, err := azure.ContainerUserDelegationSAS(key, azure.ContainerSASOptions{
+		Account:     account,
+		Container:   config.ContainerName.ValueString(),
+		Permissions: permissions,
+		Start:       start,
+		Expiry:      expiry,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error generating SAS token", "Could not sign the SAS token: "+err.Error())
+		return
+	}
+
+	config.SasToken = types.StringValue(token)
+	config.ExpiresAt = types.StringValue(expiry.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &config)...)
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// tagsAllAttribute returns the schema of the computed tags_all attribute of
+// taggable Azure resources.
+func tagsAllAttribute() schema.MapAttribute {
+	return mergedTagsAttribute("resource", "tags")
+}
+
+// mergedTagsAttribute returns the schema of a computed attribute holding the
+// tags applied to subject: the provider default tags merged with the tags
+// attribute.
+func mergedTagsAttribute(subject, tags string) schema.MapAttribute {
+	return schema.MapAttribute{
+		ElementType: types.StringType,
+		Computed:    true,
+		Description: fmt.Sprintf("Tags applied to the %s: the provider default_tags merged with %s, %s taking precedence", subject, tags, tags),
+	}
+}
+
+// planTagsAll merges the provider default tags with the tags of a resource
+// plan. Tags set on the resource override defaults with the same key. The
+// result is null when there are no tags at all, and unknown while tags is.
+func planTagsAll(ctx context.Context, defaults map[string]string, tags types.Map) (types.Map, diag.Diagnostics) {
+	if tags.IsUnknown() {
+		return types.MapUnknown(types.StringType), nil
+	}
+
+	merged := make(map[string]string, len(defaults))
+	for k, v := range defaults {
+		merged[k] = v
+	}
+	var resourceTags map[string]string
+	diags := tags.ElementsAs(ctx, &resourceTags, false)
+	for k, v := range resourceTags {
+		merged[k] = v
+	}
+	if len(merged) == 0 {
+		return types.MapNull(types.StringType), diags
+	}
+
+	tagsAll, d := types.MapValueFrom(ctx, types.StringType, merged)
+	diags.Append(d...)
+	return tagsAll, diags
+}
+
+// modifyPlanTagsAll sets tags_all in the plan of a taggable resource, so a
+// change of the provider default tags shows up as an update.
+func modifyPlanTagsAll(ctx context.Context, defaults map[string]string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanMergedTags(ctx, defaults, path.Root("tags"), path.Root("tags_all"), req, resp)
+}
+
+// modifyPlanMergedTags sets the attribute at tagsAll in the plan to the
+// provider default tags merged with the attribute at tags. It does nothing
+// when the object holding tags is null.
+func modifyPlanMergedTags(ctx context.Context, defaults map[string]string, tags, tagsAll path.Path, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+	if parent := tags.ParentPath(); len(parent.Steps()) > 0 {
+		var holder types.Object
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, parent, &holder)...)
+		if resp.Diagnostics.HasError() || holder.IsNull() || holder.IsUnknown() {
+			return
+		}
+	}
+
+	var resourceTags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, tags, &resourceTags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	merged, diags := planTagsAll(ctx, defaults, resourceTags)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, tagsAll, merged)...)
+}
+
+// readTags splits the tags found on a remote resource into tags, the ones the
+// resource sets itself, and tagsAll, all of them. A remote tag equal to a
+// provider default is left out of tags unless prior, the tags in state,
+// already has it.
+func readTags(ctx context.Context, defaults map[string]string, prior types.Map, remote map[string]string) (tags, tagsAll types.Map, diags diag.Diagnostics) {
+	var priorTags map[string]string
+	diags.Append(prior.ElementsAs(ctx, &priorTags, false)...)
+
+	own := make(map[string]string, len(remote))
+	for k, v := range remote {
+		if dv, ok := defaults[k]; ok && dv == v {
+			if _, set := priorTags[k]; !set {
+				continue
+			}
+		}
+		own[k] = v
+	}
+
+	tags = prior
+	if !prior.IsNull() || len(own) > 0 {
+		var d diag.Diagnostics
+		tags, d = types.MapValueFrom(ctx, types.StringType, own)
+		diags.Append(d...)
+	}
+
+	tagsAll = types.MapNull(types.StringType)
+	if len(remote) > 0 {
+		var d diag.Diagnostics
+		tagsAll, d = types.MapValueFrom(ctx, types.StringType, remote)
+		diags.Append(d...)
+	}
+	return tags, tagsAll, diags
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// timeoutDefaults holds the operation timeouts of a resource used when its
+// timeouts attribute does not set them.
+type timeoutDefaults struct {
+	Create time.Duration
+	Read   time.Duration
+	Update time.Duration
+	Delete time.Duration
+}
+
+// Default operation timeouts of resources with a timeouts attribute.
+const (
+	defaultCreateTimeout = 20 * time.Minute
+	defaultReadTimeout   = 5 * time.Minute
+	defaultUpdateTimeout = 20 * time.Minute
+	defaultDeleteTimeout = 5 * time.Minute
+)
+
+// defaultTimeouts are the timeouts of resources that do not declare their
+// own.
+var defaultTimeouts = timeoutDefaults{
+	Create: defaultCreateTimeout,
+	Read:   defaultReadTimeout,
+	Update: defaultUpdateTimeout,
+	Delete: defaultDeleteTimeout,
+}
+
+// timeoutsModel maps the timeouts attribute.
+type timeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Read   types.String `tfsdk:"read"`
+	Update types.String `tfsdk:"update"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+// timeoutsAttribute returns the schema of the timeouts attribute with the
+// default timeouts.
+func timeoutsAttribute() schema.SingleNestedAttribute {
+	return defaultTimeouts.attribute()
+}
+
+// attribute returns the schema of a timeouts attribute documenting d.
+func (d timeoutDefaults) attribute() schema.SingleNestedAttribute {
+	durationValidators := []validator.String{Duration()}
+	return schema.SingleNestedAttribute{
+		Optional: true,
+		Attributes: map[string]schema.Attribute{
+			"create": schema.StringAttribute{
+				Optional:    true,
+				Validators:  durationValidators,
+				Description: "Timeout of create, such as 30m. Defaults to " + shortDuration(d.Create),
+			},
+			"read": schema.StringAttribute{
+				Optional:    true,
+				Validators:  durationValidators,
+				Description: "Timeout of refresh. Defaults to " + shortDuration(d.Read),
+			},
+			"update": schema.StringAttribute{
+				Optional:    true,
+				Validators:  durationValidators,
+				Description: "Timeout of update. Defaults to " + shortDuration(d.Update),
+			},
+			"delete": schema.StringAttribute{
+				Optional:    true,
+				Validators:  durationValidators,
+				Description: "Timeout of delete. Defaults to " + shortDuration(d.Delete),
+			},
+		},
+		Description: "Operation timeouts. An operation that runs longer is cancelled, aborting its in-flight requests",
+	}
+}
+
+// shortDuration formats d without trailing zero units, such as 45m or 1h30m.
+func shortDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// withTimeout returns ctx bounded by the configured timeout, or def when it
+// is not set.
+func withTimeout(ctx context.Context, timeout types.String, def time.Duration) (context.Context, context.CancelFunc) {
+	if d, err := time.ParseDuration(timeout.ValueString()); err == nil && d > 0 {
+		def = d
+	}
+	return context.WithTimeout(ctx, def)
+}
+
+// create returns ctx bounded by the create timeout.
+func (t *timeoutsModel) create(ctx context.Context) (context.Context, context.CancelFunc) {
+	return t.createOr(ctx, defaultTimeouts)
+}
+
+// read returns ctx bounded by the read timeout.
+func (t *timeoutsModel) read(ctx context.Context) (context.Context, context.CancelFunc) {
+	return t.readOr(ctx, defaultTimeouts)
+}
+
+// update returns ctx bounded by the update timeout.
+func (t *timeoutsModel) update(ctx context.Context) (context.Context, context.CancelFunc) {
+	return t.updateOr(ctx, defaultTimeouts)
+}
+
+// delete returns ctx bounded by the delete timeout.
+func (t *timeoutsModel) delete(ctx context.Context) (context.Context, context.CancelFunc) {
+	return t.deleteOr(ctx, defaultTimeouts)
+}
+
+// createOr returns ctx bounded by the create timeout, or by def.Create when
+// it is not set.
+func (t *timeoutsModel) createOr(ctx context.Context, def timeoutDefaults) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, def.Create)
+	}
+	return withTimeout(ctx, t.Create, def.Create)
+}
+
+// readOr returns ctx bounded by the read timeout, or by def.Read when it is
+// not set.
+func (t *timeoutsModel) readOr(ctx context.Context, def timeoutDefaults) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, def.Read)
+	}
+	return withTimeout(ctx, t.Read, def.Read)
+}
+
+// updateOr returns ctx bounded by the update timeout, or by def.Update when
+// it is not set.
+func (t *timeoutsModel) updateOr(ctx context.Context, def timeoutDefaults) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, def.Update)
+	}
+	return withTimeout(ctx, t.Update, def.Update)
+}
+
+// deleteOr returns ctx bounded by the delete timeout, or by def.Delete when
+// it is not set.
+func (t *timeoutsModel) deleteOr(ctx context.Context, def timeoutDefaults) (context.Context, context.CancelFunc) {
+	if t == nil {
+		return context.WithTimeout(ctx, def.Delete)
+	}
+	return withTimeout(ctx, t.Delete, def.Delete)
+}
@@ -0,0 +1,157 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ basetypes.StringTypable                    = RFC3339Type{}
+	_ xattr.TypeWithValidate                     = RFC3339Type{}
+	_ basetypes.StringValuableWithSemanticEquals = RFC3339Value{}
+)
+
+// RFC3339Type is the attribute type of timestamps in RFC3339 format. Values
+// denoting the same instant are semantically equal, whatever their offset.
+type RFC3339Type struct {
+	basetypes.StringType
+}
+
+// String returns a human readable string of the type name.
+func (t RFC3339Type) String() string {
+	return "RFC3339Type"
+}
+
+// ValueType returns the Value type.
+func (t RFC3339Type) ValueType(_ context.Context) attr.Value {
+	return RFC3339Value{}
+}
+
+// Equal returns true if the given type is equivalent.
+func (t RFC3339Type) Equal(o attr.Type) bool {
+	other, ok := o.(RFC3339Type)
+	if !ok {
+		return false
+	}
+	return t.StringType.Equal(other.StringType)
+}
+
+// ValueFromString returns a StringValuable type given a StringValue.
+func (t RFC3339Type) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return RFC3339Value{StringValue: in}, nil
+}
+
+// ValueFromTerraform returns a Value given a tftypes.Value.
+func (t RFC3339Type) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	attrValue, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+
+	stringValue, ok := attrValue.(basetypes.StringValue)
+	if !ok {
+		return nil, fmt.Errorf("unexpected value type of %T", attrValue)
+	}
+
+	stringValuable, diags := t.ValueFromString(ctx, stringValue)
+	if diags.HasError() {
+		return nil, fmt.Errorf("unexpected error converting StringValue to StringValuable: %v", diags)
+	}
+	return stringValuable, nil
+}
+
+// Validate rejects values that are not RFC3339 timestamps.
+func (t RFC3339Type) Validate(_ context.Context, in tftypes.Value, p path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if !in.IsKnown() || in.IsNull() {
+		return diags
+	}
+
+	var s string
+	if err := in.As(&s); err != nil {
+		diags.AddAttributeError(p, "Invalid time", "Could not read the value: "+err.Error())
+		return diags
+	}
+	if _, err := time.Parse(time.RFC3339, s); err != nil {
+		diags.AddAttributeError(p, "Invalid time", "The value must be in RFC3339 format: "+err.Error())
+	}
+	return diags
+}
+
+// RFC3339Value is a value of RFC3339Type.
+type RFC3339Value struct {
+	basetypes.StringValue
+}
+
+// NewRFC3339TimeValue returns the value of t, formatted in UTC.
+func NewRFC3339TimeValue(t time.Time) RFC3339Value {
+	return RFC3339Value{StringValue: basetypes.NewStringValue(t.UTC().Format(time.RFC3339))}
+}
+
+// NewRFC3339Null returns a null timestamp.
+func NewRFC3339Null() RFC3339Value {
+	return RFC3339Value{StringValue: basetypes.NewStringNull()}
+}
+
+// NewRFC3339Unknown returns an unknown timestamp.
+func NewRFC3339Unknown() RFC3339Value {
+	return RFC3339Value{StringValue: basetypes.NewStringUnknown()}
+}
+
+// Type returns the type of the value.
+func (v RFC3339Value) Type(_ context.Context) attr.Type {
+	return RFC3339Type{}
+}
+
+// Equal returns true if the given value is equivalent, including formatting.
+func (v RFC3339Value) Equal(o attr.Value) bool {
+	other, ok := o.(RFC3339Value)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+// StringSemanticEquals reports whether the two timestamps denote the same
+// instant.
+func (v RFC3339Value) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	newValue, ok := newValuable.(RFC3339Value)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got: %T. Please report this issue to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+
+	a, err := time.Parse(time.RFC3339, v.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	b, err := time.Parse(time.RFC3339, newValue.ValueString())
+	if err != nil {
+		return false, diags
+	}
+	return a.Equal(b), diags
+}
+
+// ValueRFC3339Time returns the timestamp as a time.Time.
+func (v RFC3339Value) ValueRFC3339Time() (time.Time, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	t, err := time.Parse(time.RFC3339, v.ValueString())
+	if err != nil {
+		diags.AddError("Invalid time", "The value must be in RFC3339 format: "+err.Error())
+	}
+	return t, diags
+}
@@ -0,0 +1,574 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"terraform-provider-mrl/internal/databricks"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ validator.String = stringOneOfValidator{}
+	_ validator.Int64  = int64BetweenValidator{}
+	_ validator.String = dbfsPathNormalizedValidator{}
+	_ validator.String = volumeFilePathValidator{}
+	_ validator.String = durationValidator{}
+	_ validator.String = workspaceURLValidator{}
+	_ validator.String = conflictsWithValidator{}
+	_ validator.String = uuidValidator{}
+	_ validator.String = mountNameValidator{}
+	_ validator.Set    = setValuesOneOfValidator{}
+	_ validator.Set    = ipAddressesValidator{}
+
+	_ resource.ConfigValidator = localFileValidator{}
+)
+
+// uuidPattern matches a UUID such as a Microsoft Entra ID tenant, client or
+// subscription ID.
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// mountNamePattern matches the name of a DBFS mount point under /mnt.
+var mountNamePattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+
+// StringOneOf returns a validator that accepts only the given values.
+func StringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+// stringOneOfValidator implements the validator.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// Description returns a human-readable description of the validator.
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return "Value must be one of: " + strings.Join(v.values, ", ") + "."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("%s Got: %q.", v.Description(ctx), req.ConfigValue.ValueString()),
+	)
+}
+
+// SetValuesOneOf returns a validator that accepts only sets of strings whose
+// elements are all among the given values.
+func SetValuesOneOf(values ...string) validator.Set {
+	return setValuesOneOfValidator{values: values}
+}
+
+// setValuesOneOfValidator implements the validator.
+type setValuesOneOfValidator struct {
+	values []string
+}
+
+// Description returns a human-readable description of the validator.
+func (v setValuesOneOfValidator) Description(_ context.Context) string {
+	return "Elements must be among: " + strings.Join(v.values, ", ") + "."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v setValuesOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateSet implements the validation logic.
+func (v setValuesOneOfValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var elements []types.String
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &elements, true)...)
+	for _, element := range elements {
+		if element.IsNull() || element.IsUnknown() || slices.Contains(v.values, element.ValueString()) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), element.ValueString()),
+		)
+	}
+}
+
+// IPAddresses returns a validator that accepts only sets of IPv4 addresses
+// and CIDR ranges, such as 203.0.113.7 and 198.51.100.0/24.
+func IPAddresses() validator.Set {
+	return ipAddressesValidator{}
+}
+
+// ipAddressesValidator implements the validator.
+type ipAddressesValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v ipAddressesValidator) Description(_ context.Context) string {
+	return "Elements must be IPv4 addresses or CIDR ranges, such as 203.0.113.7 or 198.51.100.0/24."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v ipAddressesValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateSet implements the validation logic.
+func (v ipAddressesValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	var elements []types.String
+	resp.Diagnostics.Append(req.ConfigValue.ElementsAs(ctx, &elements, true)...)
+	for _, element := range elements {
+		if element.IsNull() || element.IsUnknown() || parseIPv4Range(element.ValueString()) != nil {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), element.ValueString()),
+		)
+	}
+}
+
+// parseIPv4Range returns the network of an IPv4 address or CIDR range, an
+// address being a /32 range. It returns nil for other values.
+func parseIPv4Range(value string) *net.IPNet {
+	if !strings.Contains(value, "/") {
+		value += "/32"
+	}
+	ip, network, err := net.ParseCIDR(value)
+	if err != nil || ip.To4() == nil {
+		return nil
+	}
+	return network
+}
+
+// Int64Between returns a validator that accepts only values from min to max,
+// inclusive.
+func Int64Between(min, max int64) validator.Int64 {
+	return int64BetweenValidator{min: min, max: max}
+}
+
+// int64BetweenValidator implements the validator.
+type int64BetweenValidator struct {
+	min, max int64
+}
+
+// Description returns a human-readable description of the validator.
+func (v int64BetweenValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Value must be between %d and %d.", v.min, v.max)
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v int64BetweenValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateInt64 implements the validation logic.
+func (v int64BetweenValidator) ValidateInt64(ctx context.Context, req validator.Int64Request, resp *validator.Int64Response) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if value := req.ConfigValue.ValueInt64(); value < v.min || value > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %d.", v.Description(ctx), value),
+		)
+	}
+}
+
+// DbfsPathNormalized returns a validator that accepts only absolute DBFS file
+// paths in normalized form: no duplicate or trailing slashes and no "." or
+// ".." elements. The dbfs: scheme is allowed.
+func DbfsPathNormalized() validator.String {
+	return dbfsPathNormalizedValidator{}
+}
+
+// dbfsPathNormalizedValidator implements the validator.
+type dbfsPathNormalizedValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v dbfsPathNormalizedValidator) Description(_ context.Context) string {
+	return "Value must be an absolute, normalized DBFS file path."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v dbfsPathNormalizedValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v dbfsPathNormalizedValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	p := strings.TrimPrefix(value, "dbfs:")
+	if !strings.HasPrefix(p, "/") || p == "/" || p != joinDbfsPath(p) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q, normalized: %q.", v.Description(ctx), value, joinDbfsPath(p)),
+		)
+	}
+}
+
+// VolumeFilePath returns a validator that accepts only paths of files in a
+// Unity Catalog volume, /Volumes/<catalog>/<schema>/<volume>/<file>, in
+// normalized form.
+func VolumeFilePath() validator.String {
+	return volumeFilePathValidator{}
+}
+
+// volumeFilePathValidator implements the validator.
+type volumeFilePathValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v volumeFilePathValidator) Description(_ context.Context) string {
+	return "Value must be a normalized path of a file in a Unity Catalog volume, /Volumes/<catalog>/<schema>/<volume>/<file>."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v volumeFilePathValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v volumeFilePathValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !strings.HasPrefix(value, "/Volumes/") || value != joinDbfsPath(value) || len(strings.Split(value, "/")) < 6 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
+
+// Duration returns a validator that accepts only Go duration strings, such
+// as 90s or 1h30m.
+func Duration() validator.String {
+	return durationValidator{}
+}
+
+// durationValidator implements the validator.
+type durationValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v durationValidator) Description(_ context.Context) string {
+	return "Value must be a duration such as 90s or 1h30m."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if d, err := time.ParseDuration(value); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
+
+// WorkspaceURL returns a validator that accepts only the URL or host name of
+// a Databricks workspace, such as adb-1234.5.azuredatabricks.net.
+func WorkspaceURL() validator.String {
+	return workspaceURLValidator{}
+}
+
+// workspaceURLValidator implements the validator.
+type workspaceURLValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v workspaceURLValidator) Description(_ context.Context) string {
+	return "Value must be the URL or host name of a Databricks workspace."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v workspaceURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v workspaceURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	u, err := url.Parse(databricks.WorkspaceURL(value))
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Hostname() == "" || strings.ContainsAny(value, " \t\n") || strings.Trim(u.Path, "/") != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
+
+// ConflictsWith returns a validator that rejects values set together with
+// the root attribute attr, such as workspace_url with its former name adb_id.
+func ConflictsWith(attr string) validator.String {
+	return conflictsWithValidator{attr: attr}
+}
+
+// conflictsWithValidator implements the validator.
+type conflictsWithValidator struct {
+	attr string
+}
+
+// Description returns a human-readable description of the validator.
+func (v conflictsWithValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("Value cannot be set together with %s.", v.attr)
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v conflictsWithValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v conflictsWithValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	other, _, err := tftypes.WalkAttributePath(req.Config.Raw, tftypes.NewAttributePath().WithAttributeName(v.attr))
+	if err != nil {
+		return
+	}
+	if value, ok := other.(tftypes.Value); ok && !value.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Conflicting Attributes",
+			fmt.Sprintf("%s Set only %s.", v.Description(ctx), req.Path),
+		)
+	}
+}
+
+// UUID returns a validator that accepts only UUIDs, such as Microsoft Entra
+// ID tenant, client and subscription IDs.
+func UUID() validator.String {
+	return uuidValidator{}
+}
+
+// uuidValidator implements the validator.
+type uuidValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v uuidValidator) Description(_ context.Context) string {
+	return "Value must be a UUID such as 00000000-0000-0000-0000-000000000000."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v uuidValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v uuidValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !uuidPattern.MatchString(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
+
+// MountName returns a validator that accepts only names of DBFS mount points
+// under /mnt, without slashes.
+func MountName() validator.String {
+	return mountNameValidator{}
+}
+
+// mountNameValidator implements the validator.
+type mountNameValidator struct{}
+
+// Description returns a human-readable description of the validator.
+func (v mountNameValidator) Description(_ context.Context) string {
+	return "Value must be a mount point name of letters, digits, '.', '-' and '_', such as raw."
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v mountNameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString implements the validation logic.
+func (v mountNameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if !mountNamePattern.MatchString(value) || value == "." || value == ".." {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Attribute Value",
+			fmt.Sprintf("%s Got: %q.", v.Description(ctx), value),
+		)
+	}
+}
+
+// LocalFile returns a resource config validator checking that the string
+// attribute at attrPath names an existing regular file of at most maxBytes
+// bytes. A maxBytes of zero does not limit the size.
+func LocalFile(attrPath path.Path, maxBytes int64) resource.ConfigValidator {
+	return localFileValidator{path: attrPath, maxBytes: maxBytes}
+}
+
+// LocalFileIfExists is LocalFile accepting a file that does not exist, for
+// resources that only need it at plan time, so that a refresh still works
+// once the file is removed or renamed.
+func LocalFileIfExists(attrPath path.Path, maxBytes int64) resource.ConfigValidator {
+	return localFileValidator{path: attrPath, maxBytes: maxBytes, allowMissing: true}
+}
+
+// localFileValidator implements the validator.
+type localFileValidator struct {
+	path         path.Path
+	maxBytes     int64
+	allowMissing bool
+}
+
+// Description returns a human-readable description of the validator.
+func (v localFileValidator) Description(_ context.Context) string {
+	if v.maxBytes > 0 {
+		return fmt.Sprintf("%s must be a regular file of at most %d bytes.", v.path, v.maxBytes)
+	}
+	return fmt.Sprintf("%s must be a regular file.", v.path)
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v localFileValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements the validation logic.
+func (v localFileValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var localPath types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.path, &localPath)...)
+	if resp.Diagnostics.HasError() || localPath.IsNull() || localPath.IsUnknown() {
+		return
+	}
+
+	info, err := os.Stat(localPath.ValueString())
+	switch {
+	case v.allowMissing && errors.Is(err, fs.ErrNotExist):
+		// Reported at plan time, when the file is read.
+	case err != nil:
+		resp.Diagnostics.AddAttributeError(v.path, "Invalid local file", fmt.Sprintf("Could not read %s: %s", localPath.ValueString(), err))
+	case !info.Mode().IsRegular():
+		resp.Diagnostics.AddAttributeError(v.path, "Invalid local file", localPath.ValueString()+" is not a regular file.")
+	case v.maxBytes > 0 && info.Size() > v.maxBytes:
+		resp.Diagnostics.AddAttributeError(v.path, "Invalid local file",
+			fmt.Sprintf("%s has %d bytes, more than the limit of %d bytes.", localPath.ValueString(), info.Size(), v.maxBytes))
+	}
+}
+
+// WorkspaceBlock returns a resource config validator of the workspace block:
+// its host is set, and conflicts with the workspace attributes at
+// workspaceAttrs, such as workspace_url and adb_id.
+func WorkspaceBlock(workspaceAttrs ...path.Path) resource.ConfigValidator {
+	return workspaceBlockValidator{workspaceAttrs: workspaceAttrs}
+}
+
+// workspaceBlockValidator implements the validator.
+type workspaceBlockValidator struct {
+	workspaceAttrs []path.Path
+}
+
+// Description returns a human-readable description of the validator.
+func (v workspaceBlockValidator) Description(_ context.Context) string {
+	names := make([]string, len(v.workspaceAttrs))
+	for i, workspaceAttr := range v.workspaceAttrs {
+		names[i] = workspaceAttr.String()
+	}
+	return fmt.Sprintf("workspace.host is set and conflicts with %s.", strings.Join(names, " and "))
+}
+
+// MarkdownDescription returns a markdown description of the validator.
+func (v workspaceBlockValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateResource implements the validation logic.
+func (v workspaceBlockValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var block *databricksWorkspaceBlockModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("workspace"), &block)...)
+	if resp.Diagnostics.HasError() || block == nil {
+		return
+	}
+	if block.Host.IsNull() {
+		resp.Diagnostics.AddAttributeError(path.Root("workspace").AtName("host"), "Missing workspace host",
+			"Set host in the workspace block.")
+	}
+	for _, workspaceAttr := range v.workspaceAttrs {
+		var workspace URLValue
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, workspaceAttr, &workspace)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !workspace.IsNull() && !block.Host.IsNull() {
+			resp.Diagnostics.AddAttributeError(path.Root("workspace").AtName("host"), "Conflicting workspace",
+				fmt.Sprintf("Set the workspace in either %s or workspace.host, not both.", workspaceAttr))
+		}
+	}
+}
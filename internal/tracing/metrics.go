@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// statsdPrefix is the prefix of the statsd metric names.
+const statsdPrefix = "mrl.api"
+
+// Metrics records API calls by endpoint: how many were made, how often they
+// were retried or failed and how long they took. The calls made during an
+// operation started with Start are logged with tflog and added to its span
+// when the operation ends, and each call is sent to statsd when an address is
+// configured. A nil *Metrics records nothing.
+type Metrics struct {
+	statsd net.Conn
+}
+
+// NewMetrics returns a Metrics sending to the statsd server at statsdAddress,
+// a host:port over UDP, or to no statsd server when it is empty.
+func NewMetrics(statsdAddress string) (*Metrics, error) {
+	m := &Metrics{}
+	if statsdAddress != "" {
+		conn, err := net.Dial("udp", statsdAddress)
+		if err != nil {
+			return nil, err
+		}
+		m.statsd = conn
+	}
+	return m, nil
+}
+
+// Record records a call to endpoint that was retried retries times and took
+// duration in total, retries included.
+func (m *Metrics) Record(ctx context.Context, endpoint string, retries int, duration time.Duration, failed bool) {
+	if m == nil {
+		return
+	}
+	if op, ok := ctx.Value(operationKey{}).(*operation); ok {
+		op.record(endpoint, retries, duration, failed)
+	}
+	if m.statsd != nil {
+		m.sendStatsd(endpoint, retries, duration, failed)
+	}
+}
+
+// sendStatsd sends the call as statsd counters and a timer named after the
+// endpoint, e.g. mrl.api.post_api_2_0_dbfs_create.calls. Send errors are
+// ignored, as statsd over UDP is best effort.
+func (m *Metrics) sendStatsd(endpoint string, retries int, duration time.Duration, failed bool) {
+	name := statsdPrefix + "." + statsdName(endpoint)
+	lines := []string{
+		name + ".calls:1|c",
+		fmt.Sprintf("%s.duration:%d|ms", name, duration.Milliseconds()),
+	}
+	if retries > 0 {
+		lines = append(lines, fmt.Sprintf("%s.retries:%d|c", name, retries))
+	}
+	if failed {
+		lines = append(lines, name+".errors:1|c")
+	}
+	_, _ = m.statsd.Write([]byte(strings.Join(lines, "\n")))
+}
+
+// statsdName returns endpoint in lower case with every run of characters
+// that are not letters or digits replaced by a single underscore, e.g.
+// post_api_2_0_dbfs_create for "POST /api/2.0/dbfs/create".
+func statsdName(endpoint string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(endpoint) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			underscore = false
+		} else if !underscore {
+			b.WriteByte('_')
+			underscore = true
+		}
+	}
+	return strings.Trim(b.String(), "_")
+}
+
+// EndpointStats are the calls made to an endpoint during an operation.
+type EndpointStats struct {
+	Calls   int
+	Retries int
+	Errors  int
+	// Duration is the total duration of the calls and MaxDuration that of
+	// the slowest.
+	Duration    time.Duration
+	MaxDuration time.Duration
+}
+
+type operationKey struct{}
+
+// operation collects the calls made during an operation, by endpoint.
+type operation struct {
+	name string
+
+	mu        sync.Mutex
+	endpoints map[string]*EndpointStats
+}
+
+func (op *operation) record(endpoint string, retries int, duration time.Duration, failed bool) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	if op.endpoints == nil {
+		op.endpoints = map[string]*EndpointStats{}
+	}
+	stats, ok := op.endpoints[endpoint]
+	if !ok {
+		stats = &EndpointStats{}
+		op.endpoints[endpoint] = stats
+	}
+	stats.Calls++
+	stats.Retries += retries
+	if failed {
+		stats.Errors++
+	}
+	stats.Duration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+}
+
+// emit logs the calls of the operation, one line per endpoint, and adds them
+// to span as events.
+func (op *operation) emit(ctx context.Context, span trace.Span) {
+	op.mu.Lock()
+	defer op.mu.Unlock()
+
+	endpoints := make([]string, 0, len(op.endpoints))
+	for endpoint := range op.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	for _, endpoint := range endpoints {
+		stats := op.endpoints[endpoint]
+		tflog.Info(ctx, "API calls", map[string]interface{}{
+			"operation":       op.name,
+			"endpoint":        endpoint,
+			"calls":           stats.Calls,
+			"retries":         stats.Retries,
+			"errors":          stats.Errors,
+			"duration_ms":     stats.Duration.Milliseconds(),
+			"max_duration_ms": stats.MaxDuration.Milliseconds(),
+		})
+		span.AddEvent("api.calls", trace.WithAttributes(
+			attribute.String("api.endpoint", endpoint),
+			attribute.Int("api.calls", stats.Calls),
+			attribute.Int("api.retries", stats.Retries),
+			attribute.Int("api.errors", stats.Errors),
+			attribute.Int64("api.duration_ms", stats.Duration.Milliseconds()),
+			attribute.Int64("api.max_duration_ms", stats.MaxDuration.Milliseconds()),
+		))
+	}
+}
+
+// operationSpan is the span of an operation, which emits the API calls of
+// the operation when it ends.
+type operationSpan struct {
+	trace.Span
+	ctx context.Context
+	op  *operation
+}
+
+// End implements trace.Span.
+func (s *operationSpan) End(options ...trace.SpanEndOption) {
+	s.op.emit(s.ctx, s.Span)
+	s.Span.End(options...)
+}
@@ -8,6 +8,7 @@ import (
 	"flag"
 	"log"
 	"terraform-provider-mrl/internal/provider"
+	"terraform-provider-mrl/internal/tracing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
@@ -20,7 +21,7 @@ import (
 
 // Run the docs generation tool, check its repository for more information on how it works and how docs
 // can be customized.
-//go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs
+//go:generate go run github.com/hashicorp/terraform-plugin-docs/cmd/tfplugindocs@v0.16.0
 
 var (
 	// these will be set by the goreleaser configuration
@@ -47,7 +48,17 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	shutdownTracing, err := tracing.Setup(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err = providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Flush any buffered spans before exiting.
+	if shutdownErr := shutdownTracing(context.Background()); shutdownErr != nil {
+		log.Println(shutdownErr.Error())
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())